@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+// runCommand runs an admin subcommand and writes its result to out as JSON:
+//
+//	reconcile [-sku SKU] [-fix]    compare recorded stock with the ledger, of one product or all of them
+//	stock-at -sku SKU [-at TIME]   replay the ledger of a product up to an RFC 3339 time, now by default
+func runCommand(ctx context.Context, usecases *Usecases, args []string, out io.Writer) error {
+	var result interface{}
+	switch args[0] {
+	case "reconcile":
+		fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+		sku := fs.String("sku", "", "product to reconcile; all products when empty")
+		fix := fs.Bool("fix", false, "correct drifted stock to the ledger")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var err error
+		if *sku != "" {
+			result, err = usecases.Reconciliation.ReconcileProduct(ctx, *sku, *fix)
+		} else {
+			result, err = usecases.Reconciliation.ReconcileAll(ctx, *fix)
+		}
+		if err != nil {
+			return err
+		}
+
+	case "stock-at":
+		fs := flag.NewFlagSet("stock-at", flag.ContinueOnError)
+		sku := fs.String("sku", "", "product to replay")
+		at := fs.String("at", "", "RFC 3339 time to replay up to; now when empty")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *sku == "" {
+			return fmt.Errorf("stock-at: -sku is required")
+		}
+
+		until := time.Now()
+		if *at != "" {
+			parsed, err := time.Parse(time.RFC3339, *at)
+			if err != nil {
+				return fmt.Errorf("stock-at: invalid -at: %w", err)
+			}
+			until = parsed
+		}
+
+		var err error
+		if result, err = usecases.Reconciliation.GetStockAt(ctx, *sku, until); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown command %q; expected reconcile or stock-at", args[0])
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
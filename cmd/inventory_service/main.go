@@ -5,6 +5,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"runtime/debug"
@@ -21,11 +22,15 @@ import (
 
 	// Update these imports to match your project structure
 
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/allocation"
+	grpcctl "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/grpc"
+	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/grpc/proto"
 	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/http"
 	eventSvc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
 	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
 	gormrepo "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/scheduler"
 	appconfig "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/config"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
@@ -35,7 +40,12 @@ import (
 
 // Repositories holds all repository implementations
 type Repositories struct {
-	InventoryRepository repository.InventoryRepository
+	InventoryRepository  repository.InventoryRepository
+	WarehouseRepository  repository.WarehouseRepository
+	CycleCountRepository repository.CycleCountRepository
+	SupplierRepository   repository.SupplierRepository
+	PurchaseOrderRepo    repository.PurchaseOrderRepository
+	Transactor           repository.Transactor
 }
 
 // Services holds all service implementations
@@ -47,12 +57,24 @@ type Services struct {
 // Usecases holds all usecase implementations
 type Usecases struct {
 	InventoryUsecase   usecase.InventoryUsecase
+	WarehouseUsecase   usecase.WarehouseUsecase
 	ReservationUsecase usecase.ReservationProcessorUsecase
+	CycleCountUsecase  usecase.CycleCountUsecase
+	Reconciliation     usecase.ReconciliationUsecase
+	SupplierUsecase    usecase.SupplierUsecase
+	PurchaseOrders     usecase.PurchaseOrderUsecase
+	AvailabilityStream usecase.AvailabilityStreamUsecase
 }
 
 // Controllers holds all controllers
 type Controllers struct {
-	HTTP *httpctl.InventoryHandler
+	HTTP           *httpctl.InventoryHandler
+	Warehouse      *httpctl.WarehouseHandler
+	CycleCount     *httpctl.CycleCountHandler
+	Reconciliation *httpctl.ReconciliationHandler
+	PurchaseOrder  *httpctl.PurchaseOrderHandler
+	Availability   *httpctl.AvailabilityStreamHandler
+	GRPC           *grpcctl.InventoryServer
 }
 
 type GormLogAdapter struct {
@@ -101,6 +123,7 @@ func main() {
 		Brokers:         config.Messaging.Brokers,
 		InventoryTopic:  config.Messaging.InventoryTopic,
 		OrderTopic:      config.Messaging.OrderTopic,
+		ProductTopic:    config.Messaging.ProductTopic,
 		ConsumerGroupID: config.Messaging.ConsumerGroupID,
 	}
 	eventServicePublisher, err := eventSvc.NewKafkaEventPublisher(eventConfig)
@@ -109,10 +132,25 @@ func main() {
 	}
 	// Initialize usecases
 	// usecases := initUsecases(repositories, nil) // We'll set event service after initializing usecases
-	usecases := initUsecases(repositories, eventServicePublisher)
+	usecases, err := initUsecases(ctx, repositories, eventServicePublisher, config)
+	if err != nil {
+		log.Fatal("Failed to initialize usecases", "error", err)
+	}
+
+	// Run an admin subcommand instead of the service when one is given
+	if flag.NArg() > 0 {
+		err := runCommand(ctx, usecases, flag.Args(), os.Stdout)
+		if closeErr := eventServicePublisher.Close(); closeErr != nil {
+			log.Error("Failed to close event service", "error", closeErr)
+		}
+		if err != nil {
+			log.Fatal("Command failed", "command", flag.Arg(0), "error", err)
+		}
+		return
+	}
 
 	// Initialize Kafka consumer (needs usecase)
-	kafkaConsumer, err := eventSvc.NewKafkaEventSubscriber(eventConfig, usecases.ReservationUsecase)
+	kafkaConsumer, err := eventSvc.NewKafkaEventSubscriber(eventConfig, usecases.ReservationUsecase, usecases.InventoryUsecase, usecases.PurchaseOrders)
 	if err != nil {
 		log.Fatal("Failed to initialize Kafka consumer", "error", err)
 	}
@@ -127,14 +165,46 @@ func main() {
 	if err := kafkaConsumer.SubscribeToOrderEvents(ctx); err != nil {
 		log.Fatal("Failed to start Kafka consumer", "error", err)
 	}
+
+	// Provision inventory items for products created in the product service
+	if err := kafkaConsumer.SubscribeToProductEvents(ctx); err != nil {
+		log.Fatal("Failed to start product event consumer", "error", err)
+	}
+
+	// Draft purchase orders for stock that runs low
+	if err := kafkaConsumer.SubscribeToInventoryEvents(ctx); err != nil {
+		log.Fatal("Failed to start inventory event consumer", "error", err)
+	}
+
+	// Release the stock of reservations that were never completed
+	scheduler.NewExpirySweeper(usecases.ReservationUsecase, config.Expiry.SweepInterval, log).Start(ctx)
+
+	// Check the recorded stock against the stock ledger
+	scheduler.NewReconciler(usecases.Reconciliation, config.Ledger.ReconcileInterval, config.Ledger.AutoFix, log).Start(ctx)
+
+	// Hear about stock changes made by any replica
+	availabilityConsumer, err := eventSvc.NewAvailabilityEventConsumer(eventConfig, config.Streaming.ReplicaID, usecases.AvailabilityStream)
+	if err != nil {
+		log.Fatal("Failed to initialize availability event consumer", "error", err)
+	}
+	if err := availabilityConsumer.Start(ctx); err != nil {
+		log.Fatal("Failed to start availability event consumer", "error", err)
+	}
+
+	// Push changed stock availability to stream subscribers
+	scheduler.NewAvailabilityFlusher(usecases.AvailabilityStream, config.Streaming.CoalesceInterval, log).Start(ctx)
+
 	defer func() {
+		if err := availabilityConsumer.Close(); err != nil {
+			log.Error("Failed to close availability event consumer", "error", err)
+		}
 		if err := eventServicePublisher.Close(); err != nil {
 			log.Error("Failed to close event service", "error", err)
 		}
 	}()
 
 	// Initialize controllers
-	controllers := initControllers(usecases, log)
+	controllers := initControllers(usecases, config.Server, log)
 
 	// Start servers
 	servers := initServers(config, controllers, log)
@@ -167,7 +237,19 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 	log.Info("Connected to database")
 
 	// Auto migrate models
-	if err := db.AutoMigrate(&model.InventoryItem{}, &model.InventoryReservation{}, &model.StockTransaction{}); err != nil {
+	if err := db.AutoMigrate(
+		&model.InventoryItem{},
+		&model.InventoryReservation{},
+		&model.StockTransaction{},
+		&model.Warehouse{},
+		&model.StockLevel{},
+		&model.StockTransfer{},
+		&model.CycleCount{},
+		&model.CycleCountLine{},
+		&model.Supplier{},
+		&model.PurchaseOrder{},
+		&model.PurchaseOrderLine{},
+	); err != nil {
 		return nil, err
 	}
 
@@ -187,30 +269,97 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 // initRepositories initializes all repositories
 func initRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		InventoryRepository: gormrepo.NewGormInventoryRepository(db),
+		InventoryRepository:  gormrepo.NewGormInventoryRepository(db),
+		WarehouseRepository:  gormrepo.NewGormWarehouseRepository(db),
+		CycleCountRepository: gormrepo.NewGormCycleCountRepository(db),
+		SupplierRepository:   gormrepo.NewGormSupplierRepository(db),
+		PurchaseOrderRepo:    gormrepo.NewGormPurchaseOrderRepository(db),
+		Transactor:           gormrepo.NewGormTransactor(db),
 	}
 }
 
 // initUsecases initializes all usecases
-func initUsecases(repos *Repositories, eventService service.EventPublisherService) *Usecases {
-	inventoryUsecase := usecase.NewInventoryUsecase(repos.InventoryRepository, eventService)
-	return &Usecases{
-		InventoryUsecase:   inventoryUsecase,
-		ReservationUsecase: usecase.NewReservationProcessorUsecase(repos.InventoryRepository, eventService, inventoryUsecase),
+func initUsecases(ctx context.Context, repos *Repositories, eventService service.EventPublisherService, config *appconfig.Config) (*Usecases, error) {
+	allocator, err := allocation.NewStrategy(config.Warehouses.AllocationStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock and reservations recorded before warehouses belong to the default warehouse
+	warehouseUsecase := usecase.NewWarehouseUsecase(repos.WarehouseRepository, repos.InventoryRepository, repos.Transactor)
+	defaultWarehouse, err := warehouseUsecase.EnsureDefaultWarehouse(ctx, config.Warehouses.DefaultCode, config.Warehouses.DefaultName)
+	if err != nil {
+		return nil, err
 	}
+
+	// Stock changes announced on the inventory topic are pushed to availability streams
+	availabilityStream := usecase.NewAvailabilityStreamUsecase(
+		repos.InventoryRepository,
+		usecase.AvailabilityStreamOptions{MaxProducts: config.Streaming.MaxProducts},
+	)
+
+	inventoryUsecase := usecase.NewInventoryUsecase(
+		repos.InventoryRepository,
+		repos.WarehouseRepository,
+		repos.Transactor,
+		eventService,
+		allocator,
+		usecase.InventoryOptions{DefaultWarehouseID: defaultWarehouse.ID},
+	)
+	return &Usecases{
+		InventoryUsecase: inventoryUsecase,
+		WarehouseUsecase: warehouseUsecase,
+		ReservationUsecase: usecase.NewReservationProcessorUsecase(
+			repos.InventoryRepository,
+			eventService,
+			inventoryUsecase,
+			usecase.ReservationOptions{ExpiryBatchSize: config.Expiry.BatchSize},
+		),
+		CycleCountUsecase: usecase.NewCycleCountUsecase(
+			repos.CycleCountRepository,
+			repos.InventoryRepository,
+			repos.WarehouseRepository,
+			repos.Transactor,
+			eventService,
+		),
+		Reconciliation: usecase.NewReconciliationUsecase(
+			repos.InventoryRepository,
+			repos.WarehouseRepository,
+			repos.Transactor,
+			eventService,
+			usecase.ReconciliationOptions{BatchSize: config.Ledger.BatchSize},
+		),
+		SupplierUsecase: usecase.NewSupplierUsecase(repos.SupplierRepository),
+		PurchaseOrders: usecase.NewPurchaseOrderUsecase(
+			repos.PurchaseOrderRepo,
+			repos.SupplierRepository,
+			repos.InventoryRepository,
+			repos.WarehouseRepository,
+			repos.Transactor,
+			eventService,
+			usecase.PurchaseOrderOptions{DefaultWarehouseID: defaultWarehouse.ID},
+		),
+		AvailabilityStream: availabilityStream,
+	}, nil
 }
 
 // initControllers initializes all controllers
-func initControllers(usecases *Usecases, log applogger.Logger) *Controllers {
+func initControllers(usecases *Usecases, config appconfig.ServerConfig, log applogger.Logger) *Controllers {
 	return &Controllers{
-		HTTP: httpctl.NewInventoryHandler(usecases.InventoryUsecase, log),
+		HTTP:           httpctl.NewInventoryHandler(usecases.InventoryUsecase, log),
+		Warehouse:      httpctl.NewWarehouseHandler(usecases.WarehouseUsecase, log),
+		CycleCount:     httpctl.NewCycleCountHandler(usecases.CycleCountUsecase, log),
+		Reconciliation: httpctl.NewReconciliationHandler(usecases.Reconciliation, log),
+		PurchaseOrder:  httpctl.NewPurchaseOrderHandler(usecases.SupplierUsecase, usecases.PurchaseOrders, log),
+		Availability:   httpctl.NewAvailabilityStreamHandler(usecases.AvailabilityStream, config.WriteTimeout, log),
+		GRPC:           grpcctl.NewInventoryServer(usecases.InventoryUsecase, usecases.AvailabilityStream, log),
 	}
 }
 
 // initServers initializes and starts all servers
 func initServers(config *appconfig.Config, controllers *Controllers, log applogger.Logger) *Servers {
 	// Initialize HTTP server
-	httpServer := initHTTPServer(config.Server, controllers.HTTP, log)
+	httpServer := initHTTPServer(config.Server, controllers, log)
 
 	// Start HTTP server
 	go func() {
@@ -220,13 +369,17 @@ func initServers(config *appconfig.Config, controllers *Controllers, log applogg
 		}
 	}()
 
+	// Initialize and start gRPC server
+	grpcServer := initGRPCServer(config.GRPC, controllers.GRPC, log)
+
 	return &Servers{
 		HTTP: httpServer,
+		GRPC: grpcServer,
 	}
 }
 
 // initHTTPServer initializes the HTTP server
-func initHTTPServer(config appconfig.ServerConfig, handler *httpctl.InventoryHandler, log applogger.Logger) *fiber.App {
+func initHTTPServer(config appconfig.ServerConfig, controllers *Controllers, log applogger.Logger) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
@@ -257,11 +410,36 @@ func initHTTPServer(config appconfig.ServerConfig, handler *httpctl.InventoryHan
 
 	// Register routes
 	api := app.Group("/api")
-	handler.RegisterRoutes(api)
+	controllers.HTTP.RegisterRoutes(api)
+	controllers.Warehouse.RegisterRoutes(api)
+	controllers.CycleCount.RegisterRoutes(api)
+	controllers.Reconciliation.RegisterRoutes(api)
+	controllers.PurchaseOrder.RegisterRoutes(api)
+	controllers.Availability.RegisterRoutes(api)
 
 	return app
 }
 
+// initGRPCServer initializes and starts the gRPC server
+func initGRPCServer(config appconfig.GRPCConfig, server *grpcctl.InventoryServer, log applogger.Logger) *grpc.Server {
+	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%s", config.Port))
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC", "error", err)
+	}
+
+	s := grpc.NewServer()
+	pb.RegisterInventoryServiceServer(s, server)
+
+	log.Info("Starting gRPC server", "port", config.Port)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatal("Failed to serve gRPC", "error", err)
+		}
+	}()
+
+	return s
+}
+
 // handleGracefulShutdown configures graceful shutdown for all servers
 func handleGracefulShutdown(ctx context.Context, cancel context.CancelFunc, servers *Servers, log applogger.Logger) {
 	quit := make(chan os.Signal, 1)
@@ -276,7 +454,7 @@ func handleGracefulShutdown(ctx context.Context, cancel context.CancelFunc, serv
 	}
 
 	// Shutdown gRPC server
-	// servers.GRPC.GracefulStop()
+	servers.GRPC.GracefulStop()
 
 	cancel()
 	log.Info("Shutdown complete")
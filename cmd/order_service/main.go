@@ -19,6 +19,7 @@ import (
 	"google.golang.org/grpc"
 
 	// Update these imports to match your project structure
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/adapter/client"
 	grpcctl "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/adapter/controller/grpc"
 	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/adapter/controller/grpc/proto"
 	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/adapter/controller/http"
@@ -30,6 +31,7 @@ import (
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/repository"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/service"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/usecase/interfaces"
 	applogger "github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
 )
 
@@ -42,6 +44,9 @@ type Repositories struct {
 type Services struct {
 	EventPublisher  service.EventPublisherService
 	EventSubscriber service.EventSubscriberService
+	AddressProvider interfaces.AddressProvider
+	PriceProvider   interfaces.PriceProvider
+	StockChecker    interfaces.StockChecker
 }
 
 // Usecases holds all usecase implementations
@@ -100,9 +105,38 @@ func main() {
 		log.Fatal("Failed to initialize Kafka producer", "error", err)
 	}
 	eventServicePublisher := eventSvc.NewKafkaEventPublisherService(kafkaProducer, log)
+
+	// Initialize the user service client used for default addresses
+	userClient, err := client.NewUserServiceClient(config.Services.UserService)
+	if err != nil {
+		log.Fatal("Failed to initialize user service client", "error", err)
+	}
+	defer userClient.Close()
+
+	// Initialize the product service client used to price order items
+	productClient, err := client.NewProductServiceClient(config.Services.ProductService)
+	if err != nil {
+		log.Fatal("Failed to initialize product service client", "error", err)
+	}
+	defer productClient.Close()
+
+	// Initialize the inventory service client used to check stock at checkout
+	inventoryClient, err := client.NewInventoryServiceClient(config.Services.InventoryService)
+	if err != nil {
+		log.Fatal("Failed to initialize inventory service client", "error", err)
+	}
+	defer inventoryClient.Close()
+
+	services := &Services{
+		EventPublisher:  eventServicePublisher,
+		AddressProvider: userClient,
+		PriceProvider:   productClient,
+		StockChecker:    inventoryClient,
+	}
+
 	// Initialize usecases
 	// usecases := initUsecases(repositories, nil) // We'll set event service after initializing usecases
-	usecases := initUsecases(repositories, eventServicePublisher)
+	usecases := initUsecases(repositories, services)
 
 	// Initialize Kafka consumer (needs usecase)
 	kafkaConsumer, err := consumer.NewKafkaConsumer(
@@ -176,9 +210,9 @@ func initRepositories(db *mongo.Database) *Repositories {
 }
 
 // initUsecases initializes all usecases
-func initUsecases(repos *Repositories, eventService service.EventPublisherService) *Usecases {
+func initUsecases(repos *Repositories, services *Services) *Usecases {
 	return &Usecases{
-		OrderUsecase: usecase.NewOrderUsecase(repos.OrderRepository, eventService),
+		OrderUsecase: usecase.NewOrderUsecase(repos.OrderRepository, services.EventPublisher, services.AddressProvider, services.PriceProvider, services.StockChecker),
 	}
 }
 
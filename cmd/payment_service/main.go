@@ -21,6 +21,7 @@ import (
 
 	// Update these imports to match your project structure
 
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/allocation"
 	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/http"
 	eventSvc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
 	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
@@ -36,6 +37,10 @@ import (
 // Repositories holds all repository implementations
 type Repositories struct {
 	InventoryRepository repository.InventoryRepository
+	WarehouseRepository repository.WarehouseRepository
+	SupplierRepository  repository.SupplierRepository
+	PurchaseOrderRepo   repository.PurchaseOrderRepository
+	Transactor          repository.Transactor
 }
 
 // Services holds all service implementations
@@ -48,6 +53,7 @@ type Services struct {
 type Usecases struct {
 	InventoryUsecase   usecase.InventoryUsecase
 	ReservationUsecase usecase.ReservationProcessorUsecase
+	PurchaseOrders     usecase.PurchaseOrderUsecase
 }
 
 // Controllers holds all controllers
@@ -101,6 +107,7 @@ func main() {
 		Brokers:         config.Messaging.Brokers,
 		InventoryTopic:  config.Messaging.InventoryTopic,
 		OrderTopic:      config.Messaging.OrderTopic,
+		ProductTopic:    config.Messaging.ProductTopic,
 		ConsumerGroupID: config.Messaging.ConsumerGroupID,
 	}
 	eventServicePublisher, err := eventSvc.NewKafkaEventPublisher(eventConfig)
@@ -109,10 +116,13 @@ func main() {
 	}
 	// Initialize usecases
 	// usecases := initUsecases(repositories, nil) // We'll set event service after initializing usecases
-	usecases := initUsecases(repositories, eventServicePublisher)
+	usecases, err := initUsecases(ctx, repositories, eventServicePublisher, config)
+	if err != nil {
+		log.Fatal("Failed to initialize usecases", "error", err)
+	}
 
 	// Initialize Kafka consumer (needs usecase)
-	kafkaConsumer, err := eventSvc.NewKafkaEventSubscriber(eventConfig, usecases.ReservationUsecase)
+	kafkaConsumer, err := eventSvc.NewKafkaEventSubscriber(eventConfig, usecases.ReservationUsecase, usecases.InventoryUsecase, usecases.PurchaseOrders)
 	if err != nil {
 		log.Fatal("Failed to initialize Kafka consumer", "error", err)
 	}
@@ -167,7 +177,17 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 	log.Info("Connected to database")
 
 	// Auto migrate models
-	if err := db.AutoMigrate(&model.InventoryItem{}, &model.InventoryReservation{}, &model.StockTransaction{}); err != nil {
+	if err := db.AutoMigrate(
+		&model.InventoryItem{},
+		&model.InventoryReservation{},
+		&model.StockTransaction{},
+		&model.Warehouse{},
+		&model.StockLevel{},
+		&model.StockTransfer{},
+		&model.Supplier{},
+		&model.PurchaseOrder{},
+		&model.PurchaseOrderLine{},
+	); err != nil {
 		return nil, err
 	}
 
@@ -188,16 +208,53 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 func initRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
 		InventoryRepository: gormrepo.NewGormInventoryRepository(db),
+		WarehouseRepository: gormrepo.NewGormWarehouseRepository(db),
+		SupplierRepository:  gormrepo.NewGormSupplierRepository(db),
+		PurchaseOrderRepo:   gormrepo.NewGormPurchaseOrderRepository(db),
+		Transactor:          gormrepo.NewGormTransactor(db),
 	}
 }
 
 // initUsecases initializes all usecases
-func initUsecases(repos *Repositories, eventService service.EventPublisherService) *Usecases {
-	inventoryUsecase := usecase.NewInventoryUsecase(repos.InventoryRepository, eventService)
-	return &Usecases{
-		InventoryUsecase:   inventoryUsecase,
-		ReservationUsecase: usecase.NewReservationProcessorUsecase(repos.InventoryRepository, eventService, inventoryUsecase),
+func initUsecases(ctx context.Context, repos *Repositories, eventService service.EventPublisherService, config *appconfig.Config) (*Usecases, error) {
+	allocator, err := allocation.NewStrategy(config.Warehouses.AllocationStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock and reservations recorded before warehouses belong to the default warehouse
+	warehouseUsecase := usecase.NewWarehouseUsecase(repos.WarehouseRepository, repos.InventoryRepository, repos.Transactor)
+	defaultWarehouse, err := warehouseUsecase.EnsureDefaultWarehouse(ctx, config.Warehouses.DefaultCode, config.Warehouses.DefaultName)
+	if err != nil {
+		return nil, err
 	}
+
+	inventoryUsecase := usecase.NewInventoryUsecase(
+		repos.InventoryRepository,
+		repos.WarehouseRepository,
+		repos.Transactor,
+		eventService,
+		allocator,
+		usecase.InventoryOptions{DefaultWarehouseID: defaultWarehouse.ID},
+	)
+	return &Usecases{
+		InventoryUsecase: inventoryUsecase,
+		ReservationUsecase: usecase.NewReservationProcessorUsecase(
+			repos.InventoryRepository,
+			eventService,
+			inventoryUsecase,
+			usecase.ReservationOptions{ExpiryBatchSize: config.Expiry.BatchSize},
+		),
+		PurchaseOrders: usecase.NewPurchaseOrderUsecase(
+			repos.PurchaseOrderRepo,
+			repos.SupplierRepository,
+			repos.InventoryRepository,
+			repos.WarehouseRepository,
+			repos.Transactor,
+			eventService,
+			usecase.PurchaseOrderOptions{DefaultWarehouseID: defaultWarehouse.ID},
+		),
+	}, nil
 }
 
 // initControllers initializes all controllers
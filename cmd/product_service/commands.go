@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// runCommand runs an admin subcommand and writes its result to out as JSON:
+//
+//	migrate-inventory [-batch N]   move the stock records kept here to the inventory service
+func runCommand(ctx context.Context, usecases *Usecases, args []string, out io.Writer) error {
+	var result interface{}
+	switch args[0] {
+	case "migrate-inventory":
+		fs := flag.NewFlagSet("migrate-inventory", flag.ContinueOnError)
+		batch := fs.Int("batch", 100, "records migrated per batch")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		migration, err := usecases.InventoryUsecase.MigrateLegacyInventory(ctx, *batch)
+		if err != nil {
+			return err
+		}
+		result = migration
+
+	default:
+		return fmt.Errorf("unknown command %q; expected migrate-inventory", args[0])
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"os/signal"
 	"runtime/debug"
@@ -21,15 +22,24 @@ import (
 	gormlogger "gorm.io/gorm/logger"
 
 	// Update these imports to match your project structure
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/client"
 	grpcctl "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/grpc"
 	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/grpc/proto"
 	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/http"
+	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/event"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/imaging"
 
 	gormrepo "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/scheduler"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/search"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/storage"
 	appconfig "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/config"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/valueobject"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
 	applogger "github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
 )
 
@@ -38,6 +48,12 @@ type Repositories struct {
 	ProductRepository   repository.ProductRepository
 	CategoryRepository  repository.CategoryRepository
 	InventoryRepository repository.InventoryRepository
+	VariantRepository   repository.VariantRepository
+	MediaRepository     repository.MediaRepository
+	ImportJobRepository repository.ImportJobRepository
+	OutboxRepository    repository.OutboxRepository
+	PriceRepository     repository.PriceRepository
+	Transactor          repository.Transactor
 }
 
 // Usecases holds all usecase implementations
@@ -45,6 +61,12 @@ type Usecases struct {
 	ProductUsecase   usecase.ProductUsecase
 	CategoryUsecase  usecase.CategoryUsecase
 	InventoryUsecase usecase.InventoryUsecase
+	VariantUsecase   usecase.VariantUsecase
+	SearchUsecase    usecase.SearchUsecase
+	MediaUsecase     usecase.MediaUsecase
+	BulkUsecase      usecase.BulkProductUsecase
+	OutboxUsecase    usecase.OutboxUsecase
+	PriceUsecase     usecase.PriceUsecase
 }
 
 // Controllers holds all controllers
@@ -97,8 +119,62 @@ func main() {
 	// Initialize repositories
 	repositories := initRepositories(db)
 
+	// Fill in materialised category paths for categories created before they existed
+	if err := repositories.CategoryRepository.RebuildPaths(ctx); err != nil {
+		log.Fatal("Failed to rebuild category paths", "error", err)
+	}
+
+	// Products created before the price book existed need their current price on record
+	if _, err := repositories.PriceRepository.BackfillHistory(ctx); err != nil {
+		log.Fatal("Failed to backfill price history", "error", err)
+	}
+
+	// Initialize event publisher
+	eventPublisher, err := messaging.NewKafkaEventPublisher(&messaging.KafkaConfig{
+		Brokers:      config.Kafka.Brokers,
+		ProductTopic: config.Kafka.ProductTopic,
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize event publisher", "error", err)
+	}
+	defer eventPublisher.Close()
+
+	// Initialize the inventory service client; stock is held by the inventory service
+	inventoryClient, err := client.NewInventoryServiceClient(config.Services.InventoryService)
+	if err != nil {
+		log.Fatal("Failed to initialize inventory service client", "error", err)
+	}
+	defer inventoryClient.Close()
+
 	// Initialize usecases
-	usecases := initUsecases(repositories)
+	usecases := initUsecases(repositories, initSearchIndex(config.Search, log), initMediaStorage(config.Media, log), eventPublisher, inventoryClient, config)
+
+	// Run an admin subcommand instead of the service when one is given
+	if flag.NArg() > 0 {
+		if err := runCommand(ctx, usecases, flag.Args(), os.Stdout); err != nil {
+			log.Fatal("Command failed", "command", flag.Arg(0), "error", err)
+		}
+		return
+	}
+
+	// Build the search index from the product database
+	if err := usecases.SearchUsecase.Reindex(ctx); err != nil {
+		log.Fatal("Failed to build search index", "error", err)
+	}
+
+	// Imports run in memory and cannot survive a restart
+	if err := usecases.BulkUsecase.FailInterruptedImports(ctx); err != nil {
+		log.Fatal("Failed to clean up interrupted imports", "error", err)
+	}
+
+	// Publish and unpublish products on schedule
+	scheduler.NewLifecycleScheduler(usecases.ProductUsecase, config.Lifecycle.SchedulerInterval, log).Start(ctx)
+
+	// Apply scheduled price changes
+	scheduler.NewPriceScheduler(usecases.PriceUsecase, config.Pricing.SchedulerInterval, log).Start(ctx)
+
+	// Publish product events stored in the outbox
+	scheduler.NewOutboxRelay(usecases.OutboxUsecase, config.Outbox.PollInterval, log).Start(ctx)
 
 	// Initialize controllers
 	controllers := initControllers(usecases, log)
@@ -134,10 +210,17 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 	log.Info("Connected to database")
 
 	// Auto migrate models
-	if err := db.AutoMigrate(&model.Product{}, &model.Category{}, &model.Inventory{}); err != nil {
+	if err := db.AutoMigrate(&model.Product{}, &model.Category{}, &model.Inventory{}, &model.ProductOption{}, &model.ProductVariant{}, &model.ProductMedia{}, &model.ImportJob{}, &model.ImportRowError{}, &model.OutboxEvent{}, &model.PriceChange{}, &model.ScheduledPrice{}, &model.Sale{}); err != nil {
 		return nil, err
 	}
 
+	// Products created before the lifecycle was introduced use the old statuses
+	for legacy, status := range valueobject.LegacyProductStatuses {
+		if err := db.Unscoped().Model(&model.Product{}).Where("status = ?", legacy).Update("status", status.String()).Error; err != nil {
+			return nil, err
+		}
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -157,34 +240,98 @@ func initRepositories(db *gorm.DB) *Repositories {
 		ProductRepository:   gormrepo.NewGormProductRepository(db),
 		CategoryRepository:  gormrepo.NewGormCategoryRepository(db),
 		InventoryRepository: gormrepo.NewGormInventoryRepository(db),
+		VariantRepository:   gormrepo.NewGormVariantRepository(db),
+		MediaRepository:     gormrepo.NewGormMediaRepository(db),
+		ImportJobRepository: gormrepo.NewGormImportJobRepository(db),
+		OutboxRepository:    gormrepo.NewGormOutboxRepository(db),
+		PriceRepository:     gormrepo.NewGormPriceRepository(db),
+		Transactor:          gormrepo.NewGormTransactor(db),
+	}
+}
+
+// initSearchIndex initializes the search index for the configured backend
+func initSearchIndex(config appconfig.SearchConfig, log applogger.Logger) interfaces.SearchIndex {
+	if config.Backend != "" && config.Backend != "memory" {
+		log.Warn("Unknown search backend, using the embedded index", "backend", config.Backend)
 	}
+	return search.NewMemoryIndex(config.PriceBuckets)
+}
+
+// initMediaStorage initializes the media storage for the configured backend
+func initMediaStorage(config appconfig.MediaConfig, log applogger.Logger) interfaces.MediaStorage {
+	if config.Backend != "" && config.Backend != "local" {
+		log.Warn("Unknown media backend, using local storage", "backend", config.Backend)
+	}
+	return storage.NewLocalStorage(config.LocalDir, config.BaseURL)
 }
 
 // initUsecases initializes all usecases
-func initUsecases(repos *Repositories) *Usecases {
-	categoryUsecase := usecase.NewCategoryUsecase(repos.CategoryRepository, repos.ProductRepository)
-	inventoryUsecase := usecase.NewInventoryUsecase(repos.InventoryRepository, repos.ProductRepository)
-	productUsecase := usecase.NewProductUsecase(repos.ProductRepository, repos.CategoryRepository, repos.InventoryRepository)
+func initUsecases(repos *Repositories, searchIndex interfaces.SearchIndex, mediaStorage interfaces.MediaStorage, eventPublisher service.EventPublisherService, inventoryService interfaces.InventoryService, config *appconfig.Config) *Usecases {
+	categoryUsecase := usecase.NewCategoryUsecase(repos.CategoryRepository, repos.ProductRepository, repos.Transactor)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryService, repos.InventoryRepository, repos.ProductRepository, repos.VariantRepository)
+	mediaUsecase := usecase.NewMediaUsecase(
+		repos.MediaRepository,
+		repos.ProductRepository,
+		repos.OutboxRepository,
+		repos.Transactor,
+		searchIndex,
+		mediaStorage,
+		imaging.NewProcessor(),
+		usecase.MediaOptions{
+			ThumbnailWidths: config.Media.ThumbnailWidths,
+			MaxUploadSize:   config.Media.MaxUploadSize,
+		},
+	)
+	productUsecase := usecase.NewProductUsecase(repos.ProductRepository, repos.CategoryRepository, repos.VariantRepository, searchIndex, mediaUsecase, repos.OutboxRepository, repos.PriceRepository, repos.Transactor)
+	variantUsecase := usecase.NewVariantUsecase(repos.VariantRepository, repos.ProductRepository, repos.OutboxRepository, repos.Transactor)
 
 	return &Usecases{
 		ProductUsecase:   productUsecase,
 		CategoryUsecase:  categoryUsecase,
 		InventoryUsecase: inventoryUsecase,
+		VariantUsecase:   variantUsecase,
+		SearchUsecase:    usecase.NewSearchUsecase(searchIndex, repos.ProductRepository, repos.CategoryRepository),
+		MediaUsecase:     mediaUsecase,
+		BulkUsecase: usecase.NewBulkProductUsecase(
+			repos.ImportJobRepository,
+			repos.ProductRepository,
+			repos.CategoryRepository,
+			repos.VariantRepository,
+			repos.OutboxRepository,
+			repos.PriceRepository,
+			repos.Transactor,
+			searchIndex,
+			usecase.BulkOptions{
+				BatchSize: config.Import.BatchSize,
+				MaxRows:   config.Import.MaxRows,
+				Instance:  config.Import.InstanceID,
+			},
+		),
+		OutboxUsecase: usecase.NewOutboxUsecase(
+			repos.OutboxRepository,
+			repos.Transactor,
+			eventPublisher,
+			usecase.OutboxOptions{
+				BatchSize: config.Outbox.BatchSize,
+				Retention: config.Outbox.Retention,
+			},
+		),
+		PriceUsecase: usecase.NewPriceUsecase(repos.PriceRepository, repos.ProductRepository, repos.VariantRepository, productUsecase, repos.Transactor),
 	}
 }
 
 // initControllers initializes all controllers
 func initControllers(usecases *Usecases, log applogger.Logger) *Controllers {
 	return &Controllers{
-		HTTP: httpctl.NewProductHandler(usecases.ProductUsecase, usecases.CategoryUsecase, usecases.InventoryUsecase, log),
-		GRPC: grpcctl.NewProductServer(usecases.ProductUsecase, usecases.CategoryUsecase, usecases.InventoryUsecase, log),
+		HTTP: httpctl.NewProductHandler(usecases.ProductUsecase, usecases.CategoryUsecase, usecases.InventoryUsecase, usecases.VariantUsecase, usecases.SearchUsecase, usecases.MediaUsecase, usecases.BulkUsecase, usecases.PriceUsecase, log),
+		GRPC: grpcctl.NewProductServer(usecases.ProductUsecase, usecases.CategoryUsecase, usecases.InventoryUsecase, usecases.VariantUsecase, usecases.SearchUsecase, usecases.PriceUsecase, log),
 	}
 }
 
 // initServers initializes and starts all servers
 func initServers(config *appconfig.Config, controllers *Controllers, log applogger.Logger) *Servers {
 	// Initialize HTTP server
-	httpServer := initHTTPServer(config.Server, controllers.HTTP, log)
+	httpServer := initHTTPServer(config.Server, config.Media, controllers.HTTP, log)
 
 	// Start HTTP server
 	go func() {
@@ -204,11 +351,12 @@ func initServers(config *appconfig.Config, controllers *Controllers, log applogg
 }
 
 // initHTTPServer initializes the HTTP server
-func initHTTPServer(config appconfig.ServerConfig, handler *httpctl.ProductHandler, log applogger.Logger) *fiber.App {
+func initHTTPServer(config appconfig.ServerConfig, mediaConfig appconfig.MediaConfig, handler *httpctl.ProductHandler, log applogger.Logger) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
 		IdleTimeout:  config.IdleTimeout,
+		BodyLimit:    config.BodyLimit,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 
@@ -233,6 +381,13 @@ func initHTTPServer(config appconfig.ServerConfig, handler *httpctl.ProductHandl
 		},
 	}))
 
+	// Serve locally stored media under the path of its base URL
+	if mediaConfig.Backend == "local" {
+		if mediaURL, err := url.Parse(mediaConfig.BaseURL); err == nil && mediaURL.Path != "" {
+			app.Static(mediaURL.Path, mediaConfig.LocalDir)
+		}
+	}
+
 	// Register routes
 	api := app.Group("/api")
 	handler.RegisterRoutes(api)
@@ -20,30 +20,46 @@ import (
 	gormlogger "gorm.io/gorm/logger"
 
 	// Update these imports to match your project structure
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/client"
 	grpcctl "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/grpc"
 	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/grpc/proto"
 	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/http"
+	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/event"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/mailer"
+	oidcadapter "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/oidc"
 
 	gormrepo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
 	appconfig "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/config"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/service"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
 	applogger "github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
 )
 
 // Repositories holds all repository implementations
 type Repositories struct {
-	UserRepository  repository.UserRepository
-	TokenRepository repository.TokenRepository
+	UserRepository         repository.UserRepository
+	TokenRepository        repository.TokenRepository
+	LoginAttemptRepository repository.LoginAttemptRepository
+	IdentityRepository     repository.IdentityRepository
+	AddressRepository      repository.AddressRepository
+	ErasureRepository      repository.ErasureRequestRepository
 }
 
 // Usecases holds all usecase implementations
 type Usecases struct {
-	UserUsecase  usecase.UserUsecase
-	TokenUsecase usecase.TokenUsecase
-	AuthUsecase  usecase.AuthUsecase
+	UserUsecase    usecase.UserUsecase
+	TokenUsecase   usecase.TokenUsecase
+	AuthUsecase    usecase.AuthUsecase
+	AccountUsecase usecase.AccountUsecase
+	AddressUsecase usecase.AddressUsecase
+	TwoFactor      usecase.TwoFactorUsecase
+	SocialLogin    usecase.SocialLoginUsecase
+	Privacy        usecase.PrivacyUsecase
+	UserAdmin      usecase.UserAdminUsecase
 }
 
 // Controllers holds all controllers
@@ -96,8 +112,35 @@ func main() {
 	// Initialize repositories
 	repositories := initRepositories(db)
 
+	// Initialize event publisher
+	kafkaConfig := &messaging.KafkaConfig{
+		Brokers:         config.Kafka.Brokers,
+		UserTopic:       config.Kafka.UserTopic,
+		ConsumerGroupID: config.Kafka.ConsumerGroupID,
+	}
+	eventPublisher, err := messaging.NewKafkaEventPublisher(kafkaConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize event publisher", "error", err)
+	}
+	defer eventPublisher.Close()
+
+	// Initialize personal data sources for data-subject exports
+	orderClient, err := client.NewOrderServiceClient(config.Services.OrderService)
+	if err != nil {
+		log.Fatal("Failed to initialize order service client", "error", err)
+	}
+	defer orderClient.Close()
+
 	// Initialize usecases
-	usecases := initUsecases(repositories, config)
+	usecases := initUsecases(repositories, eventPublisher, []interfaces.PersonalDataSource{orderClient}, config, log)
+
+	// Initialize event subscriber for erasure acknowledgements
+	eventSubscriber, err := messaging.NewKafkaEventSubscriber(kafkaConfig, usecases.Privacy, log)
+	if err != nil {
+		log.Fatal("Failed to initialize event subscriber", "error", err)
+	}
+	eventSubscriber.Start(ctx)
+	defer eventSubscriber.Close()
 
 	// Initialize controllers
 	controllers := initControllers(usecases, log)
@@ -132,11 +175,22 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 	}
 	log.Info("Connected to database")
 
+	// Users created before email verification existed have no email_verified column yet
+	backfillEmailVerified := db.Migrator().HasTable(&model.User{}) && !db.Migrator().HasColumn(&model.User{}, "EmailVerified")
+
 	// Auto migrate models
-	if err := db.AutoMigrate(&model.Token{}, &model.User{}); err != nil {
+	if err := db.AutoMigrate(&model.Token{}, &model.User{}, &model.LoginAttempt{}, &model.Identity{}, &model.OIDCLoginState{}, &model.Address{}, &model.ErasureRequest{}); err != nil {
 		return nil, err
 	}
 
+	// Treat those users as verified so requiring verification does not lock them out
+	if backfillEmailVerified {
+		if err := db.Model(&model.User{}).Where("1 = 1").Update("email_verified", true).Error; err != nil {
+			return nil, err
+		}
+		log.Info("Marked existing users as email verified")
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -153,31 +207,110 @@ func initDatabase(config appconfig.DatabaseConfig, log applogger.Logger) (*gorm.
 // initRepositories initializes all repositories
 func initRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		UserRepository:  gormrepo.NewGormUserRepository(db),
-		TokenRepository: gormrepo.NewGormTokenRepository(db),
+		UserRepository:         gormrepo.NewGormUserRepository(db),
+		TokenRepository:        gormrepo.NewGormTokenRepository(db),
+		LoginAttemptRepository: gormrepo.NewGormLoginAttemptRepository(db),
+		IdentityRepository:     gormrepo.NewGormIdentityRepository(db),
+		AddressRepository:      gormrepo.NewGormAddressRepository(db),
+		ErasureRepository:      gormrepo.NewGormErasureRequestRepository(db),
 	}
 }
 
+// initMailer initializes the mailer for the configured driver
+func initMailer(config appconfig.MailerConfig, log applogger.Logger) interfaces.Mailer {
+	if config.Driver == "smtp" {
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     config.Host,
+			Port:     config.Port,
+			Username: config.Username,
+			Password: config.Password,
+			From:     config.From,
+		})
+	}
+	return mailer.NewFileMailer(config.Dir, config.From, log)
+}
+
 // initUsecases initializes all usecases
-func initUsecases(repos *Repositories, config *appconfig.Config) *Usecases {
+func initUsecases(repos *Repositories, eventPub service.EventPublisherService, dataSources []interfaces.PersonalDataSource, config *appconfig.Config, log applogger.Logger) *Usecases {
 	jwtSvc := jwt_service.NewJWTService(config.JWT)
 
 	userUsecase := usecase.NewUserUsecase(repos.UserRepository)
 	tokenUsecase := usecase.NewTokenUsecase(repos.TokenRepository, jwtSvc)
-	authUsecase := usecase.NewAuthUsecase(userUsecase, tokenUsecase)
+	accountUsecase := usecase.NewAccountUsecase(
+		repos.UserRepository,
+		repos.TokenRepository,
+		initMailer(config.Mailer, log),
+		usecase.AccountOptions{
+			BaseURL:               config.Account.BaseURL,
+			VerificationTokenTTL:  config.Account.VerificationTokenTTL,
+			PasswordResetTokenTTL: config.Account.PasswordResetTokenTTL,
+		},
+	)
+	loginGuard := usecase.NewLoginGuardUsecase(
+		repos.LoginAttemptRepository,
+		eventPub,
+		usecase.LoginGuardOptions{
+			MaxAccountAttempts: config.Security.MaxAccountAttempts,
+			MaxIPAttempts:      config.Security.MaxIPAttempts,
+			AttemptWindow:      config.Security.AttemptWindow,
+			BaseLockout:        config.Security.BaseLockout,
+			MaxLockout:         config.Security.MaxLockout,
+		},
+	)
+	twoFactor := usecase.NewTwoFactorUsecase(
+		repos.UserRepository,
+		repos.TokenRepository,
+		usecase.TwoFactorOptions{
+			Issuer:       config.TwoFactor.Issuer,
+			ChallengeTTL: config.TwoFactor.ChallengeTTL,
+		},
+	)
+	authUsecase := usecase.NewAuthUsecase(userUsecase, tokenUsecase, accountUsecase, loginGuard, twoFactor, config.Account.RequireEmailVerification)
+
+	providers := make([]interfaces.IdentityProvider, 0, len(config.OIDC.Providers))
+	for _, p := range config.OIDC.Providers {
+		providers = append(providers, oidcadapter.NewProvider(p))
+	}
+	socialLogin := usecase.NewSocialLoginUsecase(
+		providers,
+		repos.IdentityRepository,
+		repos.UserRepository,
+		authUsecase,
+		accountUsecase,
+		config.OIDC.StateTTL,
+	)
+	privacy := usecase.NewPrivacyUsecase(
+		repos.UserRepository,
+		repos.AddressRepository,
+		repos.IdentityRepository,
+		repos.TokenRepository,
+		repos.LoginAttemptRepository,
+		repos.ErasureRepository,
+		dataSources,
+		eventPub,
+		usecase.PrivacyOptions{
+			ErasureServices: config.Privacy.ErasureServices,
+		},
+	)
 
 	return &Usecases{
-		UserUsecase:  userUsecase,
-		TokenUsecase: tokenUsecase,
-		AuthUsecase:  authUsecase,
+		UserUsecase:    userUsecase,
+		TokenUsecase:   tokenUsecase,
+		AuthUsecase:    authUsecase,
+		AccountUsecase: accountUsecase,
+		AddressUsecase: usecase.NewAddressUsecase(repos.AddressRepository, repos.UserRepository),
+		TwoFactor:      twoFactor,
+		SocialLogin:    socialLogin,
+		Privacy:        privacy,
+		UserAdmin:      usecase.NewUserAdminUsecase(repos.UserRepository, repos.TokenRepository),
 	}
 }
 
 // initControllers initializes all controllers
 func initControllers(usecases *Usecases, log applogger.Logger) *Controllers {
 	return &Controllers{
-		HTTP: httpctl.NewUserHandler(usecases.AuthUsecase, usecases.UserUsecase, log),
-		GRPC: grpcctl.NewUserServer(usecases.AuthUsecase, usecases.UserUsecase, log),
+		HTTP: httpctl.NewUserHandler(usecases.AuthUsecase, usecases.UserUsecase, usecases.AccountUsecase, usecases.AddressUsecase, usecases.TokenUsecase, usecases.TwoFactor, usecases.SocialLogin, usecases.Privacy, usecases.UserAdmin, log),
+		GRPC: grpcctl.NewUserServer(usecases.AuthUsecase, usecases.UserUsecase, usecases.AddressUsecase, log),
 	}
 }
 
@@ -209,6 +342,11 @@ func initHTTPServer(config appconfig.ServerConfig, handler *httpctl.UserHandler,
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
 		IdleTimeout:  config.IdleTimeout,
+		// Login throttling is keyed on the client IP, not the gateway's
+		ProxyHeader:             config.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          config.TrustedProxies,
+		EnableIPValidation:      true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 
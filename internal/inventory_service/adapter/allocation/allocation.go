@@ -0,0 +1,79 @@
+// Package allocation implements the strategies that decide which warehouses order items ship from
+package allocation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+)
+
+// NewStrategy returns the allocation strategy with the given name
+func NewStrategy(name string) (service.AllocationStrategy, error) {
+	switch name {
+	case service.AllocationPriority, "":
+		return PriorityStrategy{}, nil
+	case service.AllocationClosest:
+		return ClosestStrategy{}, nil
+	case service.AllocationFewestSplits:
+		return FewestSplitsStrategy{}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", entity.ErrUnknownAllocationStrategy, name)
+}
+
+// byPriority orders warehouses by priority, then by code so the order is stable
+func byPriority(a, b *entity.Warehouse) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return a.Code < b.Code
+}
+
+// allocateInOrder takes each product from its warehouses in the order given by less until the
+// requested quantity is allocated. Levels of warehouses missing from warehouses are ignored.
+func allocateInOrder(
+	items map[string]int,
+	levels []*entity.StockLevel,
+	warehouses map[string]*entity.Warehouse,
+	less func(a, b *entity.Warehouse) bool,
+) ([]entity.Allocation, error) {
+	byProduct := make(map[string][]*entity.StockLevel, len(items))
+	for _, level := range levels {
+		if _, ok := warehouses[level.WarehouseID]; !ok || level.AvailableQty <= 0 {
+			continue
+		}
+		byProduct[level.ProductID] = append(byProduct[level.ProductID], level)
+	}
+
+	var allocations []entity.Allocation
+	for _, productID := range sortedProductIDs(items) {
+		candidates := byProduct[productID]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return less(warehouses[candidates[i].WarehouseID], warehouses[candidates[j].WarehouseID])
+		})
+
+		remaining := items[productID]
+		for _, level := range candidates {
+			if remaining == 0 {
+				break
+			}
+			qty := min(remaining, level.AvailableQty)
+			allocations = append(allocations, entity.Allocation{ProductID: productID, WarehouseID: level.WarehouseID, Qty: qty})
+			remaining -= qty
+		}
+		if remaining > 0 {
+			return nil, entity.ErrInsufficientStock
+		}
+	}
+	return allocations, nil
+}
+
+func sortedProductIDs(items map[string]int) []string {
+	productIDs := make([]string, 0, len(items))
+	for productID := range items {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+	return productIDs
+}
@@ -0,0 +1,77 @@
+package allocation
+
+import (
+	"math"
+	"strings"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+)
+
+const earthRadiusKm = 6371.0
+
+// ClosestStrategy takes each product from the warehouses closest to the shipping address.
+// Distances are used when both ends have coordinates; otherwise warehouses in the destination
+// country come first, then those sharing the longest postal code prefix with it.
+type ClosestStrategy struct{}
+
+// Name returns the name the strategy is configured by
+func (ClosestStrategy) Name() string {
+	return service.AllocationClosest
+}
+
+// Allocate takes each product from the warehouses closest to destination, or in priority order
+// when there is no destination
+func (ClosestStrategy) Allocate(
+	items map[string]int,
+	levels []*entity.StockLevel,
+	warehouses map[string]*entity.Warehouse,
+	destination *entity.Address,
+) ([]entity.Allocation, error) {
+	if destination == nil {
+		return allocateInOrder(items, levels, warehouses, byPriority)
+	}
+	return allocateInOrder(items, levels, warehouses, func(a, b *entity.Warehouse) bool {
+		distA, okA := distanceKm(a, destination)
+		distB, okB := distanceKm(b, destination)
+		switch {
+		case okA && okB && distA != distB:
+			return distA < distB
+		case okA != okB:
+			return okA
+		}
+		if matchA, matchB := regionMatch(a, destination), regionMatch(b, destination); matchA != matchB {
+			return matchA > matchB
+		}
+		return byPriority(a, b)
+	})
+}
+
+// distanceKm returns the great-circle distance between a warehouse and an address, if both have coordinates
+func distanceKm(w *entity.Warehouse, a *entity.Address) (float64, bool) {
+	if !w.HasCoordinates() || !a.HasCoordinates() {
+		return 0, false
+	}
+	lat1, lat2 := radians(*w.Latitude), radians(*a.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(*a.Longitude - *w.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)), true
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// regionMatch scores how close a warehouse is to an address without coordinates:
+// the same country outranks any postal code match, and longer shared postal code prefixes rank higher
+func regionMatch(w *entity.Warehouse, a *entity.Address) int {
+	if a.Country == "" || !strings.EqualFold(w.Country, a.Country) {
+		return 0
+	}
+	prefix := 0
+	for prefix < len(w.PostalCode) && prefix < len(a.PostalCode) && w.PostalCode[prefix] == a.PostalCode[prefix] {
+		prefix++
+	}
+	return 100 + prefix
+}
@@ -0,0 +1,82 @@
+package allocation
+
+import (
+	"sort"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+)
+
+// FewestSplitsStrategy ships an order from as few warehouses as it can. It picks a warehouse that
+// holds the whole order when there is one; otherwise it repeatedly picks the warehouse covering the
+// most of what is left. Ties go to the higher priority warehouse.
+type FewestSplitsStrategy struct{}
+
+// Name returns the name the strategy is configured by
+func (FewestSplitsStrategy) Name() string {
+	return service.AllocationFewestSplits
+}
+
+// Allocate splits the order across as few warehouses as it can
+func (FewestSplitsStrategy) Allocate(
+	items map[string]int,
+	levels []*entity.StockLevel,
+	warehouses map[string]*entity.Warehouse,
+	_ *entity.Address,
+) ([]entity.Allocation, error) {
+	stock := make(map[string]map[string]int, len(warehouses))
+	for _, level := range levels {
+		if _, ok := warehouses[level.WarehouseID]; !ok || level.AvailableQty <= 0 {
+			continue
+		}
+		if _, ok := items[level.ProductID]; !ok {
+			continue
+		}
+		if stock[level.WarehouseID] == nil {
+			stock[level.WarehouseID] = map[string]int{}
+		}
+		stock[level.WarehouseID][level.ProductID] = level.AvailableQty
+	}
+
+	candidates := make([]*entity.Warehouse, 0, len(stock))
+	for warehouseID := range stock {
+		candidates = append(candidates, warehouses[warehouseID])
+	}
+	sort.Slice(candidates, func(i, j int) bool { return byPriority(candidates[i], candidates[j]) })
+
+	remaining := make(map[string]int, len(items))
+	for productID, qty := range items {
+		remaining[productID] = qty
+	}
+
+	var allocations []entity.Allocation
+	for len(remaining) > 0 {
+		var best *entity.Warehouse
+		bestUnits := 0
+		for _, warehouse := range candidates {
+			units := 0
+			for productID, qty := range remaining {
+				units += min(qty, stock[warehouse.ID][productID])
+			}
+			if units > bestUnits {
+				best, bestUnits = warehouse, units
+			}
+		}
+		if best == nil {
+			return nil, entity.ErrInsufficientStock
+		}
+
+		for _, productID := range sortedProductIDs(remaining) {
+			qty := min(remaining[productID], stock[best.ID][productID])
+			if qty == 0 {
+				continue
+			}
+			allocations = append(allocations, entity.Allocation{ProductID: productID, WarehouseID: best.ID, Qty: qty})
+			stock[best.ID][productID] -= qty
+			if remaining[productID] -= qty; remaining[productID] == 0 {
+				delete(remaining, productID)
+			}
+		}
+	}
+	return allocations, nil
+}
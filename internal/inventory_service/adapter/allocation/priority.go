@@ -0,0 +1,24 @@
+package allocation
+
+import (
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+)
+
+// PriorityStrategy takes each product from the warehouses in priority order
+type PriorityStrategy struct{}
+
+// Name returns the name the strategy is configured by
+func (PriorityStrategy) Name() string {
+	return service.AllocationPriority
+}
+
+// Allocate takes each product from the highest priority warehouses holding it
+func (PriorityStrategy) Allocate(
+	items map[string]int,
+	levels []*entity.StockLevel,
+	warehouses map[string]*entity.Warehouse,
+	_ *entity.Address,
+) ([]entity.Allocation, error) {
+	return allocateInOrder(items, levels, warehouses, byPriority)
+}
@@ -1,4 +1,4 @@
-// internal/product_service/adapter/controller/grpc/product_grpc.go
+// internal/inventory_service/adapter/controller/grpc/grpc.go
 package grpcctl
 
 import (
@@ -10,689 +10,372 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/grpc/proto"
-	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
-	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/grpc/proto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
 )
 
-// ProductServer implements the gRPC ProductService interface
-type ProductServer struct {
-	pb.UnimplementedProductServiceServer
-	productUsecase   usecase.ProductUsecase
-	categoryUsecase  usecase.CategoryUsecase
+// InventoryServer implements the gRPC InventoryService interface
+type InventoryServer struct {
+	pb.UnimplementedInventoryServiceServer
 	inventoryUsecase usecase.InventoryUsecase
+	streamUsecase    usecase.AvailabilityStreamUsecase
 	logger           logger.Logger
 }
 
-// NewProductServer creates a new ProductServer instance
-func NewProductServer(
-	pu usecase.ProductUsecase,
-	cu usecase.CategoryUsecase,
-	iu usecase.InventoryUsecase,
-	logger logger.Logger,
-) *ProductServer {
-	return &ProductServer{
-		productUsecase:   pu,
-		categoryUsecase:  cu,
+// NewInventoryServer creates a new InventoryServer instance
+func NewInventoryServer(iu usecase.InventoryUsecase, au usecase.AvailabilityStreamUsecase, logger logger.Logger) *InventoryServer {
+	return &InventoryServer{
 		inventoryUsecase: iu,
+		streamUsecase:    au,
 		logger:           logger,
 	}
 }
 
-// CreateProduct creates a new product
-func (s *ProductServer) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.ProductResponse, error) {
-	s.logger.Info("gRPC CreateProduct request received", "name", req.Name, "sku", req.Sku)
+// GetInventoryItem gets the inventory item of a product
+func (s *InventoryServer) GetInventoryItem(ctx context.Context, req *pb.GetInventoryItemRequest) (*pb.InventoryItem, error) {
+	s.logger.Info("gRPC GetInventoryItem request received", "productId", req.ProductId)
 
-	product := entity.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		CategoryID:  req.CategoryId,
-		ImageURL:    req.ImageUrl,
-		SKU:         req.Sku,
-		Status:      req.Status,
-	}
-
-	createdProduct, err := s.productUsecase.CreateProduct(ctx, &product)
-	if err != nil {
-		s.logger.Error("Failed to create product", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertProductToProto(createdProduct), nil
-}
-
-// GetProduct gets a product by ID
-func (s *ProductServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.ProductResponse, error) {
-	s.logger.Info("gRPC GetProduct request received", "id", req.Id)
-
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Product ID is required")
 	}
 
-	product, err := s.productUsecase.GetProductByID(ctx, req.Id)
+	item, err := s.inventoryUsecase.GetInventoryItem(ctx, req.ProductId)
 	if err != nil {
-		s.logger.Error("Failed to get product", "error", err)
+		s.logger.Error("Failed to get inventory item", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertProductToProto(product), nil
+	return convertItemToProto(item), nil
 }
 
-// GetProductBySKU gets a product by SKU
-func (s *ProductServer) GetProductBySKU(ctx context.Context, req *pb.GetProductBySKURequest) (*pb.ProductResponse, error) {
-	s.logger.Info("gRPC GetProductBySKU request received", "sku", req.Sku)
-
-	if req.Sku == "" {
-		return nil, status.Error(codes.InvalidArgument, "product SKU is required")
-	}
+// CheckAvailability checks whether each of several products can be ordered in the requested quantity.
+// Quantities requested for the same product are added up; results are ordered by product ID.
+func (s *InventoryServer) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
+	s.logger.Info("gRPC CheckAvailability request received", "items", len(req.Items))
 
-	product, err := s.productUsecase.GetProductBySKU(ctx, req.Sku)
+	items, err := convertQuantities(req.Items)
 	if err != nil {
-		s.logger.Error("Failed to get product by SKU", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertProductToProto(product), nil
-}
-
-// ListProducts lists products with optional filtering
-func (s *ProductServer) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
-	s.logger.Info("gRPC ListProducts request received", "page", req.Page, "pageSize", req.PageSize)
-
-	page := int(req.Page)
-	pageSize := int(req.PageSize)
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	// Convert filters from proto map to Go map
-	filters := make(map[string]interface{})
-	for k, v := range req.Filters {
-		filters[k] = v
+		return nil, err
 	}
 
-	products, total, err := s.productUsecase.ListProducts(ctx, page, pageSize, filters)
+	availabilities, err := s.inventoryUsecase.CheckAvailability(ctx, items)
 	if err != nil {
-		s.logger.Error("Failed to list products", "error", err)
+		s.logger.Error("Failed to check availability", "error", err)
 		return nil, handleError(err)
 	}
 
-	// Convert entities to proto responses
-	protoProducts := make([]*pb.ProductResponse, len(products))
-	for i, product := range products {
-		protoProducts[i] = convertProductToProto(product)
-	}
-
-	totalPages := total / pageSize
-	if total%pageSize != 0 {
-		totalPages++
+	resp := &pb.CheckAvailabilityResponse{Items: make([]*pb.Availability, len(availabilities))}
+	for i, availability := range availabilities {
+		resp.Items[i] = &pb.Availability{
+			ProductId:     availability.ProductID,
+			RequestedQty:  int32(availability.RequestedQty),
+			AvailableQty:  int32(availability.AvailableQty),
+			InStock:       availability.InStock,
+			Found:         availability.Found,
+			Backorderable: availability.Backorderable,
+		}
 	}
-
-	return &pb.ListProductsResponse{
-		Total:      int32(total),
-		Page:       int32(page),
-		PageSize:   int32(pageSize),
-		TotalPages: int32(totalPages),
-		Products:   protoProducts,
-	}, nil
+	return resp, nil
 }
 
-// UpdateProduct updates an existing product
-func (s *ProductServer) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.ProductResponse, error) {
-	s.logger.Info("gRPC UpdateProduct request received", "id", req.Id)
-
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	product := entity.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		CategoryID:  req.CategoryId,
-		ImageURL:    req.ImageUrl,
-		SKU:         req.Sku,
-		Status:      req.Status,
-	}
+// ProvisionInventoryItem makes sure a product has an inventory item, creating an empty one if needed
+func (s *InventoryServer) ProvisionInventoryItem(ctx context.Context, req *pb.GetInventoryItemRequest) (*pb.InventoryItem, error) {
+	s.logger.Info("gRPC ProvisionInventoryItem request received", "productId", req.ProductId)
 
-	updatedProduct, err := s.productUsecase.UpdateProduct(ctx, req.Id, product)
+	item, err := s.inventoryUsecase.ProvisionInventoryItem(ctx, req.ProductId)
 	if err != nil {
-		s.logger.Error("Failed to update product", "error", err)
+		s.logger.Error("Failed to provision inventory item", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertProductToProto(updatedProduct), nil
+	return convertItemToProto(item), nil
 }
 
-// DeleteProduct deletes a product by ID
-func (s *ProductServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC DeleteProduct request received", "id", req.Id)
+// ImportStock gives a product with no stock yet its stock from another system
+func (s *InventoryServer) ImportStock(ctx context.Context, req *pb.ImportStockRequest) (*pb.InventoryItem, error) {
+	s.logger.Info("gRPC ImportStock request received", "productId", req.ProductId, "quantity", req.Quantity, "referenceId", req.ReferenceId)
 
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	err := s.productUsecase.DeleteProduct(ctx, req.Id)
+	item, err := s.inventoryUsecase.ImportStock(ctx, req.ProductId, int(req.Quantity), req.ReferenceId)
 	if err != nil {
-		s.logger.Error("Failed to delete product", "error", err)
+		s.logger.Error("Failed to import stock", "error", err)
 		return nil, handleError(err)
 	}
 
-	return &emptypb.Empty{}, nil
+	return convertItemToProto(item), nil
 }
 
-// GetProductsByCategory gets products by category ID
-func (s *ProductServer) GetProductsByCategory(ctx context.Context, req *pb.GetProductsByCategoryRequest) (*pb.ListProductsResponse, error) {
-	s.logger.Info("gRPC GetProductsByCategory request received", "categoryId", req.CategoryId)
+// ReserveStock reserves stock for all items of an order, or for none of them. Stock is taken from the
+// warehouses chosen for the destination, when one is given.
+func (s *InventoryServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	s.logger.Info("gRPC ReserveStock request received", "orderId", req.OrderId, "items", len(req.Items))
 
-	if req.CategoryId == "" {
-		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Order ID is required")
 	}
-
-	page := int(req.Page)
-	pageSize := int(req.PageSize)
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	products, total, err := s.productUsecase.GetProductsByCategory(ctx, req.CategoryId, page, pageSize)
+	items, err := convertQuantities(req.Items)
 	if err != nil {
-		s.logger.Error("Failed to get products by category", "error", err)
-		return nil, handleError(err)
+		return nil, err
 	}
 
-	// Convert entities to proto responses
-	protoProducts := make([]*pb.ProductResponse, len(products))
-	for i, product := range products {
-		protoProducts[i] = convertProductToProto(product)
-	}
-
-	totalPages := total / pageSize
-	if total%pageSize != 0 {
-		totalPages++
-	}
-
-	return &pb.ListProductsResponse{
-		Total:      int32(total),
-		Page:       int32(page),
-		PageSize:   int32(pageSize),
-		TotalPages: int32(totalPages),
-		Products:   protoProducts,
-	}, nil
-}
-
-// CreateCategory creates a new category
-func (s *ProductServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CategoryResponse, error) {
-	s.logger.Info("gRPC CreateCategory request received", "name", req.Name)
-
-	var parentID *string
-	if req.ParentId != nil {
-		parentID = req.ParentId
-	}
-
-	category := entity.Category{
-		Name:        req.Name,
-		Description: req.Description,
-		ParentID:    parentID,
-	}
-
-	createdCategory, err := s.categoryUsecase.CreateCategory(ctx, &category)
-	if err != nil {
-		s.logger.Error("Failed to create category", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertCategoryToProto(createdCategory), nil
-}
-
-// GetCategory gets a category by ID
-func (s *ProductServer) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.CategoryResponse, error) {
-	s.logger.Info("gRPC GetCategory request received", "id", req.Id)
-
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "category ID is required")
-	}
-
-	category, err := s.categoryUsecase.GetCategoryByID(ctx, req.Id)
+	reservations, err := s.inventoryUsecase.ReserveStock(ctx, req.OrderId, items, convertAddressFromProto(req.Destination))
 	if err != nil {
-		s.logger.Error("Failed to get category", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertCategoryToProto(category), nil
-}
-
-// ListCategories lists categories with pagination
-func (s *ProductServer) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
-	s.logger.Info("gRPC ListCategories request received", "page", req.Page, "pageSize", req.PageSize)
-
-	page := int(req.Page)
-	pageSize := int(req.PageSize)
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	categories, total, err := s.categoryUsecase.ListCategories(ctx, page, pageSize)
-	if err != nil {
-		s.logger.Error("Failed to list categories", "error", err)
+		s.logger.Error("Failed to reserve stock", "error", err)
 		return nil, handleError(err)
 	}
 
-	// Convert entities to proto responses
-	protoCategories := make([]*pb.CategoryResponse, len(categories))
-	for i, category := range categories {
-		protoCategories[i] = convertCategoryToProto(category)
-	}
-
-	totalPages := total / pageSize
-	if total%pageSize != 0 {
-		totalPages++
-	}
-
-	return &pb.ListCategoriesResponse{
-		Total:      int32(total),
-		Page:       int32(page),
-		PageSize:   int32(pageSize),
-		TotalPages: int32(totalPages),
-		Categories: protoCategories,
-	}, nil
+	return convertReservationsToProto(reservations), nil
 }
 
-// UpdateCategory updates an existing category
-func (s *ProductServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.CategoryResponse, error) {
-	s.logger.Info("gRPC UpdateCategory request received", "id", req.Id)
+// CompleteReservation completes the reservations of an order and deducts their stock
+func (s *InventoryServer) CompleteReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC CompleteReservation request received", "orderId", req.OrderId)
 
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Order ID is required")
 	}
 
-	var parentID *string
-	if req.ParentId != nil {
-		parentID = req.ParentId
-	}
-
-	category := entity.Category{
-		Name:        req.Name,
-		Description: req.Description,
-		ParentID:    parentID,
-	}
-
-	updatedCategory, err := s.categoryUsecase.UpdateCategory(ctx, req.Id, category)
-	if err != nil {
-		s.logger.Error("Failed to update category", "error", err)
+	if err := s.inventoryUsecase.CompleteReservation(ctx, req.OrderId); err != nil {
+		s.logger.Error("Failed to complete reservation", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertCategoryToProto(updatedCategory), nil
+	return &emptypb.Empty{}, nil
 }
 
-// DeleteCategory deletes a category by ID
-func (s *ProductServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC DeleteCategory request received", "id", req.Id)
+// CancelReservation cancels the reservations of an order and releases their stock
+func (s *InventoryServer) CancelReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC CancelReservation request received", "orderId", req.OrderId)
 
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Order ID is required")
 	}
 
-	err := s.categoryUsecase.DeleteCategory(ctx, req.Id)
-	if err != nil {
-		s.logger.Error("Failed to delete category", "error", err)
+	if err := s.inventoryUsecase.CancelReservation(ctx, req.OrderId); err != nil {
+		s.logger.Error("Failed to cancel reservation", "error", err)
 		return nil, handleError(err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-// GetChildCategories gets child categories for a parent category
-func (s *ProductServer) GetChildCategories(ctx context.Context, req *pb.GetChildCategoriesRequest) (*pb.ListCategoriesResponse, error) {
-	s.logger.Info("gRPC GetChildCategories request received", "parentId", req.ParentId)
+// GetReservations gets the reservations of an order
+func (s *InventoryServer) GetReservations(ctx context.Context, req *pb.OrderReservationRequest) (*pb.ReserveStockResponse, error) {
+	s.logger.Info("gRPC GetReservations request received", "orderId", req.OrderId)
 
-	if req.ParentId == "" {
-		return nil, status.Error(codes.InvalidArgument, "parent category ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Order ID is required")
 	}
 
-	categories, err := s.categoryUsecase.GetChildCategories(ctx, req.ParentId)
+	reservations, err := s.inventoryUsecase.GetReservationsByOrderID(ctx, req.OrderId)
 	if err != nil {
-		s.logger.Error("Failed to get child categories", "error", err)
+		s.logger.Error("Failed to get reservations", "error", err)
 		return nil, handleError(err)
 	}
 
-	// Convert entities to proto responses
-	protoCategories := make([]*pb.CategoryResponse, len(categories))
-	for i, category := range categories {
-		protoCategories[i] = convertCategoryToProto(category)
-	}
-
-	return &pb.ListCategoriesResponse{
-		Total:      int32(len(categories)),
-		Page:       1,
-		PageSize:   int32(len(categories)),
-		TotalPages: 1,
-		Categories: protoCategories,
-	}, nil
+	return convertReservationsToProto(reservations), nil
 }
 
-// GetInventory gets inventory for a product
-func (s *ProductServer) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC GetInventory request received", "productId", req.ProductId)
+// GetStockTransactionHistory gets a page of the stock transactions of a product
+func (s *InventoryServer) GetStockTransactionHistory(ctx context.Context, req *pb.GetStockTransactionHistoryRequest) (*pb.StockTransactionHistoryResponse, error) {
+	s.logger.Info("gRPC GetStockTransactionHistory request received", "productId", req.ProductId, "page", req.Page, "pageSize", req.PageSize)
 
 	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+		return nil, status.Error(codes.InvalidArgument, "Product ID is required")
 	}
 
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	page, pageSize := pagination(req.Page, req.PageSize)
+	transactions, total, err := s.inventoryUsecase.GetStockTransactionHistory(ctx, req.ProductId, page, pageSize)
 	if err != nil {
-		s.logger.Error("Failed to get inventory", "error", err)
+		s.logger.Error("Failed to get stock transaction history", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertInventoryToProto(inventory), nil
-}
-
-// UpdateInventory updates inventory for a product
-func (s *ProductServer) UpdateInventory(ctx context.Context, req *pb.UpdateInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC UpdateInventory request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	err := s.inventoryUsecase.UpdateInventory(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
-		s.logger.Error("Failed to update inventory", "error", err)
-		return nil, handleError(err)
+	resp := &pb.StockTransactionHistoryResponse{
+		Transactions: make([]*pb.StockTransaction, len(transactions)),
+		Total:        int32(total),
 	}
-
-	// Get updated inventory
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
-	if err != nil {
-		s.logger.Error("Failed to get updated inventory", "error", err)
-		return nil, handleError(err)
+	for i, transaction := range transactions {
+		resp.Transactions[i] = convertTransactionToProto(transaction)
 	}
-
-	return convertInventoryToProto(inventory), nil
+	return resp, nil
 }
 
-// ReserveStock reserves stock for a product
-func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ReserveStock request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
-	}
+// ListLowStockItems gets a page of the items whose stock is at or below their reorder level
+func (s *InventoryServer) ListLowStockItems(ctx context.Context, req *pb.ListLowStockItemsRequest) (*pb.ListLowStockItemsResponse, error) {
+	s.logger.Info("gRPC ListLowStockItems request received", "page", req.Page, "pageSize", req.PageSize)
 
-	err := s.inventoryUsecase.ReserveStock(ctx, req.ProductId, int(req.Quantity))
+	page, pageSize := pagination(req.Page, req.PageSize)
+	items, total, err := s.inventoryUsecase.GetLowStockItems(ctx, page, pageSize)
 	if err != nil {
-		s.logger.Error("Failed to reserve stock", "error", err)
+		s.logger.Error("Failed to get low stock items", "error", err)
 		return nil, handleError(err)
 	}
 
-	return &emptypb.Empty{}, nil
-}
-
-// ConfirmReservation confirms a reservation
-func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ConfirmReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
+	resp := &pb.ListLowStockItemsResponse{
+		Items: make([]*pb.InventoryItem, len(items)),
+		Total: int32(total),
 	}
-
-	err := s.inventoryUsecase.ConfirmReservation(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
-		s.logger.Error("Failed to confirm reservation", "error", err)
-		return nil, handleError(err)
+	for i, item := range items {
+		resp.Items[i] = convertItemToProto(item)
 	}
-
-	return &emptypb.Empty{}, nil
+	return resp, nil
 }
 
-// CancelReservation cancels a reservation
-func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC CancelReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
-	}
+// WatchAvailability streams the availability of products: first their current availability,
+// then their availability whenever it changes, until the client goes away
+func (s *InventoryServer) WatchAvailability(req *pb.WatchAvailabilityRequest, stream pb.InventoryService_WatchAvailabilityServer) error {
+	s.logger.Info("gRPC WatchAvailability request received", "products", len(req.ProductIds))
 
-	err := s.inventoryUsecase.CancelReservation(ctx, req.ProductId, int(req.Quantity))
+	ctx := stream.Context()
+	sub, err := s.streamUsecase.Subscribe(ctx, req.ProductIds)
 	if err != nil {
-		s.logger.Error("Failed to cancel reservation", "error", err)
-		return nil, handleError(err)
+		s.logger.Error("Failed to watch availability", "error", err)
+		return handleError(err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Ready():
+			for _, update := range sub.Next() {
+				if err := stream.Send(convertAvailabilityUpdateToProto(update)); err != nil {
+					return err
+				}
+			}
+		}
 	}
-
-	return &emptypb.Empty{}, nil
 }
 
-// CheckStock checks if a product is in stock
-func (s *ProductServer) CheckStock(ctx context.Context, req *pb.CheckStockRequest) (*pb.CheckStockResponse, error) {
-	s.logger.Info("gRPC CheckStock request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
+// Helper functions
 
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
+// pagination defaults a missing page to the first one and a missing page size to 10
+func pagination(page, pageSize int32) (int, int) {
+	if page < 1 {
+		page = 1
 	}
-
-	inStock, err := s.inventoryUsecase.IsInStock(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
-		s.logger.Error("Failed to check stock", "error", err)
-		return nil, handleError(err)
+	if pageSize < 1 {
+		pageSize = 10
 	}
-
-	return &pb.CheckStockResponse{
-		ProductId: req.ProductId,
-		Quantity:  req.Quantity,
-		InStock:   inStock,
-	}, nil
+	return int(page), int(pageSize)
 }
-func (s *ProductServer) PatchProduct(ctx context.Context, req *pb.PatchProductRequest) (*pb.ProductResponse, error) {
-	s.logger.Info("gRPC PatchProduct request received", "id", req.Id)
-
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
 
-	// Convert the fields map from protobuf to Go map for the usecase
-	patchData := make(map[string]interface{})
-
-	// Process fields based on which ones are set in the request
-	if req.Name != nil {
-		patchData["name"] = req.GetName()
-	}
-	if req.Description != nil {
-		patchData["description"] = req.GetDescription()
-	}
-	if req.Price != nil {
-		patchData["price"] = req.GetPrice()
+// convertQuantities adds up the requested quantities by product
+func convertQuantities(quantities []*pb.StockQuantity) (map[string]int, error) {
+	if len(quantities) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Items are required")
 	}
-	if req.CategoryId != nil {
-		patchData["category_id"] = req.GetCategoryId()
-	}
-	if req.ImageUrl != nil {
-		patchData["image_url"] = req.GetImageUrl()
-	}
-	if req.Sku != nil {
-		patchData["sku"] = req.GetSku()
-	}
-	if req.Status != nil {
-		patchData["status"] = req.GetStatus()
-	}
-
-	updatedProduct, err := s.productUsecase.UpdateProductPartial(ctx, req.Id, patchData)
-	if err != nil {
-		s.logger.Error("Failed to patch product", "error", err)
-		return nil, handleError(err)
+	items := make(map[string]int, len(quantities))
+	for _, quantity := range quantities {
+		if quantity.ProductId == "" || quantity.Quantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "Each item needs a product ID and a positive quantity")
+		}
+		items[quantity.ProductId] += int(quantity.Quantity)
 	}
-
-	return convertProductToProto(updatedProduct), nil
+	return items, nil
 }
 
-// Add a PatchCategory method to handle partial category updates
-func (s *ProductServer) PatchCategory(ctx context.Context, req *pb.PatchCategoryRequest) (*pb.CategoryResponse, error) {
-	s.logger.Info("gRPC PatchCategory request received", "id", req.Id)
-
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "category ID is required")
-	}
-
-	// Convert the fields map from protobuf to Go map for the usecase
-	patchData := make(map[string]interface{})
-
-	// Process fields based on which ones are set in the request
-	if req.Name != nil {
-		patchData["name"] = req.GetName()
-	}
-	if req.Description != nil {
-		patchData["description"] = req.GetDescription()
+func convertAddressFromProto(address *pb.Address) *entity.Address {
+	if address == nil {
+		return nil
 	}
-	if req.ParentId != nil {
-		if req.GetParentId() == "" {
-			// Empty string represents removing the parent (making it top-level)
-			patchData["parent_id"] = nil
-		} else {
-			patchData["parent_id"] = req.GetParentId()
-		}
+	return &entity.Address{
+		Country:    address.Country,
+		State:      address.State,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
 	}
-
-	updatedCategory, err := s.categoryUsecase.UpdateCategoryPartial(ctx, req.Id, patchData)
-	if err != nil {
-		s.logger.Error("Failed to patch category", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertCategoryToProto(updatedCategory), nil
 }
 
-// Add a PatchInventory method to handle partial inventory updates
-func (s *ProductServer) PatchInventory(ctx context.Context, req *pb.PatchInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC PatchInventory request received", "productId", req.ProductId)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+func convertItemToProto(item *entity.InventoryItem) *pb.InventoryItem {
+	return &pb.InventoryItem{
+		ProductId:       item.ProductID,
+		AvailableQty:    int32(item.AvailableQty),
+		ReservedQty:     int32(item.ReservedQty),
+		SoldQty:         int32(item.SoldQty),
+		ReorderLevel:    int32(item.ReorderLevel),
+		BackorderPolicy: item.BackorderPolicy,
+		BackorderLimit:  int32(item.BackorderLimit),
+		BackorderedQty:  int32(item.BackorderedQty),
+		UpdatedAt:       timestamppb.New(item.UpdatedAt),
+		ReorderQty:      int32(item.ReorderQty),
+		SupplierId:      item.SupplierID,
 	}
+}
 
-	// Convert the fields map from protobuf to Go map for the usecase
-	patchData := make(map[string]interface{})
-
-	// Process fields based on which ones are set in the request
-	if req.Quantity != nil {
-		patchData["quantity"] = int(req.GetQuantity())
+func convertAvailabilityUpdateToProto(update *entity.AvailabilityUpdate) *pb.AvailabilityUpdate {
+	resp := &pb.AvailabilityUpdate{
+		ProductId:     update.ProductID,
+		AvailableQty:  int32(update.AvailableQty),
+		InStock:       update.InStock,
+		Backorderable: update.Backorderable,
 	}
-	if req.Reserved != nil {
-		patchData["reserved"] = int(req.GetReserved())
+	if !update.UpdatedAt.IsZero() {
+		resp.UpdatedAt = timestamppb.New(update.UpdatedAt)
 	}
+	return resp
+}
 
-	updatedInventory, err := s.inventoryUsecase.UpdateInventoryPartial(ctx, req.ProductId, patchData)
-	if err != nil {
-		s.logger.Error("Failed to patch inventory", "error", err)
-		return nil, handleError(err)
+func convertReservationsToProto(reservations []*entity.InventoryReservation) *pb.ReserveStockResponse {
+	resp := &pb.ReserveStockResponse{Reservations: make([]*pb.Reservation, len(reservations))}
+	for i, reservation := range reservations {
+		resp.Reservations[i] = convertReservationToProto(reservation)
 	}
-
-	return convertInventoryToProto(updatedInventory), nil
+	return resp
 }
 
-// Helper functions to convert domain entities to protobuf responses
-func convertProductToProto(product *entity.Product) *pb.ProductResponse {
-	return &pb.ProductResponse{
-		Id:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		CategoryId:  product.CategoryID,
-		ImageUrl:    product.ImageURL,
-		Sku:         product.SKU,
-		Status:      product.Status,
-		CreatedAt:   timestamppb.New(product.CreatedAt),
-		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+func convertReservationToProto(reservation *entity.InventoryReservation) *pb.Reservation {
+	return &pb.Reservation{
+		ReservationId: reservation.ReservationID,
+		OrderId:       reservation.OrderID,
+		ProductId:     reservation.ProductID,
+		WarehouseId:   reservation.WarehouseID,
+		Qty:           int32(reservation.Qty),
+		Status:        reservation.Status,
+		ReservedAt:    timestamppb.New(reservation.ReservedAt),
+		ExpiresAt:     timestamppb.New(reservation.ExpiresAt),
 	}
 }
 
-func convertCategoryToProto(category *entity.Category) *pb.CategoryResponse {
-	resp := &pb.CategoryResponse{
-		Id:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		Level:       int32(category.Level),
-		CreatedAt:   timestamppb.New(category.CreatedAt),
-		UpdatedAt:   timestamppb.New(category.UpdatedAt),
+func convertTransactionToProto(transaction *entity.StockTransaction) *pb.StockTransaction {
+	resp := &pb.StockTransaction{
+		TransactionId: transaction.TransactionID,
+		ProductId:     transaction.ProductID,
+		WarehouseId:   transaction.WarehouseID,
+		Type:          transaction.Type,
+		Qty:           int32(transaction.Qty),
+		OccurredAt:    timestamppb.New(transaction.OccurredAt),
+		ReasonCode:    transaction.ReasonCode,
+		ActorId:       transaction.ActorID,
 	}
-
-	if category.ParentID != nil {
-		resp.ParentId = category.ParentID
+	if transaction.ReferenceID != nil {
+		resp.ReferenceId = *transaction.ReferenceID
 	}
-
 	return resp
 }
 
-func convertInventoryToProto(inventory *entity.Inventory) *pb.InventoryResponse {
-	return &pb.InventoryResponse{
-		ProductId: inventory.ProductID,
-		Quantity:  int32(inventory.Quantity),
-		Reserved:  int32(inventory.Reserved),
-		Available: int32(inventory.Quantity - inventory.Reserved),
-		UpdatedAt: timestamppb.New(inventory.UpdatedAt),
-	}
-}
-
-// handleError maps domain errors to appropriate gRPC status errors
+// handleError maps domain errors to gRPC status errors
 func handleError(err error) error {
 	var statusCode codes.Code
 	var message string
 
 	switch {
-	case errors.Is(err, entity.ErrProductNotFound):
-		statusCode = codes.NotFound
-		message = "Product not found"
-	case errors.Is(err, entity.ErrCategoryNotFound):
-		statusCode = codes.NotFound
-		message = "Category not found"
 	case errors.Is(err, entity.ErrInventoryNotFound):
 		statusCode = codes.NotFound
-		message = "Inventory not found"
-	case errors.Is(err, entity.ErrProductSKUExists):
-		statusCode = codes.AlreadyExists
-		message = "Product SKU already exists"
-	case errors.Is(err, entity.ErrCategoryAlreadyExists):
-		statusCode = codes.AlreadyExists
-		message = "Category already exists"
+		message = "Inventory item not found"
 	case errors.Is(err, entity.ErrInsufficientStock):
 		statusCode = codes.FailedPrecondition
 		message = "Insufficient stock"
-	case errors.Is(err, entity.ErrInvalidProductData) || errors.Is(err, entity.ErrInvalidCategoryData):
+	case errors.Is(err, entity.ErrStockAlreadyExists):
+		statusCode = codes.AlreadyExists
+		message = "Inventory item already has stock"
+	case errors.Is(err, entity.ErrInvalidProductData) || errors.Is(err, entity.ErrInvalidStockAdjustment):
 		statusCode = codes.InvalidArgument
 		message = "Invalid data provided"
-	case errors.Is(err, entity.ErrInternalServerError):
-		statusCode = codes.Internal
-		message = "Internal server error"
 	default:
 		statusCode = codes.Internal
 		message = "Something went wrong"
@@ -0,0 +1,117 @@
+package httpctl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// heartbeatInterval is how often an idle stream sends a comment so proxies keep it open
+const heartbeatInterval = 10 * time.Second
+
+// AvailabilityStreamHandler handles HTTP requests streaming stock availability as Server-Sent Events
+type AvailabilityStreamHandler struct {
+	usecase     uc.AvailabilityStreamUsecase
+	maxDuration time.Duration
+	logger      logger.Logger
+}
+
+// NewAvailabilityStreamHandler creates a new instance of AvailabilityStreamHandler.
+// Streams are ended before maxDuration, the server's write timeout, has passed; clients reconnect
+// and start over from the current availability. A maxDuration of zero leaves streams open.
+func NewAvailabilityStreamHandler(usecase uc.AvailabilityStreamUsecase, maxDuration time.Duration, logger logger.Logger) *AvailabilityStreamHandler {
+	return &AvailabilityStreamHandler{
+		usecase:     usecase,
+		maxDuration: maxDuration,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers the routes for availability streaming
+func (h *AvailabilityStreamHandler) RegisterRoutes(r fiber.Router) {
+	r.Get("/availability/stream", h.StreamAvailability) // e.g., /availability/stream?sku=SKU123,SKU456
+}
+
+// StreamAvailability handles streaming the availability of products: first their current availability,
+// then their availability whenever it changes. Each event carries the availability of one product.
+// GET /availability/stream?sku=<comma-separated SKUs>
+func (h *AvailabilityStreamHandler) StreamAvailability(c *fiber.Ctx) error {
+	var skus []string
+	for _, sku := range strings.Split(c.Query("sku"), ",") {
+		if sku = strings.TrimSpace(sku); sku != "" {
+			skus = append(skus, sku)
+		}
+	}
+	if len(skus) == 0 {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	// The request context is recycled once the handler returns, so the stream gets its own
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := h.usecase.Subscribe(ctx, skus)
+	if err != nil {
+		cancel()
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		var deadline <-chan time.Time
+		if h.maxDuration > 0 {
+			// Leave a heartbeat's worth of time to end the stream cleanly
+			lifetime := h.maxDuration - heartbeatInterval
+			if lifetime <= 0 {
+				lifetime = h.maxDuration / 2
+			}
+			timer := time.NewTimer(lifetime)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		fmt.Fprint(w, "retry: 1000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-deadline:
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			case <-sub.Ready():
+				for _, update := range sub.Next() {
+					data, err := json.Marshal(update)
+					if err != nil {
+						h.logger.Error("Failed to encode availability update", "error", err)
+						continue
+					}
+					fmt.Fprintf(w, "event: availability\ndata: %s\n\n", data)
+				}
+			}
+
+			// A failed flush means the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package httpctl
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/dto"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// CycleCountHandler handles HTTP requests for cycle counts
+type CycleCountHandler struct {
+	usecase  uc.CycleCountUsecase
+	logger   logger.Logger
+	validate *validator.Validate
+}
+
+// NewCycleCountHandler creates a new instance of CycleCountHandler
+func NewCycleCountHandler(usecase uc.CycleCountUsecase, logger logger.Logger) *CycleCountHandler {
+	return &CycleCountHandler{
+		usecase:  usecase,
+		logger:   logger,
+		validate: validator.New(),
+	}
+}
+
+// RegisterRoutes registers the routes for cycle counts
+func (h *CycleCountHandler) RegisterRoutes(r fiber.Router) {
+	countGroup := r.Group("/cycle-counts")
+	countGroup.Post("/", h.StartCycleCount)
+	countGroup.Get("/:id", h.GetCycleCount)
+	countGroup.Put("/:id/counts", h.RecordCounts)
+	countGroup.Post("/:id/post", h.PostCycleCount)
+	countGroup.Post("/:id/cancel", h.CancelCycleCount)
+}
+
+// StartCycleCount handles starting a cycle count at a warehouse
+// POST /cycle-counts
+func (h *CycleCountHandler) StartCycleCount(c *fiber.Ctx) error {
+	var req dto.StartCycleCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for StartCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for StartCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	count, err := h.usecase.StartCycleCount(c.Context(), req.WarehouseID, req.ProductIDs, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Cycle count started", count)
+}
+
+// GetCycleCount handles retrieving a cycle count by ID
+// GET /cycle-counts/:id
+func (h *CycleCountHandler) GetCycleCount(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	count, err := h.usecase.GetCycleCount(c.Context(), id)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Cycle count retrieved", count)
+}
+
+// RecordCounts handles recording counted quantities of an open cycle count
+// PUT /cycle-counts/:id/counts
+func (h *CycleCountHandler) RecordCounts(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.RecordCountsRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for RecordCounts", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for RecordCounts", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	count, err := h.usecase.RecordCounts(c.Context(), id, req.Counts, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Counts recorded", count)
+}
+
+// PostCycleCount handles posting the variances of a fully counted cycle count
+// POST /cycle-counts/:id/post
+func (h *CycleCountHandler) PostCycleCount(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.PostCycleCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for PostCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for PostCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	count, err := h.usecase.PostCycleCount(c.Context(), id, req.ReasonCode, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Cycle count posted", count)
+}
+
+// CancelCycleCount handles cancelling an open cycle count
+// POST /cycle-counts/:id/cancel
+func (h *CycleCountHandler) CancelCycleCount(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.CancelCycleCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for CancelCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for CancelCycleCount", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	count, err := h.usecase.CancelCycleCount(c.Context(), id, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Cycle count cancelled", count)
+}
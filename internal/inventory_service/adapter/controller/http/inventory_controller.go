@@ -38,7 +38,8 @@ func (h *InventoryHandler) RegisterRoutes(r fiber.Router) {
 	inventoryGroup.Get("/low-stock", h.GetLowStockItems)
 	inventoryGroup.Get("/:sku", h.GetInventoryItem)
 	inventoryGroup.Put("/:sku", h.UpdateInventoryItem)
-	inventoryGroup.Post("/:sku/stock/add", h.AddStock) // e.g., /inventory/SKU123/stock/add
+	inventoryGroup.Post("/:sku/stock/add", h.AddStock)      // e.g., /inventory/SKU123/stock/add
+	inventoryGroup.Post("/:sku/adjustments", h.AdjustStock) // e.g., /inventory/SKU123/adjustments
 	inventoryGroup.Post("/reserve", h.ReserveStock)
 	inventoryGroup.Post("/:orderID/complete", h.CompleteReservation)         // e.g., /inventory/ORDERID456/complete
 	inventoryGroup.Post("/:orderID/cancel", h.CancelReservation)             // e.g., /inventory/ORDERID456/cancel
@@ -50,11 +51,16 @@ func (h *InventoryHandler) RegisterRoutes(r fiber.Router) {
 // For simplicity, I'll include a localized version here that includes inventory errors.
 // In a real application, this should be part of a shared adapter/httpctl package.
 func (h *InventoryHandler) handleInventoryError(c *fiber.Ctx, err error) error {
+	return handleInventoryError(c, h.logger, err)
+}
+
+// handleInventoryError maps inventory and warehouse errors to responses for every handler of the service
+func handleInventoryError(c *fiber.Ctx, log logger.Logger, err error) error {
 	var statusCode int
 	var message string
 
 	// Log the actual error for debugging purposes (optional based on logging policy)
-	h.logger.Error("Handler encountered an error", "error", err)
+	log.Error("Handler encountered an error", "error", err)
 
 	switch {
 	case errors.Is(err, ErrBadRequest): // Assuming ErrBadRequest is defined in the same package
@@ -75,6 +81,48 @@ func (h *InventoryHandler) handleInventoryError(c *fiber.Ctx, err error) error {
 	case errors.Is(err, entity.ErrSKUAlreadyExists):
 		statusCode = http.StatusConflict
 		message = "SKU already exists"
+	case errors.Is(err, entity.ErrWarehouseNotFound):
+		statusCode = http.StatusNotFound
+		message = "Warehouse not found"
+	case errors.Is(err, entity.ErrWarehouseCodeExists):
+		statusCode = http.StatusConflict
+		message = "Warehouse code already exists"
+	case errors.Is(err, entity.ErrInvalidWarehouseData):
+		statusCode = http.StatusBadRequest
+		message = "Invalid warehouse data"
+	case errors.Is(err, entity.ErrInvalidStockTransfer):
+		statusCode = http.StatusBadRequest
+		message = "Invalid stock transfer"
+	case errors.Is(err, entity.ErrInvalidStockAdjustment):
+		statusCode = http.StatusBadRequest
+		message = "Invalid stock adjustment"
+	case errors.Is(err, entity.ErrCycleCountNotFound):
+		statusCode = http.StatusNotFound
+		message = "Cycle count not found"
+	case errors.Is(err, entity.ErrCycleCountClosed):
+		statusCode = http.StatusConflict
+		message = "Cycle count is no longer open"
+	case errors.Is(err, entity.ErrCycleCountIncomplete):
+		statusCode = http.StatusConflict
+		message = "Cycle count has uncounted products"
+	case errors.Is(err, entity.ErrSupplierNotFound):
+		statusCode = http.StatusNotFound
+		message = "Supplier not found"
+	case errors.Is(err, entity.ErrInvalidSupplierData):
+		statusCode = http.StatusBadRequest
+		message = "Invalid supplier data"
+	case errors.Is(err, entity.ErrPurchaseOrderNotFound):
+		statusCode = http.StatusNotFound
+		message = "Purchase order not found"
+	case errors.Is(err, entity.ErrInvalidPurchaseOrder):
+		statusCode = http.StatusBadRequest
+		message = "Invalid purchase order"
+	case errors.Is(err, entity.ErrPurchaseOrderStatus):
+		statusCode = http.StatusConflict
+		message = "Purchase order cannot be changed in its current status"
+	case errors.Is(err, entity.ErrReceiptExceedsOrder):
+		statusCode = http.StatusConflict
+		message = "Received quantity exceeds the quantity outstanding"
 	// Add other specific domain errors here
 	default:
 		// Fallback for unexpected errors
@@ -181,7 +229,7 @@ func (h *InventoryHandler) AddStock(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	updatedItem, err := h.usecase.AddStock(ctx, sku, req.Quantity, req.ReferenceID)
+	updatedItem, err := h.usecase.AdjustStock(ctx, req.ToEntity(sku))
 	if err != nil {
 		return h.handleInventoryError(c, err)
 	}
@@ -189,6 +237,33 @@ func (h *InventoryHandler) AddStock(c *fiber.Ctx) error {
 	return SuccessResp(c, fiber.StatusOK, "Stock added successfully", updatedItem)
 }
 
+// AdjustStock handles a reason-coded adjustment of the stock of an inventory item
+// POST /inventory/:sku/adjustments
+func (h *InventoryHandler) AdjustStock(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return h.handleInventoryError(c, ErrBadRequest)
+	}
+
+	var req dto.StockAdjustmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for AdjustStock", "error", err)
+		return h.handleInventoryError(c, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for AdjustStock", "error", err)
+		return h.handleInventoryError(c, ErrBadRequest)
+	}
+
+	updatedItem, err := h.usecase.AdjustStock(c.Context(), req.ToEntity(sku))
+	if err != nil {
+		return h.handleInventoryError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock adjusted", updatedItem)
+}
+
 // ReserveStock handles reserving stock for an order
 // POST /inventory/reserve
 func (h *InventoryHandler) ReserveStock(c *fiber.Ctx) error {
@@ -204,7 +279,7 @@ func (h *InventoryHandler) ReserveStock(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	reservations, err := h.usecase.ReserveStock(ctx, req.OrderID, req.Items)
+	reservations, err := h.usecase.ReserveStock(ctx, req.OrderID, req.Items, req.ShippingAddress)
 	if err != nil {
 		return h.handleInventoryError(c, err)
 	}
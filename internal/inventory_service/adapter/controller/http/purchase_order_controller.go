@@ -0,0 +1,282 @@
+package httpctl
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/dto"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// PurchaseOrderHandler handles HTTP requests for suppliers and purchase orders
+type PurchaseOrderHandler struct {
+	suppliers      uc.SupplierUsecase
+	purchaseOrders uc.PurchaseOrderUsecase
+	logger         logger.Logger
+	validate       *validator.Validate
+}
+
+// NewPurchaseOrderHandler creates a new instance of PurchaseOrderHandler
+func NewPurchaseOrderHandler(suppliers uc.SupplierUsecase, purchaseOrders uc.PurchaseOrderUsecase, logger logger.Logger) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		suppliers:      suppliers,
+		purchaseOrders: purchaseOrders,
+		logger:         logger,
+		validate:       validator.New(),
+	}
+}
+
+// RegisterRoutes registers the routes for suppliers and purchase orders
+func (h *PurchaseOrderHandler) RegisterRoutes(r fiber.Router) {
+	supplierGroup := r.Group("/suppliers")
+	supplierGroup.Post("/", h.CreateSupplier)
+	supplierGroup.Get("/", h.ListSuppliers)
+	supplierGroup.Get("/:id", h.GetSupplier)
+	supplierGroup.Put("/:id", h.UpdateSupplier)
+
+	poGroup := r.Group("/purchase-orders")
+	poGroup.Post("/", h.CreatePurchaseOrder)
+	poGroup.Get("/", h.ListPurchaseOrders)
+	poGroup.Post("/replenish", h.ReplenishLowStock) // drafts orders for every item low on stock
+	poGroup.Get("/:id", h.GetPurchaseOrder)
+	poGroup.Post("/:id/approve", h.ApprovePurchaseOrder)
+	poGroup.Post("/:id/receive", h.ReceivePurchaseOrder)
+	poGroup.Post("/:id/cancel", h.CancelPurchaseOrder)
+}
+
+// CreateSupplier handles the creation of a new supplier
+// POST /suppliers
+func (h *PurchaseOrderHandler) CreateSupplier(c *fiber.Ctx) error {
+	var req dto.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for CreateSupplier", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for CreateSupplier", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	supplier := req.ToEntity("")
+	created, err := h.suppliers.CreateSupplier(c.Context(), &supplier)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Supplier created", created)
+}
+
+// ListSuppliers handles listing all suppliers
+// GET /suppliers
+func (h *PurchaseOrderHandler) ListSuppliers(c *fiber.Ctx) error {
+	suppliers, err := h.suppliers.ListSuppliers(c.Context())
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Suppliers retrieved", suppliers)
+}
+
+// GetSupplier handles retrieving a supplier by ID
+// GET /suppliers/:id
+func (h *PurchaseOrderHandler) GetSupplier(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	supplier, err := h.suppliers.GetSupplier(c.Context(), id)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Supplier retrieved", supplier)
+}
+
+// UpdateSupplier handles updating an existing supplier
+// PUT /suppliers/:id
+func (h *PurchaseOrderHandler) UpdateSupplier(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for UpdateSupplier", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for UpdateSupplier", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	supplier := req.ToEntity(id)
+	updated, err := h.suppliers.UpdateSupplier(c.Context(), &supplier)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Supplier updated", updated)
+}
+
+// CreatePurchaseOrder handles drafting a purchase order by hand
+// POST /purchase-orders
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *fiber.Ctx) error {
+	var req dto.CreatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for CreatePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for CreatePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	po := req.ToEntity()
+	created, err := h.purchaseOrders.CreatePurchaseOrder(c.Context(), &po)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Purchase order drafted", created)
+}
+
+// ListPurchaseOrders handles listing purchase orders with pagination, optionally by status
+// GET /purchase-orders?status=APPROVED&page=1&pageSize=10
+func (h *PurchaseOrderHandler) ListPurchaseOrders(c *fiber.Ctx) error {
+	var req dto.GetPurchaseOrdersRequest
+	if err := c.QueryParser(&req); err != nil {
+		h.logger.Error("Failed to parse query params for ListPurchaseOrders", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	// Set default pagination values if not provided or invalid
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 10 // Default page size
+	}
+
+	pos, total, err := h.purchaseOrders.ListPurchaseOrders(c.Context(), req.Status, req.Page, req.PageSize)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Purchase orders retrieved", dto.PurchaseOrdersWithTotal{
+		PurchaseOrders: pos,
+		Total:          total,
+	})
+}
+
+// ReplenishLowStock handles drafting purchase orders for every item low on stock
+// POST /purchase-orders/replenish
+func (h *PurchaseOrderHandler) ReplenishLowStock(c *fiber.Ctx) error {
+	pos, err := h.purchaseOrders.ReplenishLowStock(c.Context())
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Replenishment drafted", pos)
+}
+
+// GetPurchaseOrder handles retrieving a purchase order by ID
+// GET /purchase-orders/:id
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	po, err := h.purchaseOrders.GetPurchaseOrder(c.Context(), id)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Purchase order retrieved", po)
+}
+
+// ApprovePurchaseOrder handles approving a draft purchase order
+// POST /purchase-orders/:id/approve
+func (h *PurchaseOrderHandler) ApprovePurchaseOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.PurchaseOrderActorRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for ApprovePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for ApprovePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	po, err := h.purchaseOrders.ApprovePurchaseOrder(c.Context(), id, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Purchase order approved", po)
+}
+
+// ReceivePurchaseOrder handles receiving stock against an approved purchase order
+// POST /purchase-orders/:id/receive
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.ReceivePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for ReceivePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for ReceivePurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	po, err := h.purchaseOrders.ReceivePurchaseOrder(c.Context(), id, req.Received, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock received", po)
+}
+
+// CancelPurchaseOrder handles cancelling an open purchase order
+// POST /purchase-orders/:id/cancel
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.PurchaseOrderActorRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for CancelPurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for CancelPurchaseOrder", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	po, err := h.purchaseOrders.CancelPurchaseOrder(c.Context(), id, req.ActorID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Purchase order cancelled", po)
+}
@@ -0,0 +1,84 @@
+package httpctl
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// ReconciliationHandler handles admin HTTP requests for the stock ledger and its reconciliation
+type ReconciliationHandler struct {
+	usecase uc.ReconciliationUsecase
+	logger  logger.Logger
+}
+
+// NewReconciliationHandler creates a new instance of ReconciliationHandler
+func NewReconciliationHandler(usecase uc.ReconciliationUsecase, logger logger.Logger) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the admin routes for the stock ledger
+func (h *ReconciliationHandler) RegisterRoutes(r fiber.Router) {
+	adminGroup := r.Group("/admin/inventory")
+	adminGroup.Post("/reconcile", h.ReconcileAll)
+	adminGroup.Get("/:sku/ledger", h.GetStockAt)           // e.g., /admin/inventory/SKU123/ledger?at=2024-01-02T15:04:05Z
+	adminGroup.Post("/:sku/reconcile", h.ReconcileProduct) // e.g., /admin/inventory/SKU123/reconcile?fix=true
+}
+
+// GetStockAt handles retrieving the stock of a product at a point in time, now when at is omitted
+// GET /admin/inventory/:sku/ledger?at=<RFC 3339 time>
+func (h *ReconciliationHandler) GetStockAt(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.logger.Error("Invalid time for GetStockAt", "error", err)
+			return handleInventoryError(c, h.logger, ErrBadRequest)
+		}
+		at = parsed
+	}
+
+	snapshot, err := h.usecase.GetStockAt(c.Context(), sku, at)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock retrieved from ledger", snapshot)
+}
+
+// ReconcileProduct handles reconciling the stock of a product with its ledger
+// POST /admin/inventory/:sku/reconcile?fix=true
+func (h *ReconciliationHandler) ReconcileProduct(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	reconciliation, err := h.usecase.ReconcileProduct(c.Context(), sku, c.QueryBool("fix"))
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock reconciled", reconciliation)
+}
+
+// ReconcileAll handles reconciling the stock of every product with its ledger
+// POST /admin/inventory/reconcile?fix=true
+func (h *ReconciliationHandler) ReconcileAll(c *fiber.Ctx) error {
+	report, err := h.usecase.ReconcileAll(c.Context(), c.QueryBool("fix"))
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock reconciled", report)
+}
@@ -0,0 +1,193 @@
+package httpctl
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/dto"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// WarehouseHandler handles HTTP requests for warehouses and per-warehouse stock
+type WarehouseHandler struct {
+	usecase  uc.WarehouseUsecase
+	logger   logger.Logger
+	validate *validator.Validate
+}
+
+// NewWarehouseHandler creates a new instance of WarehouseHandler
+func NewWarehouseHandler(usecase uc.WarehouseUsecase, logger logger.Logger) *WarehouseHandler {
+	return &WarehouseHandler{
+		usecase:  usecase,
+		logger:   logger,
+		validate: validator.New(),
+	}
+}
+
+// RegisterRoutes registers the routes for warehouses and per-warehouse stock
+func (h *WarehouseHandler) RegisterRoutes(r fiber.Router) {
+	warehouseGroup := r.Group("/warehouses")
+	warehouseGroup.Post("/", h.CreateWarehouse)
+	warehouseGroup.Get("/", h.ListWarehouses)
+	warehouseGroup.Get("/:id", h.GetWarehouse)
+	warehouseGroup.Put("/:id", h.UpdateWarehouse)
+
+	inventoryGroup := r.Group("/inventory")
+	inventoryGroup.Get("/:sku/locations", h.GetStockLevels)    // e.g., /inventory/SKU123/locations
+	inventoryGroup.Post("/:sku/transfers", h.TransferStock)    // e.g., /inventory/SKU123/transfers
+	inventoryGroup.Get("/:sku/transfers", h.GetStockTransfers) // e.g., /inventory/SKU123/transfers
+}
+
+// CreateWarehouse handles the creation of a new warehouse
+// POST /warehouses
+func (h *WarehouseHandler) CreateWarehouse(c *fiber.Ctx) error {
+	var req dto.WarehouseRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for CreateWarehouse", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for CreateWarehouse", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	warehouse := req.ToEntity("")
+	created, err := h.usecase.CreateWarehouse(c.Context(), &warehouse)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Warehouse created", created)
+}
+
+// ListWarehouses handles listing all warehouses
+// GET /warehouses
+func (h *WarehouseHandler) ListWarehouses(c *fiber.Ctx) error {
+	warehouses, err := h.usecase.ListWarehouses(c.Context())
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Warehouses retrieved", warehouses)
+}
+
+// GetWarehouse handles retrieving a warehouse by ID
+// GET /warehouses/:id
+func (h *WarehouseHandler) GetWarehouse(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	warehouse, err := h.usecase.GetWarehouse(c.Context(), id)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Warehouse retrieved", warehouse)
+}
+
+// UpdateWarehouse handles updating an existing warehouse
+// PUT /warehouses/:id
+func (h *WarehouseHandler) UpdateWarehouse(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.WarehouseRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for UpdateWarehouse", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for UpdateWarehouse", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	warehouse := req.ToEntity(id)
+	updated, err := h.usecase.UpdateWarehouse(c.Context(), &warehouse)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Warehouse updated", updated)
+}
+
+// GetStockLevels handles retrieving the stock of a product at each warehouse
+// GET /inventory/:sku/locations
+func (h *WarehouseHandler) GetStockLevels(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	levels, err := h.usecase.GetStockLevels(c.Context(), sku)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock levels retrieved", levels)
+}
+
+// TransferStock handles moving stock of a product between warehouses
+// POST /inventory/:sku/transfers
+func (h *WarehouseHandler) TransferStock(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.TransferStockRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to decode request body for TransferStock", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Error("Request validation failed for TransferStock", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	transfer, err := h.usecase.TransferStock(c.Context(), sku, req.FromWarehouseID, req.ToWarehouseID, req.Quantity, req.ReferenceID)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Stock transferred", transfer)
+}
+
+// GetStockTransfers handles listing the stock transfers of a product with pagination
+// GET /inventory/:sku/transfers?page=1&pageSize=10
+func (h *WarehouseHandler) GetStockTransfers(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	var req dto.GetTransactionHistoryRequest
+	if err := c.QueryParser(&req); err != nil {
+		h.logger.Error("Failed to parse query params for GetStockTransfers", "error", err)
+		return handleInventoryError(c, h.logger, ErrBadRequest)
+	}
+
+	// Set default pagination values if not provided or invalid
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 10 // Default page size
+	}
+
+	transfers, total, err := h.usecase.GetStockTransfers(c.Context(), sku, req.Page, req.PageSize)
+	if err != nil {
+		return handleInventoryError(c, h.logger, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Stock transfers retrieved", dto.StockTransfersWithTotal{
+		Transfers: transfers,
+		Total:     total,
+	})
+}
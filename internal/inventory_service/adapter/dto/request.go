@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
 )
 
 // CreateInventoryItemRequest represents the request body for creating an inventory item
@@ -16,28 +17,42 @@ type CreateInventoryItemRequest struct {
 	ReservedQty  int    `json:"reserved_qty" validate:"min=0"`
 	SoldQty      int    `json:"sold_qty" validate:"min=0"`
 	ReorderLevel int    `json:"reorder_level" validate:"min=0"`
+	// Replenishment settings; ReorderQty is ordered from the supplier when stock falls to ReorderLevel
+	ReorderQty int    `json:"reorder_qty" validate:"min=0"`
+	SupplierID string `json:"supplier_id"`
+	// Backorder settings; the policy is one of NONE (default), BACKORDER or PREORDER
+	BackorderPolicy string `json:"backorder_policy" validate:"omitempty,oneof=NONE BACKORDER PREORDER"`
+	BackorderLimit  int    `json:"backorder_limit" validate:"min=0"` // 0 means no limit
 }
 
 // ToEntity converts the request DTO to an InventoryItem entity
 func (d *CreateInventoryItemRequest) ToEntity() entity.InventoryItem {
 	return entity.InventoryItem{
-		ProductID:    d.ProductID,
-		AvailableQty: d.AvailableQty,
-		ReservedQty:  d.ReservedQty,
-		SoldQty:      d.SoldQty,
-		ReorderLevel: d.ReorderLevel,
-		UpdatedAt:    time.Now(), // Will be overwritten by usecase
+		ProductID:       d.ProductID,
+		AvailableQty:    d.AvailableQty,
+		ReservedQty:     d.ReservedQty,
+		SoldQty:         d.SoldQty,
+		ReorderLevel:    d.ReorderLevel,
+		ReorderQty:      d.ReorderQty,
+		SupplierID:      d.SupplierID,
+		BackorderPolicy: d.BackorderPolicy,
+		BackorderLimit:  d.BackorderLimit,
+		UpdatedAt:       time.Now(), // Will be overwritten by usecase
 	}
 }
 
 // UpdateInventoryItemRequest represents the request body for updating an inventory item
+// Stock quantities are changed with stock adjustments and cycle counts, not here.
 type UpdateInventoryItemRequest struct {
 	Name         string `json:"name"` // Allow zero value for optional fields
 	Description  string `json:"description"`
-	AvailableQty int    `json:"available_qty" validate:"min=0"`
-	ReservedQty  int    `json:"reserved_qty" validate:"min=0"`
-	SoldQty      int    `json:"sold_qty" validate:"min=0"`
 	ReorderLevel int    `json:"reorder_level" validate:"min=0"`
+	// Replenishment settings; ReorderQty is ordered from the supplier when stock falls to ReorderLevel
+	ReorderQty int    `json:"reorder_qty" validate:"min=0"`
+	SupplierID string `json:"supplier_id"`
+	// Backorder settings; the policy is one of NONE (default), BACKORDER or PREORDER
+	BackorderPolicy string `json:"backorder_policy" validate:"omitempty,oneof=NONE BACKORDER PREORDER"`
+	BackorderLimit  int    `json:"backorder_limit" validate:"min=0"` // 0 means no limit
 	// Note: ProductID is expected from path parameter for update
 }
 
@@ -45,11 +60,12 @@ type UpdateInventoryItemRequest struct {
 // It requires the existing ProductID to be set
 func (d *UpdateInventoryItemRequest) ToEntity(sku string) entity.InventoryItem {
 	return entity.InventoryItem{
-		ProductID:    sku, // Use ProductID from path param
-		AvailableQty: d.AvailableQty,
-		ReservedQty:  d.ReservedQty,
-		SoldQty:      d.SoldQty,
-		ReorderLevel: d.ReorderLevel,
+		ProductID:       sku, // Use ProductID from path param
+		ReorderLevel:    d.ReorderLevel,
+		ReorderQty:      d.ReorderQty,
+		SupplierID:      d.SupplierID,
+		BackorderPolicy: d.BackorderPolicy,
+		BackorderLimit:  d.BackorderLimit,
 		// CreatedAt should not be updated here
 		UpdatedAt: time.Now(), // Will be overwritten by usecase
 	}
@@ -59,12 +75,186 @@ func (d *UpdateInventoryItemRequest) ToEntity(sku string) entity.InventoryItem {
 type AddStockRequest struct {
 	Quantity    int    `json:"quantity" validate:"required,min=1"`
 	ReferenceID string `json:"reference_id"`
+	WarehouseID string `json:"warehouse_id"` // omit for the default warehouse
+	ReasonCode  string `json:"reason_code" validate:"required,max=64"`
+	ActorID     string `json:"actor_id" validate:"required"`
+}
+
+// ToEntity converts the request DTO to a RECEIVE stock adjustment
+func (d *AddStockRequest) ToEntity(sku string) entity.StockAdjustment {
+	return entity.StockAdjustment{
+		ProductID:   sku,
+		WarehouseID: d.WarehouseID,
+		Type:        valueobject.StockTypeReceived.String(),
+		Qty:         d.Quantity,
+		ReasonCode:  d.ReasonCode,
+		ActorID:     d.ActorID,
+		ReferenceID: d.ReferenceID,
+	}
+}
+
+// StockAdjustmentRequest represents the request body for a reason-coded stock adjustment
+type StockAdjustmentRequest struct {
+	Type        string `json:"type" validate:"required,oneof=RECEIVE DAMAGE SHRINKAGE RETURN_TO_STOCK CYCLE_COUNT"`
+	Quantity    int    `json:"quantity" validate:"required"` // positive; a signed variance for CYCLE_COUNT
+	WarehouseID string `json:"warehouse_id"`                 // omit for the default warehouse
+	ReasonCode  string `json:"reason_code" validate:"required,max=64"`
+	ActorID     string `json:"actor_id" validate:"required"`
+	ReferenceID string `json:"reference_id"`
+}
+
+// ToEntity converts the request DTO to a StockAdjustment entity
+func (d *StockAdjustmentRequest) ToEntity(sku string) entity.StockAdjustment {
+	return entity.StockAdjustment{
+		ProductID:   sku,
+		WarehouseID: d.WarehouseID,
+		Type:        d.Type,
+		Qty:         d.Quantity,
+		ReasonCode:  d.ReasonCode,
+		ActorID:     d.ActorID,
+		ReferenceID: d.ReferenceID,
+	}
+}
+
+// StartCycleCountRequest represents the request body for starting a cycle count
+type StartCycleCountRequest struct {
+	WarehouseID string   `json:"warehouse_id" validate:"required"`
+	ProductIDs  []string `json:"product_ids"` // omit to count every product held at the warehouse
+	ActorID     string   `json:"actor_id" validate:"required"`
+}
+
+// RecordCountsRequest represents the request body for recording counted quantities by product ID
+type RecordCountsRequest struct {
+	Counts  map[string]int `json:"counts" validate:"required,min=1,dive,min=0"`
+	ActorID string         `json:"actor_id" validate:"required"`
+}
+
+// PostCycleCountRequest represents the request body for posting a cycle count
+type PostCycleCountRequest struct {
+	ReasonCode string `json:"reason_code" validate:"required,max=64"`
+	ActorID    string `json:"actor_id" validate:"required"`
+}
+
+// CancelCycleCountRequest represents the request body for cancelling a cycle count
+type CancelCycleCountRequest struct {
+	ActorID string `json:"actor_id" validate:"required"`
 }
 
 // ReserveStockRequest represents the request body for reserving stock
 type ReserveStockRequest struct {
-	OrderID string         `json:"order_id" validate:"required"`
-	Items   map[string]int `json:"items" validate:"required"` // map[sku]quantity
+	OrderID         string          `json:"order_id" validate:"required"`
+	Items           map[string]int  `json:"items" validate:"required"` // map[sku]quantity
+	ShippingAddress *entity.Address `json:"shipping_address"`          // used by the closest allocation strategy
+}
+
+// WarehouseRequest represents the request body for creating or updating a warehouse
+type WarehouseRequest struct {
+	Code       string   `json:"code" validate:"required"`
+	Name       string   `json:"name" validate:"required"`
+	Country    string   `json:"country"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude  *float64 `json:"longitude" validate:"omitempty,longitude"`
+	Priority   int      `json:"priority" validate:"min=0"`
+	Active     *bool    `json:"active"` // defaults to true
+}
+
+// ToEntity converts the request DTO to a Warehouse entity
+func (d *WarehouseRequest) ToEntity(id string) entity.Warehouse {
+	active := true
+	if d.Active != nil {
+		active = *d.Active
+	}
+	return entity.Warehouse{
+		ID:         id,
+		Code:       d.Code,
+		Name:       d.Name,
+		Country:    d.Country,
+		PostalCode: d.PostalCode,
+		Latitude:   d.Latitude,
+		Longitude:  d.Longitude,
+		Priority:   d.Priority,
+		Active:     active,
+	}
+}
+
+// TransferStockRequest represents the request body for moving stock between warehouses
+type TransferStockRequest struct {
+	FromWarehouseID string `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   string `json:"to_warehouse_id" validate:"required"`
+	Quantity        int    `json:"quantity" validate:"required,min=1"`
+	ReferenceID     string `json:"reference_id"`
+}
+
+// SupplierRequest represents the request body for creating or updating a supplier
+type SupplierRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Email        string `json:"email" validate:"omitempty,email"`
+	Phone        string `json:"phone"`
+	LeadTimeDays int    `json:"lead_time_days" validate:"min=0"`
+	Active       *bool  `json:"active"` // defaults to true
+}
+
+// ToEntity converts the request DTO to a Supplier entity
+func (d *SupplierRequest) ToEntity(id string) entity.Supplier {
+	active := true
+	if d.Active != nil {
+		active = *d.Active
+	}
+	return entity.Supplier{
+		ID:           id,
+		Name:         d.Name,
+		Email:        d.Email,
+		Phone:        d.Phone,
+		LeadTimeDays: d.LeadTimeDays,
+		Active:       active,
+	}
+}
+
+// PurchaseOrderLineRequest represents a line of a purchase order request
+type PurchaseOrderLineRequest struct {
+	ProductID  string `json:"product_id" validate:"required"`
+	OrderedQty int    `json:"ordered_qty" validate:"required,min=1"`
+}
+
+// CreatePurchaseOrderRequest represents the request body for drafting a purchase order by hand
+type CreatePurchaseOrderRequest struct {
+	SupplierID  string                     `json:"supplier_id" validate:"required"`
+	WarehouseID string                     `json:"warehouse_id"` // omit for the default warehouse
+	ActorID     string                     `json:"actor_id" validate:"required"`
+	Lines       []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// ToEntity converts the request DTO to a PurchaseOrder entity
+func (d *CreatePurchaseOrderRequest) ToEntity() entity.PurchaseOrder {
+	lines := make([]*entity.PurchaseOrderLine, len(d.Lines))
+	for i, line := range d.Lines {
+		lines[i] = &entity.PurchaseOrderLine{ProductID: line.ProductID, OrderedQty: line.OrderedQty}
+	}
+	return entity.PurchaseOrder{
+		SupplierID:  d.SupplierID,
+		WarehouseID: d.WarehouseID,
+		CreatedBy:   d.ActorID,
+		Lines:       lines,
+	}
+}
+
+// ReceivePurchaseOrderRequest represents the request body for receiving quantities by product ID
+type ReceivePurchaseOrderRequest struct {
+	Received map[string]int `json:"received" validate:"required,min=1,dive,min=1"`
+	ActorID  string         `json:"actor_id" validate:"required"`
+}
+
+// PurchaseOrderActorRequest represents the request body for approving or cancelling a purchase order
+type PurchaseOrderActorRequest struct {
+	ActorID string `json:"actor_id" validate:"required"`
+}
+
+// GetPurchaseOrdersRequest represents the query parameters for listing purchase orders
+type GetPurchaseOrdersRequest struct {
+	Status   string `query:"status"`
+	Page     int    `query:"page" validate:"min=1"`
+	PageSize int    `query:"pageSize" validate:"min=1"`
 }
 
 // GetTransactionHistoryRequest represents the query parameters for transaction history
@@ -85,8 +275,20 @@ type InventoryItemsWithTotal struct {
 	Total int                     `json:"total"`
 }
 
+// StockTransfersWithTotal represents a response structure for paginated lists of stock transfers
+type StockTransfersWithTotal struct {
+	Transfers []*entity.StockTransfer `json:"transfers"`
+	Total     int                     `json:"total"`
+}
+
 // StockTransactionsWithTotal represents a response structure for paginated lists of transactions
 type StockTransactionsWithTotal struct {
 	Transactions []*entity.StockTransaction `json:"transactions"`
 	Total        int                        `json:"total"`
 }
+
+// PurchaseOrdersWithTotal represents a response structure for paginated lists of purchase orders
+type PurchaseOrdersWithTotal struct {
+	PurchaseOrders []*entity.PurchaseOrder `json:"purchase_orders"`
+	Total          int                     `json:"total"`
+}
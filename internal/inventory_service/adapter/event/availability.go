@@ -0,0 +1,91 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/segmentio/kafka-go"
+)
+
+// AvailabilityEventConsumer tells the availability stream about the stock changes announced on the
+// inventory topic. Subscribers of every replica must hear about changes made by any replica, so each
+// replica reads the topic in a consumer group of its own. It starts from the newest message, since
+// subscriptions begin with the current availability.
+type AvailabilityEventConsumer struct {
+	reader *kafka.Reader
+	stream usecase.AvailabilityStreamUsecase
+}
+
+// NewAvailabilityEventConsumer creates a new instance of AvailabilityEventConsumer.
+// The replica ID names its consumer group and must differ between replicas.
+func NewAvailabilityEventConsumer(config *KafkaConfig, replicaID string, stream usecase.AvailabilityStreamUsecase) (*AvailabilityEventConsumer, error) {
+	if replicaID == "" {
+		return nil, fmt.Errorf("a replica ID is required to read stock changes")
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     config.Brokers,
+		Topic:       config.InventoryTopic,
+		GroupID:     config.ConsumerGroupID + "-availability-" + replicaID,
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.LastOffset,
+	})
+
+	return &AvailabilityEventConsumer{
+		reader: reader,
+		stream: stream,
+	}, nil
+}
+
+// Start reads stock changes until ctx is cancelled
+func (c *AvailabilityEventConsumer) Start(ctx context.Context) error {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Context canceled, stopping availability event subscription")
+				return
+			default:
+				msg, err := c.reader.FetchMessage(ctx)
+				if err != nil {
+					log.Printf("Error reading message from inventory topic: %v", err)
+					continue
+				}
+
+				c.HandleStockEvent(msg.Value)
+
+				if err := c.reader.CommitMessages(ctx, msg); err != nil {
+					log.Printf("Error committing availability message: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// HandleStockEvent marks the product of a stock update, reservation or release as changed.
+// Other events do not change availability.
+func (c *AvailabilityEventConsumer) HandleStockEvent(data []byte) {
+	var payload StockEventPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		log.Printf("Skipping malformed inventory message: %v", err)
+		return
+	}
+
+	switch payload.EventType {
+	case service.EventTypeStockUpdated, service.EventTypeStockReserved, service.EventTypeStockReleased:
+		if payload.SKU != "" {
+			c.stream.NotifyStockChanged(payload.SKU)
+		}
+	}
+}
+
+// Close closes the Kafka reader connection
+func (c *AvailabilityEventConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -178,6 +178,67 @@ func (k *KafkaEventPublisher) PublishStockLow(ctx context.Context, item *entity.
 	return k.serializeAndPublish(ctx, payload, false)
 }
 
+// PublishReservationExpired publishes an event that the reservations of an order expired
+func (k *KafkaEventPublisher) PublishReservationExpired(ctx context.Context, orderID string, reservations []*entity.InventoryReservation) error {
+	quantity := 0
+	for _, reservation := range reservations {
+		quantity += reservation.Qty
+	}
+	payload := StockEventPayload{
+		EventType: service.EventTypeOrderReservationExpired,
+		Timestamp: time.Now(),
+		OrderID:   orderID,
+		Quantity:  quantity,
+		Reason:    "Reservation expired",
+		Data: map[string]interface{}{
+			"reservations": reservations,
+		},
+	}
+
+	// Publish to order topic so the order can be cancelled
+	return k.serializeAndPublish(ctx, payload, true)
+}
+
+// PublishStockBackordered publishes an event that part of an order was queued as a backorder or preorder
+func (k *KafkaEventPublisher) PublishStockBackordered(ctx context.Context, reservation *entity.InventoryReservation, policy string) error {
+	payload := StockEventPayload{
+		EventType:     service.EventTypeStockBackordered,
+		Timestamp:     time.Now(),
+		SKU:           reservation.ProductID,
+		OrderID:       reservation.OrderID,
+		Quantity:      reservation.Qty,
+		ReservationID: reservation.ReservationID,
+		Data: map[string]interface{}{
+			"product_id":      reservation.ProductID,
+			"backordered_qty": reservation.Qty,
+			"policy":          policy,
+			"reservation":     reservation,
+		},
+	}
+
+	return k.serializeAndPublish(ctx, payload, false)
+}
+
+// PublishBackorderAllocated publishes an event that received stock was reserved for a backordered
+// reservation, with the quantity of it still waiting for stock
+func (k *KafkaEventPublisher) PublishBackorderAllocated(ctx context.Context, reservation *entity.InventoryReservation, backorderedQty int) error {
+	payload := StockEventPayload{
+		EventType:     service.EventTypeBackorderAllocated,
+		Timestamp:     time.Now(),
+		SKU:           reservation.ProductID,
+		OrderID:       reservation.OrderID,
+		Quantity:      reservation.Qty,
+		ReservationID: reservation.ReservationID,
+		Data: map[string]interface{}{
+			"product_id":      reservation.ProductID,
+			"backordered_qty": backorderedQty,
+			"reservation":     reservation,
+		},
+	}
+
+	return k.serializeAndPublish(ctx, payload, false)
+}
+
 // Close closes the Kafka writer connections
 func (k *KafkaEventPublisher) Close() error {
 	if err := k.writer.Close(); err != nil {
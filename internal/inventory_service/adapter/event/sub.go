@@ -3,19 +3,30 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
 	"github.com/segmentio/kafka-go"
 )
 
+const (
+	// retryBaseDelay is the delay before the first retry of a failed event
+	retryBaseDelay = time.Second
+	// retryMaxDelay caps the delay between retries of a failed event
+	retryMaxDelay = time.Minute
+)
+
 // KafkaConfig holds the configuration for Kafka connection
 type KafkaConfig struct {
 	Brokers         []string `yaml:"brokers"`
 	InventoryTopic  string   `yaml:"inventory_topic"`
 	OrderTopic      string   `yaml:"order_topic"`
+	ProductTopic    string   `yaml:"product_topic"`
 	ConsumerGroupID string   `yaml:"consumer_group_id"`
 }
 
@@ -31,7 +42,11 @@ type KafkaEventPublisher struct {
 type KafkaEventSubscriber struct {
 	orderReader         *kafka.Reader
 	reservationReader   *kafka.Reader
+	productReader       *kafka.Reader
+	inventoryReader     *kafka.Reader
 	inventoryUsecase    usecase.ReservationProcessorUsecase
+	itemUsecase         usecase.InventoryUsecase
+	purchaseOrders      usecase.PurchaseOrderUsecase
 	kafkaConfig         *KafkaConfig
 	orderMessageHandler func(ctx context.Context, msg []byte) error
 	serviceState        string // Can be used for health checks
@@ -51,6 +66,22 @@ type OrderItemData struct {
 	Quantity int    `json:"quantity"`
 }
 
+// ProductEventPayload represents the product event structure published by the product service
+type ProductEventPayload struct {
+	EventID   string    `json:"event_id"`
+	EventType string    `json:"event_type"`
+	ProductID string    `json:"product_id"`
+	VariantID string    `json:"variant_id,omitempty"`
+	SKU       string    `json:"sku"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// StockLowEventPayload represents the low-stock event published by this service
+type StockLowEventPayload struct {
+	EventType string `json:"event_type"`
+	SKU       string `json:"sku"`
+}
+
 // ReservationEventPayload represents the event payload for reservation/release operations
 type ReservationEventPayload struct {
 	EventType     string    `json:"event_type"`
@@ -63,6 +94,8 @@ type ReservationEventPayload struct {
 func NewKafkaEventSubscriber(
 	config *KafkaConfig,
 	inventoryUsecase usecase.ReservationProcessorUsecase,
+	itemUsecase usecase.InventoryUsecase,
+	purchaseOrders usecase.PurchaseOrderUsecase,
 ) (*KafkaEventSubscriber, error) {
 
 	// Reader for order events
@@ -74,6 +107,22 @@ func NewKafkaEventSubscriber(
 		MaxBytes:    10e6,             // 10MB
 		StartOffset: kafka.LastOffset, // Start from the newest message
 	})
+	// Reader for product catalogue events; starts from the oldest message so no product is missed
+	productReader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     config.Brokers,
+		Topic:       config.ProductTopic,
+		GroupID:     config.ConsumerGroupID + "-products",
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.FirstOffset,
+	})
+	// Reader for this service's own inventory events, to replenish stock that runs low
+	inventoryReader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     config.Brokers,
+		Topic:       config.InventoryTopic,
+		GroupID:     config.ConsumerGroupID + "-replenishment",
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.LastOffset,
+	})
 	// Reader for inventory reservation/release events (if needed as a separate topic)
 	// reservationReader := kafka.NewReader(kafka.ReaderConfig{
 	// 	Brokers:     config.Brokers,
@@ -87,7 +136,11 @@ func NewKafkaEventSubscriber(
 	return &KafkaEventSubscriber{
 		orderReader: orderReader,
 		// reservationReader: reservationReader,
+		productReader:    productReader,
+		inventoryReader:  inventoryReader,
 		inventoryUsecase: inventoryUsecase,
+		itemUsecase:      itemUsecase,
+		purchaseOrders:   purchaseOrders,
 		kafkaConfig:      config,
 		serviceState:     "ready",
 	}, nil
@@ -159,13 +212,163 @@ func (k *KafkaEventSubscriber) HandleOrderCancelled(ctx context.Context, orderDa
 	return k.inventoryUsecase.ProcessRelease(ctx, orderData)
 }
 
+// SubscribeToProductEvents subscribes to product catalogue events
+func (k *KafkaEventSubscriber) SubscribeToProductEvents(ctx context.Context) error {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Context canceled, stopping product event subscription")
+				return
+			default:
+				msg, err := k.productReader.FetchMessage(ctx)
+				if err != nil {
+					log.Printf("Error reading message from product topic: %v", err)
+					continue
+				}
+
+				// Committing past a failed event would leave the product or variant without inventory
+				if !k.retry(ctx, "product", k.HandleProductEvent, msg) {
+					return
+				}
+
+				if err := k.productReader.CommitMessages(ctx, msg); err != nil {
+					log.Printf("Error committing product message: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// retry handles an event until it succeeds, doubling the delay after each failure.
+// It reports false if the subscription is stopped before the event is handled.
+func (k *KafkaEventSubscriber) retry(ctx context.Context, topic string, handle func(ctx context.Context, data []byte) error, msg kafka.Message) bool {
+	delay := retryBaseDelay
+	for {
+		err := handle(ctx, msg.Value)
+		if err == nil {
+			return true
+		}
+
+		log.Printf("Error processing %s message at offset %d, retrying in %s: %v", topic, msg.Offset, delay, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, retryMaxDelay)
+	}
+}
+
+// HandleProductEvent provisions inventory for products and variants announced by the product service.
+// The stock of a variant is held under the variant ID. Deleted products keep their inventory item so
+// stock history stays intact; deleted variants keep theirs too but are retired.
+// Events without a product or variant ID are skipped, since retrying cannot fix them.
+func (k *KafkaEventSubscriber) HandleProductEvent(ctx context.Context, productData []byte) error {
+	var payload ProductEventPayload
+	if err := json.Unmarshal(productData, &payload); err != nil {
+		// Retrying cannot fix a malformed message
+		log.Printf("Skipping malformed product message: %v", err)
+		return nil
+	}
+
+	switch payload.EventType {
+	case "product.created", "product.updated":
+		if _, err := k.itemUsecase.ProvisionInventoryItem(ctx, payload.ProductID); errors.Is(err, entity.ErrInvalidProductData) {
+			log.Printf("Skipping %s without a product ID", payload.EventType)
+		} else if err != nil {
+			return fmt.Errorf("failed to provision inventory for product %s: %w", payload.ProductID, err)
+		}
+		return nil
+	case "variant.created":
+		if _, err := k.itemUsecase.ProvisionInventoryItem(ctx, payload.VariantID); errors.Is(err, entity.ErrInvalidProductData) {
+			log.Printf("Skipping %s without a variant ID", payload.EventType)
+		} else if err != nil {
+			return fmt.Errorf("failed to provision inventory for variant %s: %w", payload.VariantID, err)
+		}
+		return nil
+	case "variant.deleted":
+		if _, err := k.itemUsecase.RetireInventoryItem(ctx, payload.VariantID); errors.Is(err, entity.ErrInvalidProductData) {
+			log.Printf("Skipping %s without a variant ID", payload.EventType)
+		} else if err != nil {
+			return fmt.Errorf("failed to retire inventory of variant %s: %w", payload.VariantID, err)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// SubscribeToInventoryEvents subscribes to this service's own inventory events
+func (k *KafkaEventSubscriber) SubscribeToInventoryEvents(ctx context.Context) error {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Context canceled, stopping inventory event subscription")
+				return
+			default:
+				msg, err := k.inventoryReader.FetchMessage(ctx)
+				if err != nil {
+					log.Printf("Error reading message from inventory topic: %v", err)
+					continue
+				}
+
+				// Committing past a failed stock.low would leave the product without a replenishment draft
+				if !k.retry(ctx, "inventory", k.HandleInventoryEvent, msg) {
+					return
+				}
+
+				if err := k.inventoryReader.CommitMessages(ctx, msg); err != nil {
+					log.Printf("Error committing inventory message: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// HandleInventoryEvent drafts purchase orders for products that run low on stock.
+// Products whose inventory item is gone are skipped.
+func (k *KafkaEventSubscriber) HandleInventoryEvent(ctx context.Context, inventoryData []byte) error {
+	var payload StockLowEventPayload
+	if err := json.Unmarshal(inventoryData, &payload); err != nil {
+		// Retrying cannot fix a malformed message
+		log.Printf("Skipping malformed inventory message: %v", err)
+		return nil
+	}
+	if payload.EventType != service.EventTypeStockLow {
+		return nil
+	}
+
+	_, err := k.purchaseOrders.DraftReplenishment(ctx, payload.SKU)
+	if errors.Is(err, entity.ErrInventoryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to draft replenishment for product %s: %w", payload.SKU, err)
+	}
+	return nil
+}
+
 // Close closes the Kafka reader connections
 func (k *KafkaEventSubscriber) Close() error {
 	if err := k.orderReader.Close(); err != nil {
 		return fmt.Errorf("failed to close order reader: %w", err)
 	}
-	if err := k.reservationReader.Close(); err != nil {
-		return fmt.Errorf("failed to close reservation reader: %w", err)
+	if err := k.productReader.Close(); err != nil {
+		return fmt.Errorf("failed to close product reader: %w", err)
+	}
+	if err := k.inventoryReader.Close(); err != nil {
+		return fmt.Errorf("failed to close inventory reader: %w", err)
+	}
+	if k.reservationReader != nil {
+		if err := k.reservationReader.Close(); err != nil {
+			return fmt.Errorf("failed to close reservation reader: %w", err)
+		}
 	}
 	k.serviceState = "closed"
 	return nil
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormCycleCountRepository implements CycleCountRepository interface using GORM
+type GormCycleCountRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCycleCountRepository creates a new cycle count repository instance
+func NewGormCycleCountRepository(db *gorm.DB) *GormCycleCountRepository {
+	return &GormCycleCountRepository{db: db}
+}
+
+// CreateCycleCount creates a cycle count with its lines
+func (r *GormCycleCountRepository) CreateCycleCount(ctx context.Context, count *entity.CycleCount) (*entity.CycleCount, error) {
+	countModel := model.NewCycleCountModel(count)
+	err := conn(ctx, r.db).Create(countModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return countModel.ToEntity(), nil
+}
+
+// GetCycleCount retrieves a cycle count with its lines by ID
+func (r *GormCycleCountRepository) GetCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error) {
+	return r.getCycleCount(conn(ctx, r.db), countID)
+}
+
+// LockCycleCount retrieves a cycle count with its lines by ID and locks it until the transaction
+// in ctx ends
+func (r *GormCycleCountRepository) LockCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error) {
+	return r.getCycleCount(conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), countID)
+}
+
+func (r *GormCycleCountRepository) getCycleCount(db *gorm.DB, countID string) (*entity.CycleCount, error) {
+	var count model.CycleCount
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id")
+	}).Where("count_id = ?", countID).First(&count).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrCycleCountNotFound
+		}
+		return nil, err
+	}
+	return count.ToEntity(), nil
+}
+
+// UpdateCycleCount updates a cycle count and its lines
+func (r *GormCycleCountRepository) UpdateCycleCount(ctx context.Context, count *entity.CycleCount) (*entity.CycleCount, error) {
+	countModel := model.NewCycleCountModel(count)
+	err := conn(ctx, r.db).Session(&gorm.Session{FullSaveAssociations: true}).Save(countModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return countModel.ToEntity(), nil
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GormInventoryRepository implements InventoryRepository interface using GORM
@@ -22,18 +26,56 @@ func NewGormInventoryRepository(db *gorm.DB) *GormInventoryRepository {
 // GetInventoryItem retrieves an inventory item by SKU
 func (r *GormInventoryRepository) GetInventoryItem(ctx context.Context, product_id string) (*entity.InventoryItem, error) {
 	var item model.InventoryItem
-	err := r.db.WithContext(ctx).Where("product_id = ?", product_id).First(&item).Error
+	err := conn(ctx, r.db).Where("product_id = ?", product_id).First(&item).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrInventoryNotFound
+		}
 		return nil, err
 	}
 	return item.ToEntity(), nil
 }
 
+// LockInventoryItems retrieves inventory items by product ID and locks them until the transaction
+// in ctx ends. Rows are locked in product ID order so concurrent callers cannot deadlock.
+func (r *GormInventoryRepository) LockInventoryItems(ctx context.Context, productIDs []string) ([]*entity.InventoryItem, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	var items []model.InventoryItem
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id IN ?", productIDs).
+		Order("product_id").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byProductID := make(map[string]*entity.InventoryItem, len(items))
+	for i := range items {
+		byProductID[items[i].ProductID] = items[i].ToEntity()
+	}
+	result := make([]*entity.InventoryItem, len(productIDs))
+	for i, productID := range productIDs {
+		item, ok := byProductID[productID]
+		if !ok {
+			return nil, entity.ErrInventoryNotFound
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
 // CreateInventoryItem creates a new inventory item
 func (r *GormInventoryRepository) CreateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
 	itemModel := model.NewInventoryItemModel(item)
-	err := r.db.WithContext(ctx).Create(itemModel).Error
+	err := conn(ctx, r.db).Create(itemModel).Error
 	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, entity.ErrSKUAlreadyExists
+		}
 		return nil, err
 	}
 	return itemModel.ToEntity(), nil
@@ -43,7 +85,7 @@ func (r *GormInventoryRepository) CreateInventoryItem(ctx context.Context, item
 func (r *GormInventoryRepository) UpdateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
 	itemModel := model.NewInventoryItemModel(item)
 	itemModel.UpdatedAt = time.Now()
-	err := r.db.WithContext(ctx).Save(itemModel).Error
+	err := conn(ctx, r.db).Save(itemModel).Error
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +95,7 @@ func (r *GormInventoryRepository) UpdateInventoryItem(ctx context.Context, item
 // CreateReservation creates a new inventory reservation
 func (r *GormInventoryRepository) CreateReservation(ctx context.Context, reservation *entity.InventoryReservation) (*entity.InventoryReservation, error) {
 	reservationModel := model.NewInventoryReservationModel(reservation)
-	err := r.db.WithContext(ctx).Create(reservationModel).Error
+	err := conn(ctx, r.db).Create(reservationModel).Error
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +105,7 @@ func (r *GormInventoryRepository) CreateReservation(ctx context.Context, reserva
 // GetReservationByID retrieves a reservation by ID
 func (r *GormInventoryRepository) GetReservationByID(ctx context.Context, reservationID string) (*entity.InventoryReservation, error) {
 	var reservation model.InventoryReservation
-	err := r.db.WithContext(ctx).Where("reservation_id = ?", reservationID).First(&reservation).Error
+	err := conn(ctx, r.db).Where("reservation_id = ?", reservationID).First(&reservation).Error
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +115,67 @@ func (r *GormInventoryRepository) GetReservationByID(ctx context.Context, reserv
 // GetReservationsByOrderID retrieves all reservations for an order
 func (r *GormInventoryRepository) GetReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error) {
 	var reservations []model.InventoryReservation
-	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).Find(&reservations).Error
+	err := conn(ctx, r.db).Where("order_id = ?", orderID).Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.InventoryReservation, len(reservations))
+	for i, res := range reservations {
+		result[i] = res.ToEntity()
+	}
+	return result, nil
+}
+
+// LockReservationsByOrderID retrieves all reservations for an order and locks them
+// until the transaction in ctx ends
+func (r *GormInventoryRepository) LockReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error) {
+	var reservations []model.InventoryReservation
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		Order("reservation_id").
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.InventoryReservation, len(reservations))
+	for i, res := range reservations {
+		result[i] = res.ToEntity()
+	}
+	return result, nil
+}
+
+// LockBackorderedReservations retrieves the BACKORDERED reservations of a product, oldest first, and
+// locks them until the transaction in ctx ends. Reservations locked by another transaction, such as
+// an order being cancelled, are waited for, so backorders are always filled in queue order.
+func (r *GormInventoryRepository) LockBackorderedReservations(ctx context.Context, productID string) ([]*entity.InventoryReservation, error) {
+	var reservations []model.InventoryReservation
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND status = ?", productID, valueobject.ReserveStatusBackordered.String()).
+		Order("reserved_at, reservation_id").
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.InventoryReservation, len(reservations))
+	for i, res := range reservations {
+		result[i] = res.ToEntity()
+	}
+	return result, nil
+}
+
+// GetExpiredReservations retrieves reservations still in RESERVED status that expired before now, oldest first
+func (r *GormInventoryRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.InventoryReservation, error) {
+	var reservations []model.InventoryReservation
+	err := conn(ctx, r.db).
+		Where("status = ? AND expires_at < ?", valueobject.ReserveStatusReserved.String(), now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&reservations).Error
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +190,7 @@ func (r *GormInventoryRepository) GetReservationsByOrderID(ctx context.Context,
 // UpdateReservation updates an existing reservation
 func (r *GormInventoryRepository) UpdateReservation(ctx context.Context, reservation *entity.InventoryReservation) (*entity.InventoryReservation, error) {
 	reservationModel := model.NewInventoryReservationModel(reservation)
-	err := r.db.WithContext(ctx).Save(reservationModel).Error
+	err := conn(ctx, r.db).Save(reservationModel).Error
 	if err != nil {
 		return nil, err
 	}
@@ -97,13 +199,13 @@ func (r *GormInventoryRepository) UpdateReservation(ctx context.Context, reserva
 
 // DeleteReservation deletes a reservation
 func (r *GormInventoryRepository) DeleteReservation(ctx context.Context, reservationID string) error {
-	return r.db.WithContext(ctx).Where("reservation_id = ?", reservationID).Delete(&model.InventoryReservation{}).Error
+	return conn(ctx, r.db).Where("reservation_id = ?", reservationID).Delete(&model.InventoryReservation{}).Error
 }
 
 // RecordStockTransaction records a stock transaction
 func (r *GormInventoryRepository) RecordStockTransaction(ctx context.Context, transaction *entity.StockTransaction) (*entity.StockTransaction, error) {
 	transactionModel := model.NewStockTransactionModel(transaction)
-	err := r.db.WithContext(ctx).Create(transactionModel).Error
+	err := conn(ctx, r.db).Create(transactionModel).Error
 	if err != nil {
 		return nil, err
 	}
@@ -116,13 +218,13 @@ func (r *GormInventoryRepository) GetStockTransactions(ctx context.Context, prod
 	var total int64
 
 	// Get total count
-	err := r.db.WithContext(ctx).Model(&model.StockTransaction{}).Where("product_id = ?", product_id).Count(&total).Error
+	err := conn(ctx, r.db).Model(&model.StockTransaction{}).Where("product_id = ?", product_id).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err = r.db.WithContext(ctx).Where("product_id = ?", product_id).
+	err = conn(ctx, r.db).Where("product_id = ?", product_id).
 		Order("occurred_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -138,13 +240,47 @@ func (r *GormInventoryRepository) GetStockTransactions(ctx context.Context, prod
 	return result, int(total), nil
 }
 
+// GetStockLedgerTotals sums the quantities of a product's stock transactions by warehouse and type,
+// counting only those that occurred at or before until when it is not nil
+func (r *GormInventoryRepository) GetStockLedgerTotals(ctx context.Context, productID string, until *time.Time) ([]*entity.StockLedgerTotal, error) {
+	query := conn(ctx, r.db).Model(&model.StockTransaction{}).
+		Select("warehouse_id, type, SUM(qty) AS qty").
+		Where("product_id = ?", productID)
+	if until != nil {
+		query = query.Where("occurred_at <= ?", *until)
+	}
+
+	var totals []*entity.StockLedgerTotal
+	err := query.Group("warehouse_id, type").
+		Order("warehouse_id, type").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListProductIDs lists the product IDs of inventory items after afterProductID in ascending order
+func (r *GormInventoryRepository) ListProductIDs(ctx context.Context, afterProductID string, limit int) ([]string, error) {
+	var productIDs []string
+	err := conn(ctx, r.db).Model(&model.InventoryItem{}).
+		Where("product_id > ?", afterProductID).
+		Order("product_id").
+		Limit(limit).
+		Pluck("product_id", &productIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return productIDs, nil
+}
+
 // GetLowStockItems retrieves items with stock below their reorder level
 func (r *GormInventoryRepository) GetLowStockItems(ctx context.Context, limit, offset int) ([]*entity.InventoryItem, int, error) {
 	var items []model.InventoryItem
 	var total int64
 
 	// Get total count
-	err := r.db.WithContext(ctx).Model(&model.InventoryItem{}).
+	err := conn(ctx, r.db).Model(&model.InventoryItem{}).
 		Where("available_qty <= reorder_level").
 		Count(&total).Error
 	if err != nil {
@@ -152,7 +288,7 @@ func (r *GormInventoryRepository) GetLowStockItems(ctx context.Context, limit, o
 	}
 
 	// Get paginated results
-	err = r.db.WithContext(ctx).Where("available_qty <= reorder_level").
+	err = conn(ctx, r.db).Where("available_qty <= reorder_level").
 		Order("updated_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// CycleCount is the GORM model for cycle counts
+type CycleCount struct {
+	CountID     string `gorm:"primaryKey"`
+	WarehouseID string `gorm:"index;not null"`
+	Status      string `gorm:"not null"`
+	CreatedBy   string `gorm:"not null"`
+	CreatedAt   time.Time
+	ClosedBy    string `gorm:"not null;default:''"`
+	ClosedAt    *time.Time
+	Lines       []CycleCountLine `gorm:"foreignKey:CountID"`
+}
+
+// CycleCountLine is the GORM model for the count of one product in a cycle count
+type CycleCountLine struct {
+	CountID     string `gorm:"primaryKey"`
+	ProductID   string `gorm:"primaryKey"`
+	ExpectedQty int    `gorm:"not null"`
+	CountedQty  *int
+	CountedBy   string `gorm:"not null;default:''"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *CycleCount) ToEntity() *entity.CycleCount {
+	lines := make([]*entity.CycleCountLine, len(m.Lines))
+	for i, line := range m.Lines {
+		lines[i] = &entity.CycleCountLine{
+			ProductID:   line.ProductID,
+			ExpectedQty: line.ExpectedQty,
+			CountedQty:  line.CountedQty,
+			CountedBy:   line.CountedBy,
+		}
+	}
+	return &entity.CycleCount{
+		CountID:     m.CountID,
+		WarehouseID: m.WarehouseID,
+		Status:      m.Status,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		ClosedBy:    m.ClosedBy,
+		ClosedAt:    m.ClosedAt,
+		Lines:       lines,
+	}
+}
+
+// NewCycleCountModel creates a new GORM model from a domain entity
+func NewCycleCountModel(count *entity.CycleCount) *CycleCount {
+	lines := make([]CycleCountLine, len(count.Lines))
+	for i, line := range count.Lines {
+		lines[i] = CycleCountLine{
+			CountID:     count.CountID,
+			ProductID:   line.ProductID,
+			ExpectedQty: line.ExpectedQty,
+			CountedQty:  line.CountedQty,
+			CountedBy:   line.CountedBy,
+		}
+	}
+	return &CycleCount{
+		CountID:     count.CountID,
+		WarehouseID: count.WarehouseID,
+		Status:      count.Status,
+		CreatedBy:   count.CreatedBy,
+		CreatedAt:   count.CreatedAt,
+		ClosedBy:    count.ClosedBy,
+		ClosedAt:    count.ClosedAt,
+		Lines:       lines,
+	}
+}
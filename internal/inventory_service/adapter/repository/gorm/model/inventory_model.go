@@ -4,39 +4,59 @@ import (
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
 )
 
 // InventoryItem is the GORM model for inventory items
 type InventoryItem struct {
-	ProductID    string    `gorm:"primaryKey"`
-	AvailableQty int       `gorm:"not null"`
-	ReservedQty  int       `gorm:"not null"`
-	SoldQty      int       `gorm:"not null"`
-	ReorderLevel int       `gorm:"not null"`
-	UpdatedAt    time.Time `gorm:"not null"`
+	ProductID       string    `gorm:"primaryKey"`
+	AvailableQty    int       `gorm:"not null"`
+	ReservedQty     int       `gorm:"not null"`
+	SoldQty         int       `gorm:"not null"`
+	ReorderLevel    int       `gorm:"not null"`
+	ReorderQty      int       `gorm:"not null;default:0"`
+	SupplierID      string    `gorm:"index;not null;default:''"`
+	BackorderPolicy string    `gorm:"not null;default:'NONE'"`
+	BackorderLimit  int       `gorm:"not null;default:0"`
+	BackorderedQty  int       `gorm:"not null;default:0"`
+	UpdatedAt       time.Time `gorm:"not null"`
 }
 
 // ToEntity converts a GORM model to a domain entity
 func (m *InventoryItem) ToEntity() *entity.InventoryItem {
 	return &entity.InventoryItem{
-		ProductID:    m.ProductID,
-		AvailableQty: m.AvailableQty,
-		ReservedQty:  m.ReservedQty,
-		SoldQty:      m.SoldQty,
-		ReorderLevel: m.ReorderLevel,
-		UpdatedAt:    m.UpdatedAt,
+		ProductID:       m.ProductID,
+		AvailableQty:    m.AvailableQty,
+		ReservedQty:     m.ReservedQty,
+		SoldQty:         m.SoldQty,
+		ReorderLevel:    m.ReorderLevel,
+		ReorderQty:      m.ReorderQty,
+		SupplierID:      m.SupplierID,
+		BackorderPolicy: m.BackorderPolicy,
+		BackorderLimit:  m.BackorderLimit,
+		BackorderedQty:  m.BackorderedQty,
+		UpdatedAt:       m.UpdatedAt,
 	}
 }
 
 // NewInventoryItemModel creates a new GORM model from a domain entity
 func NewInventoryItemModel(item *entity.InventoryItem) *InventoryItem {
+	policy := item.BackorderPolicy
+	if policy == "" {
+		policy = valueobject.BackorderPolicyNone.String()
+	}
 	return &InventoryItem{
-		ProductID:    item.ProductID,
-		AvailableQty: item.AvailableQty,
-		ReservedQty:  item.ReservedQty,
-		SoldQty:      item.SoldQty,
-		ReorderLevel: item.ReorderLevel,
-		UpdatedAt:    item.UpdatedAt,
+		ProductID:       item.ProductID,
+		AvailableQty:    item.AvailableQty,
+		ReservedQty:     item.ReservedQty,
+		SoldQty:         item.SoldQty,
+		ReorderLevel:    item.ReorderLevel,
+		ReorderQty:      item.ReorderQty,
+		SupplierID:      item.SupplierID,
+		BackorderPolicy: policy,
+		BackorderLimit:  item.BackorderLimit,
+		BackorderedQty:  item.BackorderedQty,
+		UpdatedAt:       item.UpdatedAt,
 	}
 }
 
@@ -44,11 +64,12 @@ func NewInventoryItemModel(item *entity.InventoryItem) *InventoryItem {
 type InventoryReservation struct {
 	ReservationID string    `gorm:"primaryKey"`
 	OrderID       string    `gorm:"index;not null"`
-	ProductID     string    `gorm:"index;not null"` // SKU is the product_id for inventory           string    `gorm:"index;not null"`
+	ProductID     string    `gorm:"index;index:idx_reservation_backorder,priority:1;not null"` // SKU is the product_id for inventory
+	WarehouseID   string    `gorm:"index;not null;default:''"`
 	Qty           int       `gorm:"not null"`
-	Status        string    `gorm:"not null"`
-	ReservedAt    time.Time `gorm:"not null"`
-	ExpiresAt     time.Time `gorm:"not null"`
+	Status        string    `gorm:"index:idx_reservation_expiry,priority:1;index:idx_reservation_backorder,priority:2;not null"`
+	ReservedAt    time.Time `gorm:"index:idx_reservation_backorder,priority:3;not null"`
+	ExpiresAt     time.Time `gorm:"index:idx_reservation_expiry,priority:2;not null"`
 }
 
 // ToEntity converts a GORM model to a domain entity
@@ -57,6 +78,7 @@ func (m *InventoryReservation) ToEntity() *entity.InventoryReservation {
 		ReservationID: m.ReservationID,
 		OrderID:       m.OrderID,
 		ProductID:     m.ProductID,
+		WarehouseID:   m.WarehouseID,
 		Qty:           m.Qty,
 		Status:        m.Status,
 		ReservedAt:    m.ReservedAt,
@@ -70,6 +92,7 @@ func NewInventoryReservationModel(reservation *entity.InventoryReservation) *Inv
 		ReservationID: reservation.ReservationID,
 		OrderID:       reservation.OrderID,
 		ProductID:     reservation.ProductID,
+		WarehouseID:   reservation.WarehouseID,
 		Qty:           reservation.Qty,
 		Status:        reservation.Status,
 		ReservedAt:    reservation.ReservedAt,
@@ -80,11 +103,14 @@ func NewInventoryReservationModel(reservation *entity.InventoryReservation) *Inv
 // StockTransaction is the GORM model for stock transactions
 type StockTransaction struct {
 	TransactionID string    `gorm:"primaryKey"`
-	ProductID     string    `gorm:"index;not null"`
+	ProductID     string    `gorm:"index;index:idx_stock_transaction_ledger,priority:1;not null"`
+	WarehouseID   string    `gorm:"not null;default:''"`
 	Type          string    `gorm:"not null"`
 	Qty           int       `gorm:"not null"`
-	OccurredAt    time.Time `gorm:"not null;index"`
+	OccurredAt    time.Time `gorm:"not null;index;index:idx_stock_transaction_ledger,priority:2"`
 	ReferenceID   *string
+	ReasonCode    string `gorm:"not null;default:''"`
+	ActorID       string `gorm:"not null;default:''"`
 }
 
 // ToEntity converts a GORM model to a domain entity
@@ -92,10 +118,13 @@ func (m *StockTransaction) ToEntity() *entity.StockTransaction {
 	return &entity.StockTransaction{
 		TransactionID: m.TransactionID,
 		ProductID:     m.ProductID,
+		WarehouseID:   m.WarehouseID,
 		Type:          m.Type,
 		Qty:           m.Qty,
 		OccurredAt:    m.OccurredAt,
 		ReferenceID:   m.ReferenceID,
+		ReasonCode:    m.ReasonCode,
+		ActorID:       m.ActorID,
 	}
 }
 
@@ -104,9 +133,12 @@ func NewStockTransactionModel(transaction *entity.StockTransaction) *StockTransa
 	return &StockTransaction{
 		TransactionID: transaction.TransactionID,
 		ProductID:     transaction.ProductID,
+		WarehouseID:   transaction.WarehouseID,
 		Type:          transaction.Type,
 		Qty:           transaction.Qty,
 		OccurredAt:    transaction.OccurredAt,
 		ReferenceID:   transaction.ReferenceID,
+		ReasonCode:    transaction.ReasonCode,
+		ActorID:       transaction.ActorID,
 	}
 }
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// Supplier is the GORM model for suppliers
+type Supplier struct {
+	ID           string `gorm:"primaryKey"`
+	Name         string `gorm:"not null"`
+	Email        string
+	Phone        string
+	LeadTimeDays int       `gorm:"not null;default:0"`
+	Active       bool      `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *Supplier) ToEntity() *entity.Supplier {
+	return &entity.Supplier{
+		ID:           m.ID,
+		Name:         m.Name,
+		Email:        m.Email,
+		Phone:        m.Phone,
+		LeadTimeDays: m.LeadTimeDays,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// NewSupplierModel creates a new GORM model from a domain entity
+func NewSupplierModel(supplier *entity.Supplier) *Supplier {
+	return &Supplier{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		Email:        supplier.Email,
+		Phone:        supplier.Phone,
+		LeadTimeDays: supplier.LeadTimeDays,
+		Active:       supplier.Active,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
+
+// PurchaseOrder is the GORM model for purchase orders
+type PurchaseOrder struct {
+	ID          string `gorm:"primaryKey"`
+	SupplierID  string `gorm:"index:idx_purchase_order_draft,priority:1;not null"`
+	WarehouseID string `gorm:"index:idx_purchase_order_draft,priority:2;not null"`
+	Status      string `gorm:"index;index:idx_purchase_order_draft,priority:3;not null"`
+	CreatedBy   string `gorm:"not null"`
+	ApprovedBy  string `gorm:"not null;default:''"`
+	ApprovedAt  *time.Time
+	ExpectedAt  *time.Time
+	CancelledBy string              `gorm:"not null;default:''"`
+	CreatedAt   time.Time           `gorm:"not null"`
+	UpdatedAt   time.Time           `gorm:"not null"`
+	Lines       []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID"`
+}
+
+// PurchaseOrderLine is the GORM model for the quantity of one product on a purchase order
+type PurchaseOrderLine struct {
+	PurchaseOrderID string `gorm:"primaryKey"`
+	ProductID       string `gorm:"primaryKey;index"`
+	OrderedQty      int    `gorm:"not null"`
+	ReceivedQty     int    `gorm:"not null;default:0"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *PurchaseOrder) ToEntity() *entity.PurchaseOrder {
+	lines := make([]*entity.PurchaseOrderLine, len(m.Lines))
+	for i, line := range m.Lines {
+		lines[i] = &entity.PurchaseOrderLine{
+			ProductID:   line.ProductID,
+			OrderedQty:  line.OrderedQty,
+			ReceivedQty: line.ReceivedQty,
+		}
+	}
+	return &entity.PurchaseOrder{
+		ID:          m.ID,
+		SupplierID:  m.SupplierID,
+		WarehouseID: m.WarehouseID,
+		Status:      m.Status,
+		CreatedBy:   m.CreatedBy,
+		ApprovedBy:  m.ApprovedBy,
+		ApprovedAt:  m.ApprovedAt,
+		ExpectedAt:  m.ExpectedAt,
+		CancelledBy: m.CancelledBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Lines:       lines,
+	}
+}
+
+// NewPurchaseOrderModel creates a new GORM model from a domain entity
+func NewPurchaseOrderModel(po *entity.PurchaseOrder) *PurchaseOrder {
+	lines := make([]PurchaseOrderLine, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = PurchaseOrderLine{
+			PurchaseOrderID: po.ID,
+			ProductID:       line.ProductID,
+			OrderedQty:      line.OrderedQty,
+			ReceivedQty:     line.ReceivedQty,
+		}
+	}
+	return &PurchaseOrder{
+		ID:          po.ID,
+		SupplierID:  po.SupplierID,
+		WarehouseID: po.WarehouseID,
+		Status:      po.Status,
+		CreatedBy:   po.CreatedBy,
+		ApprovedBy:  po.ApprovedBy,
+		ApprovedAt:  po.ApprovedAt,
+		ExpectedAt:  po.ExpectedAt,
+		CancelledBy: po.CancelledBy,
+		CreatedAt:   po.CreatedAt,
+		UpdatedAt:   po.UpdatedAt,
+		Lines:       lines,
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// Warehouse is the GORM model for warehouses
+type Warehouse struct {
+	ID         string `gorm:"primaryKey"`
+	Code       string `gorm:"uniqueIndex;not null"`
+	Name       string `gorm:"not null"`
+	Country    string
+	PostalCode string
+	Latitude   *float64
+	Longitude  *float64
+	Priority   int       `gorm:"not null"`
+	Active     bool      `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"not null"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *Warehouse) ToEntity() *entity.Warehouse {
+	return &entity.Warehouse{
+		ID:         m.ID,
+		Code:       m.Code,
+		Name:       m.Name,
+		Country:    m.Country,
+		PostalCode: m.PostalCode,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		Priority:   m.Priority,
+		Active:     m.Active,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// NewWarehouseModel creates a new GORM model from a domain entity
+func NewWarehouseModel(warehouse *entity.Warehouse) *Warehouse {
+	return &Warehouse{
+		ID:         warehouse.ID,
+		Code:       warehouse.Code,
+		Name:       warehouse.Name,
+		Country:    warehouse.Country,
+		PostalCode: warehouse.PostalCode,
+		Latitude:   warehouse.Latitude,
+		Longitude:  warehouse.Longitude,
+		Priority:   warehouse.Priority,
+		Active:     warehouse.Active,
+		CreatedAt:  warehouse.CreatedAt,
+		UpdatedAt:  warehouse.UpdatedAt,
+	}
+}
+
+// StockLevel is the GORM model for the stock of a product at a warehouse
+type StockLevel struct {
+	ProductID    string    `gorm:"primaryKey"`
+	WarehouseID  string    `gorm:"primaryKey"`
+	AvailableQty int       `gorm:"not null"`
+	ReservedQty  int       `gorm:"not null"`
+	SoldQty      int       `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *StockLevel) ToEntity() *entity.StockLevel {
+	return &entity.StockLevel{
+		ProductID:    m.ProductID,
+		WarehouseID:  m.WarehouseID,
+		AvailableQty: m.AvailableQty,
+		ReservedQty:  m.ReservedQty,
+		SoldQty:      m.SoldQty,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// NewStockLevelModel creates a new GORM model from a domain entity
+func NewStockLevelModel(level *entity.StockLevel) *StockLevel {
+	return &StockLevel{
+		ProductID:    level.ProductID,
+		WarehouseID:  level.WarehouseID,
+		AvailableQty: level.AvailableQty,
+		ReservedQty:  level.ReservedQty,
+		SoldQty:      level.SoldQty,
+		UpdatedAt:    level.UpdatedAt,
+	}
+}
+
+// StockTransfer is the GORM model for stock transfers between warehouses
+type StockTransfer struct {
+	TransferID      string `gorm:"primaryKey"`
+	ProductID       string `gorm:"index:idx_transfer_product,priority:1;not null"`
+	FromWarehouseID string `gorm:"not null"`
+	ToWarehouseID   string `gorm:"not null"`
+	Qty             int    `gorm:"not null"`
+	ReferenceID     *string
+	TransferredAt   time.Time `gorm:"index:idx_transfer_product,priority:2;not null"`
+}
+
+// ToEntity converts a GORM model to a domain entity
+func (m *StockTransfer) ToEntity() *entity.StockTransfer {
+	return &entity.StockTransfer{
+		TransferID:      m.TransferID,
+		ProductID:       m.ProductID,
+		FromWarehouseID: m.FromWarehouseID,
+		ToWarehouseID:   m.ToWarehouseID,
+		Qty:             m.Qty,
+		ReferenceID:     m.ReferenceID,
+		TransferredAt:   m.TransferredAt,
+	}
+}
+
+// NewStockTransferModel creates a new GORM model from a domain entity
+func NewStockTransferModel(transfer *entity.StockTransfer) *StockTransfer {
+	return &StockTransfer{
+		TransferID:      transfer.TransferID,
+		ProductID:       transfer.ProductID,
+		FromWarehouseID: transfer.FromWarehouseID,
+		ToWarehouseID:   transfer.ToWarehouseID,
+		Qty:             transfer.Qty,
+		ReferenceID:     transfer.ReferenceID,
+		TransferredAt:   transfer.TransferredAt,
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormPurchaseOrderRepository implements PurchaseOrderRepository interface using GORM
+type GormPurchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPurchaseOrderRepository creates a new purchase order repository instance
+func NewGormPurchaseOrderRepository(db *gorm.DB) *GormPurchaseOrderRepository {
+	return &GormPurchaseOrderRepository{db: db}
+}
+
+// CreatePurchaseOrder creates a purchase order with its lines
+func (r *GormPurchaseOrderRepository) CreatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	poModel := model.NewPurchaseOrderModel(po)
+	err := conn(ctx, r.db).Create(poModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return poModel.ToEntity(), nil
+}
+
+// GetPurchaseOrder retrieves a purchase order with its lines by ID
+func (r *GormPurchaseOrderRepository) GetPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	return r.getPurchaseOrder(conn(ctx, r.db).Where("id = ?", id))
+}
+
+// LockPurchaseOrder retrieves a purchase order with its lines by ID and locks it until the
+// transaction in ctx ends
+func (r *GormPurchaseOrderRepository) LockPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	return r.getPurchaseOrder(conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+// LockDraftPurchaseOrder retrieves the oldest DRAFT purchase order from a supplier to a warehouse and
+// locks it until the transaction in ctx ends, failing with ErrPurchaseOrderNotFound if there is none
+func (r *GormPurchaseOrderRepository) LockDraftPurchaseOrder(ctx context.Context, supplierID, warehouseID string) (*entity.PurchaseOrder, error) {
+	return r.getPurchaseOrder(conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("supplier_id = ? AND warehouse_id = ? AND status = ?", supplierID, warehouseID, valueobject.PurchaseOrderStatusDraft.String()).
+		Order("created_at"))
+}
+
+func (r *GormPurchaseOrderRepository) getPurchaseOrder(db *gorm.DB) (*entity.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id")
+	}).First(&po).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return po.ToEntity(), nil
+}
+
+// HasOutstandingPurchaseOrderLine reports whether a product is still to be received on any open
+// purchase order
+func (r *GormPurchaseOrderRepository) HasOutstandingPurchaseOrderLine(ctx context.Context, productID string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&model.PurchaseOrderLine{}).
+		Joins("JOIN purchase_orders ON purchase_orders.id = purchase_order_lines.purchase_order_id").
+		Where("purchase_order_lines.product_id = ?", productID).
+		Where("purchase_order_lines.received_qty < purchase_order_lines.ordered_qty").
+		Where("purchase_orders.status IN ?", []string{
+			valueobject.PurchaseOrderStatusDraft.String(),
+			valueobject.PurchaseOrderStatusApproved.String(),
+			valueobject.PurchaseOrderStatusPartiallyReceived.String(),
+		}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListPurchaseOrders lists purchase orders newest first, only those in status unless it is empty
+func (r *GormPurchaseOrderRepository) ListPurchaseOrders(ctx context.Context, status string, limit, offset int) ([]*entity.PurchaseOrder, int, error) {
+	query := conn(ctx, r.db).Model(&model.PurchaseOrder{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var pos []model.PurchaseOrder
+	err := query.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id")
+	}).Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&pos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*entity.PurchaseOrder, len(pos))
+	for i := range pos {
+		result[i] = pos[i].ToEntity()
+	}
+	return result, int(total), nil
+}
+
+// UpdatePurchaseOrder updates a purchase order and its lines
+func (r *GormPurchaseOrderRepository) UpdatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	poModel := model.NewPurchaseOrderModel(po)
+	err := conn(ctx, r.db).Session(&gorm.Session{FullSaveAssociations: true}).Save(poModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return poModel.ToEntity(), nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormSupplierRepository implements SupplierRepository interface using GORM
+type GormSupplierRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSupplierRepository creates a new supplier repository instance
+func NewGormSupplierRepository(db *gorm.DB) *GormSupplierRepository {
+	return &GormSupplierRepository{db: db}
+}
+
+// CreateSupplier creates a new supplier
+func (r *GormSupplierRepository) CreateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	supplierModel := model.NewSupplierModel(supplier)
+	err := conn(ctx, r.db).Create(supplierModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return supplierModel.ToEntity(), nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (r *GormSupplierRepository) GetSupplier(ctx context.Context, id string) (*entity.Supplier, error) {
+	return r.getSupplier(conn(ctx, r.db), id)
+}
+
+// LockSupplier retrieves a supplier by ID and locks it until the transaction in ctx ends
+func (r *GormSupplierRepository) LockSupplier(ctx context.Context, id string) (*entity.Supplier, error) {
+	return r.getSupplier(conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *GormSupplierRepository) getSupplier(db *gorm.DB, id string) (*entity.Supplier, error) {
+	var supplier model.Supplier
+	err := db.Where("id = ?", id).First(&supplier).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return supplier.ToEntity(), nil
+}
+
+// ListSuppliers lists suppliers by name, only active ones when activeOnly is set
+func (r *GormSupplierRepository) ListSuppliers(ctx context.Context, activeOnly bool) ([]*entity.Supplier, error) {
+	var suppliers []model.Supplier
+	query := conn(ctx, r.db).Order("name")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Supplier, len(suppliers))
+	for i := range suppliers {
+		result[i] = suppliers[i].ToEntity()
+	}
+	return result, nil
+}
+
+// UpdateSupplier updates an existing supplier
+func (r *GormSupplierRepository) UpdateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	supplierModel := model.NewSupplierModel(supplier)
+	err := conn(ctx, r.db).Save(supplierModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return supplierModel.ToEntity(), nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormWarehouseRepository implements WarehouseRepository interface using GORM
+type GormWarehouseRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWarehouseRepository creates a new warehouse repository instance
+func NewGormWarehouseRepository(db *gorm.DB) *GormWarehouseRepository {
+	return &GormWarehouseRepository{db: db}
+}
+
+// CreateWarehouse creates a new warehouse
+func (r *GormWarehouseRepository) CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	warehouseModel := model.NewWarehouseModel(warehouse)
+	err := conn(ctx, r.db).Create(warehouseModel).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, entity.ErrWarehouseCodeExists
+		}
+		return nil, err
+	}
+	return warehouseModel.ToEntity(), nil
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (r *GormWarehouseRepository) GetWarehouse(ctx context.Context, id string) (*entity.Warehouse, error) {
+	return r.getWarehouse(ctx, "id = ?", id)
+}
+
+// GetWarehouseByCode retrieves a warehouse by code
+func (r *GormWarehouseRepository) GetWarehouseByCode(ctx context.Context, code string) (*entity.Warehouse, error) {
+	return r.getWarehouse(ctx, "code = ?", code)
+}
+
+func (r *GormWarehouseRepository) getWarehouse(ctx context.Context, query string, arg string) (*entity.Warehouse, error) {
+	var warehouse model.Warehouse
+	err := conn(ctx, r.db).Where(query, arg).First(&warehouse).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	return warehouse.ToEntity(), nil
+}
+
+// ListWarehouses retrieves warehouses in priority order, optionally only active ones
+func (r *GormWarehouseRepository) ListWarehouses(ctx context.Context, activeOnly bool) ([]*entity.Warehouse, error) {
+	var warehouses []model.Warehouse
+	query := conn(ctx, r.db).Order("priority").Order("code")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Warehouse, len(warehouses))
+	for i := range warehouses {
+		result[i] = warehouses[i].ToEntity()
+	}
+	return result, nil
+}
+
+// UpdateWarehouse updates an existing warehouse
+func (r *GormWarehouseRepository) UpdateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	warehouseModel := model.NewWarehouseModel(warehouse)
+	warehouseModel.UpdatedAt = time.Now()
+	err := conn(ctx, r.db).Save(warehouseModel).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, entity.ErrWarehouseCodeExists
+		}
+		return nil, err
+	}
+	return warehouseModel.ToEntity(), nil
+}
+
+// GetStockLevels retrieves the stock levels of a product at every warehouse holding it
+func (r *GormWarehouseRepository) GetStockLevels(ctx context.Context, productID string) ([]*entity.StockLevel, error) {
+	var levels []model.StockLevel
+	err := conn(ctx, r.db).Where("product_id = ?", productID).Order("warehouse_id").Find(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.StockLevel, len(levels))
+	for i := range levels {
+		result[i] = levels[i].ToEntity()
+	}
+	return result, nil
+}
+
+// GetWarehouseStockLevels retrieves the stock levels of every product held at a warehouse, by product ID
+func (r *GormWarehouseRepository) GetWarehouseStockLevels(ctx context.Context, warehouseID string) ([]*entity.StockLevel, error) {
+	var levels []model.StockLevel
+	err := conn(ctx, r.db).Where("warehouse_id = ?", warehouseID).Order("product_id").Find(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.StockLevel, len(levels))
+	for i := range levels {
+		result[i] = levels[i].ToEntity()
+	}
+	return result, nil
+}
+
+// LockStockLevels retrieves the stock levels of the products and locks them until the transaction
+// in ctx ends. Rows are locked in product and warehouse ID order so concurrent callers cannot deadlock.
+func (r *GormWarehouseRepository) LockStockLevels(ctx context.Context, productIDs []string) ([]*entity.StockLevel, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	var levels []model.StockLevel
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id IN ?", productIDs).
+		Order("product_id").
+		Order("warehouse_id").
+		Find(&levels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.StockLevel, len(levels))
+	for i := range levels {
+		result[i] = levels[i].ToEntity()
+	}
+	return result, nil
+}
+
+// SaveStockLevel creates or updates a stock level
+func (r *GormWarehouseRepository) SaveStockLevel(ctx context.Context, level *entity.StockLevel) (*entity.StockLevel, error) {
+	levelModel := model.NewStockLevelModel(level)
+	levelModel.UpdatedAt = time.Now()
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{UpdateAll: true}).Create(levelModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return levelModel.ToEntity(), nil
+}
+
+// CreateStockTransfer records a stock transfer
+func (r *GormWarehouseRepository) CreateStockTransfer(ctx context.Context, transfer *entity.StockTransfer) (*entity.StockTransfer, error) {
+	transferModel := model.NewStockTransferModel(transfer)
+	err := conn(ctx, r.db).Create(transferModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return transferModel.ToEntity(), nil
+}
+
+// GetStockTransfers retrieves the stock transfers of a product, newest first
+func (r *GormWarehouseRepository) GetStockTransfers(ctx context.Context, productID string, limit, offset int) ([]*entity.StockTransfer, int, error) {
+	var transfers []model.StockTransfer
+	var total int64
+
+	// Get total count
+	err := conn(ctx, r.db).Model(&model.StockTransfer{}).Where("product_id = ?", productID).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err = conn(ctx, r.db).Where("product_id = ?", productID).
+		Order("transferred_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*entity.StockTransfer, len(transfers))
+	for i := range transfers {
+		result[i] = transfers[i].ToEntity()
+	}
+	return result, int(total), nil
+}
+
+// AssignToWarehouse moves stock and reservations that predate warehouses to the given warehouse
+func (r *GormWarehouseRepository) AssignToWarehouse(ctx context.Context, warehouseID string) (int, error) {
+	db := conn(ctx, r.db)
+	result := db.Exec(`
+		INSERT INTO stock_levels (product_id, warehouse_id, available_qty, reserved_qty, sold_qty, updated_at)
+		SELECT i.product_id, ?, i.available_qty, i.reserved_qty, i.sold_qty, ?
+		FROM inventory_items i
+		WHERE NOT EXISTS (SELECT 1 FROM stock_levels s WHERE s.product_id = i.product_id)`,
+		warehouseID, time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	err := db.Model(&model.InventoryReservation{}).
+		Where("warehouse_id = ?", "").
+		Update("warehouse_id", warehouseID).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// The ledger of the stock is replayed per warehouse
+	err = db.Model(&model.StockTransaction{}).
+		Where("warehouse_id = ?", "").
+		Update("warehouse_id", warehouseID).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected), nil
+}
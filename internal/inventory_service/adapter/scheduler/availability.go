@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// AvailabilityFlusher periodically pushes changed stock availability to stream subscribers.
+// Its interval bounds how often a subscriber hears about a product, however often the product changes.
+type AvailabilityFlusher struct {
+	streamUsecase usecase.AvailabilityStreamUsecase
+	interval      time.Duration
+	logger        logger.Logger
+}
+
+// NewAvailabilityFlusher creates a new instance of AvailabilityFlusher
+func NewAvailabilityFlusher(au usecase.AvailabilityStreamUsecase, interval time.Duration, l logger.Logger) *AvailabilityFlusher {
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	return &AvailabilityFlusher{
+		streamUsecase: au,
+		interval:      interval,
+		logger:        l,
+	}
+}
+
+// Start flushes on every tick until ctx is cancelled
+func (f *AvailabilityFlusher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.run(ctx)
+			}
+		}
+	}()
+}
+
+// run pushes the changed availability; products that fail to load are retried on the next tick
+func (f *AvailabilityFlusher) run(ctx context.Context) {
+	if err := f.streamUsecase.Flush(ctx); err != nil {
+		f.logger.Error("Failed to push stock availability", "error", err)
+	}
+}
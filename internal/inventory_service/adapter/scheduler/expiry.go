@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// ExpirySweeper periodically releases the stock of expired reservations.
+// Replicas may run it side by side; reservations are settled under row locks.
+type ExpirySweeper struct {
+	reservationUsecase usecase.ReservationProcessorUsecase
+	interval           time.Duration
+	logger             logger.Logger
+}
+
+// NewExpirySweeper creates a new instance of ExpirySweeper
+func NewExpirySweeper(ru usecase.ReservationProcessorUsecase, interval time.Duration, l logger.Logger) *ExpirySweeper {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &ExpirySweeper{
+		reservationUsecase: ru,
+		interval:           interval,
+		logger:             l,
+	}
+}
+
+// Start sweeps once and then on every tick until ctx is cancelled
+func (s *ExpirySweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// run expires the reservations that are due; failures are retried on the next tick
+func (s *ExpirySweeper) run(ctx context.Context) {
+	expired, err := s.reservationUsecase.ProcessReservationExpiry(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to expire reservations", "error", err)
+	}
+	if expired > 0 {
+		s.logger.Info("Expired reservations", "count", expired)
+	}
+}
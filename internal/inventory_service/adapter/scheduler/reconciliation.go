@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// Reconciler periodically checks the recorded stock of every product against the stock ledger,
+// logging the products that drifted and correcting them when autoFix is set.
+type Reconciler struct {
+	reconciliationUsecase usecase.ReconciliationUsecase
+	interval              time.Duration
+	autoFix               bool
+	logger                logger.Logger
+}
+
+// NewReconciler creates a new instance of Reconciler
+func NewReconciler(ru usecase.ReconciliationUsecase, interval time.Duration, autoFix bool, l logger.Logger) *Reconciler {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &Reconciler{
+		reconciliationUsecase: ru,
+		interval:              interval,
+		autoFix:               autoFix,
+		logger:                l,
+	}
+}
+
+// Start reconciles on every tick until ctx is cancelled
+func (r *Reconciler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.run(ctx)
+			}
+		}
+	}()
+}
+
+// run reconciles every product once; failures are retried on the next tick
+func (r *Reconciler) run(ctx context.Context) {
+	report, err := r.reconciliationUsecase.ReconcileAll(ctx, r.autoFix)
+	if err != nil {
+		r.logger.Error("Failed to reconcile stock", "error", err)
+		return
+	}
+	for _, reconciliation := range report.Drifted {
+		r.logger.Warn("Stock drifted from ledger",
+			"product_id", reconciliation.ProductID,
+			"drifts", len(reconciliation.Drifts),
+			"fixed", reconciliation.Fixed)
+	}
+	r.logger.Info("Reconciled stock", "checked", report.Checked, "drifted", len(report.Drifted))
+}
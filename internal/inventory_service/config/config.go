@@ -10,15 +10,20 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server    ServerConfig   `yaml:"server"`
-	Database  DatabaseConfig `yaml:"database"`
-	GRPC      GRPCConfig     `yaml:"grpc"`
-	Messaging KafkaConfig    `yaml:"kafka"`
+	Server     ServerConfig    `yaml:"server"`
+	Database   DatabaseConfig  `yaml:"database"`
+	GRPC       GRPCConfig      `yaml:"grpc"`
+	Messaging  KafkaConfig     `yaml:"kafka"`
+	Expiry     ExpiryConfig    `yaml:"expiry"`
+	Warehouses WarehouseConfig `yaml:"warehouses"`
+	Ledger     LedgerConfig    `yaml:"ledger"`
+	Streaming  StreamingConfig `yaml:"streaming"`
 }
 type KafkaConfig struct {
 	Brokers         []string `yaml:"brokers"`
 	InventoryTopic  string   `yaml:"inventory_topic"`
 	OrderTopic      string   `yaml:"order_topic"`
+	ProductTopic    string   `yaml:"product_topic"`
 	ConsumerGroupID string   `yaml:"consumer_group_id"`
 }
 
@@ -47,6 +52,33 @@ type GRPCConfig struct {
 	Port string `yaml:"port"`
 }
 
+// ExpiryConfig contains reservation expiry configuration
+type ExpiryConfig struct {
+	SweepInterval time.Duration `yaml:"sweepInterval"` // how often expired reservations are released
+	BatchSize     int           `yaml:"batchSize"`     // expired reservations loaded at a time
+}
+
+// WarehouseConfig contains multi-warehouse stock configuration
+type WarehouseConfig struct {
+	DefaultCode        string `yaml:"defaultCode"`        // warehouse holding stock added without one
+	DefaultName        string `yaml:"defaultName"`        // name the default warehouse is created with
+	AllocationStrategy string `yaml:"allocationStrategy"` // "priority", "closest" or "fewest_splits"
+}
+
+// LedgerConfig contains stock ledger reconciliation configuration
+type LedgerConfig struct {
+	ReconcileInterval time.Duration `yaml:"reconcileInterval"` // how often recorded stock is checked against the ledger
+	AutoFix           bool          `yaml:"autoFix"`           // correct drifted stock instead of only reporting it
+	BatchSize         int           `yaml:"batchSize"`         // products loaded at a time
+}
+
+// StreamingConfig contains stock availability streaming configuration
+type StreamingConfig struct {
+	CoalesceInterval time.Duration `yaml:"coalesceInterval"` // how often changed availability is pushed; changes in between are merged
+	MaxProducts      int           `yaml:"maxProducts"`      // products a single subscription may watch
+	ReplicaID        string        `yaml:"replicaId"`        // names this replica's consumer group for stock changes; defaults to the host name
+}
+
 // LoadConfig loads configuration from a YAML file
 func LoadConfig(configPath string) (*Config, error) {
 	// Set default configuration
@@ -67,15 +99,38 @@ func LoadConfig(configPath string) (*Config, error) {
 			MaxOpen:  25,
 			MaxLife:  5 * time.Minute,
 		},
-		// GRPC: GRPCConfig{
-		// 	Port: "50052", // Different port from user service
-		// },
+		GRPC: GRPCConfig{
+			Port: "50054", // Different port from the other services
+		},
 		Messaging: KafkaConfig{
 			Brokers:         []string{"localhost:9092"},
 			InventoryTopic:  "inventory_events",
 			OrderTopic:      "order_events",
+			ProductTopic:    "product_events",
 			ConsumerGroupID: "inventory_service",
 		},
+		Expiry: ExpiryConfig{
+			SweepInterval: time.Minute,
+			BatchSize:     100,
+		},
+		Warehouses: WarehouseConfig{
+			DefaultCode:        "DEFAULT",
+			DefaultName:        "Default warehouse",
+			AllocationStrategy: "priority",
+		},
+		Ledger: LedgerConfig{
+			ReconcileInterval: 24 * time.Hour,
+			BatchSize:         100,
+		},
+		Streaming: StreamingConfig{
+			CoalesceInterval: 500 * time.Millisecond,
+			MaxProducts:      100,
+		},
+	}
+
+	// Every replica reads all stock changes in a consumer group of its own
+	if hostname, err := os.Hostname(); err == nil {
+		config.Streaming.ReplicaID = hostname
 	}
 
 	// Read config file
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Reason code and actor of the stock an inventory item is created with
+const (
+	ReasonInitialStock = "INITIAL_STOCK"
+	SystemActorID      = "system"
+)
+
+// ReasonStockImport is the reason code of stock imported from the product service, which kept stock
+// of its own before stock moved to this service
+const ReasonStockImport = "STOCK_IMPORT"
+
+// StockAdjustment is a reason-coded change of the stock of a product at a warehouse
+type StockAdjustment struct {
+	ProductID   string `json:"product_id"`
+	WarehouseID string `json:"warehouse_id"`
+	Type        string `json:"type"`
+	Qty         int    `json:"qty"` // positive, except for the signed variance of a cycle count
+	ReasonCode  string `json:"reason_code"`
+	ActorID     string `json:"actor_id"`
+	ReferenceID string `json:"reference_id,omitempty"`
+}
+
+// CycleCount is a count of the stock on hand of products at a warehouse. The expected quantities are
+// snapshotted when the count starts, and posting the count adjusts the stock by the variances.
+type CycleCount struct {
+	CountID     string            `json:"count_id"`
+	WarehouseID string            `json:"warehouse_id"`
+	Status      string            `json:"status"`
+	CreatedBy   string            `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ClosedBy    string            `json:"closed_by,omitempty"`
+	ClosedAt    *time.Time        `json:"closed_at,omitempty"`
+	Lines       []*CycleCountLine `json:"lines"`
+}
+
+// CycleCountLine is the count of one product. Quantities are of stock on hand, available and reserved.
+type CycleCountLine struct {
+	ProductID   string `json:"product_id"`
+	ExpectedQty int    `json:"expected_qty"`
+	CountedQty  *int   `json:"counted_qty,omitempty"`
+	CountedBy   string `json:"counted_by,omitempty"`
+}
+
+// Variance returns the counted quantity less the expected one, or 0 while the product is not counted
+func (l *CycleCountLine) Variance() int {
+	if l.CountedQty == nil {
+		return 0
+	}
+	return *l.CountedQty - l.ExpectedQty
+}
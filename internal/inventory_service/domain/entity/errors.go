@@ -8,6 +8,25 @@ var (
 	ErrInsufficientStock  = errors.New("insufficient stock for reservation")
 	ErrInvalidProductData = errors.New("invalid product data")
 	ErrSKUAlreadyExists   = errors.New("SKU already exists")
+	ErrStockAlreadyExists = errors.New("inventory item already has stock")
+
+	ErrWarehouseNotFound         = errors.New("warehouse not found")
+	ErrWarehouseCodeExists       = errors.New("warehouse code already exists")
+	ErrInvalidWarehouseData      = errors.New("invalid warehouse data")
+	ErrInvalidStockTransfer      = errors.New("invalid stock transfer")
+	ErrUnknownAllocationStrategy = errors.New("unknown allocation strategy")
+
+	ErrInvalidStockAdjustment = errors.New("invalid stock adjustment")
+	ErrCycleCountNotFound     = errors.New("cycle count not found")
+	ErrCycleCountClosed       = errors.New("cycle count is no longer open")
+	ErrCycleCountIncomplete   = errors.New("cycle count has uncounted products")
+
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrInvalidSupplierData   = errors.New("invalid supplier data")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrPurchaseOrderStatus   = errors.New("purchase order cannot be changed in its current status")
+	ErrReceiptExceedsOrder   = errors.New("received quantity exceeds the quantity outstanding")
 	// Add other domain-specific errors here
 )
 
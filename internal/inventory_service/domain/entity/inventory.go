@@ -4,14 +4,44 @@ import (
 	"time"
 )
 
-// InventoryItem tracks the main stock information of each ProductID
+// InventoryItem tracks the main stock information of each ProductID.
+// Its quantities are the totals of the product's stock levels across all warehouses.
+// BackorderedQty is the quantity ordered beyond stock that is still waiting for it.
+// When stock falls to ReorderLevel, ReorderQty is ordered from the supplier SupplierID.
 type InventoryItem struct {
-	ProductID    string    `json:"product_id"`
-	AvailableQty int       `json:"available_qty"`
-	ReservedQty  int       `json:"reserved_qty"`
-	SoldQty      int       `json:"sold_qty"`
-	ReorderLevel int       `json:"reorder_level"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ProductID       string    `json:"product_id"`
+	AvailableQty    int       `json:"available_qty"`
+	ReservedQty     int       `json:"reserved_qty"`
+	SoldQty         int       `json:"sold_qty"`
+	ReorderLevel    int       `json:"reorder_level"`
+	ReorderQty      int       `json:"reorder_qty"`           // 0 means the item is not replenished automatically
+	SupplierID      string    `json:"supplier_id,omitempty"` // preferred supplier for replenishment
+	BackorderPolicy string    `json:"backorder_policy"`
+	BackorderLimit  int       `json:"backorder_limit"` // 0 means no limit
+	BackorderedQty  int       `json:"backordered_qty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// StockAvailability reports whether a requested quantity of a product can be ordered. Backorderable
+// means the part that is not available can be queued as a backorder. Products without an inventory
+// item are reported with Found unset.
+type StockAvailability struct {
+	ProductID     string `json:"product_id"`
+	RequestedQty  int    `json:"requested_qty"`
+	AvailableQty  int    `json:"available_qty"`
+	InStock       bool   `json:"in_stock"`
+	Backorderable bool   `json:"backorderable"`
+	Found         bool   `json:"found"`
+}
+
+// AvailabilityUpdate is the availability of a product pushed to the subscribers of its stream.
+// Backorderable means more of the product can be ordered than is available.
+type AvailabilityUpdate struct {
+	ProductID     string    `json:"product_id"`
+	AvailableQty  int       `json:"available_qty"`
+	InStock       bool      `json:"in_stock"`
+	Backorderable bool      `json:"backorderable"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // InventoryReservation tracks each reservation of a product
@@ -19,18 +49,23 @@ type InventoryReservation struct {
 	ReservationID string    `json:"reservation_id"`
 	OrderID       string    `json:"order_id"`
 	ProductID     string    `json:"product_id"`
+	WarehouseID   string    `json:"warehouse_id"`
 	Qty           int       `json:"qty"`
 	Status        string    `json:"status"`
 	ReservedAt    time.Time `json:"reserved_at"`
 	ExpiresAt     time.Time `json:"expires_at"`
 }
 
-// StockTransaction tracks the stock transactions
+// StockTransaction tracks the stock transactions. Adjustments carry the reason code and the actor
+// who made them.
 type StockTransaction struct {
 	TransactionID string    `json:"transaction_id"`
 	ProductID     string    `json:"product_id"`
+	WarehouseID   string    `json:"warehouse_id,omitempty"`
 	Type          string    `json:"type"`
 	Qty           int       `json:"qty"`
 	OccurredAt    time.Time `json:"occurred_at"`
 	ReferenceID   *string   `json:"reference_id"`
+	ReasonCode    string    `json:"reason_code,omitempty"`
+	ActorID       string    `json:"actor_id,omitempty"`
 }
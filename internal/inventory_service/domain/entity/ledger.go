@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// StockBalance is the stock of a product at a warehouse, or across all warehouses when WarehouseID is empty
+type StockBalance struct {
+	WarehouseID  string `json:"warehouse_id,omitempty"`
+	AvailableQty int    `json:"available_qty"`
+	ReservedQty  int    `json:"reserved_qty"`
+	SoldQty      int    `json:"sold_qty"`
+}
+
+// StockLedgerTotal is the summed quantity of a product's stock transactions of one type at a warehouse
+type StockLedgerTotal struct {
+	WarehouseID string
+	Type        string
+	Qty         int
+}
+
+// StockSnapshot is the stock of a product at a point in time, replayed from its stock transactions
+type StockSnapshot struct {
+	ProductID  string          `json:"product_id"`
+	At         time.Time       `json:"at"`
+	Total      StockBalance    `json:"total"`
+	Warehouses []*StockBalance `json:"warehouses"`
+}
+
+// StockDrift is a difference between the stock recorded for a product, in total or at a warehouse, and its ledger
+type StockDrift struct {
+	WarehouseID string       `json:"warehouse_id,omitempty"` // empty for the inventory item's totals
+	Recorded    StockBalance `json:"recorded"`
+	Ledger      StockBalance `json:"ledger"`
+}
+
+// StockReconciliation is the result of reconciling the stock of a product with its ledger
+type StockReconciliation struct {
+	ProductID string        `json:"product_id"`
+	Drifts    []*StockDrift `json:"drifts"`
+	Fixed     bool          `json:"fixed"` // the recorded stock was corrected to the ledger
+}
+
+// ReconciliationReport is the result of reconciling the stock of every product
+type ReconciliationReport struct {
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Checked    int                    `json:"checked"`
+	Drifted    []*StockReconciliation `json:"drifted"`
+}
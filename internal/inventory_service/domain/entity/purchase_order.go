@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// ReasonPurchaseOrderReceipt is the reason code of stock received against a purchase order
+const ReasonPurchaseOrderReceipt = "PO_RECEIPT"
+
+// Supplier is a vendor stock is replenished from
+type Supplier struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Phone        string    `json:"phone"`
+	LeadTimeDays int       `json:"lead_time_days"` // days from approval to delivery, for the expected date
+	Active       bool      `json:"active"`         // inactive suppliers are not ordered from
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PurchaseOrder is an order of stock from a supplier, delivered to a warehouse. Drafts are created by
+// hand or from low-stock events, and stock is received against approved orders, possibly in parts.
+type PurchaseOrder struct {
+	ID          string               `json:"id"`
+	SupplierID  string               `json:"supplier_id"`
+	WarehouseID string               `json:"warehouse_id"`
+	Status      string               `json:"status"`
+	CreatedBy   string               `json:"created_by"`
+	ApprovedBy  string               `json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time           `json:"approved_at,omitempty"`
+	ExpectedAt  *time.Time           `json:"expected_at,omitempty"`
+	CancelledBy string               `json:"cancelled_by,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	Lines       []*PurchaseOrderLine `json:"lines"`
+}
+
+// PurchaseOrderLine is the quantity of one product ordered on a purchase order, and received so far
+type PurchaseOrderLine struct {
+	ProductID   string `json:"product_id"`
+	OrderedQty  int    `json:"ordered_qty"`
+	ReceivedQty int    `json:"received_qty"`
+}
+
+// OutstandingQty returns the quantity still to be received
+func (l *PurchaseOrderLine) OutstandingQty() int {
+	return l.OrderedQty - l.ReceivedQty
+}
+
+// FullyReceived reports whether every line of the order has been received in full
+func (po *PurchaseOrder) FullyReceived() bool {
+	for _, line := range po.Lines {
+		if line.OutstandingQty() > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+)
+
+// Warehouse is a location stock is held at and shipped from
+type Warehouse struct {
+	ID         string    `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Country    string    `json:"country"`
+	PostalCode string    `json:"postal_code"`
+	Latitude   *float64  `json:"latitude,omitempty"`
+	Longitude  *float64  `json:"longitude,omitempty"`
+	Priority   int       `json:"priority"` // lower ships first
+	Active     bool      `json:"active"`   // inactive warehouses keep their stock but are not allocated from
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HasCoordinates reports whether the warehouse location is known precisely
+func (w *Warehouse) HasCoordinates() bool {
+	return w.Latitude != nil && w.Longitude != nil
+}
+
+// StockLevel tracks the stock of a product at one warehouse
+type StockLevel struct {
+	ProductID    string    `json:"product_id"`
+	WarehouseID  string    `json:"warehouse_id"`
+	AvailableQty int       `json:"available_qty"`
+	ReservedQty  int       `json:"reserved_qty"`
+	SoldQty      int       `json:"sold_qty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// StockTransfer records stock moved from one warehouse to another
+type StockTransfer struct {
+	TransferID      string    `json:"transfer_id"`
+	ProductID       string    `json:"product_id"`
+	FromWarehouseID string    `json:"from_warehouse_id"`
+	ToWarehouseID   string    `json:"to_warehouse_id"`
+	Qty             int       `json:"qty"`
+	ReferenceID     *string   `json:"reference_id"`
+	TransferredAt   time.Time `json:"transferred_at"`
+}
+
+// Address is the destination an order ships to
+type Address struct {
+	Country    string   `json:"country"`
+	State      string   `json:"state"`
+	City       string   `json:"city"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+}
+
+// HasCoordinates reports whether the address location is known precisely
+func (a *Address) HasCoordinates() bool {
+	return a.Latitude != nil && a.Longitude != nil
+}
+
+// Allocation is a quantity of a product taken from the stock of one warehouse
+type Allocation struct {
+	ProductID   string `json:"product_id"`
+	WarehouseID string `json:"warehouse_id"`
+	Qty         int    `json:"qty"`
+}
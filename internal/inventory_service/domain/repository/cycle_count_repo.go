@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// CycleCountRepository defines the interface for cycle count persistence operations
+type CycleCountRepository interface {
+	// CreateCycleCount creates a cycle count with its lines
+	CreateCycleCount(ctx context.Context, count *entity.CycleCount) (*entity.CycleCount, error)
+
+	// GetCycleCount retrieves a cycle count with its lines by ID
+	GetCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error)
+
+	// LockCycleCount retrieves a cycle count with its lines by ID and locks it until the transaction
+	// in ctx ends
+	LockCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error)
+
+	// UpdateCycleCount updates a cycle count and its lines
+	UpdateCycleCount(ctx context.Context, count *entity.CycleCount) (*entity.CycleCount, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
 )
@@ -11,6 +12,10 @@ type InventoryRepository interface {
 	// GetInventoryItem retrieves an inventory item by SKU
 	GetInventoryItem(ctx context.Context, sku string) (*entity.InventoryItem, error)
 
+	// LockInventoryItems retrieves inventory items by product ID, in the given order, and locks them
+	// until the transaction in ctx ends. It fails with ErrInventoryNotFound if any item is missing.
+	LockInventoryItems(ctx context.Context, productIDs []string) ([]*entity.InventoryItem, error)
+
 	// CreateInventoryItem creates a new inventory item
 	CreateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error)
 
@@ -26,6 +31,17 @@ type InventoryRepository interface {
 	// GetReservationsByOrderID retrieves all reservations for an order
 	GetReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error)
 
+	// LockReservationsByOrderID retrieves all reservations for an order and locks them
+	// until the transaction in ctx ends
+	LockReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error)
+
+	// LockBackorderedReservations retrieves the BACKORDERED reservations of a product, oldest first, and
+	// locks them until the transaction in ctx ends. Reservations locked by another transaction are waited for.
+	LockBackorderedReservations(ctx context.Context, productID string) ([]*entity.InventoryReservation, error)
+
+	// GetExpiredReservations retrieves reservations still in RESERVED status that expired before now, oldest first
+	GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.InventoryReservation, error)
+
 	// UpdateReservation updates an existing reservation
 	UpdateReservation(ctx context.Context, reservation *entity.InventoryReservation) (*entity.InventoryReservation, error)
 
@@ -38,6 +54,13 @@ type InventoryRepository interface {
 	// GetStockTransactions retrieves stock transactions for a SKU
 	GetStockTransactions(ctx context.Context, sku string, limit, offset int) ([]*entity.StockTransaction, int, error)
 
+	// GetStockLedgerTotals sums the quantities of a product's stock transactions by warehouse and type,
+	// counting only those that occurred at or before until when it is not nil
+	GetStockLedgerTotals(ctx context.Context, productID string, until *time.Time) ([]*entity.StockLedgerTotal, error)
+
+	// ListProductIDs lists the product IDs of inventory items after afterProductID in ascending order
+	ListProductIDs(ctx context.Context, afterProductID string, limit int) ([]string, error)
+
 	// GetLowStockItems retrieves items with stock below their reorder level
 	GetLowStockItems(ctx context.Context, limit, offset int) ([]*entity.InventoryItem, int, error)
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// PurchaseOrderRepository defines the interface for purchase order persistence operations
+type PurchaseOrderRepository interface {
+	// CreatePurchaseOrder creates a purchase order with its lines
+	CreatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error)
+
+	// GetPurchaseOrder retrieves a purchase order with its lines by ID
+	GetPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error)
+
+	// LockPurchaseOrder retrieves a purchase order with its lines by ID and locks it until the
+	// transaction in ctx ends
+	LockPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error)
+
+	// LockDraftPurchaseOrder retrieves the oldest DRAFT purchase order from a supplier to a warehouse and
+	// locks it until the transaction in ctx ends, failing with ErrPurchaseOrderNotFound if there is none
+	LockDraftPurchaseOrder(ctx context.Context, supplierID, warehouseID string) (*entity.PurchaseOrder, error)
+
+	// HasOutstandingPurchaseOrderLine reports whether a product is still to be received on any open
+	// purchase order
+	HasOutstandingPurchaseOrderLine(ctx context.Context, productID string) (bool, error)
+
+	// ListPurchaseOrders lists purchase orders newest first, only those in status unless it is empty
+	ListPurchaseOrders(ctx context.Context, status string, limit, offset int) ([]*entity.PurchaseOrder, int, error)
+
+	// UpdatePurchaseOrder updates a purchase order and its lines
+	UpdatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// SupplierRepository defines the interface for supplier persistence operations
+type SupplierRepository interface {
+	// CreateSupplier creates a new supplier
+	CreateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+
+	// GetSupplier retrieves a supplier by ID
+	GetSupplier(ctx context.Context, id string) (*entity.Supplier, error)
+
+	// LockSupplier retrieves a supplier by ID and locks it until the transaction in ctx ends
+	LockSupplier(ctx context.Context, id string) (*entity.Supplier, error)
+
+	// ListSuppliers lists suppliers by name, only active ones when activeOnly is set
+	ListSuppliers(ctx context.Context, activeOnly bool) ([]*entity.Supplier, error)
+
+	// UpdateSupplier updates an existing supplier
+	UpdateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+}
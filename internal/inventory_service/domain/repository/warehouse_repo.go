@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// WarehouseRepository defines the interface for warehouse and per-warehouse stock persistence operations
+type WarehouseRepository interface {
+	// CreateWarehouse creates a new warehouse. It fails with ErrWarehouseCodeExists if the code is taken.
+	CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error)
+
+	// GetWarehouse retrieves a warehouse by ID
+	GetWarehouse(ctx context.Context, id string) (*entity.Warehouse, error)
+
+	// GetWarehouseByCode retrieves a warehouse by code
+	GetWarehouseByCode(ctx context.Context, code string) (*entity.Warehouse, error)
+
+	// ListWarehouses retrieves warehouses in priority order, optionally only active ones
+	ListWarehouses(ctx context.Context, activeOnly bool) ([]*entity.Warehouse, error)
+
+	// UpdateWarehouse updates an existing warehouse
+	UpdateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error)
+
+	// GetStockLevels retrieves the stock levels of a product at every warehouse holding it
+	GetStockLevels(ctx context.Context, productID string) ([]*entity.StockLevel, error)
+
+	// GetWarehouseStockLevels retrieves the stock levels of every product held at a warehouse, by product ID
+	GetWarehouseStockLevels(ctx context.Context, warehouseID string) ([]*entity.StockLevel, error)
+
+	// LockStockLevels retrieves the stock levels of the products, ordered by product and warehouse ID,
+	// and locks them until the transaction in ctx ends. Warehouses without a level are left out.
+	LockStockLevels(ctx context.Context, productIDs []string) ([]*entity.StockLevel, error)
+
+	// SaveStockLevel creates or updates a stock level
+	SaveStockLevel(ctx context.Context, level *entity.StockLevel) (*entity.StockLevel, error)
+
+	// CreateStockTransfer records a stock transfer
+	CreateStockTransfer(ctx context.Context, transfer *entity.StockTransfer) (*entity.StockTransfer, error)
+
+	// GetStockTransfers retrieves the stock transfers of a product, newest first
+	GetStockTransfers(ctx context.Context, productID string, limit, offset int) ([]*entity.StockTransfer, int, error)
+
+	// AssignToWarehouse moves stock and reservations that predate warehouses to the given warehouse.
+	// Items without any stock level get one at the warehouse holding their totals, and reservations
+	// and stock transactions without a warehouse are recorded against it. It returns the number of stock
+	// levels created.
+	AssignToWarehouse(ctx context.Context, warehouseID string) (int, error)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+)
+
+// Allocation strategy names
+const (
+	AllocationPriority     = "priority"      // warehouses in priority order
+	AllocationClosest      = "closest"       // warehouses closest to the shipping address first
+	AllocationFewestSplits = "fewest_splits" // as few warehouses as possible for the whole order
+)
+
+// AllocationStrategy decides which warehouses the items of an order are taken from
+type AllocationStrategy interface {
+	// Name returns the name the strategy is configured by
+	Name() string
+
+	// Allocate splits the requested quantity of each product across the given stock levels, which belong
+	// to active warehouses. The destination may be nil. It fails with ErrInsufficientStock when a
+	// product cannot be allocated in full.
+	Allocate(
+		items map[string]int,
+		levels []*entity.StockLevel,
+		warehouses map[string]*entity.Warehouse,
+		destination *entity.Address,
+	) ([]entity.Allocation, error)
+}
@@ -14,6 +14,8 @@ const (
 	EventTypeStockReleased            = "inventory.stock.released"
 	EventTypeStockDeducted            = "inventory.stock.deducted"
 	EventTypeStockLow                 = "inventory.stock.low"
+	EventTypeStockBackordered         = "inventory.stock.backordered"
+	EventTypeBackorderAllocated       = "inventory.backorder.allocated"
 	EventTypeOrderReservationCreated  = "order.reservation.created"
 	EventTypeOrderReservationCanceled = "order.reservation.canceled"
 	EventTypeOrderReservationExpired  = "order.reservation.expired"
@@ -39,6 +41,16 @@ type EventPublisherService interface {
 	// PublishStockLow publishes an event that stock is below reorder level
 	PublishStockLow(ctx context.Context, item *entity.InventoryItem) error
 
+	// PublishReservationExpired publishes an event that the reservations of an order expired
+	PublishReservationExpired(ctx context.Context, orderID string, reservations []*entity.InventoryReservation) error
+
+	// PublishStockBackordered publishes an event that part of an order was queued as a backorder or preorder
+	PublishStockBackordered(ctx context.Context, reservation *entity.InventoryReservation, policy string) error
+
+	// PublishBackorderAllocated publishes an event that received stock was reserved for a backordered
+	// reservation, with the quantity of it still waiting for stock
+	PublishBackorderAllocated(ctx context.Context, reservation *entity.InventoryReservation, backorderedQty int) error
+
 	// Close closes the publisher connections
 	Close() error
 }
@@ -60,6 +72,12 @@ type EventSubscriberService interface {
 	// HandleReleaseRequest handles release requests
 	HandleReleaseRequest(ctx context.Context, releaseData []byte) error
 
+	// SubscribeToProductEvents subscribes to product catalogue events
+	SubscribeToProductEvents(ctx context.Context) error
+
+	// HandleProductEvent provisions inventory for products announced by the product service
+	HandleProductEvent(ctx context.Context, productData []byte) error
+
 	// Close closes the subscriber connections
 	Close() error
 }
@@ -0,0 +1,45 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+)
+
+// BackorderPolicy decides whether an item can be ordered beyond its stock
+type BackorderPolicy string
+
+const (
+	BackorderPolicyNone      BackorderPolicy = "NONE"
+	BackorderPolicyBackorder BackorderPolicy = "BACKORDER" // out of stock, more is on the way
+	BackorderPolicyPreorder  BackorderPolicy = "PREORDER"  // not released yet
+)
+
+func (p BackorderPolicy) String() string {
+	return string(p)
+}
+
+func (p BackorderPolicy) IsValid() bool {
+	policies := [...]BackorderPolicy{BackorderPolicyNone, BackorderPolicyBackorder, BackorderPolicyPreorder}
+	for _, policy := range policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsBackorder reports whether orders beyond stock are queued instead of refused
+func (p BackorderPolicy) AllowsBackorder() bool {
+	return p == BackorderPolicyBackorder || p == BackorderPolicyPreorder
+}
+
+func ParseBackorderPolicy(policy string) (BackorderPolicy, error) {
+	if policy == "" {
+		return BackorderPolicyNone, nil
+	}
+	policy = strings.ToUpper(policy)
+	if !BackorderPolicy(policy).IsValid() {
+		return "", errors.New("invalid backorder policy")
+	}
+	return BackorderPolicy(policy), nil
+}
@@ -0,0 +1,36 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+)
+
+type CycleCountStatus string
+
+const (
+	CycleCountStatusOpen      CycleCountStatus = "OPEN"
+	CycleCountStatusPosted    CycleCountStatus = "POSTED"
+	CycleCountStatusCancelled CycleCountStatus = "CANCELLED"
+)
+
+func (s CycleCountStatus) String() string {
+	return string(s)
+}
+
+func (s CycleCountStatus) IsValid() bool {
+	statuses := [...]CycleCountStatus{CycleCountStatusOpen, CycleCountStatusPosted, CycleCountStatusCancelled}
+	for _, status := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseCycleCountStatus(status string) (CycleCountStatus, error) {
+	status = strings.ToUpper(status)
+	if !CycleCountStatus(status).IsValid() {
+		return "", errors.New("invalid cycle count status")
+	}
+	return CycleCountStatus(status), nil
+}
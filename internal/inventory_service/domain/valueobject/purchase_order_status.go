@@ -0,0 +1,51 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusApproved          PurchaseOrderStatus = "APPROVED"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "CANCELLED"
+)
+
+func (s PurchaseOrderStatus) String() string {
+	return string(s)
+}
+
+func (s PurchaseOrderStatus) IsValid() bool {
+	statuses := [...]PurchaseOrderStatus{
+		PurchaseOrderStatusDraft, PurchaseOrderStatusApproved, PurchaseOrderStatusPartiallyReceived,
+		PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled,
+	}
+	for _, status := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether stock may still arrive against a purchase order in this status
+func (s PurchaseOrderStatus) IsOpen() bool {
+	return s == PurchaseOrderStatusDraft || s == PurchaseOrderStatusApproved || s == PurchaseOrderStatusPartiallyReceived
+}
+
+// CanReceive reports whether stock can be received against a purchase order in this status
+func (s PurchaseOrderStatus) CanReceive() bool {
+	return s == PurchaseOrderStatusApproved || s == PurchaseOrderStatusPartiallyReceived
+}
+
+func ParsePurchaseOrderStatus(status string) (PurchaseOrderStatus, error) {
+	status = strings.ToUpper(status)
+	if !PurchaseOrderStatus(status).IsValid() {
+		return "", errors.New("invalid purchase order status")
+	}
+	return PurchaseOrderStatus(status), nil
+}
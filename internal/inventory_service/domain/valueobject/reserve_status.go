@@ -11,6 +11,9 @@ const (
 	ReserveStatusReserved  ReserveStatus = "RESERVED"
 	ReserveStatusCompleted ReserveStatus = "COMPLETED"
 	ReserveStatusCancelled ReserveStatus = "CANCELLED"
+	ReserveStatusExpired   ReserveStatus = "EXPIRED"
+	// A backordered reservation waits for stock and holds none; it has no warehouse until it is allocated
+	ReserveStatusBackordered ReserveStatus = "BACKORDERED"
 )
 
 func (s ReserveStatus) String() string {
@@ -18,7 +21,7 @@ func (s ReserveStatus) String() string {
 }
 
 func (s ReserveStatus) IsValid() bool {
-	statuses := [...]ReserveStatus{ReserveStatusReserved, ReserveStatusCompleted, ReserveStatusCancelled}
+	statuses := [...]ReserveStatus{ReserveStatusReserved, ReserveStatusCompleted, ReserveStatusCancelled, ReserveStatusExpired, ReserveStatusBackordered}
 	for _, status := range statuses {
 		if s == status {
 			return true
@@ -11,6 +11,16 @@ const (
 	StockTypeReserved StockType = "RESERVE"
 	StockTypeReleased StockType = "RELEASE"
 	StockTypeDeducted StockType = "DEDUCT"
+	// A transfer is recorded as a TRANSFER_OUT at the source warehouse and a TRANSFER_IN at the destination
+	StockTypeTransferOut StockType = "TRANSFER_OUT"
+	StockTypeTransferIn  StockType = "TRANSFER_IN"
+
+	// Adjustments change the stock on hand and carry a reason code and the actor who made them
+	StockTypeReceived   StockType = "RECEIVE"
+	StockTypeDamaged    StockType = "DAMAGE"
+	StockTypeShrinkage  StockType = "SHRINKAGE"
+	StockTypeReturned   StockType = "RETURN_TO_STOCK"
+	StockTypeCycleCount StockType = "CYCLE_COUNT" // the quantity is the signed variance counted
 )
 
 func (s StockType) String() string {
@@ -18,7 +28,10 @@ func (s StockType) String() string {
 }
 
 func (s StockType) IsValid() bool {
-	statuses := [...]StockType{StockTypeReserved, StockTypeReleased, StockTypeDeducted}
+	statuses := [...]StockType{
+		StockTypeReserved, StockTypeReleased, StockTypeDeducted, StockTypeTransferOut, StockTypeTransferIn,
+		StockTypeReceived, StockTypeDamaged, StockTypeShrinkage, StockTypeReturned, StockTypeCycleCount,
+	}
 	for _, status := range statuses {
 		if s == status {
 			return true
@@ -27,6 +40,44 @@ func (s StockType) IsValid() bool {
 	return false
 }
 
+// IsAdjustment reports whether the type is a reason-coded stock adjustment
+func (s StockType) IsAdjustment() bool {
+	switch s {
+	case StockTypeReceived, StockTypeDamaged, StockTypeShrinkage, StockTypeReturned, StockTypeCycleCount:
+		return true
+	}
+	return false
+}
+
+// AdjustmentDelta returns the change of the available quantity made by an adjustment of qty
+func (s StockType) AdjustmentDelta(qty int) int {
+	switch s {
+	case StockTypeReceived, StockTypeReturned, StockTypeCycleCount:
+		return qty
+	case StockTypeDamaged, StockTypeShrinkage:
+		return -qty
+	}
+	return 0
+}
+
+// LedgerDelta returns the changes of the available, reserved and sold quantities at a warehouse made by
+// a transaction of qty of this type. Replaying a product's transactions through it yields its stock.
+func (s StockType) LedgerDelta(qty int) (available, reserved, sold int) {
+	switch s {
+	case StockTypeReserved:
+		return -qty, qty, 0
+	case StockTypeReleased:
+		return qty, -qty, 0
+	case StockTypeDeducted:
+		return 0, -qty, qty
+	case StockTypeTransferOut:
+		return -qty, 0, 0
+	case StockTypeTransferIn:
+		return qty, 0, 0
+	}
+	return s.AdjustmentDelta(qty), 0, 0
+}
+
 func ParseStockType(status string) (StockType, error) {
 	status = strings.ToLower(status)
 	if !StockType(status).IsValid() {
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+)
+
+// stockAdjuster applies reason-coded stock adjustments, for stock adjustments and cycle counts alike
+type stockAdjuster struct {
+	repo       repository.InventoryRepository
+	warehouses repository.WarehouseRepository
+	eventPub   service.EventPublisherService
+}
+
+// adjustmentResult is what applying adjustments changed, for the events published once it is committed
+type adjustmentResult struct {
+	items     []*entity.InventoryItem
+	allocated []backorderAllocation
+}
+
+// validateAdjustment checks that an adjustment has a product, a warehouse, an adjustment type, a reason
+// code, an actor and a quantity: positive, or any non-zero variance for a cycle count
+func validateAdjustment(adjustment entity.StockAdjustment) error {
+	stockType := valueobject.StockType(adjustment.Type)
+	if adjustment.ProductID == "" || adjustment.WarehouseID == "" || !stockType.IsAdjustment() ||
+		adjustment.ReasonCode == "" || adjustment.ActorID == "" {
+		return entity.ErrInvalidStockAdjustment
+	}
+	if adjustment.Qty <= 0 && !(stockType == valueobject.StockTypeCycleCount && adjustment.Qty < 0) {
+		return entity.ErrInvalidStockAdjustment
+	}
+	return nil
+}
+
+// apply applies validated adjustments in the transaction in ctx, with the inventory items and then the
+// stock levels of their products locked in product ID order. Each adjustment is recorded as a stock
+// transaction. Stock that comes in at an active warehouse is reserved for the item's backorders first.
+// It fails with ErrInsufficientStock if an adjustment would take more than is available.
+func (a *stockAdjuster) apply(ctx context.Context, adjustments []entity.StockAdjustment, now time.Time) (*adjustmentResult, error) {
+	productIDs := make([]string, 0, len(adjustments))
+	warehouses := make(map[string]*entity.Warehouse)
+	for _, adjustment := range adjustments {
+		productIDs = append(productIDs, adjustment.ProductID)
+		if _, ok := warehouses[adjustment.WarehouseID]; ok {
+			continue
+		}
+		warehouse, err := a.warehouses.GetWarehouse(ctx, adjustment.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		warehouses[adjustment.WarehouseID] = warehouse
+	}
+	sort.Strings(productIDs)
+	productIDs = slices.Compact(productIDs)
+
+	items, err := a.repo.LockInventoryItems(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	byProductID := make(map[string]*entity.InventoryItem, len(items))
+	for _, item := range items {
+		byProductID[item.ProductID] = item
+	}
+	levels, err := a.warehouses.LockStockLevels(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	byLocation := indexStockLevels(levels)
+
+	result := &adjustmentResult{}
+	var touched []*entity.StockLevel
+	for _, adjustment := range adjustments {
+		location := stockLocation{adjustment.ProductID, adjustment.WarehouseID}
+		level, ok := byLocation[location]
+		if !ok {
+			level = &entity.StockLevel{ProductID: adjustment.ProductID, WarehouseID: adjustment.WarehouseID}
+			byLocation[location] = level
+		}
+		if !slices.Contains(touched, level) {
+			touched = append(touched, level)
+		}
+
+		item := byProductID[adjustment.ProductID]
+		delta := valueobject.StockType(adjustment.Type).AdjustmentDelta(adjustment.Qty)
+		if level.AvailableQty+delta < 0 {
+			return nil, entity.ErrInsufficientStock
+		}
+		item.AvailableQty += delta
+		level.AvailableQty += delta
+
+		// Record stock transaction
+		var refPtr *string
+		if adjustment.ReferenceID != "" {
+			refID := adjustment.ReferenceID
+			refPtr = &refID
+		}
+		if _, err := a.repo.RecordStockTransaction(ctx, &entity.StockTransaction{
+			TransactionID: uuid.New().String(),
+			ProductID:     adjustment.ProductID,
+			WarehouseID:   adjustment.WarehouseID,
+			Type:          adjustment.Type,
+			Qty:           adjustment.Qty,
+			OccurredAt:    now,
+			ReferenceID:   refPtr,
+			ReasonCode:    adjustment.ReasonCode,
+			ActorID:       adjustment.ActorID,
+		}); err != nil {
+			return nil, err
+		}
+
+		if delta > 0 && warehouses[adjustment.WarehouseID].Active && item.BackorderedQty > 0 {
+			allocated, err := a.allocateBackorders(ctx, item, level, now)
+			if err != nil {
+				return nil, err
+			}
+			result.allocated = append(result.allocated, allocated...)
+		}
+	}
+
+	for _, level := range touched {
+		if _, err := a.warehouses.SaveStockLevel(ctx, level); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range items {
+		item.UpdatedAt = now
+		updatedItem, err := a.repo.UpdateInventoryItem(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		result.items = append(result.items, updatedItem)
+	}
+	return result, nil
+}
+
+// publish publishes the events of applied adjustments once they are committed
+func (a *stockAdjuster) publish(ctx context.Context, result *adjustmentResult) {
+	for _, allocation := range result.allocated {
+		// Publish backorder allocated event
+		if err := a.eventPub.PublishBackorderAllocated(ctx, allocation.reservation, allocation.backorderedQty); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing backorder allocated event: %v\n", err)
+		}
+	}
+	for _, item := range result.items {
+		// Publish stock updated event
+		if err := a.eventPub.PublishStockUpdated(ctx, item); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock updated event: %v\n", err)
+		}
+		if item.AvailableQty <= item.ReorderLevel {
+			if err := a.eventPub.PublishStockLow(ctx, item); err != nil {
+				// Log error but continue
+				fmt.Printf("Error publishing stock low event: %v\n", err)
+			}
+		}
+	}
+}
+
+// backorderAllocation is stock reserved for a backorder, with the quantity of it still waiting for stock
+type backorderAllocation struct {
+	reservation    *entity.InventoryReservation
+	backorderedQty int
+}
+
+// allocateBackorders reserves the available stock of a level for the backorders of its product, oldest
+// first, until the stock runs out. A backorder that cannot be filled completely is split: the filled
+// part becomes a RESERVED reservation and the rest keeps its place in the queue. The caller must hold
+// the item and stock level locks and save both afterwards.
+func (a *stockAdjuster) allocateBackorders(
+	ctx context.Context,
+	item *entity.InventoryItem,
+	level *entity.StockLevel,
+	now time.Time,
+) ([]backorderAllocation, error) {
+	backorders, err := a.repo.LockBackorderedReservations(ctx, item.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocated []backorderAllocation
+	for _, backorder := range backorders {
+		if level.AvailableQty == 0 {
+			break
+		}
+		qty := min(backorder.Qty, level.AvailableQty)
+		remaining := backorder.Qty - qty
+
+		reservation := &entity.InventoryReservation{
+			ReservationID: backorder.ReservationID,
+			OrderID:       backorder.OrderID,
+			ProductID:     backorder.ProductID,
+			WarehouseID:   level.WarehouseID,
+			Qty:           qty,
+			Status:        valueobject.ReserveStatusReserved.String(),
+			ReservedAt:    backorder.ReservedAt, // the order keeps the time it was placed
+			ExpiresAt:     backorderFillExpiry,
+		}
+		if remaining == 0 {
+			reservation, err = a.repo.UpdateReservation(ctx, reservation)
+		} else {
+			backorder.Qty = remaining
+			if _, err := a.repo.UpdateReservation(ctx, backorder); err != nil {
+				return nil, err
+			}
+			reservation.ReservationID = uuid.New().String()
+			reservation, err = a.repo.CreateReservation(ctx, reservation)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		reserveAvailable(&item.AvailableQty, &item.ReservedQty, &item.SoldQty, qty)
+		reserveAvailable(&level.AvailableQty, &level.ReservedQty, &level.SoldQty, qty)
+		item.BackorderedQty -= qty
+
+		// Record stock transaction
+		refID := reservation.ReservationID
+		if _, err := a.repo.RecordStockTransaction(ctx, &entity.StockTransaction{
+			TransactionID: uuid.New().String(),
+			ProductID:     reservation.ProductID,
+			WarehouseID:   reservation.WarehouseID,
+			Type:          valueobject.StockTypeReserved.String(),
+			Qty:           qty,
+			OccurredAt:    now,
+			ReferenceID:   &refID,
+		}); err != nil {
+			return nil, err
+		}
+
+		allocated = append(allocated, backorderAllocation{reservation: reservation, backorderedQty: remaining})
+	}
+	return allocated, nil
+}
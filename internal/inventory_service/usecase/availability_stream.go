@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// AvailabilityStreamUsecase pushes changes of the availability of products to their subscribers.
+// Changes are coalesced: however often a product changes between two flushes, its subscribers get
+// its availability once, as of the flush.
+type AvailabilityStreamUsecase interface {
+	// NotifyStockChanged marks the stock of a product as changed since the last flush
+	NotifyStockChanged(productID string)
+
+	// Subscribe watches the availability of products until ctx is done. The subscription first
+	// carries the current availability of each product.
+	Subscribe(ctx context.Context, productIDs []string) (*AvailabilitySubscription, error)
+
+	// Flush pushes the availability of the products changed since the last flush to their subscribers
+	Flush(ctx context.Context) error
+}
+
+// AvailabilityStreamOptions configures availability streaming
+type AvailabilityStreamOptions struct {
+	MaxProducts int // products a single subscription may watch
+}
+
+// AvailabilitySubscription receives the availability of the products it watches. Only the latest
+// availability of each product is kept until it is read, so slow readers skip intermediate changes
+// instead of holding up the others.
+type AvailabilitySubscription struct {
+	mu      sync.Mutex
+	pending map[string]*entity.AvailabilityUpdate
+	ready   chan struct{}
+}
+
+// Ready is signalled when updates are waiting to be read with Next
+func (s *AvailabilitySubscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Next returns the updates waiting since the last call, ordered by product ID
+func (s *AvailabilitySubscription) Next() []*entity.AvailabilityUpdate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updates := make([]*entity.AvailabilityUpdate, 0, len(s.pending))
+	for _, update := range s.pending {
+		updates = append(updates, update)
+	}
+	s.pending = make(map[string]*entity.AvailabilityUpdate)
+
+	sort.Slice(updates, func(i, j int) bool { return updates[i].ProductID < updates[j].ProductID })
+	return updates
+}
+
+// push queues an update, replacing any unread update of the same product unless initial is set
+func (s *AvailabilitySubscription) push(update *entity.AvailabilityUpdate, initial bool) {
+	s.mu.Lock()
+	if _, ok := s.pending[update.ProductID]; ok && initial {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[update.ProductID] = update
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// availabilityStreamUsecase implements the AvailabilityStreamUsecase interface
+type availabilityStreamUsecase struct {
+	repo        repository.InventoryRepository
+	options     AvailabilityStreamOptions
+	mu          sync.Mutex
+	changed     map[string]struct{}
+	subscribers map[string]map[*AvailabilitySubscription]struct{}
+	errBuilder  *utils.ErrorBuilder
+}
+
+// NewAvailabilityStreamUsecase creates a new instance of AvailabilityStreamUsecase
+func NewAvailabilityStreamUsecase(repo repository.InventoryRepository, options AvailabilityStreamOptions) AvailabilityStreamUsecase {
+	if options.MaxProducts <= 0 {
+		options.MaxProducts = 100
+	}
+	return &availabilityStreamUsecase{
+		repo:        repo,
+		options:     options,
+		changed:     make(map[string]struct{}),
+		subscribers: make(map[string]map[*AvailabilitySubscription]struct{}),
+		errBuilder:  utils.NewErrorBuilder("AvailabilityStreamUsecase"),
+	}
+}
+
+// NotifyStockChanged marks the stock of a product as changed. Products nobody watches are ignored.
+func (au *availabilityStreamUsecase) NotifyStockChanged(productID string) {
+	au.mu.Lock()
+	defer au.mu.Unlock()
+
+	if len(au.subscribers[productID]) > 0 {
+		au.changed[productID] = struct{}{}
+	}
+}
+
+// Subscribe watches the availability of products until ctx is done
+func (au *availabilityStreamUsecase) Subscribe(ctx context.Context, productIDs []string) (*AvailabilitySubscription, error) {
+	unique := make(map[string]struct{}, len(productIDs))
+	for _, productID := range productIDs {
+		if productID == "" {
+			return nil, au.errBuilder.Err(entity.ErrInvalidProductData)
+		}
+		unique[productID] = struct{}{}
+	}
+	if len(unique) == 0 || len(unique) > au.options.MaxProducts {
+		return nil, au.errBuilder.Err(entity.ErrInvalidProductData)
+	}
+
+	sub := &AvailabilitySubscription{
+		pending: make(map[string]*entity.AvailabilityUpdate),
+		ready:   make(chan struct{}, 1),
+	}
+
+	// Register before reading the current availability so no change in between is missed
+	au.mu.Lock()
+	for productID := range unique {
+		if au.subscribers[productID] == nil {
+			au.subscribers[productID] = make(map[*AvailabilitySubscription]struct{})
+		}
+		au.subscribers[productID][sub] = struct{}{}
+	}
+	au.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		au.unsubscribe(sub, unique)
+	}()
+
+	for productID := range unique {
+		update, err := au.currentAvailability(ctx, productID)
+		if err != nil {
+			au.unsubscribe(sub, unique)
+			return nil, au.errBuilder.Err(err)
+		}
+		sub.push(update, true)
+	}
+	return sub, nil
+}
+
+// Flush pushes the availability of the products changed since the last flush to their subscribers.
+// Products that fail to load are marked as changed again and retried on the next flush.
+func (au *availabilityStreamUsecase) Flush(ctx context.Context) error {
+	au.mu.Lock()
+	changed := au.changed
+	au.changed = make(map[string]struct{})
+	au.mu.Unlock()
+
+	var errs []error
+	for productID := range changed {
+		update, err := au.currentAvailability(ctx, productID)
+		if err != nil {
+			errs = append(errs, err)
+			au.NotifyStockChanged(productID)
+			continue
+		}
+
+		au.mu.Lock()
+		subs := make([]*AvailabilitySubscription, 0, len(au.subscribers[productID]))
+		for sub := range au.subscribers[productID] {
+			subs = append(subs, sub)
+		}
+		au.mu.Unlock()
+
+		for _, sub := range subs {
+			sub.push(update, false)
+		}
+	}
+
+	if len(errs) > 0 {
+		return au.errBuilder.Err(errors.Join(errs...))
+	}
+	return nil
+}
+
+// unsubscribe stops pushing updates of the products to a subscription
+func (au *availabilityStreamUsecase) unsubscribe(sub *AvailabilitySubscription, productIDs map[string]struct{}) {
+	au.mu.Lock()
+	defer au.mu.Unlock()
+
+	for productID := range productIDs {
+		delete(au.subscribers[productID], sub)
+		if len(au.subscribers[productID]) == 0 {
+			delete(au.subscribers, productID)
+			delete(au.changed, productID)
+		}
+	}
+}
+
+// currentAvailability loads the availability of a product. Products without an inventory item have none.
+func (au *availabilityStreamUsecase) currentAvailability(ctx context.Context, productID string) (*entity.AvailabilityUpdate, error) {
+	item, err := au.repo.GetInventoryItem(ctx, productID)
+	if errors.Is(err, entity.ErrInventoryNotFound) {
+		return &entity.AvailabilityUpdate{ProductID: productID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AvailabilityUpdate{
+		ProductID:     item.ProductID,
+		AvailableQty:  item.AvailableQty,
+		InStock:       item.AvailableQty > 0,
+		Backorderable: canBackorder(item, 1),
+		UpdatedAt:     item.UpdatedAt,
+	}, nil
+}
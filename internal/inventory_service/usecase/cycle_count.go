@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// CycleCountUsecase defines the interface for counting the stock on hand at a warehouse
+type CycleCountUsecase interface {
+	// StartCycleCount snapshots the expected stock on hand of products at a warehouse, or of every
+	// product held there when productIDs is empty
+	StartCycleCount(ctx context.Context, warehouseID string, productIDs []string, actorID string) (*entity.CycleCount, error)
+
+	// GetCycleCount retrieves a cycle count by ID
+	GetCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error)
+
+	// RecordCounts records the counted quantities of products of an open cycle count
+	RecordCounts(ctx context.Context, countID string, counted map[string]int, actorID string) (*entity.CycleCount, error)
+
+	// PostCycleCount posts the variances of a fully counted cycle count as CYCLE_COUNT adjustments
+	PostCycleCount(ctx context.Context, countID, reasonCode, actorID string) (*entity.CycleCount, error)
+
+	// CancelCycleCount closes an open cycle count without adjusting any stock
+	CancelCycleCount(ctx context.Context, countID, actorID string) (*entity.CycleCount, error)
+}
+
+// cycleCountUsecase implements the CycleCountUsecase interface
+type cycleCountUsecase struct {
+	counts     repository.CycleCountRepository
+	inventory  repository.InventoryRepository
+	warehouses repository.WarehouseRepository
+	transactor repository.Transactor
+	adjuster   *stockAdjuster
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewCycleCountUsecase creates a new instance of CycleCountUsecase
+func NewCycleCountUsecase(
+	counts repository.CycleCountRepository,
+	inventory repository.InventoryRepository,
+	warehouses repository.WarehouseRepository,
+	tx repository.Transactor,
+	eventPub service.EventPublisherService,
+) CycleCountUsecase {
+	return &cycleCountUsecase{
+		counts:     counts,
+		inventory:  inventory,
+		warehouses: warehouses,
+		transactor: tx,
+		adjuster:   &stockAdjuster{repo: inventory, warehouses: warehouses, eventPub: eventPub},
+		errBuilder: utils.NewErrorBuilder("CycleCountUsecase"),
+	}
+}
+
+// StartCycleCount snapshots the expected stock on hand, available and reserved, of products at a
+// warehouse, or of every product held there when productIDs is empty
+func (cu *cycleCountUsecase) StartCycleCount(ctx context.Context, warehouseID string, productIDs []string, actorID string) (*entity.CycleCount, error) {
+	if actorID == "" {
+		return nil, cu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
+	}
+	if _, err := cu.warehouses.GetWarehouse(ctx, warehouseID); err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+
+	levels, err := cu.warehouses.GetWarehouseStockLevels(ctx, warehouseID)
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	onHand := make(map[string]int, len(levels))
+	for _, level := range levels {
+		onHand[level.ProductID] = level.AvailableQty + level.ReservedQty
+	}
+
+	if len(productIDs) == 0 {
+		for _, level := range levels {
+			productIDs = append(productIDs, level.ProductID)
+		}
+	} else {
+		productIDs = slices.Clone(productIDs)
+		sort.Strings(productIDs)
+		productIDs = slices.Compact(productIDs)
+		// Products the warehouse holds none of are counted too, as long as they are stocked items
+		for _, productID := range productIDs {
+			if _, ok := onHand[productID]; ok {
+				continue
+			}
+			if _, err := cu.inventory.GetInventoryItem(ctx, productID); err != nil {
+				return nil, cu.errBuilder.Err(err)
+			}
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil, cu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
+	}
+
+	count := &entity.CycleCount{
+		CountID:     uuid.New().String(),
+		WarehouseID: warehouseID,
+		Status:      valueobject.CycleCountStatusOpen.String(),
+		CreatedBy:   actorID,
+		CreatedAt:   time.Now(),
+	}
+	for _, productID := range productIDs {
+		count.Lines = append(count.Lines, &entity.CycleCountLine{
+			ProductID:   productID,
+			ExpectedQty: onHand[productID],
+		})
+	}
+
+	created, err := cu.counts.CreateCycleCount(ctx, count)
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return created, nil
+}
+
+// GetCycleCount retrieves a cycle count by ID
+func (cu *cycleCountUsecase) GetCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error) {
+	count, err := cu.counts.GetCycleCount(ctx, countID)
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return count, nil
+}
+
+// RecordCounts records the counted quantities of products of an open cycle count. Products can be
+// counted again until the count is posted; the last count is kept.
+func (cu *cycleCountUsecase) RecordCounts(ctx context.Context, countID string, counted map[string]int, actorID string) (*entity.CycleCount, error) {
+	if len(counted) == 0 || actorID == "" {
+		return nil, cu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
+	}
+
+	var updated *entity.CycleCount
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		count, err := cu.lockOpenCycleCount(ctx, countID)
+		if err != nil {
+			return err
+		}
+
+		byProductID := make(map[string]*entity.CycleCountLine, len(count.Lines))
+		for _, line := range count.Lines {
+			byProductID[line.ProductID] = line
+		}
+		for productID, qty := range counted {
+			line, ok := byProductID[productID]
+			if !ok || qty < 0 {
+				return entity.ErrInvalidStockAdjustment
+			}
+			line.CountedQty = &qty
+			line.CountedBy = actorID
+		}
+
+		updated, err = cu.counts.UpdateCycleCount(ctx, count)
+		return err
+	})
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// PostCycleCount posts the variances of a fully counted cycle count as CYCLE_COUNT adjustments of the
+// available stock, referencing the count, and closes it in the same transaction. It fails with
+// ErrInsufficientStock if a shortfall is larger than the stock available, which is the case when
+// fewer units were counted than are reserved.
+func (cu *cycleCountUsecase) PostCycleCount(ctx context.Context, countID, reasonCode, actorID string) (*entity.CycleCount, error) {
+	if reasonCode == "" || actorID == "" {
+		return nil, cu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
+	}
+
+	var posted *entity.CycleCount
+	var result *adjustmentResult
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		count, err := cu.lockOpenCycleCount(ctx, countID)
+		if err != nil {
+			return err
+		}
+
+		var adjustments []entity.StockAdjustment
+		for _, line := range count.Lines {
+			if line.CountedQty == nil {
+				return entity.ErrCycleCountIncomplete
+			}
+			if variance := line.Variance(); variance != 0 {
+				adjustments = append(adjustments, entity.StockAdjustment{
+					ProductID:   line.ProductID,
+					WarehouseID: count.WarehouseID,
+					Type:        valueobject.StockTypeCycleCount.String(),
+					Qty:         variance,
+					ReasonCode:  reasonCode,
+					ActorID:     actorID,
+					ReferenceID: count.CountID,
+				})
+			}
+		}
+
+		now := time.Now()
+		result = &adjustmentResult{}
+		if len(adjustments) > 0 {
+			if result, err = cu.adjuster.apply(ctx, adjustments, now); err != nil {
+				return err
+			}
+		}
+
+		count.Status = valueobject.CycleCountStatusPosted.String()
+		count.ClosedBy = actorID
+		count.ClosedAt = &now
+		posted, err = cu.counts.UpdateCycleCount(ctx, count)
+		return err
+	})
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+
+	cu.adjuster.publish(ctx, result)
+	return posted, nil
+}
+
+// CancelCycleCount closes an open cycle count without adjusting any stock
+func (cu *cycleCountUsecase) CancelCycleCount(ctx context.Context, countID, actorID string) (*entity.CycleCount, error) {
+	if actorID == "" {
+		return nil, cu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
+	}
+
+	var cancelled *entity.CycleCount
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		count, err := cu.lockOpenCycleCount(ctx, countID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		count.Status = valueobject.CycleCountStatusCancelled.String()
+		count.ClosedBy = actorID
+		count.ClosedAt = &now
+		cancelled, err = cu.counts.UpdateCycleCount(ctx, count)
+		return err
+	})
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return cancelled, nil
+}
+
+// lockOpenCycleCount locks a cycle count, failing with ErrCycleCountClosed if it was posted or cancelled
+func (cu *cycleCountUsecase) lockOpenCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error) {
+	count, err := cu.counts.LockCycleCount(ctx, countID)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != valueobject.CycleCountStatusOpen.String() {
+		return nil, entity.ErrCycleCountClosed
+	}
+	return count, nil
+}
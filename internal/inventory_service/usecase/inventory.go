@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,24 +21,46 @@ type InventoryUsecase interface {
 	// GetInventoryItem retrieves inventory information for a product SKU
 	GetInventoryItem(ctx context.Context, productID string) (*entity.InventoryItem, error)
 
+	// CheckAvailability checks whether each of several products can be ordered in the requested quantity.
+	// The results are ordered by product ID.
+	CheckAvailability(ctx context.Context, items map[string]int) ([]*entity.StockAvailability, error)
+
 	// CreateInventoryItem creates a new inventory item
 	CreateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error)
 
 	// UpdateInventoryItem updates an existing inventory item
 	UpdateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error)
 
-	// AddStock adds stock to an inventory item
-	AddStock(ctx context.Context, productID string, quantity int, referenceID string) (*entity.InventoryItem, error)
+	// ProvisionInventoryItem makes sure a product has an inventory item, creating an empty one if needed
+	ProvisionInventoryItem(ctx context.Context, productID string) (*entity.InventoryItem, error)
+
+	// RetireInventoryItem stops replenishing and backordering a product that left the catalogue.
+	// Its stock and history are kept. It does nothing if the product has no inventory item.
+	RetireInventoryItem(ctx context.Context, productID string) (*entity.InventoryItem, error)
+
+	// ImportStock gives a product with no stock yet its stock from another system, at the default warehouse.
+	// The product is provisioned if needed. It fails with ErrStockAlreadyExists if the product has stock.
+	ImportStock(ctx context.Context, productID string, qty int, referenceID string) (*entity.InventoryItem, error)
+
+	// AdjustStock applies a reason-coded adjustment of the stock of a product at a warehouse, or at the
+	// default warehouse when none is given. Backorders of the item are reserved from stock that comes in first.
+	AdjustStock(ctx context.Context, adjustment entity.StockAdjustment) (*entity.InventoryItem, error)
 
-	// ReserveStock reserves stock for an order
-	ReserveStock(ctx context.Context, orderID string, items map[string]int) ([]*entity.InventoryReservation, error)
+	// ReserveStock reserves stock for all items of an order, or for none of them. The warehouses each item
+	// is taken from are chosen by the allocation strategy; destination may be nil. Items that allow
+	// backorders get a BACKORDERED reservation for the quantity that is not in stock.
+	ReserveStock(ctx context.Context, orderID string, items map[string]int, destination *entity.Address) ([]*entity.InventoryReservation, error)
 
 	// CompleteReservation marks a reservation as completed and deducts stock
 	CompleteReservation(ctx context.Context, orderID string) error
 
-	// CancelReservation cancels a reservation and releases stock
+	// CancelReservation cancels a reservation and releases stock, dropping any backorders of the order
 	CancelReservation(ctx context.Context, orderID string) error
 
+	// ExpireReservations releases the stock of the reservations of an order that expired before now.
+	// It returns the reservations that were expired.
+	ExpireReservations(ctx context.Context, orderID string, now time.Time) ([]*entity.InventoryReservation, error)
+
 	// GetReservationsByOrderID gets all reservations for an order
 	GetReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error)
 
@@ -46,21 +71,48 @@ type InventoryUsecase interface {
 	GetLowStockItems(ctx context.Context, page, pageSize int) ([]*entity.InventoryItem, int, error)
 }
 
+// reservationHold is how long reserved stock is held for an order before it expires
+const reservationHold = 30 * time.Minute
+
+// backorderFillExpiry is the expiry of received stock reserved for a backorder. The order has already
+// waited for it, so it is held until the order completes or is cancelled and the expiry sweep never
+// releases it. The date still fits a DATETIME column.
+var backorderFillExpiry = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// InventoryOptions configures inventory operations
+type InventoryOptions struct {
+	DefaultWarehouseID string // warehouse stock is added to and adjusted at when none is given
+}
+
 // inventoryUsecase implements the InventoryUsecase interface
 type inventoryUsecase struct {
 	repo       repository.InventoryRepository
+	warehouses repository.WarehouseRepository
+	transactor repository.Transactor
 	eventPub   service.EventPublisherService
+	allocator  service.AllocationStrategy
+	adjuster   *stockAdjuster
+	options    InventoryOptions
 	errBuilder *utils.ErrorBuilder
 }
 
 // NewInventoryUsecase creates a new instance of InventoryUsecase
 func NewInventoryUsecase(
 	repo repository.InventoryRepository,
+	warehouses repository.WarehouseRepository,
+	tx repository.Transactor,
 	eventPub service.EventPublisherService,
+	allocator service.AllocationStrategy,
+	options InventoryOptions,
 ) InventoryUsecase {
 	return &inventoryUsecase{
 		repo:       repo,
+		warehouses: warehouses,
+		transactor: tx,
 		eventPub:   eventPub,
+		allocator:  allocator,
+		adjuster:   &stockAdjuster{repo: repo, warehouses: warehouses, eventPub: eventPub},
+		options:    options,
 		errBuilder: utils.NewErrorBuilder("InventoryUsecase"),
 	}
 }
@@ -74,35 +126,108 @@ func (iu *inventoryUsecase) GetInventoryItem(ctx context.Context, productID stri
 	return item, nil
 }
 
+// CheckAvailability checks whether each of several products can be ordered in the requested quantity,
+// from stock or as a backorder. It reads the current stock without holding it; ReserveStock decides.
+func (iu *inventoryUsecase) CheckAvailability(ctx context.Context, items map[string]int) ([]*entity.StockAvailability, error) {
+	productIDs := make([]string, 0, len(items))
+	for productID, qty := range items {
+		if productID == "" || qty < 1 {
+			return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+		}
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	result := make([]*entity.StockAvailability, len(productIDs))
+	for i, productID := range productIDs {
+		availability := &entity.StockAvailability{
+			ProductID:    productID,
+			RequestedQty: items[productID],
+		}
+		item, err := iu.repo.GetInventoryItem(ctx, productID)
+		switch {
+		case errors.Is(err, entity.ErrInventoryNotFound):
+		case err != nil:
+			return nil, iu.errBuilder.Err(err)
+		default:
+			availability.Found = true
+			availability.AvailableQty = item.AvailableQty
+			availability.InStock = item.AvailableQty >= availability.RequestedQty
+			availability.Backorderable = !availability.InStock && canBackorder(item, availability.RequestedQty-item.AvailableQty)
+		}
+		result[i] = availability
+	}
+	return result, nil
+}
+
 // CreateInventoryItem creates a new inventory item
 func (iu *inventoryUsecase) CreateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
+	if err := normalizeBackorderPolicy(item); err != nil {
+		return nil, iu.errBuilder.Err(err)
+	}
+	if item.AvailableQty < 0 || item.ReservedQty < 0 || item.SoldQty < 0 || item.ReorderQty < 0 {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+	}
+	item.BackorderedQty = 0
+
 	// Set current time
 	item.UpdatedAt = time.Now()
 	// check if SKU is already in use
 	if _, err := iu.repo.GetInventoryItem(ctx, item.ProductID); err == nil {
 		return nil, iu.errBuilder.Err(entity.ErrSKUAlreadyExists)
 	}
-	// Create inventory item
-	newItem, err := iu.repo.CreateInventoryItem(ctx, item)
-	if err != nil {
-		return nil, iu.errBuilder.Err(err)
-	}
-
-	// Create initial stock transaction
-	if item.AvailableQty > 0 {
-		transaction := &entity.StockTransaction{
-			TransactionID: uuid.New().String(),
-			ProductID:     item.ProductID,
-			Type:          valueobject.StockTypeReleased.String(),
-			Qty:           item.AvailableQty,
-			OccurredAt:    time.Now(),
-		}
-
-		_, err = iu.repo.RecordStockTransaction(ctx, transaction)
+	// Create inventory item, holding its initial stock at the default warehouse
+	var newItem *entity.InventoryItem
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		newItem, err = iu.repo.CreateInventoryItem(ctx, item)
 		if err != nil {
-			// Log error but continue
-			fmt.Printf("Error recording initial stock transaction: %v\n", err)
+			return err
+		}
+		if item.AvailableQty == 0 && item.ReservedQty == 0 && item.SoldQty == 0 {
+			return nil
+		}
+		_, err = iu.warehouses.SaveStockLevel(ctx, &entity.StockLevel{
+			ProductID:    item.ProductID,
+			WarehouseID:  iu.options.DefaultWarehouseID,
+			AvailableQty: item.AvailableQty,
+			ReservedQty:  item.ReservedQty,
+			SoldQty:      item.SoldQty,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Record the initial stock in the ledger: all of it received, then the reserved and sold
+		// parts reserved and the sold part deducted
+		for _, initial := range []struct {
+			txType valueobject.StockType
+			qty    int
+		}{
+			{valueobject.StockTypeReceived, item.AvailableQty + item.ReservedQty + item.SoldQty},
+			{valueobject.StockTypeReserved, item.ReservedQty + item.SoldQty},
+			{valueobject.StockTypeDeducted, item.SoldQty},
+		} {
+			if initial.qty == 0 {
+				continue
+			}
+			if _, err := iu.repo.RecordStockTransaction(ctx, &entity.StockTransaction{
+				TransactionID: uuid.New().String(),
+				ProductID:     item.ProductID,
+				WarehouseID:   iu.options.DefaultWarehouseID,
+				Type:          initial.txType.String(),
+				Qty:           initial.qty,
+				OccurredAt:    item.UpdatedAt,
+				ReasonCode:    entity.ReasonInitialStock,
+				ActorID:       entity.SystemActorID,
+			}); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, iu.errBuilder.Err(err)
 	}
 
 	// Publish stock updated event
@@ -122,59 +247,118 @@ func (iu *inventoryUsecase) CreateInventoryItem(ctx context.Context, item *entit
 	return newItem, nil
 }
 
-// UpdateInventoryItem updates an existing inventory item
-func (iu *inventoryUsecase) UpdateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
-	// Ensure the item exists
-	existingItem, err := iu.repo.GetInventoryItem(ctx, item.ProductID)
+// ProvisionInventoryItem makes sure a product has an inventory item, creating an empty one if needed.
+// It is safe to call repeatedly for the same product.
+func (iu *inventoryUsecase) ProvisionInventoryItem(ctx context.Context, productID string) (*entity.InventoryItem, error) {
+	if productID == "" {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+	}
+
+	item, err := iu.repo.GetInventoryItem(ctx, productID)
+	if err == nil {
+		return item, nil
+	}
+	if !errors.Is(err, entity.ErrInventoryNotFound) {
+		return nil, iu.errBuilder.Err(err)
+	}
+
+	newItem, err := iu.repo.CreateInventoryItem(ctx, &entity.InventoryItem{
+		ProductID: productID,
+		UpdatedAt: time.Now(),
+	})
+	if errors.Is(err, entity.ErrSKUAlreadyExists) {
+		// Provisioned concurrently by another consumer
+		item, err = iu.repo.GetInventoryItem(ctx, productID)
+		if err != nil {
+			return nil, iu.errBuilder.Err(err)
+		}
+		return item, nil
+	}
 	if err != nil {
-		return nil, iu.errBuilder.Err(entity.ErrInventoryNotFound)
+		return nil, iu.errBuilder.Err(err)
 	}
 
-	// Check if stock level is changing
-	stockChanged := existingItem.AvailableQty != item.AvailableQty
+	// Publish stock updated event
+	if err := iu.eventPub.PublishStockUpdated(ctx, newItem); err != nil {
+		// Log error but continue
+		fmt.Printf("Error publishing stock updated event: %v\n", err)
+	}
 
-	// Set updated time
-	item.UpdatedAt = time.Now()
+	return newItem, nil
+}
 
-	// Update inventory item
-	updatedItem, err := iu.repo.UpdateInventoryItem(ctx, item)
+// RetireInventoryItem stops replenishing and backordering a product that left the catalogue, so no
+// purchase order or backorder is created for it any more. Its stock and history are kept; backorders
+// already queued stay queued. It does nothing if the product has no inventory item.
+func (iu *inventoryUsecase) RetireInventoryItem(ctx context.Context, productID string) (*entity.InventoryItem, error) {
+	if productID == "" {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+	}
+
+	var retiredItem *entity.InventoryItem
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := iu.repo.LockInventoryItems(ctx, []string{productID})
+		if errors.Is(err, entity.ErrInventoryNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		item := items[0]
+		item.ReorderLevel = 0
+		item.ReorderQty = 0
+		item.BackorderPolicy = valueobject.BackorderPolicyNone.String()
+		item.BackorderLimit = 0
+		item.UpdatedAt = time.Now()
+		retiredItem, err = iu.repo.UpdateInventoryItem(ctx, item)
+		return err
+	})
 	if err != nil {
 		return nil, iu.errBuilder.Err(err)
 	}
+	if retiredItem == nil {
+		return nil, nil
+	}
 
-	// Record stock transaction if stock level changed
-	if stockChanged {
-		// Calculate difference
-		diff := item.AvailableQty - existingItem.AvailableQty
+	// Publish stock updated event
+	if err := iu.eventPub.PublishStockUpdated(ctx, retiredItem); err != nil {
+		// Log error but continue
+		fmt.Printf("Error publishing stock updated event: %v\n", err)
+	}
 
-		var transactionType string
-		if diff > 0 {
-			transactionType = valueobject.StockTypeReleased.String()
-		} else {
-			transactionType = valueobject.StockTypeDeducted.String()
-			diff = -diff // Make positive for recording
-		}
+	return retiredItem, nil
+}
 
-		transaction := &entity.StockTransaction{
-			TransactionID: uuid.New().String(),
-			ProductID:     item.ProductID,
-			Type:          transactionType,
-			Qty:           diff,
-			OccurredAt:    time.Now(),
-		}
+// UpdateInventoryItem updates the settings of an existing inventory item: its reorder level and backorder
+// policy. Stock quantities are only changed by reservations and reason-coded adjustments, and are kept.
+// Backorders already queued stay queued when the backorder policy is turned off.
+func (iu *inventoryUsecase) UpdateInventoryItem(ctx context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
+	if err := normalizeBackorderPolicy(item); err != nil {
+		return nil, iu.errBuilder.Err(err)
+	}
+	if item.ReorderQty < 0 {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+	}
 
-		_, err = iu.repo.RecordStockTransaction(ctx, transaction)
+	var updatedItem *entity.InventoryItem
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Ensure the item exists
+		items, err := iu.repo.LockInventoryItems(ctx, []string{item.ProductID})
 		if err != nil {
-			// Log error but continue
-			fmt.Printf("Error recording stock transaction: %v\n", err)
-		}
-
-		// Publish appropriate event
-		if transactionType == valueobject.StockTypeDeducted.String() {
-			if err := iu.eventPub.PublishStockDeducted(ctx, transaction); err != nil {
-				fmt.Printf("Error publishing stock deducted event: %v\n", err)
-			}
-		}
+			return err
+		}
+		existingItem := items[0]
+		existingItem.ReorderLevel = item.ReorderLevel
+		existingItem.ReorderQty = item.ReorderQty
+		existingItem.SupplierID = item.SupplierID
+		existingItem.BackorderPolicy = item.BackorderPolicy
+		existingItem.BackorderLimit = item.BackorderLimit
+		existingItem.UpdatedAt = time.Now()
+		updatedItem, err = iu.repo.UpdateInventoryItem(ctx, existingItem)
+		return err
+	})
+	if err != nil {
+		return nil, iu.errBuilder.Err(err)
 	}
 
 	// Publish stock updated event
@@ -194,286 +378,530 @@ func (iu *inventoryUsecase) UpdateInventoryItem(ctx context.Context, item *entit
 	return updatedItem, nil
 }
 
-// AddStock adds stock to an inventory item
-func (iu *inventoryUsecase) AddStock(ctx context.Context, productID string, quantity int, referenceID string) (*entity.InventoryItem, error) {
-	if quantity <= 0 {
-		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+// ImportStock gives a product with no stock yet its stock from another system, at the default warehouse,
+// as a STOCK_IMPORT receipt referencing the record it came from. The product is provisioned if needed.
+// A product that already has stock, or had stock that was sold, fails with ErrStockAlreadyExists, so an
+// import can be rerun without counting stock twice.
+func (iu *inventoryUsecase) ImportStock(ctx context.Context, productID string, qty int, referenceID string) (*entity.InventoryItem, error) {
+	if qty < 0 {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidStockAdjustment)
 	}
-
-	// Get the current inventory item
-	item, err := iu.repo.GetInventoryItem(ctx, productID)
-	if err != nil {
-		return nil, iu.errBuilder.Err(err)
+	if _, err := iu.ProvisionInventoryItem(ctx, productID); err != nil {
+		return nil, err
 	}
 
-	// Update available quantity
-	item.AvailableQty += quantity
-	item.UpdatedAt = time.Now()
-
-	// Update inventory item
-	updatedItem, err := iu.repo.UpdateInventoryItem(ctx, item)
+	var imported *entity.InventoryItem
+	var result *adjustmentResult
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := iu.repo.LockInventoryItems(ctx, []string{productID})
+		if err != nil {
+			return err
+		}
+		item := items[0]
+		if item.AvailableQty != 0 || item.ReservedQty != 0 || item.SoldQty != 0 || item.BackorderedQty != 0 {
+			return entity.ErrStockAlreadyExists
+		}
+		if qty == 0 {
+			imported = item
+			return nil
+		}
+
+		result, err = iu.adjuster.apply(ctx, []entity.StockAdjustment{{
+			ProductID:   productID,
+			WarehouseID: iu.options.DefaultWarehouseID,
+			Type:        valueobject.StockTypeReceived.String(),
+			Qty:         qty,
+			ReasonCode:  entity.ReasonStockImport,
+			ActorID:     entity.SystemActorID,
+			ReferenceID: referenceID,
+		}}, time.Now())
+		if err != nil {
+			return err
+		}
+		imported = result.items[0]
+		return nil
+	})
 	if err != nil {
 		return nil, iu.errBuilder.Err(err)
 	}
 
-	// Record stock transaction
-	refPtr := &referenceID
-	if referenceID == "" {
-		refPtr = nil
+	if result != nil {
+		iu.adjuster.publish(ctx, result)
 	}
+	return imported, nil
+}
 
-	transaction := &entity.StockTransaction{
-		TransactionID: uuid.New().String(),
-		ProductID:     productID,
-		Type:          valueobject.StockTypeReleased.String(),
-		Qty:           quantity,
-		OccurredAt:    time.Now(),
-		ReferenceID:   refPtr,
+// AdjustStock applies a reason-coded adjustment of the stock of a product at a warehouse, or at the
+// default warehouse when none is given. Stock received or returned at an active warehouse is reserved
+// for the item's backorders first, oldest first, in the same transaction, so no other order can take it.
+func (iu *inventoryUsecase) AdjustStock(ctx context.Context, adjustment entity.StockAdjustment) (*entity.InventoryItem, error) {
+	if adjustment.WarehouseID == "" {
+		adjustment.WarehouseID = iu.options.DefaultWarehouseID
 	}
-
-	_, err = iu.repo.RecordStockTransaction(ctx, transaction)
-	if err != nil {
-		// Log error but continue
-		fmt.Printf("Error recording stock transaction: %v\n", err)
+	if err := validateAdjustment(adjustment); err != nil {
+		return nil, iu.errBuilder.Err(err)
 	}
 
-	// Publish stock updated event
-	if err := iu.eventPub.PublishStockUpdated(ctx, updatedItem); err != nil {
-		// Log error but continue
-		fmt.Printf("Error publishing stock updated event: %v\n", err)
+	var result *adjustmentResult
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = iu.adjuster.apply(ctx, []entity.StockAdjustment{adjustment}, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, iu.errBuilder.Err(err)
 	}
 
-	return updatedItem, nil
+	iu.adjuster.publish(ctx, result)
+	return result.items[0], nil
 }
 
-// ReserveStock reserves stock for an order
-func (iu *inventoryUsecase) ReserveStock(ctx context.Context, orderID string, items map[string]int) ([]*entity.InventoryReservation, error) {
-	reservations := make([]*entity.InventoryReservation, 0, len(items))
-
-	// First check if all items have sufficient stock
+// ReserveStock reserves stock for all items of an order, or for none of them.
+// The inventory items and then their stock levels are locked in product ID order for the whole
+// reservation, so concurrent reservations of the same products queue up instead of overselling or
+// deadlocking. Each item gets one reservation per warehouse the allocation strategy takes it from.
+// An item short of stock fails the whole order unless its backorder policy allows the shortfall to be
+// queued, within its backorder limit, as a BACKORDERED reservation that received stock fills later.
+func (iu *inventoryUsecase) ReserveStock(ctx context.Context, orderID string, items map[string]int, destination *entity.Address) ([]*entity.InventoryReservation, error) {
+	if len(items) == 0 {
+		return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
+	}
+	productIDs := make([]string, 0, len(items))
 	for productID, qty := range items {
-		// Get inventory item
-		inventoryItem, err := iu.repo.GetInventoryItem(ctx, productID)
-		if err != nil {
-			return nil, iu.errBuilder.Err(err)
-		}
-
-		// Check if there's enough available stock
-		if inventoryItem.AvailableQty < qty {
-			// Publish reservation failed event
-			iu.eventPub.PublishStockReservationFailed(ctx, orderID, productID, "Insufficient stock")
-			return nil, iu.errBuilder.Err(entity.ErrInsufficientStock)
+		if qty <= 0 {
+			return nil, iu.errBuilder.Err(entity.ErrInvalidProductData)
 		}
+		productIDs = append(productIDs, productID)
 	}
+	sort.Strings(productIDs)
 
-	// Now we know we have enough stock for all items, proceed with reservations
-	for productID, qty := range items {
-		// Get inventory item
-		inventoryItem, err := iu.repo.GetInventoryItem(ctx, productID)
+	var reservations, backordered []*entity.InventoryReservation
+	var lowStockItems []*entity.InventoryItem
+	var shortProductID string
+	policies := make(map[string]string)
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reservations, backordered, lowStockItems = nil, nil, nil
+
+		inventoryItems, err := iu.repo.LockInventoryItems(ctx, productIDs)
 		if err != nil {
-			// Should not happen as we already checked
-			return nil, iu.errBuilder.Err(err)
+			return err
+		}
+		levels, err := iu.warehouses.LockStockLevels(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		warehouses, err := iu.warehouses.ListWarehouses(ctx, true)
+		if err != nil {
+			return err
+		}
+		active := make(map[string]*entity.Warehouse, len(warehouses))
+		for _, warehouse := range warehouses {
+			active[warehouse.ID] = warehouse
 		}
 
-		// Create reservation with 30-minute expiry
-		reservation := &entity.InventoryReservation{
-			ReservationID: uuid.New().String(),
-			OrderID:       orderID,
-			ProductID:     productID,
-			Qty:           qty,
-			Status:        valueobject.ReserveStatusReserved.String(),
-			ReservedAt:    time.Now(),
-			ExpiresAt:     time.Now().Add(30 * time.Minute),
+		byProductID := make(map[string]*entity.InventoryItem, len(inventoryItems))
+		for _, inventoryItem := range inventoryItems {
+			byProductID[inventoryItem.ProductID] = inventoryItem
 		}
 
-		// Save the reservation
-		createdReservation, err := iu.repo.CreateReservation(ctx, reservation)
-		if err != nil {
-			return nil, iu.errBuilder.Err(err)
+		// Check every item before reserving any of them
+		allocatable := make(map[string]int, len(productIDs))
+		for _, level := range levels {
+			if _, ok := active[level.WarehouseID]; ok {
+				allocatable[level.ProductID] += level.AvailableQty
+			}
+		}
+		inStock := make(map[string]int, len(productIDs))
+		backorders := make(map[string]int)
+		for _, productID := range productIDs {
+			short := items[productID] - allocatable[productID]
+			if short <= 0 {
+				inStock[productID] = items[productID]
+				continue
+			}
+			if !canBackorder(byProductID[productID], short) {
+				shortProductID = productID
+				return entity.ErrInsufficientStock
+			}
+			backorders[productID] = short
+			if allocatable[productID] > 0 {
+				inStock[productID] = allocatable[productID]
+			}
+		}
+
+		var allocations []entity.Allocation
+		if len(inStock) > 0 {
+			allocations, err = iu.allocator.Allocate(inStock, levels, active, destination)
+			if err != nil {
+				return err
+			}
 		}
+		byLocation := indexStockLevels(levels)
+
+		now := time.Now()
+		touched := make(map[stockLocation]bool, len(allocations))
+		for _, allocation := range allocations {
+			location := stockLocation{allocation.ProductID, allocation.WarehouseID}
+
+			// Create reservation held for reservationHold
+			reservation, err := iu.repo.CreateReservation(ctx, &entity.InventoryReservation{
+				ReservationID: uuid.New().String(),
+				OrderID:       orderID,
+				ProductID:     allocation.ProductID,
+				WarehouseID:   allocation.WarehouseID,
+				Qty:           allocation.Qty,
+				Status:        valueobject.ReserveStatusReserved.String(),
+				ReservedAt:    now,
+				ExpiresAt:     now.Add(reservationHold),
+			})
+			if err != nil {
+				return err
+			}
 
-		// Update inventory item
-		inventoryItem.AvailableQty -= qty
-		inventoryItem.ReservedQty += qty
-		inventoryItem.UpdatedAt = time.Now()
+			// Move the quantity from available to reserved
+			inventoryItem := byProductID[allocation.ProductID]
+			reserveAvailable(&inventoryItem.AvailableQty, &inventoryItem.ReservedQty, &inventoryItem.SoldQty, allocation.Qty)
+			level := byLocation[location]
+			reserveAvailable(&level.AvailableQty, &level.ReservedQty, &level.SoldQty, allocation.Qty)
+			touched[location] = true
+
+			// Record stock transaction
+			refID := reservation.ReservationID
+			if _, err := iu.repo.RecordStockTransaction(ctx, &entity.StockTransaction{
+				TransactionID: uuid.New().String(),
+				ProductID:     allocation.ProductID,
+				WarehouseID:   allocation.WarehouseID,
+				Type:          valueobject.StockTypeReserved.String(),
+				Qty:           allocation.Qty,
+				OccurredAt:    now,
+				ReferenceID:   &refID,
+			}); err != nil {
+				return err
+			}
 
-		_, err = iu.repo.UpdateInventoryItem(ctx, inventoryItem)
-		if err != nil {
-			// TODO: In a real system, implement compensating transaction to roll back the reservation
-			return nil, iu.errBuilder.Err(err)
+			reservations = append(reservations, reservation)
 		}
 
-		// Record stock transaction
-		refID := createdReservation.ReservationID
-		transaction := &entity.StockTransaction{
-			TransactionID: uuid.New().String(),
-			ProductID:     productID,
-			Type:          valueobject.StockTypeReserved.String(),
-			Qty:           qty,
-			OccurredAt:    time.Now(),
-			ReferenceID:   &refID,
+		// Queue the shortfall; backorders hold no stock, so they neither expire nor have a warehouse
+		for _, productID := range productIDs {
+			qty, ok := backorders[productID]
+			if !ok {
+				continue
+			}
+			reservation, err := iu.repo.CreateReservation(ctx, &entity.InventoryReservation{
+				ReservationID: uuid.New().String(),
+				OrderID:       orderID,
+				ProductID:     productID,
+				Qty:           qty,
+				Status:        valueobject.ReserveStatusBackordered.String(),
+				ReservedAt:    now,
+				ExpiresAt:     now,
+			})
+			if err != nil {
+				return err
+			}
+			inventoryItem := byProductID[productID]
+			inventoryItem.BackorderedQty += qty
+			policies[productID] = inventoryItem.BackorderPolicy
+			backordered = append(backordered, reservation)
 		}
 
-		_, err = iu.repo.RecordStockTransaction(ctx, transaction)
-		if err != nil {
-			// Log error but continue
-			fmt.Printf("Error recording stock transaction: %v\n", err)
+		for _, level := range levels {
+			if !touched[stockLocation{level.ProductID, level.WarehouseID}] {
+				continue
+			}
+			if _, err := iu.warehouses.SaveStockLevel(ctx, level); err != nil {
+				return err
+			}
+		}
+		for _, inventoryItem := range inventoryItems {
+			inventoryItem.UpdatedAt = now
+			if _, err := iu.repo.UpdateInventoryItem(ctx, inventoryItem); err != nil {
+				return err
+			}
+			if inventoryItem.AvailableQty <= inventoryItem.ReorderLevel {
+				lowStockItems = append(lowStockItems, inventoryItem)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			// Publish reservation failed event
+			iu.eventPub.PublishStockReservationFailed(ctx, orderID, shortProductID, "Insufficient stock")
 		}
+		return nil, iu.errBuilder.Err(err)
+	}
 
-		// Publish stock reserved event
-		if err := iu.eventPub.PublishStockReserved(ctx, createdReservation); err != nil {
+	// Events are published once the reservation is committed
+	for _, reservation := range reservations {
+		if err := iu.eventPub.PublishStockReserved(ctx, reservation); err != nil {
 			// Log error but continue
 			fmt.Printf("Error publishing stock reserved event: %v\n", err)
 		}
-
-		reservations = append(reservations, createdReservation)
-
-		// Check if stock is below reorder level after reservation
-		if inventoryItem.AvailableQty <= inventoryItem.ReorderLevel {
-			if err := iu.eventPub.PublishStockLow(ctx, inventoryItem); err != nil {
-				// Log error but continue
-				fmt.Printf("Error publishing stock low event: %v\n", err)
-			}
+	}
+	for _, reservation := range backordered {
+		if err := iu.eventPub.PublishStockBackordered(ctx, reservation, policies[reservation.ProductID]); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock backordered event: %v\n", err)
+		}
+	}
+	for _, inventoryItem := range lowStockItems {
+		if err := iu.eventPub.PublishStockLow(ctx, inventoryItem); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock low event: %v\n", err)
 		}
 	}
 
-	return reservations, nil
+	return append(reservations, backordered...), nil
 }
 
 // CompleteReservation marks a reservation as completed and deducts stock
 func (iu *inventoryUsecase) CompleteReservation(ctx context.Context, orderID string) error {
-	// Get all reservations for the order
-	reservations, err := iu.repo.GetReservationsByOrderID(ctx, orderID)
+	transactions, _, err := iu.settleReservations(ctx, orderID, valueobject.ReserveStatusCompleted, valueobject.StockTypeDeducted, nil, deductReserved)
 	if err != nil {
 		return iu.errBuilder.Err(err)
 	}
 
-	if len(reservations) == 0 {
-		return iu.errBuilder.Err(entity.ErrInventoryNotFound)
-	}
-
-	for _, reservation := range reservations {
-		// Only process if the reservation is still in RESERVED status
-		if reservation.Status != valueobject.ReserveStatusReserved.String() {
-			continue
-		}
-
-		// Get inventory item
-		inventoryItem, err := iu.repo.GetInventoryItem(ctx, reservation.ProductID)
-		if err != nil {
-			return iu.errBuilder.Err(err)
-		}
-
-		// Update inventory item: move from reserved to sold
-		inventoryItem.ReservedQty -= reservation.Qty
-		inventoryItem.SoldQty += reservation.Qty
-		inventoryItem.UpdatedAt = time.Now()
-
-		_, err = iu.repo.UpdateInventoryItem(ctx, inventoryItem)
-		if err != nil {
-			return iu.errBuilder.Err(err)
-		}
-
-		// Update reservation status
-		reservation.Status = valueobject.ReserveStatusCompleted.String()
-		_, err = iu.repo.UpdateReservation(ctx, reservation)
-		if err != nil {
-			return iu.errBuilder.Err(err)
-		}
-
-		// Record stock transaction
-		refID := reservation.ReservationID
-		transaction := &entity.StockTransaction{
-			TransactionID: uuid.New().String(),
-			ProductID:     reservation.ProductID,
-			Type:          valueobject.StockTypeDeducted.String(),
-			Qty:           reservation.Qty,
-			OccurredAt:    time.Now(),
-			ReferenceID:   &refID,
-		}
-
-		_, err = iu.repo.RecordStockTransaction(ctx, transaction)
-		if err != nil {
-			// Log error but continue
-			fmt.Printf("Error recording stock transaction: %v\n", err)
-		}
-
+	for _, transaction := range transactions {
 		// Publish stock deducted event
 		if err := iu.eventPub.PublishStockDeducted(ctx, transaction); err != nil {
 			// Log error but continue
 			fmt.Printf("Error publishing stock deducted event: %v\n", err)
 		}
 	}
-
 	return nil
 }
 
 // CancelReservation cancels a reservation and releases stock
 func (iu *inventoryUsecase) CancelReservation(ctx context.Context, orderID string) error {
-	// Get all reservations for the order
-	reservations, err := iu.repo.GetReservationsByOrderID(ctx, orderID)
+	_, reservations, err := iu.settleReservations(ctx, orderID, valueobject.ReserveStatusCancelled, valueobject.StockTypeReleased, nil, releaseReserved)
 	if err != nil {
 		return iu.errBuilder.Err(err)
 	}
 
+	for _, reservation := range reservations {
+		if reservation.WarehouseID == "" {
+			// A backorder held no stock
+			continue
+		}
+		// Publish stock released event
+		if err := iu.eventPub.PublishStockReleased(ctx, reservation); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock released event: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// ExpireReservations releases the stock of the reservations of an order that expired before now.
+// Reservations are re-checked under lock, so replicas sweeping the same order release the stock once.
+func (iu *inventoryUsecase) ExpireReservations(ctx context.Context, orderID string, now time.Time) ([]*entity.InventoryReservation, error) {
+	expired := func(reservation *entity.InventoryReservation) bool {
+		return reservation.ExpiresAt.Before(now)
+	}
+	_, reservations, err := iu.settleReservations(ctx, orderID, valueobject.ReserveStatusExpired, valueobject.StockTypeReleased, expired, releaseReserved)
+	if err != nil {
+		return nil, iu.errBuilder.Err(err)
+	}
 	if len(reservations) == 0 {
-		return iu.errBuilder.Err(entity.ErrInventoryNotFound)
+		return nil, nil
 	}
 
 	for _, reservation := range reservations {
-		// Only process if the reservation is still in RESERVED status
-		if reservation.Status != valueobject.ReserveStatusReserved.String() {
-			continue
+		// Publish stock released event
+		if err := iu.eventPub.PublishStockReleased(ctx, reservation); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock released event: %v\n", err)
 		}
+	}
+	if err := iu.eventPub.PublishReservationExpired(ctx, orderID, reservations); err != nil {
+		// Log error but continue
+		fmt.Printf("Error publishing reservation expired event: %v\n", err)
+	}
+	return reservations, nil
+}
 
-		// Get inventory item
-		inventoryItem, err := iu.repo.GetInventoryItem(ctx, reservation.ProductID)
-		if err != nil {
-			return iu.errBuilder.Err(err)
-		}
+// stockMove moves a quantity between the available, reserved and sold counts of an item or stock level
+type stockMove func(available, reserved, sold *int, qty int)
 
-		// Update inventory item
-		inventoryItem.AvailableQty += reservation.Qty
-		inventoryItem.ReservedQty -= reservation.Qty
-		inventoryItem.UpdatedAt = time.Now()
+// reserveAvailable moves a quantity from available to reserved
+func reserveAvailable(available, reserved, _ *int, qty int) {
+	*available -= qty
+	*reserved += qty
+}
 
-		_, err = iu.repo.UpdateInventoryItem(ctx, inventoryItem)
-		if err != nil {
-			return iu.errBuilder.Err(err)
-		}
+// releaseReserved moves a quantity from reserved back to available
+func releaseReserved(available, reserved, _ *int, qty int) {
+	*available += qty
+	*reserved -= qty
+}
+
+// deductReserved moves a quantity from reserved to sold
+func deductReserved(_, reserved, sold *int, qty int) {
+	*reserved -= qty
+	*sold += qty
+}
+
+// normalizeBackorderPolicy validates the backorder settings of an item, defaulting the policy to NONE
+func normalizeBackorderPolicy(item *entity.InventoryItem) error {
+	policy, err := valueobject.ParseBackorderPolicy(item.BackorderPolicy)
+	if err != nil || item.BackorderLimit < 0 {
+		return entity.ErrInvalidProductData
+	}
+	item.BackorderPolicy = policy.String()
+	return nil
+}
 
-		// Update reservation status
-		reservation.Status = valueobject.ReserveStatusCancelled.String()
-		_, err = iu.repo.UpdateReservation(ctx, reservation)
+// canBackorder reports whether qty more of an item can be queued as a backorder
+func canBackorder(item *entity.InventoryItem, qty int) bool {
+	if !valueobject.BackorderPolicy(item.BackorderPolicy).AllowsBackorder() {
+		return false
+	}
+	return item.BackorderLimit == 0 || item.BackorderedQty+qty <= item.BackorderLimit
+}
+
+// stockLocation identifies the stock level of a product at a warehouse
+type stockLocation struct {
+	productID   string
+	warehouseID string
+}
+
+func indexStockLevels(levels []*entity.StockLevel) map[stockLocation]*entity.StockLevel {
+	byLocation := make(map[stockLocation]*entity.StockLevel, len(levels))
+	for _, level := range levels {
+		byLocation[stockLocation{level.ProductID, level.WarehouseID}] = level
+	}
+	return byLocation
+}
+
+// settleReservations moves the open reservations of an order matching include, or all of them when
+// include is nil, to status in one transaction. It applies move to the locked inventory item and
+// stock level of each and records a stock transaction of txType. The reservations are locked before
+// their items and stock levels, and ones that were already settled are skipped, so settling twice
+// has no effect. BACKORDERED reservations hold no stock and are only settled by cancelling them,
+// which takes them off the item's backordered quantity.
+func (iu *inventoryUsecase) settleReservations(
+	ctx context.Context,
+	orderID string,
+	status valueobject.ReserveStatus,
+	txType valueobject.StockType,
+	include func(reservation *entity.InventoryReservation) bool,
+	move stockMove,
+) ([]*entity.StockTransaction, []*entity.InventoryReservation, error) {
+	var transactions []*entity.StockTransaction
+	var settled []*entity.InventoryReservation
+	err := iu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		transactions, settled = nil, nil
+
+		reservations, err := iu.repo.LockReservationsByOrderID(ctx, orderID)
 		if err != nil {
-			return iu.errBuilder.Err(err)
+			return err
+		}
+		if len(reservations) == 0 {
+			return entity.ErrInventoryNotFound
 		}
 
-		// Record stock transaction
-		refID := reservation.ReservationID
-		transaction := &entity.StockTransaction{
-			TransactionID: uuid.New().String(),
-			ProductID:     reservation.ProductID,
-			Type:          valueobject.StockTypeReleased.String(),
-			Qty:           reservation.Qty,
-			OccurredAt:    time.Now(),
-			ReferenceID:   &refID,
+		open := make([]*entity.InventoryReservation, 0, len(reservations))
+		productIDs := make([]string, 0, len(reservations))
+		for _, reservation := range reservations {
+			// Only process if the reservation is still in RESERVED status, or a backorder being cancelled
+			backorder := reservation.Status == valueobject.ReserveStatusBackordered.String() &&
+				status == valueobject.ReserveStatusCancelled
+			if reservation.Status != valueobject.ReserveStatusReserved.String() && !backorder {
+				continue
+			}
+			if include != nil && !include(reservation) {
+				continue
+			}
+			open = append(open, reservation)
+			productIDs = append(productIDs, reservation.ProductID)
 		}
+		if len(open) == 0 {
+			return nil
+		}
+		sort.Strings(productIDs)
+		productIDs = slices.Compact(productIDs)
 
-		_, err = iu.repo.RecordStockTransaction(ctx, transaction)
+		inventoryItems, err := iu.repo.LockInventoryItems(ctx, productIDs)
 		if err != nil {
-			// Log error but continue
-			fmt.Printf("Error recording stock transaction: %v\n", err)
+			return err
 		}
+		byProductID := make(map[string]*entity.InventoryItem, len(inventoryItems))
+		for _, inventoryItem := range inventoryItems {
+			byProductID[inventoryItem.ProductID] = inventoryItem
+		}
+		levels, err := iu.warehouses.LockStockLevels(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		byLocation := indexStockLevels(levels)
+
+		now := time.Now()
+		touched := make(map[stockLocation]bool, len(open))
+		for _, reservation := range open {
+			if reservation.Status == valueobject.ReserveStatusBackordered.String() {
+				byProductID[reservation.ProductID].BackorderedQty -= reservation.Qty
+				reservation.Status = status.String()
+				if _, err := iu.repo.UpdateReservation(ctx, reservation); err != nil {
+					return err
+				}
+				settled = append(settled, reservation)
+				continue
+			}
 
-		// Publish stock released event
-		if err := iu.eventPub.PublishStockReleased(ctx, reservation); err != nil {
-			// Log error but continue
-			fmt.Printf("Error publishing stock released event: %v\n", err)
+			location := stockLocation{reservation.ProductID, reservation.WarehouseID}
+			level, ok := byLocation[location]
+			if !ok {
+				return entity.ErrInventoryNotFound
+			}
+			inventoryItem := byProductID[reservation.ProductID]
+			move(&inventoryItem.AvailableQty, &inventoryItem.ReservedQty, &inventoryItem.SoldQty, reservation.Qty)
+			move(&level.AvailableQty, &level.ReservedQty, &level.SoldQty, reservation.Qty)
+			touched[location] = true
+
+			// Update reservation status
+			reservation.Status = status.String()
+			if _, err := iu.repo.UpdateReservation(ctx, reservation); err != nil {
+				return err
+			}
+
+			// Record stock transaction
+			refID := reservation.ReservationID
+			transaction, err := iu.repo.RecordStockTransaction(ctx, &entity.StockTransaction{
+				TransactionID: uuid.New().String(),
+				ProductID:     reservation.ProductID,
+				WarehouseID:   reservation.WarehouseID,
+				Type:          txType.String(),
+				Qty:           reservation.Qty,
+				OccurredAt:    now,
+				ReferenceID:   &refID,
+			})
+			if err != nil {
+				return err
+			}
+
+			transactions = append(transactions, transaction)
+			settled = append(settled, reservation)
 		}
-	}
 
-	return nil
+		for _, level := range levels {
+			if !touched[stockLocation{level.ProductID, level.WarehouseID}] {
+				continue
+			}
+			if _, err := iu.warehouses.SaveStockLevel(ctx, level); err != nil {
+				return err
+			}
+		}
+		for _, inventoryItem := range inventoryItems {
+			inventoryItem.UpdatedAt = now
+			if _, err := iu.repo.UpdateInventoryItem(ctx, inventoryItem); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return transactions, settled, err
 }
 
 // GetReservationsByOrderID gets all reservations for an order
@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// PurchaseOrderUsecase defines the interface for replenishing stock from suppliers
+type PurchaseOrderUsecase interface {
+	// CreatePurchaseOrder drafts a purchase order by hand
+	CreatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error)
+
+	// DraftReplenishment puts the reorder quantity of a product low on stock on a draft purchase order
+	// from its supplier. It returns nil when the product is not to be replenished now.
+	DraftReplenishment(ctx context.Context, productID string) (*entity.PurchaseOrder, error)
+
+	// ReplenishLowStock drafts replenishment for every product below its reorder level
+	ReplenishLowStock(ctx context.Context) ([]*entity.PurchaseOrder, error)
+
+	// GetPurchaseOrder retrieves a purchase order by ID
+	GetPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error)
+
+	// ListPurchaseOrders lists purchase orders newest first, only those in status unless it is empty
+	ListPurchaseOrders(ctx context.Context, status string, page, pageSize int) ([]*entity.PurchaseOrder, int, error)
+
+	// ApprovePurchaseOrder approves a draft purchase order so stock can be received against it
+	ApprovePurchaseOrder(ctx context.Context, id, actorID string) (*entity.PurchaseOrder, error)
+
+	// ReceivePurchaseOrder receives stock by product ID against an approved purchase order
+	ReceivePurchaseOrder(ctx context.Context, id string, received map[string]int, actorID string) (*entity.PurchaseOrder, error)
+
+	// CancelPurchaseOrder cancels what is still outstanding on an open purchase order
+	CancelPurchaseOrder(ctx context.Context, id, actorID string) (*entity.PurchaseOrder, error)
+}
+
+// PurchaseOrderOptions configures replenishment
+type PurchaseOrderOptions struct {
+	DefaultWarehouseID string // warehouse replenishment is delivered to
+	BatchSize          int    // low-stock items loaded at a time by ReplenishLowStock
+}
+
+// purchaseOrderUsecase implements the PurchaseOrderUsecase interface
+type purchaseOrderUsecase struct {
+	orders     repository.PurchaseOrderRepository
+	suppliers  repository.SupplierRepository
+	inventory  repository.InventoryRepository
+	warehouses repository.WarehouseRepository
+	transactor repository.Transactor
+	adjuster   *stockAdjuster
+	options    PurchaseOrderOptions
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewPurchaseOrderUsecase creates a new instance of PurchaseOrderUsecase
+func NewPurchaseOrderUsecase(
+	orders repository.PurchaseOrderRepository,
+	suppliers repository.SupplierRepository,
+	inventory repository.InventoryRepository,
+	warehouses repository.WarehouseRepository,
+	tx repository.Transactor,
+	eventPub service.EventPublisherService,
+	options PurchaseOrderOptions,
+) PurchaseOrderUsecase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+	return &purchaseOrderUsecase{
+		orders:     orders,
+		suppliers:  suppliers,
+		inventory:  inventory,
+		warehouses: warehouses,
+		transactor: tx,
+		adjuster:   &stockAdjuster{repo: inventory, warehouses: warehouses, eventPub: eventPub},
+		options:    options,
+		errBuilder: utils.NewErrorBuilder("PurchaseOrderUsecase"),
+	}
+}
+
+// CreatePurchaseOrder drafts a purchase order by hand, to the default warehouse unless one is given.
+// Each product may appear on one line only.
+func (pu *purchaseOrderUsecase) CreatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	if po.WarehouseID == "" {
+		po.WarehouseID = pu.options.DefaultWarehouseID
+	}
+	if po.CreatedBy == "" || len(po.Lines) == 0 {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+	}
+	seen := make(map[string]bool, len(po.Lines))
+	for _, line := range po.Lines {
+		if line.ProductID == "" || line.OrderedQty <= 0 || seen[line.ProductID] {
+			return nil, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+		}
+		seen[line.ProductID] = true
+		if _, err := pu.inventory.GetInventoryItem(ctx, line.ProductID); err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+		line.ReceivedQty = 0
+	}
+	if _, err := pu.suppliers.GetSupplier(ctx, po.SupplierID); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if _, err := pu.warehouses.GetWarehouse(ctx, po.WarehouseID); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	sort.Slice(po.Lines, func(i, j int) bool { return po.Lines[i].ProductID < po.Lines[j].ProductID })
+
+	now := time.Now()
+	po.ID = uuid.New().String()
+	po.Status = valueobject.PurchaseOrderStatusDraft.String()
+	po.ApprovedBy, po.ApprovedAt, po.ExpectedAt, po.CancelledBy = "", nil, nil, ""
+	po.CreatedAt = now
+	po.UpdatedAt = now
+
+	created, err := pu.orders.CreatePurchaseOrder(ctx, po)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return created, nil
+}
+
+// DraftReplenishment puts the reorder quantity of a product at or below its reorder level on the draft
+// purchase order from its supplier to the default warehouse, starting a draft if there is none. Products
+// without a supplier or reorder quantity, from inactive suppliers, or still to be received on an open
+// purchase order are skipped, so repeated low-stock events draft the product once. Drafts are made
+// with the supplier and then the draft locked, so concurrent events for the same supplier share one
+// draft and a draft being approved is not added to.
+func (pu *purchaseOrderUsecase) DraftReplenishment(ctx context.Context, productID string) (*entity.PurchaseOrder, error) {
+	var drafted *entity.PurchaseOrder
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := pu.inventory.GetInventoryItem(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if item.SupplierID == "" || item.ReorderQty <= 0 || item.AvailableQty > item.ReorderLevel {
+			return nil
+		}
+		supplier, err := pu.suppliers.LockSupplier(ctx, item.SupplierID)
+		if errors.Is(err, entity.ErrSupplierNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !supplier.Active {
+			return nil
+		}
+
+		onOrder, err := pu.orders.HasOutstandingPurchaseOrderLine(ctx, productID)
+		if err != nil || onOrder {
+			return err
+		}
+
+		now := time.Now()
+		line := &entity.PurchaseOrderLine{ProductID: productID, OrderedQty: item.ReorderQty}
+		draft, err := pu.orders.LockDraftPurchaseOrder(ctx, supplier.ID, pu.options.DefaultWarehouseID)
+		if errors.Is(err, entity.ErrPurchaseOrderNotFound) {
+			drafted, err = pu.orders.CreatePurchaseOrder(ctx, &entity.PurchaseOrder{
+				ID:          uuid.New().String(),
+				SupplierID:  supplier.ID,
+				WarehouseID: pu.options.DefaultWarehouseID,
+				Status:      valueobject.PurchaseOrderStatusDraft.String(),
+				CreatedBy:   entity.SystemActorID,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+				Lines:       []*entity.PurchaseOrderLine{line},
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		draft.Lines = append(draft.Lines, line)
+		sort.Slice(draft.Lines, func(i, j int) bool { return draft.Lines[i].ProductID < draft.Lines[j].ProductID })
+		draft.UpdatedAt = now
+		drafted, err = pu.orders.UpdatePurchaseOrder(ctx, draft)
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return drafted, nil
+}
+
+// ReplenishLowStock drafts replenishment for every product at or below its reorder level, returning
+// the purchase orders drafted to, once each
+func (pu *purchaseOrderUsecase) ReplenishLowStock(ctx context.Context) ([]*entity.PurchaseOrder, error) {
+	var productIDs []string
+	for offset := 0; ; offset += pu.options.BatchSize {
+		items, total, err := pu.inventory.GetLowStockItems(ctx, pu.options.BatchSize, offset)
+		if err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+		for _, item := range items {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		if len(items) == 0 || offset+len(items) >= total {
+			break
+		}
+	}
+
+	var drafted []*entity.PurchaseOrder
+	byID := make(map[string]int)
+	for _, productID := range productIDs {
+		po, err := pu.DraftReplenishment(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		if po == nil {
+			continue
+		}
+		if i, ok := byID[po.ID]; ok {
+			drafted[i] = po
+			continue
+		}
+		byID[po.ID] = len(drafted)
+		drafted = append(drafted, po)
+	}
+	return drafted, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order by ID
+func (pu *purchaseOrderUsecase) GetPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	po, err := pu.orders.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return po, nil
+}
+
+// ListPurchaseOrders lists purchase orders newest first, only those in status unless it is empty
+func (pu *purchaseOrderUsecase) ListPurchaseOrders(ctx context.Context, status string, page, pageSize int) ([]*entity.PurchaseOrder, int, error) {
+	if status != "" {
+		parsed, err := valueobject.ParsePurchaseOrderStatus(status)
+		if err != nil {
+			return nil, 0, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+		}
+		status = parsed.String()
+	}
+
+	offset := (page - 1) * pageSize
+	pos, total, err := pu.orders.ListPurchaseOrders(ctx, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, pu.errBuilder.Err(err)
+	}
+	return pos, total, nil
+}
+
+// ApprovePurchaseOrder approves a draft purchase order. Its expected delivery is set from the
+// supplier's lead time.
+func (pu *purchaseOrderUsecase) ApprovePurchaseOrder(ctx context.Context, id, actorID string) (*entity.PurchaseOrder, error) {
+	if actorID == "" {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+	}
+
+	var approved *entity.PurchaseOrder
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		po, err := pu.orders.LockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.Status != valueobject.PurchaseOrderStatusDraft.String() {
+			return entity.ErrPurchaseOrderStatus
+		}
+		supplier, err := pu.suppliers.GetSupplier(ctx, po.SupplierID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		expectedAt := now.AddDate(0, 0, supplier.LeadTimeDays)
+		po.Status = valueobject.PurchaseOrderStatusApproved.String()
+		po.ApprovedBy = actorID
+		po.ApprovedAt = &now
+		po.ExpectedAt = &expectedAt
+		po.UpdatedAt = now
+		approved, err = pu.orders.UpdatePurchaseOrder(ctx, po)
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return approved, nil
+}
+
+// ReceivePurchaseOrder receives stock by product ID against an approved purchase order. Each line is
+// added to the stock at the order's warehouse as a RECEIVE adjustment referencing the order, in the
+// same transaction that records the received quantity, and may be received in several parts. It fails
+// with ErrReceiptExceedsOrder if more is received than is outstanding on a line.
+func (pu *purchaseOrderUsecase) ReceivePurchaseOrder(ctx context.Context, id string, received map[string]int, actorID string) (*entity.PurchaseOrder, error) {
+	if len(received) == 0 || actorID == "" {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+	}
+
+	var updated *entity.PurchaseOrder
+	var result *adjustmentResult
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		po, err := pu.orders.LockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !valueobject.PurchaseOrderStatus(po.Status).CanReceive() {
+			return entity.ErrPurchaseOrderStatus
+		}
+
+		var adjustments []entity.StockAdjustment
+		for _, line := range po.Lines {
+			qty, ok := received[line.ProductID]
+			if !ok {
+				continue
+			}
+			if qty <= 0 {
+				return entity.ErrInvalidPurchaseOrder
+			}
+			if qty > line.OutstandingQty() {
+				return entity.ErrReceiptExceedsOrder
+			}
+			line.ReceivedQty += qty
+			adjustments = append(adjustments, entity.StockAdjustment{
+				ProductID:   line.ProductID,
+				WarehouseID: po.WarehouseID,
+				Type:        valueobject.StockTypeReceived.String(),
+				Qty:         qty,
+				ReasonCode:  entity.ReasonPurchaseOrderReceipt,
+				ActorID:     actorID,
+				ReferenceID: po.ID,
+			})
+		}
+		if len(adjustments) != len(received) {
+			// A product that is not on the order
+			return entity.ErrInvalidPurchaseOrder
+		}
+
+		now := time.Now()
+		if result, err = pu.adjuster.apply(ctx, adjustments, now); err != nil {
+			return err
+		}
+
+		po.Status = valueobject.PurchaseOrderStatusPartiallyReceived.String()
+		if po.FullyReceived() {
+			po.Status = valueobject.PurchaseOrderStatusReceived.String()
+		}
+		po.UpdatedAt = now
+		updated, err = pu.orders.UpdatePurchaseOrder(ctx, po)
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	pu.adjuster.publish(ctx, result)
+	return updated, nil
+}
+
+// CancelPurchaseOrder cancels an open purchase order. Stock already received against it is kept.
+func (pu *purchaseOrderUsecase) CancelPurchaseOrder(ctx context.Context, id, actorID string) (*entity.PurchaseOrder, error) {
+	if actorID == "" {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPurchaseOrder)
+	}
+
+	var cancelled *entity.PurchaseOrder
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		po, err := pu.orders.LockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !valueobject.PurchaseOrderStatus(po.Status).IsOpen() {
+			return entity.ErrPurchaseOrderStatus
+		}
+
+		po.Status = valueobject.PurchaseOrderStatusCancelled.String()
+		po.CancelledBy = actorID
+		po.UpdatedAt = time.Now()
+		cancelled, err = pu.orders.UpdatePurchaseOrder(ctx, po)
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return cancelled, nil
+}
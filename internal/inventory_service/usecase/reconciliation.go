@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// ReconciliationUsecase defines the interface for checking recorded stock against the stock ledger.
+// The stock transactions are authoritative; inventory items and stock levels are projections of them.
+type ReconciliationUsecase interface {
+	// GetStockAt replays the stock transactions of a product up to a point in time
+	GetStockAt(ctx context.Context, productID string, at time.Time) (*entity.StockSnapshot, error)
+
+	// ReconcileProduct compares the recorded stock of a product with its ledger, correcting it when fix is set
+	ReconcileProduct(ctx context.Context, productID string, fix bool) (*entity.StockReconciliation, error)
+
+	// ReconcileAll reconciles every product, reporting the ones that drifted from their ledger
+	ReconcileAll(ctx context.Context, fix bool) (*entity.ReconciliationReport, error)
+}
+
+// ReconciliationOptions configures stock reconciliation
+type ReconciliationOptions struct {
+	BatchSize int // product IDs loaded at a time by ReconcileAll
+}
+
+// reconciliationUsecase implements the ReconciliationUsecase interface
+type reconciliationUsecase struct {
+	repo       repository.InventoryRepository
+	warehouses repository.WarehouseRepository
+	transactor repository.Transactor
+	eventPub   service.EventPublisherService
+	options    ReconciliationOptions
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewReconciliationUsecase creates a new instance of ReconciliationUsecase
+func NewReconciliationUsecase(
+	repo repository.InventoryRepository,
+	warehouses repository.WarehouseRepository,
+	tx repository.Transactor,
+	eventPub service.EventPublisherService,
+	options ReconciliationOptions,
+) ReconciliationUsecase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+	return &reconciliationUsecase{
+		repo:       repo,
+		warehouses: warehouses,
+		transactor: tx,
+		eventPub:   eventPub,
+		options:    options,
+		errBuilder: utils.NewErrorBuilder("ReconciliationUsecase"),
+	}
+}
+
+// GetStockAt replays the stock transactions of a product that occurred at or before at
+func (ru *reconciliationUsecase) GetStockAt(ctx context.Context, productID string, at time.Time) (*entity.StockSnapshot, error) {
+	if _, err := ru.repo.GetInventoryItem(ctx, productID); err != nil {
+		return nil, ru.errBuilder.Err(err)
+	}
+
+	totals, err := ru.repo.GetStockLedgerTotals(ctx, productID, &at)
+	if err != nil {
+		return nil, ru.errBuilder.Err(err)
+	}
+	warehouses := replayLedger(totals)
+	return &entity.StockSnapshot{
+		ProductID:  productID,
+		At:         at,
+		Total:      sumBalances(warehouses),
+		Warehouses: warehouses,
+	}, nil
+}
+
+// ReconcileProduct compares the inventory item and stock levels of a product with its ledger. The item
+// and its stock levels are locked while the ledger is replayed, so no stock change can interleave. When
+// fix is set the drifted quantities are overwritten with the ledger's.
+func (ru *reconciliationUsecase) ReconcileProduct(ctx context.Context, productID string, fix bool) (*entity.StockReconciliation, error) {
+	reconciliation := &entity.StockReconciliation{ProductID: productID}
+	var fixed *entity.InventoryItem
+	err := ru.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := ru.repo.LockInventoryItems(ctx, []string{productID})
+		if err != nil {
+			return err
+		}
+		item := items[0]
+		levels, err := ru.warehouses.LockStockLevels(ctx, []string{productID})
+		if err != nil {
+			return err
+		}
+		totals, err := ru.repo.GetStockLedgerTotals(ctx, productID, nil)
+		if err != nil {
+			return err
+		}
+
+		// Compare every warehouse holding stock on either side
+		ledger := replayLedger(totals)
+		byWarehouseID := make(map[string]*entity.StockBalance, len(ledger))
+		for _, balance := range ledger {
+			byWarehouseID[balance.WarehouseID] = balance
+		}
+		byLocation := indexStockLevels(levels)
+		for _, level := range levels {
+			if _, ok := byWarehouseID[level.WarehouseID]; !ok {
+				byWarehouseID[level.WarehouseID] = &entity.StockBalance{WarehouseID: level.WarehouseID}
+			}
+		}
+		warehouseIDs := make([]string, 0, len(byWarehouseID))
+		for warehouseID := range byWarehouseID {
+			warehouseIDs = append(warehouseIDs, warehouseID)
+		}
+		sort.Strings(warehouseIDs)
+
+		var drifted []*entity.StockLevel
+		for _, warehouseID := range warehouseIDs {
+			expected := *byWarehouseID[warehouseID]
+			recorded := entity.StockBalance{WarehouseID: warehouseID}
+			level, ok := byLocation[stockLocation{productID, warehouseID}]
+			if ok {
+				recorded.AvailableQty, recorded.ReservedQty, recorded.SoldQty = level.AvailableQty, level.ReservedQty, level.SoldQty
+			} else {
+				level = &entity.StockLevel{ProductID: productID, WarehouseID: warehouseID}
+			}
+			if recorded == expected {
+				continue
+			}
+			reconciliation.Drifts = append(reconciliation.Drifts, &entity.StockDrift{
+				WarehouseID: warehouseID,
+				Recorded:    recorded,
+				Ledger:      expected,
+			})
+			level.AvailableQty, level.ReservedQty, level.SoldQty = expected.AvailableQty, expected.ReservedQty, expected.SoldQty
+			drifted = append(drifted, level)
+		}
+
+		// The inventory item holds the totals across warehouses
+		expected := sumBalances(ledger)
+		recorded := entity.StockBalance{AvailableQty: item.AvailableQty, ReservedQty: item.ReservedQty, SoldQty: item.SoldQty}
+		if recorded != expected {
+			reconciliation.Drifts = append(reconciliation.Drifts, &entity.StockDrift{
+				Recorded: recorded,
+				Ledger:   expected,
+			})
+		}
+
+		if !fix || len(reconciliation.Drifts) == 0 {
+			return nil
+		}
+		now := time.Now()
+		for _, level := range drifted {
+			level.UpdatedAt = now
+			if _, err := ru.warehouses.SaveStockLevel(ctx, level); err != nil {
+				return err
+			}
+		}
+		item.AvailableQty, item.ReservedQty, item.SoldQty = expected.AvailableQty, expected.ReservedQty, expected.SoldQty
+		item.UpdatedAt = now
+		if fixed, err = ru.repo.UpdateInventoryItem(ctx, item); err != nil {
+			return err
+		}
+		reconciliation.Fixed = true
+		return nil
+	})
+	if err != nil {
+		return nil, ru.errBuilder.Err(err)
+	}
+
+	if fixed != nil {
+		if err := ru.eventPub.PublishStockUpdated(ctx, fixed); err != nil {
+			// Log error but continue
+			fmt.Printf("Error publishing stock updated event: %v\n", err)
+		}
+	}
+	return reconciliation, nil
+}
+
+// ReconcileAll reconciles every product in batches, each in its own transaction. Products deleted while
+// it runs are skipped.
+func (ru *reconciliationUsecase) ReconcileAll(ctx context.Context, fix bool) (*entity.ReconciliationReport, error) {
+	report := &entity.ReconciliationReport{StartedAt: time.Now()}
+	after := ""
+	for {
+		productIDs, err := ru.repo.ListProductIDs(ctx, after, ru.options.BatchSize)
+		if err != nil {
+			return nil, ru.errBuilder.Err(err)
+		}
+		for _, productID := range productIDs {
+			reconciliation, err := ru.ReconcileProduct(ctx, productID, fix)
+			if errors.Is(err, entity.ErrInventoryNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			report.Checked++
+			if len(reconciliation.Drifts) > 0 {
+				report.Drifted = append(report.Drifted, reconciliation)
+			}
+		}
+		if len(productIDs) < ru.options.BatchSize {
+			break
+		}
+		after = productIDs[len(productIDs)-1]
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// replayLedger applies the ledger totals of a product to its balance at each warehouse, in warehouse order
+func replayLedger(totals []*entity.StockLedgerTotal) []*entity.StockBalance {
+	var balances []*entity.StockBalance
+	byWarehouseID := make(map[string]*entity.StockBalance)
+	for _, total := range totals {
+		balance, ok := byWarehouseID[total.WarehouseID]
+		if !ok {
+			balance = &entity.StockBalance{WarehouseID: total.WarehouseID}
+			byWarehouseID[total.WarehouseID] = balance
+			balances = append(balances, balance)
+		}
+		available, reserved, sold := valueobject.StockType(total.Type).LedgerDelta(total.Qty)
+		balance.AvailableQty += available
+		balance.ReservedQty += reserved
+		balance.SoldQty += sold
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].WarehouseID < balances[j].WarehouseID })
+	return balances
+}
+
+// sumBalances adds up the balances of a product at each warehouse
+func sumBalances(balances []*entity.StockBalance) entity.StockBalance {
+	var total entity.StockBalance
+	for _, balance := range balances {
+		total.AvailableQty += balance.AvailableQty
+		total.ReservedQty += balance.ReservedQty
+		total.SoldQty += balance.SoldQty
+	}
+	return total
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,8 +22,14 @@ type ReservationProcessorUsecase interface {
 	// ProcessReleaseRequest processes a request to release reserved inventory
 	ProcessRelease(ctx context.Context, orderData []byte) error
 
-	// ProcessReservationExpiry checks and processes expired reservations
-	ProcessReservationExpiry(ctx context.Context) error
+	// ProcessReservationExpiry releases the stock of reservations that expired before now.
+	// It returns the number of reservations expired.
+	ProcessReservationExpiry(ctx context.Context, now time.Time) (int, error)
+}
+
+// ReservationOptions configures reservation processing
+type ReservationOptions struct {
+	ExpiryBatchSize int // expired reservations loaded at a time
 }
 
 // reservationProcessorUsecase implements the ReservationProcessorUsecase interface
@@ -30,6 +37,7 @@ type reservationProcessorUsecase struct {
 	repo        repository.InventoryRepository
 	eventPub    service.EventPublisherService
 	inventoryUC InventoryUsecase
+	options     ReservationOptions
 	errBuilder  *utils.ErrorBuilder
 }
 
@@ -40,32 +48,47 @@ type reservationProcessorUsecase struct {
 //		Items   map[string]int `json:"items"`
 //	}
 type OrderReservationPayload struct {
-	EventID     string          `json:"event_id"`
-	EventType   string          `json:"event_type"`
-	OccurredAt  time.Time       `json:"occurred_at"`
-	OrderID     string          `json:"order_id"`
-	UserID      string          `json:"user_id"`
-	TotalAmount float64         `json:"total_amount"`
-	Status      string          `json:"status"`
-	Items       []OrderItemData `json:"items,omitempty"`
-	Data        interface{}     `json:"data,omitempty"`
+	EventID         string          `json:"event_id"`
+	EventType       string          `json:"event_type"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	OrderID         string          `json:"order_id"`
+	UserID          string          `json:"user_id"`
+	TotalAmount     float64         `json:"total_amount"`
+	Status          string          `json:"status"`
+	Items           []OrderItemData `json:"items,omitempty"`
+	ShippingAddress *entity.Address `json:"shipping_address,omitempty"` // picks warehouses under the closest strategy
+	Data            interface{}     `json:"data,omitempty"`
 }
 type OrderItemData struct {
 	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id,omitempty"`
 	Quantity  int     `json:"quantity"`
 	Price     float64 `json:"price"`
 }
 
+// StockID returns the ID the item's stock is kept under: the variant for variant lines, otherwise the product
+func (i OrderItemData) StockID() string {
+	if i.VariantID != "" {
+		return i.VariantID
+	}
+	return i.ProductID
+}
+
 // NewReservationProcessorUsecase creates a new instance of ReservationProcessorUsecase
 func NewReservationProcessorUsecase(
 	repo repository.InventoryRepository,
 	eventPub service.EventPublisherService,
 	inventoryUC InventoryUsecase,
+	options ReservationOptions,
 ) ReservationProcessorUsecase {
+	if options.ExpiryBatchSize <= 0 {
+		options.ExpiryBatchSize = 100
+	}
 	return &reservationProcessorUsecase{
 		repo:        repo,
 		eventPub:    eventPub,
 		inventoryUC: inventoryUC,
+		options:     options,
 		errBuilder:  utils.NewErrorBuilder("ReservationProcessorUsecase"),
 	}
 }
@@ -106,20 +129,24 @@ func (rpu *reservationProcessorUsecase) ProcessReservation(ctx context.Context,
 			return rpu.errBuilder.Err(fmt.Errorf("failed to cancel existing reservations: %w", err))
 		}
 	}
+	// Lines for the same stock are reserved together
 	mapQuantity := make(map[string]int)
 	for _, item := range payload.Items {
-		mapQuantity[item.ProductID] = item.Quantity
+		mapQuantity[item.StockID()] += item.Quantity
 	}
 	// Create new reservations
-	reservations, err := rpu.inventoryUC.ReserveStock(ctx, payload.OrderID, mapQuantity)
+	reservations, err := rpu.inventoryUC.ReserveStock(ctx, payload.OrderID, mapQuantity, payload.ShippingAddress)
 	if err != nil {
 		// Publish reservation failed event
 		rpu.eventPub.PublishStockReservationFailed(ctx, payload.OrderID, "", err.Error())
 		return rpu.errBuilder.Err(fmt.Errorf("failed to reserve stock: %w", err))
 	}
 
-	// Publish order reservation created event
+	// Publish order reservation created event; backorders were announced as backordered
 	for _, reservation := range reservations {
+		if reservation.Status != valueobject.ReserveStatusReserved.String() {
+			continue
+		}
 		if err := rpu.eventPub.PublishStockReserved(ctx, reservation); err != nil {
 			// Log error but continue
 			fmt.Printf("Error publishing stock reserved event: %v\n", err)
@@ -163,115 +190,48 @@ func (rpu *reservationProcessorUsecase) ProcessRelease(ctx context.Context, orde
 	return nil
 }
 
-// ProcessReservationExpiry checks and processes expired reservations
-func (rpu *reservationProcessorUsecase) ProcessReservationExpiry(ctx context.Context) error {
-	// Get all expired reservations that are still in RESERVED status
-	now := time.Now()
-
-	// In a real implementation, you would add a method to the repository to get expired reservations
-	// For now, we'll simulate this by processing all reservations
-	// This is a simplification - in production code, add a specific repo method for this
-
-	// Example implementation (assuming repository has GetExpiredReservations method):
-	// expiredReservations, err := rpu.repo.GetExpiredReservations(ctx, now)
-
-	// For each expired reservation
-	// Here's how you would process each expired reservation:
-
-	// for _, reservation := range expiredReservations {
-	// 	// Update reservation status
-	// 	reservation.Status = valueobject.ReserveStatusCancelled.String()
-	// 	_, err := rpu.repo.UpdateReservation(ctx, reservation)
-	// 	if err != nil {
-	// 		// Log error but continue processing other reservations
-	// 		fmt.Printf("Error updating reservation status: %v\n", err)
-	// 		continue
-	// 	}
-
-	// 	// Get inventory item
-	// 	inventoryItem, err := rpu.repo.GetInventoryItem(ctx, reservation.SKU)
-	// 	if err != nil {
-	// 		fmt.Printf("Error getting inventory item for SKU %s: %v\n", reservation.SKU, err)
-	// 		continue
-	// 	}
-
-	// 	// Update inventory item
-	// 	inventoryItem.AvailableQty += reservation.Qty
-	// 	inventoryItem.ReservedQty -= reservation.Qty
-	// 	inventoryItem.UpdatedAt = now
-
-	// 	_, err = rpu.repo.UpdateInventoryItem(ctx, inventoryItem)
-	// 	if err != nil {
-	// 		fmt.Printf("Error updating inventory item for SKU %s: %v\n", reservation.SKU, err)
-	// 		continue
-	// 	}
-
-	// 	// Record stock transaction
-	// 	refID := reservation.ReservationID
-	// 	transaction := &entity.StockTransaction{
-	// 		TransactionID: uuid.New().String(),
-	// 		SKU:           reservation.SKU,
-	// 		Type:          valueobject.StockTypeReleased.String(),
-	// 		Qty:           reservation.Qty,
-	// 		OccurredAt:    now,
-	// 		ReferenceID:   &refID,
-	// 	}
-
-	// 	_, err = rpu.repo.RecordStockTransaction(ctx, transaction)
-	// 	if err != nil {
-	// 		fmt.Printf("Error recording stock transaction: %v\n", err)
-	// 	}
-
-	// 	// Publish stock released event
-	// 	if err := rpu.eventPub.PublishStockReleased(ctx, reservation); err != nil {
-	// 		fmt.Printf("Error publishing stock released event: %v\n", err)
-	// 	}
-	// }
-
-	// For now, implement a basic version that works with existing repository methods
-
-	// This is a simplified implementation
-	// Get all reservations (in production, filter by expiration)
-	allReservations, err := rpu.getAllReservations(ctx)
-	if err != nil {
-		return rpu.errBuilder.Err(fmt.Errorf("failed to get reservations: %w", err))
-	}
-
+// ProcessReservationExpiry releases the stock of reservations that expired before now, in batches,
+// until none are left. It returns the number of reservations expired. An order that fails is skipped
+// for the rest of the sweep and the failures are returned together.
+func (rpu *reservationProcessorUsecase) ProcessReservationExpiry(ctx context.Context, now time.Time) (int, error) {
 	expiredCount := 0
+	var errs []error
+	failed := make(map[string]bool)
+	for {
+		reservations, err := rpu.repo.GetExpiredReservations(ctx, now, rpu.options.ExpiryBatchSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get expired reservations: %w", err))
+			break
+		}
 
-	// Find and process expired reservations
-	for _, reservation := range allReservations {
-		// Only process RESERVED status reservations that have expired
-		if reservation.Status == valueobject.ReserveStatusReserved.String() &&
-			reservation.ExpiresAt.Before(now) {
-
-			// Process the cancellation using the existing CancelReservation method
-			// Group reservations by order ID to avoid multiple cancellations
-			if err := rpu.inventoryUC.CancelReservation(ctx, reservation.OrderID); err != nil {
-				fmt.Printf("Error cancelling expired reservation for order %s: %v\n",
-					reservation.OrderID, err)
+		// Reservations are expired per order, so an order with several items is released together
+		progressed := false
+		seen := make(map[string]bool, len(reservations))
+		for _, reservation := range reservations {
+			if seen[reservation.OrderID] || failed[reservation.OrderID] {
 				continue
 			}
+			seen[reservation.OrderID] = true
 
-			expiredCount++
+			expired, err := rpu.inventoryUC.ExpireReservations(ctx, reservation.OrderID, now)
+			if err != nil {
+				failed[reservation.OrderID] = true
+				errs = append(errs, fmt.Errorf("failed to expire reservations of order %s: %w", reservation.OrderID, err))
+				continue
+			}
+			expiredCount += len(expired)
+			progressed = progressed || len(expired) > 0
+		}
 
-			// Publish reservation expired event
-			// Implementation depends on your event publishing system
+		// Expired reservations no longer match, so the next call returns the following batch.
+		// Stop when another replica settled the whole batch first, to avoid spinning on stale reads,
+		// or when the batch only holds orders that failed.
+		if len(reservations) < rpu.options.ExpiryBatchSize || !progressed {
+			break
 		}
 	}
-
-	fmt.Printf("Processed %d expired reservations\n", expiredCount)
-
-	return nil
-}
-
-// getAllReservations is a helper method to get all reservations
-// In a real implementation, your repository should have a method to directly query expired reservations
-func (rpu *reservationProcessorUsecase) getAllReservations(ctx context.Context) ([]*entity.InventoryReservation, error) {
-	// This is a placeholder - in a real implementation, you would have a dedicated repository method
-	// to efficiently query for all reservations or specifically expired ones
-
-	// For demonstration purposes, we'll return an empty slice
-	// In production, implement a proper method in your repository
-	return []*entity.InventoryReservation{}, nil
+	if len(errs) > 0 {
+		return expiredCount, rpu.errBuilder.Err(errors.Join(errs...))
+	}
+	return expiredCount, nil
 }
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// SupplierUsecase defines the interface for supplier operations
+type SupplierUsecase interface {
+	// CreateSupplier creates a new supplier
+	CreateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+
+	// GetSupplier retrieves a supplier by ID
+	GetSupplier(ctx context.Context, id string) (*entity.Supplier, error)
+
+	// ListSuppliers retrieves all suppliers by name
+	ListSuppliers(ctx context.Context) ([]*entity.Supplier, error)
+
+	// UpdateSupplier updates an existing supplier
+	UpdateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+}
+
+// supplierUsecase implements the SupplierUsecase interface
+type supplierUsecase struct {
+	suppliers  repository.SupplierRepository
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewSupplierUsecase creates a new instance of SupplierUsecase
+func NewSupplierUsecase(suppliers repository.SupplierRepository) SupplierUsecase {
+	return &supplierUsecase{
+		suppliers:  suppliers,
+		errBuilder: utils.NewErrorBuilder("SupplierUsecase"),
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (su *supplierUsecase) CreateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	if supplier.Name == "" || supplier.LeadTimeDays < 0 {
+		return nil, su.errBuilder.Err(entity.ErrInvalidSupplierData)
+	}
+
+	now := time.Now()
+	supplier.ID = uuid.New().String()
+	supplier.CreatedAt = now
+	supplier.UpdatedAt = now
+
+	created, err := su.suppliers.CreateSupplier(ctx, supplier)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	return created, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (su *supplierUsecase) GetSupplier(ctx context.Context, id string) (*entity.Supplier, error) {
+	supplier, err := su.suppliers.GetSupplier(ctx, id)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	return supplier, nil
+}
+
+// ListSuppliers retrieves all suppliers by name
+func (su *supplierUsecase) ListSuppliers(ctx context.Context) ([]*entity.Supplier, error) {
+	suppliers, err := su.suppliers.ListSuppliers(ctx, false)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	return suppliers, nil
+}
+
+// UpdateSupplier updates an existing supplier
+func (su *supplierUsecase) UpdateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	if supplier.Name == "" || supplier.LeadTimeDays < 0 {
+		return nil, su.errBuilder.Err(entity.ErrInvalidSupplierData)
+	}
+
+	existing, err := su.suppliers.GetSupplier(ctx, supplier.ID)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	supplier.CreatedAt = existing.CreatedAt
+	supplier.UpdatedAt = time.Now()
+
+	updated, err := su.suppliers.UpdateSupplier(ctx, supplier)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	return updated, nil
+}
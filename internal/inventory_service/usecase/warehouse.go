@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// WarehouseUsecase defines the interface for warehouse and per-warehouse stock operations
+type WarehouseUsecase interface {
+	// CreateWarehouse creates a new warehouse
+	CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error)
+
+	// GetWarehouse retrieves a warehouse by ID
+	GetWarehouse(ctx context.Context, id string) (*entity.Warehouse, error)
+
+	// ListWarehouses retrieves all warehouses in priority order
+	ListWarehouses(ctx context.Context) ([]*entity.Warehouse, error)
+
+	// UpdateWarehouse updates an existing warehouse
+	UpdateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error)
+
+	// EnsureDefaultWarehouse makes sure the default warehouse exists and holds the stock and
+	// reservations recorded before warehouses were introduced
+	EnsureDefaultWarehouse(ctx context.Context, code, name string) (*entity.Warehouse, error)
+
+	// GetStockLevels retrieves the stock of a product at each warehouse
+	GetStockLevels(ctx context.Context, productID string) ([]*entity.StockLevel, error)
+
+	// TransferStock moves available stock of a product from one warehouse to another
+	TransferStock(ctx context.Context, productID, fromWarehouseID, toWarehouseID string, quantity int, referenceID string) (*entity.StockTransfer, error)
+
+	// GetStockTransfers retrieves the stock transfers of a product, newest first
+	GetStockTransfers(ctx context.Context, productID string, page, pageSize int) ([]*entity.StockTransfer, int, error)
+}
+
+// warehouseUsecase implements the WarehouseUsecase interface
+type warehouseUsecase struct {
+	warehouses repository.WarehouseRepository
+	inventory  repository.InventoryRepository
+	transactor repository.Transactor
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewWarehouseUsecase creates a new instance of WarehouseUsecase
+func NewWarehouseUsecase(
+	warehouses repository.WarehouseRepository,
+	inventory repository.InventoryRepository,
+	tx repository.Transactor,
+) WarehouseUsecase {
+	return &warehouseUsecase{
+		warehouses: warehouses,
+		inventory:  inventory,
+		transactor: tx,
+		errBuilder: utils.NewErrorBuilder("WarehouseUsecase"),
+	}
+}
+
+// CreateWarehouse creates a new warehouse
+func (wu *warehouseUsecase) CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	if warehouse.Code == "" || warehouse.Name == "" {
+		return nil, wu.errBuilder.Err(entity.ErrInvalidWarehouseData)
+	}
+
+	now := time.Now()
+	warehouse.ID = uuid.New().String()
+	warehouse.CreatedAt = now
+	warehouse.UpdatedAt = now
+
+	created, err := wu.warehouses.CreateWarehouse(ctx, warehouse)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return created, nil
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (wu *warehouseUsecase) GetWarehouse(ctx context.Context, id string) (*entity.Warehouse, error) {
+	warehouse, err := wu.warehouses.GetWarehouse(ctx, id)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return warehouse, nil
+}
+
+// ListWarehouses retrieves all warehouses in priority order
+func (wu *warehouseUsecase) ListWarehouses(ctx context.Context) ([]*entity.Warehouse, error) {
+	warehouses, err := wu.warehouses.ListWarehouses(ctx, false)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return warehouses, nil
+}
+
+// UpdateWarehouse updates an existing warehouse
+func (wu *warehouseUsecase) UpdateWarehouse(ctx context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	if warehouse.Code == "" || warehouse.Name == "" {
+		return nil, wu.errBuilder.Err(entity.ErrInvalidWarehouseData)
+	}
+
+	existing, err := wu.warehouses.GetWarehouse(ctx, warehouse.ID)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	warehouse.CreatedAt = existing.CreatedAt
+
+	updated, err := wu.warehouses.UpdateWarehouse(ctx, warehouse)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// EnsureDefaultWarehouse makes sure the default warehouse exists and holds the stock and
+// reservations recorded before warehouses were introduced. It is safe to call on every start.
+func (wu *warehouseUsecase) EnsureDefaultWarehouse(ctx context.Context, code, name string) (*entity.Warehouse, error) {
+	warehouse, err := wu.warehouses.GetWarehouseByCode(ctx, code)
+	if errors.Is(err, entity.ErrWarehouseNotFound) {
+		now := time.Now()
+		warehouse, err = wu.warehouses.CreateWarehouse(ctx, &entity.Warehouse{
+			ID:        uuid.New().String(),
+			Code:      code,
+			Name:      name,
+			Active:    true,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if errors.Is(err, entity.ErrWarehouseCodeExists) {
+			// Created concurrently by another replica
+			warehouse, err = wu.warehouses.GetWarehouseByCode(ctx, code)
+		}
+	}
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+
+	err = wu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := wu.warehouses.AssignToWarehouse(ctx, warehouse.ID)
+		return err
+	})
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return warehouse, nil
+}
+
+// GetStockLevels retrieves the stock of a product at each warehouse
+func (wu *warehouseUsecase) GetStockLevels(ctx context.Context, productID string) ([]*entity.StockLevel, error) {
+	if _, err := wu.inventory.GetInventoryItem(ctx, productID); err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	levels, err := wu.warehouses.GetStockLevels(ctx, productID)
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return levels, nil
+}
+
+// TransferStock moves available stock of a product from one warehouse to another.
+// Only available stock moves; reservations stay with the warehouse they were allocated from.
+// The item is locked first, as for every other stock change, so transfers and reservations of
+// the product run one at a time.
+func (wu *warehouseUsecase) TransferStock(
+	ctx context.Context,
+	productID, fromWarehouseID, toWarehouseID string,
+	quantity int,
+	referenceID string,
+) (*entity.StockTransfer, error) {
+	if quantity <= 0 || fromWarehouseID == "" || fromWarehouseID == toWarehouseID {
+		return nil, wu.errBuilder.Err(entity.ErrInvalidStockTransfer)
+	}
+	for _, warehouseID := range []string{fromWarehouseID, toWarehouseID} {
+		if _, err := wu.warehouses.GetWarehouse(ctx, warehouseID); err != nil {
+			return nil, wu.errBuilder.Err(err)
+		}
+	}
+
+	refPtr := &referenceID
+	if referenceID == "" {
+		refPtr = nil
+	}
+
+	var transfer *entity.StockTransfer
+	err := wu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := wu.inventory.LockInventoryItems(ctx, []string{productID}); err != nil {
+			return err
+		}
+		levels, err := wu.warehouses.LockStockLevels(ctx, []string{productID})
+		if err != nil {
+			return err
+		}
+		byLocation := indexStockLevels(levels)
+
+		from, ok := byLocation[stockLocation{productID, fromWarehouseID}]
+		if !ok || from.AvailableQty < quantity {
+			return entity.ErrInsufficientStock
+		}
+		to, ok := byLocation[stockLocation{productID, toWarehouseID}]
+		if !ok {
+			to = &entity.StockLevel{ProductID: productID, WarehouseID: toWarehouseID}
+		}
+
+		from.AvailableQty -= quantity
+		to.AvailableQty += quantity
+		for _, level := range []*entity.StockLevel{from, to} {
+			if _, err := wu.warehouses.SaveStockLevel(ctx, level); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		transfer, err = wu.warehouses.CreateStockTransfer(ctx, &entity.StockTransfer{
+			TransferID:      uuid.New().String(),
+			ProductID:       productID,
+			FromWarehouseID: fromWarehouseID,
+			ToWarehouseID:   toWarehouseID,
+			Qty:             quantity,
+			ReferenceID:     refPtr,
+			TransferredAt:   now,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Record both sides of the transfer against it
+		refID := transfer.TransferID
+		for _, side := range []struct {
+			warehouseID string
+			txType      valueobject.StockType
+		}{
+			{fromWarehouseID, valueobject.StockTypeTransferOut},
+			{toWarehouseID, valueobject.StockTypeTransferIn},
+		} {
+			if _, err := wu.inventory.RecordStockTransaction(ctx, &entity.StockTransaction{
+				TransactionID: uuid.New().String(),
+				ProductID:     productID,
+				WarehouseID:   side.warehouseID,
+				Type:          side.txType.String(),
+				Qty:           quantity,
+				OccurredAt:    now,
+				ReferenceID:   &refID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, wu.errBuilder.Err(err)
+	}
+	return transfer, nil
+}
+
+// GetStockTransfers retrieves the stock transfers of a product, newest first
+func (wu *warehouseUsecase) GetStockTransfers(ctx context.Context, productID string, page, pageSize int) ([]*entity.StockTransfer, int, error) {
+	offset := (page - 1) * pageSize
+	transfers, total, err := wu.warehouses.GetStockTransfers(ctx, productID, pageSize, offset)
+	if err != nil {
+		return nil, 0, wu.errBuilder.Err(err)
+	}
+	return transfers, total, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	inventorypb "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/grpc/proto"
+)
+
+// InventoryServiceClient checks stock availability in the inventory service over gRPC
+type InventoryServiceClient struct {
+	conn   *grpc.ClientConn
+	client inventorypb.InventoryServiceClient
+}
+
+// NewInventoryServiceClient creates a client for the inventory service at the given address
+func NewInventoryServiceClient(address string) (*InventoryServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory service client: %w", err)
+	}
+	return &InventoryServiceClient{
+		conn:   conn,
+		client: inventorypb.NewInventoryServiceClient(conn),
+	}, nil
+}
+
+// Unavailable returns the stock IDs that can be ordered neither from stock nor as a backorder.
+// Stock IDs the inventory service does not know are unavailable.
+func (c *InventoryServiceClient) Unavailable(ctx context.Context, quantities map[string]int) ([]string, error) {
+	req := &inventorypb.CheckAvailabilityRequest{}
+	for stockID, quantity := range quantities {
+		req.Items = append(req.Items, &inventorypb.StockQuantity{ProductId: stockID, Quantity: int32(quantity)})
+	}
+
+	resp, err := c.client.CheckAvailability(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stock availability: %w", err)
+	}
+
+	var unavailable []string
+	for _, availability := range resp.Items {
+		if !availability.InStock && !availability.Backorderable {
+			unavailable = append(unavailable, availability.ProductId)
+		}
+	}
+	return unavailable, nil
+}
+
+// Close closes the underlying connection
+func (c *InventoryServiceClient) Close() error {
+	return c.conn.Close()
+}
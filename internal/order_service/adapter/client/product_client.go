@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	productpb "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/grpc/proto"
+)
+
+// ProductServiceClient reads prices from the product service over gRPC
+type ProductServiceClient struct {
+	conn   *grpc.ClientConn
+	client productpb.ProductServiceClient
+}
+
+// NewProductServiceClient creates a client for the product service at the given address
+func NewProductServiceClient(address string) (*ProductServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product service client: %w", err)
+	}
+	return &ProductServiceClient{
+		conn:   conn,
+		client: productpb.NewProductServiceClient(conn),
+	}, nil
+}
+
+// GetEffectivePrice returns the unit price of a product, or of its variant when variantID is set, at the given time
+func (c *ProductServiceClient) GetEffectivePrice(ctx context.Context, productID, variantID string, at time.Time) (float64, error) {
+	resp, err := c.client.GetEffectivePrice(ctx, &productpb.GetEffectivePriceRequest{
+		ProductId: productID,
+		VariantId: variantID,
+		At:        timestamppb.New(at),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get effective price of product %s: %w", productID, err)
+	}
+	return resp.Price, nil
+}
+
+// Close closes the underlying connection
+func (c *ProductServiceClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/entity"
+	userpb "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/grpc/proto"
+)
+
+// UserServiceClient reads address book data from the user service over gRPC
+type UserServiceClient struct {
+	conn   *grpc.ClientConn
+	client userpb.UserServiceClient
+}
+
+// NewUserServiceClient creates a client for the user service at the given address
+func NewUserServiceClient(address string) (*UserServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user service client: %w", err)
+	}
+	return &UserServiceClient{
+		conn:   conn,
+		client: userpb.NewUserServiceClient(conn),
+	}, nil
+}
+
+// GetDefaultAddresses returns the default shipping and billing addresses of a user. Either may be nil.
+func (c *UserServiceClient) GetDefaultAddresses(ctx context.Context, userID string) (*entity.Address, *entity.Address, error) {
+	resp, err := c.client.GetDefaultAddresses(ctx, &userpb.GetDefaultAddressesRequest{UserId: userID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get default addresses: %w", err)
+	}
+	return convertAddress(resp.Shipping), convertAddress(resp.Billing), nil
+}
+
+// Close closes the underlying connection
+func (c *UserServiceClient) Close() error {
+	return c.conn.Close()
+}
+
+func convertAddress(address *userpb.Address) *entity.Address {
+	if address == nil {
+		return nil
+	}
+	return &entity.Address{
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		Country:    address.Country,
+		PostalCode: address.PostalCode,
+	}
+}
@@ -40,6 +40,8 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderReques
 	for i, item := range req.Items {
 		items[i] = entity.OrderItem{
 			ProductID:   item.ProductId,
+			VariantID:   item.VariantId,
+			SKU:         item.Sku,
 			ProductName: item.ProductName,
 			Quantity:    int(item.Quantity),
 			Price:       item.Price,
@@ -47,23 +49,23 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderReques
 		}
 	}
 
-	// Create order entity
+	// Create order entity. Either address may be omitted to use the user's saved default.
 	order := entity.Order{
 		UserID: req.UserId,
 		Items:  items,
 		ShippingInfo: entity.Address{
-			Street:     req.ShippingInfo.Street,
-			City:       req.ShippingInfo.City,
-			State:      req.ShippingInfo.State,
-			Country:    req.ShippingInfo.Country,
-			PostalCode: req.ShippingInfo.PostalCode,
+			Street:     req.GetShippingInfo().GetStreet(),
+			City:       req.GetShippingInfo().GetCity(),
+			State:      req.GetShippingInfo().GetState(),
+			Country:    req.GetShippingInfo().GetCountry(),
+			PostalCode: req.GetShippingInfo().GetPostalCode(),
 		},
 		BillingInfo: entity.Address{
-			Street:     req.BillingInfo.Street,
-			City:       req.BillingInfo.City,
-			State:      req.BillingInfo.State,
-			Country:    req.BillingInfo.Country,
-			PostalCode: req.BillingInfo.PostalCode,
+			Street:     req.GetBillingInfo().GetStreet(),
+			City:       req.GetBillingInfo().GetCity(),
+			State:      req.GetBillingInfo().GetState(),
+			Country:    req.GetBillingInfo().GetCountry(),
+			PostalCode: req.GetBillingInfo().GetPostalCode(),
 		},
 		Payment: entity.Payment{
 			Method: req.Payment.Method,
@@ -168,6 +170,8 @@ func (s *OrderServer) UpdateOrder(ctx context.Context, req *pb.UpdateOrderReques
 	for i, item := range req.Items {
 		items[i] = entity.OrderItem{
 			ProductID:   item.ProductId,
+			VariantID:   item.VariantId,
+			SKU:         item.Sku,
 			ProductName: item.ProductName,
 			Quantity:    int(item.Quantity),
 			Price:       item.Price,
@@ -317,6 +321,8 @@ func convertOrderToProto(order *entity.Order) *pb.OrderResponse {
 	for i, item := range order.Items {
 		items[i] = &pb.OrderItem{
 			ProductId:   item.ProductID,
+			VariantId:   item.VariantID,
+			Sku:         item.SKU,
 			ProductName: item.ProductName,
 			Quantity:    int32(item.Quantity),
 			Price:       item.Price,
@@ -390,6 +396,9 @@ func handleError(err error) error {
 	case errors.Is(err, entity.ErrInvalidOrderData):
 		statusCode = codes.InvalidArgument
 		message = "Invalid order data"
+	case errors.Is(err, entity.ErrMissingAddress):
+		statusCode = codes.InvalidArgument
+		message = "Shipping address is required"
 	case errors.Is(err, entity.ErrInvalidOrderStatus):
 		statusCode = codes.InvalidArgument
 		message = "Invalid order status"
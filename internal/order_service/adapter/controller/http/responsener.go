@@ -48,6 +48,9 @@ func HandleError(c *fiber.Ctx, err error) error {
 	case errors.Is(err, entity.ErrInvalidOrderData):
 		statusCode = http.StatusBadRequest
 		message = "Invalid order data"
+	case errors.Is(err, entity.ErrMissingAddress):
+		statusCode = http.StatusBadRequest
+		message = "Shipping address is required"
 	case errors.Is(err, entity.ErrInvalidOrderStatus):
 		statusCode = http.StatusBadRequest
 		message = "Invalid order status"
@@ -17,6 +17,8 @@ type AddressRequest struct {
 // OrderItemRequest represents an order item in a request
 type OrderItemRequest struct {
 	ProductID   string  `json:"product_id" validate:"required"`
+	VariantID   string  `json:"variant_id,omitempty"`
+	SKU         string  `json:"sku,omitempty"`
 	ProductName string  `json:"product_name" validate:"required"`
 	Quantity    int     `json:"quantity" validate:"required,gt=0"`
 	Price       float64 `json:"price" validate:"required,gt=0"`
@@ -32,8 +34,8 @@ type PaymentRequest struct {
 type OrderRequest struct {
 	UserID       string             `json:"user_id" validate:"required"`
 	Items        []OrderItemRequest `json:"items" validate:"required,dive"`
-	ShippingInfo AddressRequest     `json:"shipping_info" validate:"required"`
-	BillingInfo  AddressRequest     `json:"billing_info" validate:"required"`
+	ShippingInfo AddressRequest     `json:"shipping_info"` // omit to use the user's default address
+	BillingInfo  AddressRequest     `json:"billing_info"`
 	Payment      PaymentRequest     `json:"payment" validate:"required"`
 	Notes        string             `json:"notes,omitempty"`
 }
@@ -45,6 +47,8 @@ func (o OrderRequest) ToEntity() entity.Order {
 	for i, item := range o.Items {
 		items[i] = entity.OrderItem{
 			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			SKU:         item.SKU,
 			ProductName: item.ProductName,
 			Quantity:    item.Quantity,
 			Price:       item.Price,
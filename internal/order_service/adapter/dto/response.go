@@ -19,6 +19,8 @@ type AddressResponse struct {
 // OrderItemResponse represents an order item in a response
 type OrderItemResponse struct {
 	ProductID   string  `json:"product_id"`
+	VariantID   string  `json:"variant_id,omitempty"`
+	SKU         string  `json:"sku,omitempty"`
 	ProductName string  `json:"product_name"`
 	Quantity    int     `json:"quantity"`
 	Price       float64 `json:"price"`
@@ -64,6 +66,8 @@ func OrderResponseFromEntity(order *entity.Order) OrderResponse {
 	for i, item := range order.Items {
 		items[i] = OrderItemResponse{
 			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			SKU:         item.SKU,
 			ProductName: item.ProductName,
 			Quantity:    item.Quantity,
 			Price:       item.Price,
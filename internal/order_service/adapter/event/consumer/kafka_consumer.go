@@ -35,6 +35,13 @@ type InventoryReservedPayload struct {
 	TotalAmount float64 `json:"total_amount,omitempty"`
 }
 
+// InventoryBackorderPayload defines the payload for inventory backorder events
+type InventoryBackorderPayload struct {
+	// ProductID is the stock ID of the item: the variant ID for variant lines, otherwise the product ID
+	ProductID      string `json:"product_id"`
+	BackorderedQty int    `json:"backordered_qty"`
+}
+
 // PaymentProcessedPayload defines the payload for payment processed event
 type PaymentProcessedPayload struct {
 	OrderID       string  `json:"order_id"`
@@ -44,6 +51,13 @@ type PaymentProcessedPayload struct {
 	Amount        float64 `json:"amount"`
 }
 
+// UserEventPayload defines the payload of events on the user service topic
+type UserEventPayload struct {
+	EventType string `json:"event_type"`
+	UserID    string `json:"user_id"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
 // KafkaConsumer implements a Kafka consumer for order-related events
 type KafkaConsumer struct {
 	brokers      []string
@@ -53,6 +67,7 @@ type KafkaConsumer struct {
 	topics       struct {
 		inventoryEvents string
 		paymentEvents   string
+		userEvents      string
 	}
 	wg       sync.WaitGroup
 	stopChan chan struct{}
@@ -81,6 +96,7 @@ func NewKafkaConsumer(
 	// Set default topics
 	kc.topics.inventoryEvents = "inventory-events-result"
 	kc.topics.paymentEvents = "payment-events-result"
+	kc.topics.userEvents = "user_events"
 
 	return kc, nil
 }
@@ -97,6 +113,11 @@ func (kc *KafkaConsumer) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Subscribe to user events
+	if err := kc.SubscribeToUserEvents(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,6 +187,21 @@ func (kc *KafkaConsumer) SubscribeToPaymentEvents(ctx context.Context) error {
 	return nil
 }
 
+// SubscribeToUserEvents subscribes to user service events
+func (kc *KafkaConsumer) SubscribeToUserEvents(ctx context.Context) error {
+	reader := kc.createReader(kc.topics.userEvents)
+
+	// Start goroutine to consume messages
+	kc.wg.Add(1)
+	go func() {
+		defer kc.wg.Done()
+		kc.consumeUserEvents(ctx, reader)
+	}()
+
+	kc.logger.Info("Subscribed to user events", "topic", kc.topics.userEvents)
+	return nil
+}
+
 // consumeInventoryEvents consumes messages from the inventory events topic
 func (kc *KafkaConsumer) consumeInventoryEvents(ctx context.Context, reader *kafka.Reader) {
 	for {
@@ -240,6 +276,101 @@ func (kc *KafkaConsumer) consumePaymentEvents(ctx context.Context, reader *kafka
 	}
 }
 
+const (
+	// userEventRetryBase is the delay before the first retry of a failed user event
+	userEventRetryBase = time.Second
+	// userEventRetryMax caps the delay between retries of a failed user event
+	userEventRetryMax = time.Minute
+)
+
+// consumeUserEvents consumes messages from the user events topic.
+// A message that fails processing is retried with backoff before anything after it is committed,
+// since committing a later offset would also commit the failed message.
+func (kc *KafkaConsumer) consumeUserEvents(ctx context.Context, reader *kafka.Reader) {
+	for {
+		select {
+		case <-ctx.Done():
+			kc.logger.Info("Context cancelled, stopping user events consumer")
+			return
+		case <-kc.stopChan:
+			kc.logger.Info("Stopping user events consumer")
+			return
+		default:
+			// Set a timeout for the read operation
+			readCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			msg, err := reader.FetchMessage(readCtx)
+			cancel()
+
+			if err != nil {
+				// Timeout or no message
+				if err == context.DeadlineExceeded {
+					// This is just a timeout, continue
+					continue
+				}
+				kc.logger.Error("Failed to read message", "error", err)
+				continue
+			}
+
+			// Process message, stopping without a commit if the consumer shuts down first
+			if !kc.retryUserEvent(ctx, &msg) {
+				return
+			}
+
+			// Commit the message
+			if err := reader.CommitMessages(ctx, msg); err != nil {
+				kc.logger.Error("Failed to commit message", "error", err)
+			}
+		}
+	}
+}
+
+// retryUserEvent processes a user event until it succeeds, doubling the delay after each failure.
+// It reports false if the consumer is stopped before the event is processed.
+func (kc *KafkaConsumer) retryUserEvent(ctx context.Context, msg *kafka.Message) bool {
+	delay := userEventRetryBase
+	for {
+		if err := kc.processUserEvent(ctx, msg); err == nil {
+			return true
+		}
+
+		kc.logger.Warn("Retrying user event", "offset", msg.Offset, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-kc.stopChan:
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, userEventRetryMax)
+	}
+}
+
+// processUserEvent processes a message from the user events topic.
+// Only erasure requests concern the order service; other user events are skipped.
+func (kc *KafkaConsumer) processUserEvent(ctx context.Context, msg *kafka.Message) error {
+	var payload UserEventPayload
+	if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		kc.logger.Error("Failed to unmarshal user event payload", "error", err)
+		return nil
+	}
+
+	if payload.EventType != service.EventTypeUserErasureRequested {
+		return nil
+	}
+	if payload.RequestID == "" || payload.UserID == "" {
+		kc.logger.Warn("Skipping malformed user erasure request", "user_id", payload.UserID)
+		return nil
+	}
+
+	if err := kc.orderUsecase.ProcessUserErasure(ctx, payload.RequestID, payload.UserID); err != nil {
+		kc.logger.Error("Failed to process user erasure request", "error", err, "request_id", payload.RequestID)
+		return err
+	}
+
+	kc.logger.Info("Processed user erasure request", "request_id", payload.RequestID)
+	return nil
+}
+
 // processInventoryEvent processes a message from the inventory events topic
 func (kc *KafkaConsumer) processInventoryEvent(ctx context.Context, msg *kafka.Message) {
 	// Parse message payload
@@ -284,6 +415,35 @@ func (kc *KafkaConsumer) processInventoryEvent(ctx context.Context, msg *kafka.M
 
 		kc.logger.Info("Processed inventory reserved event", "order_id", payload.OrderID, "success", inventoryData.Success)
 
+	case service.EventTypeInventoryBackordered, service.EventTypeInventoryBackorderAllocated:
+		// Parse backorder data
+		jsonData, err := json.Marshal(payload.Data)
+		if err != nil {
+			kc.logger.Error("Failed to marshal backorder data", "error", err)
+			return
+		}
+
+		var backorderData InventoryBackorderPayload
+		if err := json.Unmarshal(jsonData, &backorderData); err != nil {
+			kc.logger.Error("Failed to unmarshal backorder data", "error", err)
+			return
+		}
+
+		// Update the quantity of the item still waiting for stock
+		_, err = kc.orderUsecase.ProcessInventoryBackorder(
+			ctx,
+			payload.OrderID,
+			backorderData.ProductID,
+			backorderData.BackorderedQty,
+		)
+		if err != nil {
+			kc.logger.Error("Failed to process inventory backorder event", "error", err, "order_id", payload.OrderID)
+			return
+		}
+
+		kc.logger.Info("Processed inventory backorder event", "order_id", payload.OrderID,
+			"product_id", backorderData.ProductID, "backordered_qty", backorderData.BackorderedQty)
+
 	default:
 		kc.logger.Warn("Unknown inventory event type", "event_type", payload.EventType)
 	}
@@ -73,6 +73,11 @@ func (kes *KafkaEventPublisherService) PublishPaymentRequest(ctx context.Context
 	return kes.producer.PublishPaymentRequest(ctx, order)
 }
 
+// PublishUserErasureCompleted acknowledges that a user's orders have been anonymised.
+func (kes *KafkaEventPublisherService) PublishUserErasureCompleted(ctx context.Context, requestID, userID string) error {
+	return kes.producer.PublishUserErasureCompleted(ctx, requestID, userID)
+}
+
 // Close closes the Kafka producer.
 func (kes *KafkaEventPublisherService) Close() error {
 	if err := kes.producer.Close(); err != nil {
@@ -15,20 +15,31 @@ import (
 
 // EventPayload defines the structure of the event payload
 type EventPayload struct {
-	EventID     string          `json:"event_id"`
-	EventType   string          `json:"event_type"`
-	OccurredAt  time.Time       `json:"occurred_at"`
-	OrderID     string          `json:"order_id"`
-	UserID      string          `json:"user_id"`
-	TotalAmount float64         `json:"total_amount"`
-	Status      string          `json:"status"`
-	Items       []OrderItemData `json:"items,omitempty"`
-	Data        interface{}     `json:"data,omitempty"`
+	EventID         string          `json:"event_id"`
+	EventType       string          `json:"event_type"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	OrderID         string          `json:"order_id"`
+	UserID          string          `json:"user_id"`
+	TotalAmount     float64         `json:"total_amount"`
+	Status          string          `json:"status"`
+	Items           []OrderItemData `json:"items,omitempty"`
+	ShippingAddress *AddressData    `json:"shipping_address,omitempty"` // set on order created events
+	Data            interface{}     `json:"data,omitempty"`
+}
+
+// AddressData represents a shipping address in events
+type AddressData struct {
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postal_code"`
 }
 
 // OrderItemData represents order item data in events
 type OrderItemData struct {
 	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id,omitempty"`
+	SKU       string  `json:"sku,omitempty"`
 	Quantity  int     `json:"quantity"`
 	Price     float64 `json:"price"`
 }
@@ -42,9 +53,19 @@ type KafkaProducer struct {
 		orderEvents     string
 		inventoryEvents string
 		paymentEvents   string
+		userEvents      string
 	}
 }
 
+// UserErasurePayload is the acknowledgement sent back to the user service on its topic
+type UserErasurePayload struct {
+	EventType  string    `json:"event_type"`
+	UserID     string    `json:"user_id"`
+	RequestID  string    `json:"request_id"`
+	Service    string    `json:"service"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
 // NewKafkaProducer creates a new KafkaProducer
 func NewKafkaProducer(brokers string, logger logger.Logger) (*KafkaProducer, error) {
 	// Parse brokers string into slice
@@ -60,6 +81,7 @@ func NewKafkaProducer(brokers string, logger logger.Logger) (*KafkaProducer, err
 	kp.topics.orderEvents = "order-events"
 	kp.topics.inventoryEvents = "inventory-events"
 	kp.topics.paymentEvents = "payment-events"
+	kp.topics.userEvents = "user_events"
 
 	return kp, nil
 }
@@ -138,6 +160,8 @@ func (kp *KafkaProducer) PublishOrderCreated(ctx context.Context, order *entity.
 	for i, item := range order.Items {
 		items[i] = OrderItemData{
 			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			SKU:       item.SKU,
 			Quantity:  item.Quantity,
 			Price:     item.Price,
 		}
@@ -154,6 +178,14 @@ func (kp *KafkaProducer) PublishOrderCreated(ctx context.Context, order *entity.
 		Status:      order.Status.String(),
 		Items:       items,
 	}
+	if !order.ShippingInfo.IsZero() {
+		payload.ShippingAddress = &AddressData{
+			City:       order.ShippingInfo.City,
+			State:      order.ShippingInfo.State,
+			Country:    order.ShippingInfo.Country,
+			PostalCode: order.ShippingInfo.PostalCode,
+		}
+	}
 
 	// Produce event to Kafka
 	err := kp.produceEvent(ctx, kp.topics.orderEvents, order.ID, payload)
@@ -336,6 +368,26 @@ func (kp *KafkaProducer) PublishPaymentRequest(ctx context.Context, order *entit
 	return nil
 }
 
+// PublishUserErasureCompleted acknowledges that a user's orders have been anonymised
+func (kp *KafkaProducer) PublishUserErasureCompleted(ctx context.Context, requestID, userID string) error {
+	payload := UserErasurePayload{
+		EventType:  service.EventTypeUserErasureCompleted,
+		UserID:     userID,
+		RequestID:  requestID,
+		Service:    "order_service",
+		OccurredAt: time.Now(),
+	}
+
+	err := kp.produceEvent(ctx, kp.topics.userEvents, userID, payload)
+	if err != nil {
+		kp.logger.Error("Failed to publish user erasure completed event", "error", err, "request_id", requestID)
+		return err
+	}
+
+	kp.logger.Info("Published user erasure completed event", "request_id", requestID)
+	return nil
+}
+
 // SubscribeToInventoryEvents subscribes to inventory-related events
 // This is just a placeholder - the actual implementation will be in the consumer package
 func (kp *KafkaProducer) SubscribeToInventoryEvents(ctx context.Context) error {
@@ -11,10 +11,14 @@ import (
 // OrderItem represents a single item in an order in MongoDB
 type OrderItem struct {
 	ProductID   string  `bson:"product_id"`
+	VariantID   string  `bson:"variant_id,omitempty"`
+	SKU         string  `bson:"sku,omitempty"`
 	ProductName string  `bson:"product_name"`
 	Quantity    int     `bson:"quantity"`
 	Price       float64 `bson:"price"`
 	Subtotal    float64 `bson:"subtotal"`
+	// BackorderedQty is the part of Quantity still waiting for stock
+	BackorderedQty int `bson:"backordered_qty,omitempty"`
 }
 
 // Address represents a shipping or billing address in MongoDB
@@ -65,10 +69,14 @@ func (om *OrderModel) ToEntity() *entity.Order {
 	for i, item := range om.Items {
 		items[i] = entity.OrderItem{
 			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			SKU:         item.SKU,
 			ProductName: item.ProductName,
 			Quantity:    item.Quantity,
 			Price:       item.Price,
 			Subtotal:    item.Subtotal,
+
+			BackorderedQty: item.BackorderedQty,
 		}
 	}
 
@@ -127,10 +135,14 @@ func FromEntity(order *entity.Order) *OrderModel {
 	for i, item := range order.Items {
 		items[i] = OrderItem{
 			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			SKU:         item.SKU,
 			ProductName: item.ProductName,
 			Quantity:    item.Quantity,
 			Price:       item.Price,
 			Subtotal:    item.Subtotal,
+
+			BackorderedQty: item.BackorderedQty,
 		}
 	}
 
@@ -304,3 +304,58 @@ func (r *MongoOrderRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// SetItemBackorderedQty sets the backordered quantity of the items of an order kept under a stock ID,
+// which is the variant ID for variant lines and the product ID otherwise (see entity.OrderItem.StockID).
+// The quantity is set rather than adjusted, so redelivered events have no further effect.
+func (r *MongoOrderRepository) SetItemBackorderedQty(ctx context.Context, id, stockID string, qty int) (*entity.Order, error) {
+	item := func(prefix string) bson.M {
+		return bson.M{"$or": bson.A{
+			bson.M{prefix + "variant_id": stockID},
+			bson.M{prefix + "product_id": stockID, prefix + "variant_id": bson.M{"$in": bson.A{nil, ""}}},
+		}}
+	}
+	filter := bson.M{"_id": id, "items": bson.M{"$elemMatch": item("")}}
+	update := bson.M{
+		"$set": bson.M{
+			"items.$[item].backordered_qty": qty,
+			"updated_at":                    time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetArrayFilters(options.ArrayFilters{Filters: []interface{}{item("item.")}}).
+		SetReturnDocument(options.After)
+
+	var orderModel model.OrderModel
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&orderModel)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entity.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return orderModel.ToEntity(), nil
+}
+
+// AnonymizeByUserID replaces the owner of all of a user's orders with a pseudonym
+// and clears their addresses and notes. Amounts and items are kept for bookkeeping.
+func (r *MongoOrderRepository) AnonymizeByUserID(ctx context.Context, userID, pseudonym string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":       pseudonym,
+			"shipping_info": model.Address{},
+			"billing_info":  model.Address{},
+			"updated_at":    time.Now(),
+		},
+		"$unset": bson.M{
+			"notes": "",
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
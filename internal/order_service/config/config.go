@@ -15,6 +15,7 @@ type Config struct {
 	Database DatabaseConfig `yaml:"database"`
 	GRPC     GRPCConfig     `yaml:"grpc"`
 	Kafka    KafkaConfig    `yaml:"kafka"`
+	Services ServicesConfig `yaml:"services"`
 }
 
 // ServerConfig contains HTTP server configuration
@@ -38,6 +39,13 @@ type GRPCConfig struct {
 	Port string `yaml:"port"`
 }
 
+// ServicesConfig contains the addresses of downstream gRPC services
+type ServicesConfig struct {
+	UserService      string `yaml:"userService"`
+	ProductService   string `yaml:"productService"`
+	InventoryService string `yaml:"inventoryService"`
+}
+
 // KafkaConfig contains Kafka configuration
 type KafkaConfig struct {
 	Brokers string      `yaml:"brokers"`
@@ -84,6 +92,11 @@ func LoadConfig(configPath string) (*Config, error) {
 				PaymentResults:   "payment-events-result",
 			},
 		},
+		Services: ServicesConfig{
+			UserService:      "127.0.0.1:50051",
+			ProductService:   "127.0.0.1:50052",
+			InventoryService: "127.0.0.1:50054",
+		},
 	}
 
 	// Read config file
@@ -136,5 +149,16 @@ func overrideWithEnv(config *Config) *Config {
 		config.Kafka.GroupID = value
 	}
 
+	// Downstream services
+	if value := os.Getenv("USER_SERVICE_ADDR"); value != "" {
+		config.Services.UserService = value
+	}
+	if value := os.Getenv("PRODUCT_SERVICE_ADDR"); value != "" {
+		config.Services.ProductService = value
+	}
+	if value := os.Getenv("INVENTORY_SERVICE_ADDR"); value != "" {
+		config.Services.InventoryService = value
+	}
+
 	return config
 }
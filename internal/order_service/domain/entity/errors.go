@@ -9,6 +9,7 @@ var (
 	ErrInvalidOrderData   = errors.New("invalid order data")
 	ErrInvalidOrderStatus = errors.New("invalid order status")
 	ErrOrderAlreadyExists = errors.New("order already exists")
+	ErrMissingAddress     = errors.New("order has no shipping address and the user has no default")
 
 	// Inventory errors
 	ErrInsufficientStock = errors.New("insufficient stock")
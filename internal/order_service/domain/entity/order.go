@@ -11,10 +11,23 @@ import (
 // OrderItem represents a single item in an order
 type OrderItem struct {
 	ProductID   string  `json:"product_id" bson:"product_id"`
+	VariantID   string  `json:"variant_id,omitempty" bson:"variant_id,omitempty"`
+	SKU         string  `json:"sku,omitempty" bson:"sku,omitempty"`
 	ProductName string  `json:"product_name" bson:"product_name"`
 	Quantity    int     `json:"quantity" bson:"quantity"`
 	Price       float64 `json:"price" bson:"price"`
 	Subtotal    float64 `json:"subtotal" bson:"subtotal"`
+	// BackorderedQty is the part of Quantity the inventory service is still waiting for stock for
+	BackorderedQty int `json:"backordered_qty,omitempty" bson:"backordered_qty,omitempty"`
+}
+
+// StockID returns the ID the inventory service keeps the item's stock under:
+// the variant for variant lines, otherwise the product
+func (i OrderItem) StockID() string {
+	if i.VariantID != "" {
+		return i.VariantID
+	}
+	return i.ProductID
 }
 
 // Address represents a shipping or billing address
@@ -26,6 +39,11 @@ type Address struct {
 	PostalCode string `json:"postal_code" bson:"postal_code"`
 }
 
+// IsZero reports whether no address was given
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
 // Payment represents payment information for an order
 type Payment struct {
 	Method        string     `json:"method" bson:"method"`
@@ -27,6 +27,13 @@ type OrderRepository interface {
 	// UpdateStatus updates the status of an order
 	UpdateStatus(ctx context.Context, id string, status valueobject.OrderStatus, comment string) (*entity.Order, error)
 
+	// SetItemBackorderedQty sets the backordered quantity of the items of an order kept under a stock ID
+	SetItemBackorderedQty(ctx context.Context, id, stockID string, qty int) (*entity.Order, error)
+
 	// Delete removes an order by ID (soft delete or mark as cancelled)
 	Delete(ctx context.Context, id string) error
+
+	// AnonymizeByUserID replaces the owner of all of a user's orders with a pseudonym
+	// and clears their addresses and notes, returning the number of orders changed
+	AnonymizeByUserID(ctx context.Context, userID, pseudonym string) (int64, error)
 }
@@ -18,6 +18,14 @@ const (
 	EventTypePaymentRequested  = "payment.requested"
 	EventTypePaymentProcessed  = "payment.processed"
 	EventTypePaymentFailed     = "payment.failed"
+
+	// Inventory backorders: an item waits for stock, and each allocation reports what is still waiting
+	EventTypeInventoryBackordered        = "inventory.stock.backordered"
+	EventTypeInventoryBackorderAllocated = "inventory.backorder.allocated"
+
+	// User service data-subject erasure handshake
+	EventTypeUserErasureRequested = "user.erasure_requested"
+	EventTypeUserErasureCompleted = "user.erasure_completed"
 )
 
 // EventPublisher defines the interface for publishing events
@@ -35,6 +43,9 @@ type EventPublisherService interface {
 	PublishOrderCompleted(ctx context.Context, order *entity.Order) error
 	// PublishPaymentRequest publishes a request to process payment for an order
 	PublishPaymentRequest(ctx context.Context, order *entity.Order) error
+
+	// PublishUserErasureCompleted acknowledges that a user's orders have been anonymised
+	PublishUserErasureCompleted(ctx context.Context, requestID, userID string) error
 	Close() error
 }
 
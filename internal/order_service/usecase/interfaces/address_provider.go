@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/entity"
+)
+
+// AddressProvider looks up a customer's saved default addresses
+type AddressProvider interface {
+	// GetDefaultAddresses returns the default shipping and billing addresses. Either may be nil.
+	GetDefaultAddresses(ctx context.Context, userID string) (shipping *entity.Address, billing *entity.Address, err error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// PriceProvider looks up the price products sell for
+type PriceProvider interface {
+	// GetEffectivePrice returns the unit price of a product, or of its variant when variantID is set, at the given time
+	GetEffectivePrice(ctx context.Context, productID, variantID string, at time.Time) (float64, error)
+}
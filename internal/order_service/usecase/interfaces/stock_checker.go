@@ -0,0 +1,10 @@
+package interfaces
+
+import "context"
+
+// StockChecker checks whether products can be ordered. Stock is keyed by the variant ID of a variant
+// and by the product ID of a product without variants.
+type StockChecker interface {
+	// Unavailable returns the keys of the quantities that can be ordered neither from stock nor as a backorder
+	Unavailable(ctx context.Context, quantities map[string]int) ([]string, error)
+}
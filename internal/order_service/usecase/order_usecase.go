@@ -4,6 +4,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +13,7 @@ import (
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/repository"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/service"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/usecase/interfaces"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
 )
 
@@ -39,17 +42,26 @@ type OrderUsecase interface {
 	// ProcessInventoryReserved handles the event when inventory is reserved
 	ProcessInventoryReserved(ctx context.Context, orderID string, success bool, message string) (*entity.Order, error)
 
+	// ProcessInventoryBackorder records how much of an order's item is still waiting for stock
+	ProcessInventoryBackorder(ctx context.Context, orderID, stockID string, backorderedQty int) (*entity.Order, error)
+
 	// ProcessPaymentCompleted handles the event when payment is completed
 	ProcessPaymentCompleted(ctx context.Context, orderID string, transactionID string, success bool) (*entity.Order, error)
 
 	// UpdateOrderPartial performs a partial update of an order
 	UpdateOrderPartial(ctx context.Context, id string, patch map[string]interface{}) (*entity.Order, error)
+
+	// ProcessUserErasure anonymises a user's orders and acknowledges the erasure request
+	ProcessUserErasure(ctx context.Context, requestID, userID string) error
 }
 
 // orderUsecase implements the OrderUsecase interface
 type orderUsecase struct {
 	orderRepo  repository.OrderRepository
 	eventPub   service.EventPublisherService
+	addresses  interfaces.AddressProvider
+	prices     interfaces.PriceProvider
+	stock      interfaces.StockChecker
 	errBuilder *utils.ErrorBuilder
 }
 
@@ -57,10 +69,16 @@ type orderUsecase struct {
 func NewOrderUsecase(
 	or repository.OrderRepository,
 	es service.EventPublisherService,
+	ap interfaces.AddressProvider,
+	pp interfaces.PriceProvider,
+	sc interfaces.StockChecker,
 ) OrderUsecase {
 	return &orderUsecase{
 		orderRepo:  or,
 		eventPub:   es,
+		addresses:  ap,
+		prices:     pp,
+		stock:      sc,
 		errBuilder: utils.NewErrorBuilder("OrderUsecase"),
 	}
 }
@@ -72,6 +90,16 @@ func (ou *orderUsecase) CreateOrder(ctx context.Context, order *entity.Order) (*
 		return nil, ou.errBuilder.Err(err)
 	}
 
+	// Fill in missing addresses from the user's address book
+	if err := ou.fillDefaultAddresses(ctx, order); err != nil {
+		return nil, ou.errBuilder.Err(err)
+	}
+
+	// Refuse orders the inventory service could not fill before accepting them
+	if err := ou.checkStock(ctx, order); err != nil {
+		return nil, ou.errBuilder.Err(err)
+	}
+
 	// Generate ID if not provided
 	if order.ID == "" {
 		order.ID = uuid.New().String()
@@ -91,7 +119,10 @@ func (ou *orderUsecase) CreateOrder(ctx context.Context, order *entity.Order) (*
 		},
 	}
 
-	// Calculate total amount
+	// Price the items as of the order time and calculate total amount
+	if err := ou.priceItems(ctx, order); err != nil {
+		return nil, ou.errBuilder.Err(err)
+	}
 	order.CalculateTotalAmount()
 
 	// Create order in repository
@@ -266,6 +297,28 @@ func (ou *orderUsecase) ProcessInventoryReserved(ctx context.Context, orderID st
 	}
 }
 
+// ProcessInventoryBackorder records how much of an order's item is still waiting for stock, as reported
+// when the inventory service backorders the item and each time it allocates received stock to it.
+// The item is identified by its stock ID, the variant ID for variant lines and the product ID otherwise.
+func (ou *orderUsecase) ProcessInventoryBackorder(ctx context.Context, orderID, stockID string, backorderedQty int) (*entity.Order, error) {
+	if backorderedQty < 0 {
+		return nil, ou.errBuilder.Err(entity.ErrInvalidOrderData)
+	}
+
+	updatedOrder, err := ou.orderRepo.SetItemBackorderedQty(ctx, orderID, stockID, backorderedQty)
+	if err != nil {
+		return nil, ou.errBuilder.Err(err)
+	}
+
+	// Publish order updated event
+	if err := ou.eventPub.PublishOrderUpdated(ctx, updatedOrder); err != nil {
+		// Log error but continue
+		fmt.Println("Error publishing order updated event:", err)
+	}
+
+	return updatedOrder, nil
+}
+
 // ProcessPaymentCompleted handles the event when payment is completed
 func (ou *orderUsecase) ProcessPaymentCompleted(ctx context.Context, orderID string, transactionID string, success bool) (*entity.Order, error) {
 	// Get the order
@@ -403,3 +456,92 @@ func (ou *orderUsecase) UpdateOrderPartial(ctx context.Context, id string, patch
 
 	return updatedOrderRes, nil
 }
+
+// ProcessUserErasure anonymises a user's orders and acknowledges the erasure request.
+// Orders are kept for accounting but can no longer be linked back to the user.
+func (ou *orderUsecase) ProcessUserErasure(ctx context.Context, requestID, userID string) error {
+	if requestID == "" || userID == "" {
+		return ou.errBuilder.Err(entity.ErrInvalidOrderData)
+	}
+
+	if _, err := ou.orderRepo.AnonymizeByUserID(ctx, userID, "erased-"+requestID); err != nil {
+		return ou.errBuilder.Err(err)
+	}
+
+	if err := ou.eventPub.PublishUserErasureCompleted(ctx, requestID, userID); err != nil {
+		return ou.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// priceItems sets the price and subtotal of each item to the product price effective when the order
+// was created, so clients cannot choose their own prices. Without a price provider the prices sent
+// with the order are kept.
+func (ou *orderUsecase) priceItems(ctx context.Context, order *entity.Order) error {
+	if ou.prices == nil {
+		return nil
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		price, err := ou.prices.GetEffectivePrice(ctx, item.ProductID, item.VariantID, order.CreatedAt)
+		if err != nil {
+			return err
+		}
+		item.Price = price
+		item.Subtotal = price * float64(item.Quantity)
+	}
+	return nil
+}
+
+// checkStock fails with ErrInsufficientStock, naming the items, if any item of the order can be ordered
+// neither from stock nor as a backorder. Stock is only reserved once the order is created, so a check
+// that passes does not guarantee the reservation. Without a stock checker every order is accepted.
+func (ou *orderUsecase) checkStock(ctx context.Context, order *entity.Order) error {
+	if ou.stock == nil {
+		return nil
+	}
+
+	quantities := make(map[string]int, len(order.Items))
+	for _, item := range order.Items {
+		quantities[item.StockID()] += item.Quantity
+	}
+
+	unavailable, err := ou.stock.Unavailable(ctx, quantities)
+	if err != nil {
+		return err
+	}
+	if len(unavailable) > 0 {
+		sort.Strings(unavailable)
+		return fmt.Errorf("%w: %s", entity.ErrInsufficientStock, strings.Join(unavailable, ", "))
+	}
+	return nil
+}
+
+// fillDefaultAddresses completes an order without shipping or billing info using the user's defaults.
+// Billing falls back to the shipping address when the user has no default billing address.
+func (ou *orderUsecase) fillDefaultAddresses(ctx context.Context, order *entity.Order) error {
+	if !order.ShippingInfo.IsZero() && !order.BillingInfo.IsZero() {
+		return nil
+	}
+
+	if ou.addresses != nil {
+		shipping, billing, err := ou.addresses.GetDefaultAddresses(ctx, order.UserID)
+		if err != nil {
+			return err
+		}
+		if order.ShippingInfo.IsZero() && shipping != nil {
+			order.ShippingInfo = *shipping
+		}
+		if order.BillingInfo.IsZero() && billing != nil {
+			order.BillingInfo = *billing
+		}
+	}
+
+	if order.ShippingInfo.IsZero() {
+		return entity.ErrMissingAddress
+	}
+	if order.BillingInfo.IsZero() {
+		order.BillingInfo = order.ShippingInfo
+	}
+	return nil
+}
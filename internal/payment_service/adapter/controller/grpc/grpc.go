@@ -205,7 +205,7 @@ func (s *ProductServer) GetProductsByCategory(ctx context.Context, req *pb.GetPr
 		pageSize = 10
 	}
 
-	products, total, err := s.productUsecase.GetProductsByCategory(ctx, req.CategoryId, page, pageSize)
+	products, total, err := s.productUsecase.GetProductsByCategory(ctx, req.CategoryId, req.IncludeDescendants, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to get products by category", "error", err)
 		return nil, handleError(err)
@@ -388,13 +388,19 @@ func (s *ProductServer) GetChildCategories(ctx context.Context, req *pb.GetChild
 
 // GetInventory gets inventory for a product
 func (s *ProductServer) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC GetInventory request received", "productId", req.ProductId)
+	s.logger.Info("gRPC GetInventory request received", "productId", req.ProductId, "sku", req.Sku)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
 	}
 
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	var inventory *entity.Inventory
+	var err error
+	if req.Sku != "" {
+		inventory, err = s.inventoryUsecase.GetInventoryBySKU(ctx, req.Sku)
+	} else {
+		inventory, err = s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	}
 	if err != nil {
 		s.logger.Error("Failed to get inventory", "error", err)
 		return nil, handleError(err)
@@ -403,43 +409,28 @@ func (s *ProductServer) GetInventory(ctx context.Context, req *pb.GetInventoryRe
 	return convertInventoryToProto(inventory), nil
 }
 
-// UpdateInventory updates inventory for a product
-func (s *ProductServer) UpdateInventory(ctx context.Context, req *pb.UpdateInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC UpdateInventory request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	err := s.inventoryUsecase.UpdateInventory(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
-		s.logger.Error("Failed to update inventory", "error", err)
-		return nil, handleError(err)
-	}
+// ReserveStock reserves stock of a product for an order
+func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC ReserveStock request received", "orderId", req.OrderId, "productId", req.ProductId, "sku", req.Sku, "quantity", req.Quantity)
 
-	// Get updated inventory
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
-	if err != nil {
-		s.logger.Error("Failed to get updated inventory", "error", err)
-		return nil, handleError(err)
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
 	}
 
-	return convertInventoryToProto(inventory), nil
-}
-
-// ReserveStock reserves stock for a product
-func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ReserveStock request received", "productId", req.ProductId, "quantity", req.Quantity)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
 	}
 
 	if req.Quantity <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
 	}
 
-	err := s.inventoryUsecase.ReserveStock(ctx, req.ProductId, int(req.Quantity))
+	var err error
+	if req.Sku != "" {
+		err = s.inventoryUsecase.ReserveStockBySKU(ctx, req.OrderId, req.Sku, int(req.Quantity))
+	} else {
+		err = s.inventoryUsecase.ReserveStock(ctx, req.OrderId, req.ProductId, int(req.Quantity))
+	}
 	if err != nil {
 		s.logger.Error("Failed to reserve stock", "error", err)
 		return nil, handleError(err)
@@ -448,20 +439,15 @@ func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRe
 	return &emptypb.Empty{}, nil
 }
 
-// ConfirmReservation confirms a reservation
-func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ConfirmReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
+// ConfirmReservation confirms the reservations of an order
+func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC ConfirmReservation request received", "orderId", req.OrderId)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
 	}
 
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
-	}
-
-	err := s.inventoryUsecase.ConfirmReservation(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
+	if err := s.inventoryUsecase.ConfirmReservation(ctx, req.OrderId); err != nil {
 		s.logger.Error("Failed to confirm reservation", "error", err)
 		return nil, handleError(err)
 	}
@@ -469,20 +455,15 @@ func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.ReserveS
 	return &emptypb.Empty{}, nil
 }
 
-// CancelReservation cancels a reservation
-func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC CancelReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
+// CancelReservation cancels the reservations of an order
+func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC CancelReservation request received", "orderId", req.OrderId)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
 	}
 
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
-	}
-
-	err := s.inventoryUsecase.CancelReservation(ctx, req.ProductId, int(req.Quantity))
-	if err != nil {
+	if err := s.inventoryUsecase.CancelReservation(ctx, req.OrderId); err != nil {
 		s.logger.Error("Failed to cancel reservation", "error", err)
 		return nil, handleError(err)
 	}
@@ -492,17 +473,23 @@ func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.ReserveSt
 
 // CheckStock checks if a product is in stock
 func (s *ProductServer) CheckStock(ctx context.Context, req *pb.CheckStockRequest) (*pb.CheckStockResponse, error) {
-	s.logger.Info("gRPC CheckStock request received", "productId", req.ProductId, "quantity", req.Quantity)
+	s.logger.Info("gRPC CheckStock request received", "productId", req.ProductId, "sku", req.Sku, "quantity", req.Quantity)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
 	}
 
 	if req.Quantity <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
 	}
 
-	inStock, err := s.inventoryUsecase.IsInStock(ctx, req.ProductId, int(req.Quantity))
+	var inStock bool
+	var err error
+	if req.Sku != "" {
+		inStock, err = s.inventoryUsecase.IsInStockBySKU(ctx, req.Sku, int(req.Quantity))
+	} else {
+		inStock, err = s.inventoryUsecase.IsInStock(ctx, req.ProductId, int(req.Quantity))
+	}
 	if err != nil {
 		s.logger.Error("Failed to check stock", "error", err)
 		return nil, handleError(err)
@@ -514,6 +501,7 @@ func (s *ProductServer) CheckStock(ctx context.Context, req *pb.CheckStockReques
 		InStock:   inStock,
 	}, nil
 }
+
 func (s *ProductServer) PatchProduct(ctx context.Context, req *pb.PatchProductRequest) (*pb.ProductResponse, error) {
 	s.logger.Info("gRPC PatchProduct request received", "id", req.Id)
 
@@ -592,34 +580,6 @@ func (s *ProductServer) PatchCategory(ctx context.Context, req *pb.PatchCategory
 	return convertCategoryToProto(updatedCategory), nil
 }
 
-// Add a PatchInventory method to handle partial inventory updates
-func (s *ProductServer) PatchInventory(ctx context.Context, req *pb.PatchInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC PatchInventory request received", "productId", req.ProductId)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	// Convert the fields map from protobuf to Go map for the usecase
-	patchData := make(map[string]interface{})
-
-	// Process fields based on which ones are set in the request
-	if req.Quantity != nil {
-		patchData["quantity"] = int(req.GetQuantity())
-	}
-	if req.Reserved != nil {
-		patchData["reserved"] = int(req.GetReserved())
-	}
-
-	updatedInventory, err := s.inventoryUsecase.UpdateInventoryPartial(ctx, req.ProductId, patchData)
-	if err != nil {
-		s.logger.Error("Failed to patch inventory", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertInventoryToProto(updatedInventory), nil
-}
-
 // Helper functions to convert domain entities to protobuf responses
 func convertProductToProto(product *entity.Product) *pb.ProductResponse {
 	return &pb.ProductResponse{
@@ -181,7 +181,7 @@ func (h *InventoryHandler) AddStock(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	updatedItem, err := h.usecase.AddStock(ctx, sku, req.Quantity, req.ReferenceID)
+	updatedItem, err := h.usecase.AdjustStock(ctx, req.ToEntity(sku))
 	if err != nil {
 		return h.handleInventoryError(c, err)
 	}
@@ -204,7 +204,7 @@ func (h *InventoryHandler) ReserveStock(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	reservations, err := h.usecase.ReserveStock(ctx, req.OrderID, req.Items)
+	reservations, err := h.usecase.ReserveStock(ctx, req.OrderID, req.Items, req.ShippingAddress)
 	if err != nil {
 		return h.handleInventoryError(c, err)
 	}
@@ -0,0 +1,150 @@
+// Package catalogfile reads and writes bulk product files in CSV and JSON Lines format.
+// Both formats use the columns sku, name, description, price, category_id, status and image_url.
+package catalogfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// Columns lists the fields of a product file in CSV column order
+var Columns = []string{"sku", "name", "description", "price", "category_id", "status", "image_url"}
+
+// requiredColumns must be present in the header of a CSV file
+var requiredColumns = []string{"sku", "name", "price", "category_id"}
+
+// maxLineSize bounds a single JSON Lines record
+const maxLineSize = 1 << 20
+
+// productRecord is the JSON Lines representation of a product
+type productRecord struct {
+	SKU         string  `json:"sku"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	CategoryID  string  `json:"category_id"`
+	Status      string  `json:"status"`
+	ImageURL    string  `json:"image_url"`
+}
+
+// Read parses a product file in the given format.
+// Rows that cannot be parsed are returned with Err set; an error is only returned when the file as a whole is unreadable.
+func Read(format entity.FileFormat, r io.Reader) ([]entity.ProductImportRow, error) {
+	switch format {
+	case entity.FormatCSV:
+		return ReadCSV(r)
+	case entity.FormatJSONL:
+		return ReadJSONL(r)
+	default:
+		return nil, entity.ErrUnsupportedFormat
+	}
+}
+
+// ReadCSV parses a CSV product file with a header row naming its columns.
+// Columns may appear in any order and unknown columns are ignored.
+func ReadCSV(r io.Reader) ([]entity.ProductImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header row", entity.ErrInvalidImportFile)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, column := range requiredColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", entity.ErrInvalidImportFile, column)
+		}
+	}
+
+	var rows []entity.ProductImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("%w: %v", entity.ErrInvalidImportFile, err)
+			}
+			rows = append(rows, entity.ProductImportRow{Line: parseErr.StartLine, Err: err})
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := entity.ProductImportRow{
+			Line: line,
+			Product: entity.Product{
+				SKU:         field("sku"),
+				Name:        field("name"),
+				Description: field("description"),
+				CategoryID:  field("category_id"),
+				Status:      field("status"),
+				ImageURL:    field("image_url"),
+			},
+		}
+		if price := field("price"); price != "" {
+			row.Product.Price, err = strconv.ParseFloat(price, 64)
+			if err != nil {
+				row.Err = fmt.Errorf("invalid price %q", price)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ReadJSONL parses a JSON Lines product file with one JSON object per line. Blank lines are skipped.
+func ReadJSONL(r io.Reader) ([]entity.ProductImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	var rows []entity.ProductImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record productRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			rows = append(rows, entity.ProductImportRow{Line: line, Err: fmt.Errorf("invalid JSON: %v", err)})
+			continue
+		}
+		rows = append(rows, entity.ProductImportRow{
+			Line: line,
+			Product: entity.Product{
+				SKU:         strings.TrimSpace(record.SKU),
+				Name:        strings.TrimSpace(record.Name),
+				Description: record.Description,
+				Price:       record.Price,
+				CategoryID:  strings.TrimSpace(record.CategoryID),
+				Status:      strings.TrimSpace(record.Status),
+				ImageURL:    strings.TrimSpace(record.ImageURL),
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,95 @@
+package catalogfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+)
+
+// NewWriter creates a product writer for the given format
+func NewWriter(format entity.FileFormat, w io.Writer) (interfaces.ProductWriter, error) {
+	switch format {
+	case entity.FormatCSV:
+		return NewCSVWriter(w)
+	case entity.FormatJSONL:
+		return NewJSONLWriter(w), nil
+	default:
+		return nil, entity.ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the MIME type of a product file format
+func ContentType(format entity.FileFormat) string {
+	if format == entity.FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// CSVWriter writes products as CSV rows below a header row
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter creates a CSV product writer and writes the header row
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	cw := &CSVWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(Columns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write appends a product row
+func (cw *CSVWriter) Write(product *entity.Product) error {
+	return cw.w.Write([]string{
+		product.SKU,
+		product.Name,
+		product.Description,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		product.CategoryID,
+		product.Status,
+		product.ImageURL,
+	})
+}
+
+// Flush writes buffered rows to the underlying writer
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// JSONLWriter writes products as one JSON object per line
+type JSONLWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLWriter creates a JSON Lines product writer
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	buf := bufio.NewWriter(w)
+	return &JSONLWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+// Write appends a product line
+func (jw *JSONLWriter) Write(product *entity.Product) error {
+	return jw.enc.Encode(productRecord{
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		CategoryID:  product.CategoryID,
+		Status:      product.Status,
+		ImageURL:    product.ImageURL,
+	})
+}
+
+// Flush writes buffered lines to the underlying writer
+func (jw *JSONLWriter) Flush() error {
+	return jw.buf.Flush()
+}
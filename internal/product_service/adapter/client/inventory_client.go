@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	inventorypb "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/controller/grpc/proto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// InventoryServiceClient reads and reserves stock in the inventory service over gRPC
+type InventoryServiceClient struct {
+	conn   *grpc.ClientConn
+	client inventorypb.InventoryServiceClient
+}
+
+// NewInventoryServiceClient creates a client for the inventory service at the given address
+func NewInventoryServiceClient(address string) (*InventoryServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory service client: %w", err)
+	}
+	return &InventoryServiceClient{
+		conn:   conn,
+		client: inventorypb.NewInventoryServiceClient(conn),
+	}, nil
+}
+
+// GetStock retrieves the quantity and reserved quantity held under a stock ID
+func (c *InventoryServiceClient) GetStock(ctx context.Context, stockID string) (*entity.Inventory, error) {
+	item, err := c.client.GetInventoryItem(ctx, &inventorypb.GetInventoryItemRequest{ProductId: stockID})
+	if err != nil {
+		return nil, inventoryError(fmt.Sprintf("get stock of %s", stockID), err)
+	}
+	return &entity.Inventory{
+		Quantity:  int(item.AvailableQty + item.ReservedQty),
+		Reserved:  int(item.ReservedQty),
+		UpdatedAt: item.UpdatedAt.AsTime(),
+	}, nil
+}
+
+// ReserveStock reserves quantities by stock ID for an order, all of them or none
+func (c *InventoryServiceClient) ReserveStock(ctx context.Context, orderID string, quantities map[string]int) error {
+	req := &inventorypb.ReserveStockRequest{OrderId: orderID}
+	for stockID, quantity := range quantities {
+		req.Items = append(req.Items, &inventorypb.StockQuantity{ProductId: stockID, Quantity: int32(quantity)})
+	}
+	if _, err := c.client.ReserveStock(ctx, req); err != nil {
+		return inventoryError(fmt.Sprintf("reserve stock for order %s", orderID), err)
+	}
+	return nil
+}
+
+// CompleteReservation deducts the stock reserved for an order
+func (c *InventoryServiceClient) CompleteReservation(ctx context.Context, orderID string) error {
+	if _, err := c.client.CompleteReservation(ctx, &inventorypb.OrderReservationRequest{OrderId: orderID}); err != nil {
+		return inventoryError(fmt.Sprintf("complete reservation of order %s", orderID), err)
+	}
+	return nil
+}
+
+// CancelReservation releases the stock reserved for an order
+func (c *InventoryServiceClient) CancelReservation(ctx context.Context, orderID string) error {
+	if _, err := c.client.CancelReservation(ctx, &inventorypb.OrderReservationRequest{OrderId: orderID}); err != nil {
+		return inventoryError(fmt.Sprintf("cancel reservation of order %s", orderID), err)
+	}
+	return nil
+}
+
+// ImportStock gives a stock ID that has no stock yet the quantity of the record referenceID
+func (c *InventoryServiceClient) ImportStock(ctx context.Context, stockID string, quantity int, referenceID string) error {
+	_, err := c.client.ImportStock(ctx, &inventorypb.ImportStockRequest{
+		ProductId:   stockID,
+		Quantity:    int32(quantity),
+		ReferenceId: referenceID,
+	})
+	if err != nil {
+		return inventoryError(fmt.Sprintf("import stock of %s", stockID), err)
+	}
+	return nil
+}
+
+// Close closes the underlying connection
+func (c *InventoryServiceClient) Close() error {
+	return c.conn.Close()
+}
+
+// inventoryError maps the status of a failed call to the domain error it stands for
+func inventoryError(action string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return entity.ErrInventoryNotFound
+	case codes.FailedPrecondition:
+		return entity.ErrInsufficientStock
+	case codes.AlreadyExists:
+		return entity.ErrStockAlreadyExists
+	default:
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+}
@@ -4,6 +4,7 @@ package grpcctl
 import (
 	"context"
 	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,6 +23,9 @@ type ProductServer struct {
 	productUsecase   usecase.ProductUsecase
 	categoryUsecase  usecase.CategoryUsecase
 	inventoryUsecase usecase.InventoryUsecase
+	variantUsecase   usecase.VariantUsecase
+	searchUsecase    usecase.SearchUsecase
+	priceUsecase     usecase.PriceUsecase
 	logger           logger.Logger
 }
 
@@ -30,12 +34,18 @@ func NewProductServer(
 	pu usecase.ProductUsecase,
 	cu usecase.CategoryUsecase,
 	iu usecase.InventoryUsecase,
+	vu usecase.VariantUsecase,
+	su usecase.SearchUsecase,
+	pru usecase.PriceUsecase,
 	logger logger.Logger,
 ) *ProductServer {
 	return &ProductServer{
 		productUsecase:   pu,
 		categoryUsecase:  cu,
 		inventoryUsecase: iu,
+		variantUsecase:   vu,
+		searchUsecase:    su,
+		priceUsecase:     pru,
 		logger:           logger,
 	}
 }
@@ -205,7 +215,7 @@ func (s *ProductServer) GetProductsByCategory(ctx context.Context, req *pb.GetPr
 		pageSize = 10
 	}
 
-	products, total, err := s.productUsecase.GetProductsByCategory(ctx, req.CategoryId, page, pageSize)
+	products, total, err := s.productUsecase.GetProductsByCategory(ctx, req.CategoryId, req.IncludeDescendants, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to get products by category", "error", err)
 		return nil, handleError(err)
@@ -231,6 +241,61 @@ func (s *ProductServer) GetProductsByCategory(ctx context.Context, req *pb.GetPr
 	}, nil
 }
 
+// SearchProducts runs a full-text product search with facet counts
+func (s *ProductServer) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	s.logger.Info("gRPC SearchProducts request received", "query", req.Query, "page", req.Page, "pageSize", req.PageSize)
+
+	page := int(req.Page)
+	pageSize := int(req.PageSize)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	query := entity.SearchQuery{
+		Text:       req.Query,
+		CategoryID: req.CategoryId,
+		Status:     req.Status,
+		PriceMin:   req.PriceMin,
+		PriceMax:   req.PriceMax,
+	}
+
+	result, err := s.searchUsecase.SearchProducts(ctx, query, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to search products", "error", err)
+		return nil, handleError(err)
+	}
+
+	hits := make([]*pb.SearchHit, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = &pb.SearchHit{
+			Product: convertProductToProto(hit.Product),
+			Score:   hit.Score,
+		}
+	}
+
+	totalPages := result.Total / pageSize
+	if result.Total%pageSize != 0 {
+		totalPages++
+	}
+
+	return &pb.SearchProductsResponse{
+		Total:      int32(result.Total),
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		TotalPages: int32(totalPages),
+		Hits:       hits,
+		Facets: &pb.SearchFacets{
+			Categories:  convertFacetsToProto(result.Facets.Categories),
+			PriceRanges: convertFacetsToProto(result.Facets.PriceRanges),
+			Statuses:    convertFacetsToProto(result.Facets.Statuses),
+		},
+	}, nil
+}
+
 // CreateCategory creates a new category
 func (s *ProductServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CategoryResponse, error) {
 	s.logger.Info("gRPC CreateCategory request received", "name", req.Name)
@@ -386,103 +451,221 @@ func (s *ProductServer) GetChildCategories(ctx context.Context, req *pb.GetChild
 	}, nil
 }
 
-// GetInventory gets inventory for a product
-func (s *ProductServer) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC GetInventory request received", "productId", req.ProductId)
+// GetCategoryTree gets a category with all of its nested subcategories
+func (s *ProductServer) GetCategoryTree(ctx context.Context, req *pb.GetCategoryRequest) (*pb.CategoryTreeNode, error) {
+	s.logger.Info("gRPC GetCategoryTree request received", "id", req.Id)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
 	}
 
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	subtree, err := s.categoryUsecase.GetCategorySubtree(ctx, req.Id)
 	if err != nil {
-		s.logger.Error("Failed to get inventory", "error", err)
+		s.logger.Error("Failed to get category tree", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertInventoryToProto(inventory), nil
+	return convertCategoryTreeToProto(entity.BuildCategoryTree(subtree)), nil
 }
 
-// UpdateInventory updates inventory for a product
-func (s *ProductServer) UpdateInventory(ctx context.Context, req *pb.UpdateInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC UpdateInventory request received", "productId", req.ProductId, "quantity", req.Quantity)
+// GetCategoryBreadcrumbs gets the categories from the root down to a category
+func (s *ProductServer) GetCategoryBreadcrumbs(ctx context.Context, req *pb.GetCategoryRequest) (*pb.ListCategoriesResponse, error) {
+	s.logger.Info("gRPC GetCategoryBreadcrumbs request received", "id", req.Id)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
 	}
 
-	err := s.inventoryUsecase.UpdateInventory(ctx, req.ProductId, int(req.Quantity))
+	breadcrumbs, err := s.categoryUsecase.GetBreadcrumbs(ctx, req.Id)
 	if err != nil {
-		s.logger.Error("Failed to update inventory", "error", err)
+		s.logger.Error("Failed to get category breadcrumbs", "error", err)
 		return nil, handleError(err)
 	}
 
-	// Get updated inventory
-	inventory, err := s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	protoCategories := make([]*pb.CategoryResponse, len(breadcrumbs))
+	for i, category := range breadcrumbs {
+		protoCategories[i] = convertCategoryToProto(category)
+	}
+
+	return &pb.ListCategoriesResponse{
+		Total:      int32(len(breadcrumbs)),
+		Page:       1,
+		PageSize:   int32(len(breadcrumbs)),
+		TotalPages: 1,
+		Categories: protoCategories,
+	}, nil
+}
+
+// MoveCategory moves a category and its subtree below a new parent
+func (s *ProductServer) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.CategoryResponse, error) {
+	s.logger.Info("gRPC MoveCategory request received", "id", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	}
+
+	movedCategory, err := s.categoryUsecase.MoveCategory(ctx, req.Id, req.ParentId)
 	if err != nil {
-		s.logger.Error("Failed to get updated inventory", "error", err)
+		s.logger.Error("Failed to move category", "error", err)
 		return nil, handleError(err)
 	}
 
-	return convertInventoryToProto(inventory), nil
+	return convertCategoryToProto(movedCategory), nil
 }
 
-// ReserveStock reserves stock for a product
-func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ReserveStock request received", "productId", req.ProductId, "quantity", req.Quantity)
+// ChangeProductStatus moves a product to another lifecycle status
+func (s *ProductServer) ChangeProductStatus(ctx context.Context, req *pb.ChangeProductStatusRequest) (*pb.ProductResponse, error) {
+	s.logger.Info("gRPC ChangeProductStatus request received", "id", req.Id, "status", req.Status)
 
-	if req.ProductId == "" {
+	if req.Id == "" || req.Status == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID and status are required")
+	}
+
+	product, err := s.productUsecase.ChangeStatus(ctx, req.Id, req.Status)
+	if err != nil {
+		s.logger.Error("Failed to change product status", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertProductToProto(product), nil
+}
+
+// ScheduleProduct sets or clears the publish and unpublish times of a product
+func (s *ProductServer) ScheduleProduct(ctx context.Context, req *pb.ScheduleProductRequest) (*pb.ProductResponse, error) {
+	s.logger.Info("gRPC ScheduleProduct request received", "id", req.Id)
+
+	if req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "product ID is required")
 	}
 
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
+	var publishAt, unpublishAt *time.Time
+	if req.PublishAt != nil {
+		t := req.PublishAt.AsTime()
+		publishAt = &t
+	}
+	if req.UnpublishAt != nil {
+		t := req.UnpublishAt.AsTime()
+		unpublishAt = &t
 	}
 
-	err := s.inventoryUsecase.ReserveStock(ctx, req.ProductId, int(req.Quantity))
+	product, err := s.productUsecase.ScheduleProduct(ctx, req.Id, publishAt, unpublishAt)
 	if err != nil {
-		s.logger.Error("Failed to reserve stock", "error", err)
+		s.logger.Error("Failed to schedule product", "error", err)
 		return nil, handleError(err)
 	}
 
-	return &emptypb.Empty{}, nil
+	return convertProductToProto(product), nil
 }
 
-// ConfirmReservation confirms a reservation
-func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC ConfirmReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
+// GetEffectivePrice resolves the price of a product or variant at a point in time
+func (s *ProductServer) GetEffectivePrice(ctx context.Context, req *pb.GetEffectivePriceRequest) (*pb.EffectivePriceResponse, error) {
+	s.logger.Info("gRPC GetEffectivePrice request received", "productId", req.ProductId, "variantId", req.VariantId)
 
 	if req.ProductId == "" {
 		return nil, status.Error(codes.InvalidArgument, "product ID is required")
 	}
 
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
+	at := time.Now()
+	if req.At != nil {
+		at = req.At.AsTime()
 	}
 
-	err := s.inventoryUsecase.ConfirmReservation(ctx, req.ProductId, int(req.Quantity))
+	price, err := s.priceUsecase.GetEffectivePrice(ctx, req.ProductId, req.VariantId, at)
 	if err != nil {
-		s.logger.Error("Failed to confirm reservation", "error", err)
+		s.logger.Error("Failed to get effective price", "error", err)
 		return nil, handleError(err)
 	}
 
-	return &emptypb.Empty{}, nil
+	return &pb.EffectivePriceResponse{
+		ProductId:      price.ProductID,
+		VariantId:      price.VariantID,
+		Sku:            price.SKU,
+		At:             timestamppb.New(price.At),
+		Price:          price.Price,
+		BasePrice:      price.BasePrice,
+		CompareAtPrice: price.CompareAtPrice,
+		SaleId:         price.SaleID,
+	}, nil
 }
 
-// CancelReservation cancels a reservation
-func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
-	s.logger.Info("gRPC CancelReservation request received", "productId", req.ProductId, "quantity", req.Quantity)
+// GetInventory gets inventory for a product
+func (s *ProductServer) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.InventoryResponse, error) {
+	s.logger.Info("gRPC GetInventory request received", "productId", req.ProductId, "sku", req.Sku)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
+	}
+
+	var inventory *entity.Inventory
+	var err error
+	if req.Sku != "" {
+		inventory, err = s.inventoryUsecase.GetInventoryBySKU(ctx, req.Sku)
+	} else {
+		inventory, err = s.inventoryUsecase.GetInventory(ctx, req.ProductId)
+	}
+	if err != nil {
+		s.logger.Error("Failed to get inventory", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertInventoryToProto(inventory), nil
+}
+
+// ReserveStock reserves stock of a product for an order
+func (s *ProductServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC ReserveStock request received", "orderId", req.OrderId, "productId", req.ProductId, "sku", req.Sku, "quantity", req.Quantity)
+
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
 	}
 
 	if req.Quantity <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
 	}
 
-	err := s.inventoryUsecase.CancelReservation(ctx, req.ProductId, int(req.Quantity))
+	var err error
+	if req.Sku != "" {
+		err = s.inventoryUsecase.ReserveStockBySKU(ctx, req.OrderId, req.Sku, int(req.Quantity))
+	} else {
+		err = s.inventoryUsecase.ReserveStock(ctx, req.OrderId, req.ProductId, int(req.Quantity))
+	}
 	if err != nil {
+		s.logger.Error("Failed to reserve stock", "error", err)
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ConfirmReservation confirms the reservations of an order
+func (s *ProductServer) ConfirmReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC ConfirmReservation request received", "orderId", req.OrderId)
+
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	if err := s.inventoryUsecase.ConfirmReservation(ctx, req.OrderId); err != nil {
+		s.logger.Error("Failed to confirm reservation", "error", err)
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// CancelReservation cancels the reservations of an order
+func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.OrderReservationRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC CancelReservation request received", "orderId", req.OrderId)
+
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	if err := s.inventoryUsecase.CancelReservation(ctx, req.OrderId); err != nil {
 		s.logger.Error("Failed to cancel reservation", "error", err)
 		return nil, handleError(err)
 	}
@@ -492,17 +675,23 @@ func (s *ProductServer) CancelReservation(ctx context.Context, req *pb.ReserveSt
 
 // CheckStock checks if a product is in stock
 func (s *ProductServer) CheckStock(ctx context.Context, req *pb.CheckStockRequest) (*pb.CheckStockResponse, error) {
-	s.logger.Info("gRPC CheckStock request received", "productId", req.ProductId, "quantity", req.Quantity)
+	s.logger.Info("gRPC CheckStock request received", "productId", req.ProductId, "sku", req.Sku, "quantity", req.Quantity)
 
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	if req.ProductId == "" && req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID or SKU is required")
 	}
 
 	if req.Quantity <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than zero")
 	}
 
-	inStock, err := s.inventoryUsecase.IsInStock(ctx, req.ProductId, int(req.Quantity))
+	var inStock bool
+	var err error
+	if req.Sku != "" {
+		inStock, err = s.inventoryUsecase.IsInStockBySKU(ctx, req.Sku, int(req.Quantity))
+	} else {
+		inStock, err = s.inventoryUsecase.IsInStock(ctx, req.ProductId, int(req.Quantity))
+	}
 	if err != nil {
 		s.logger.Error("Failed to check stock", "error", err)
 		return nil, handleError(err)
@@ -514,6 +703,7 @@ func (s *ProductServer) CheckStock(ctx context.Context, req *pb.CheckStockReques
 		InStock:   inStock,
 	}, nil
 }
+
 func (s *ProductServer) PatchProduct(ctx context.Context, req *pb.PatchProductRequest) (*pb.ProductResponse, error) {
 	s.logger.Info("gRPC PatchProduct request received", "id", req.Id)
 
@@ -592,37 +782,9 @@ func (s *ProductServer) PatchCategory(ctx context.Context, req *pb.PatchCategory
 	return convertCategoryToProto(updatedCategory), nil
 }
 
-// Add a PatchInventory method to handle partial inventory updates
-func (s *ProductServer) PatchInventory(ctx context.Context, req *pb.PatchInventoryRequest) (*pb.InventoryResponse, error) {
-	s.logger.Info("gRPC PatchInventory request received", "productId", req.ProductId)
-
-	if req.ProductId == "" {
-		return nil, status.Error(codes.InvalidArgument, "product ID is required")
-	}
-
-	// Convert the fields map from protobuf to Go map for the usecase
-	patchData := make(map[string]interface{})
-
-	// Process fields based on which ones are set in the request
-	if req.Quantity != nil {
-		patchData["quantity"] = int(req.GetQuantity())
-	}
-	if req.Reserved != nil {
-		patchData["reserved"] = int(req.GetReserved())
-	}
-
-	updatedInventory, err := s.inventoryUsecase.UpdateInventoryPartial(ctx, req.ProductId, patchData)
-	if err != nil {
-		s.logger.Error("Failed to patch inventory", "error", err)
-		return nil, handleError(err)
-	}
-
-	return convertInventoryToProto(updatedInventory), nil
-}
-
 // Helper functions to convert domain entities to protobuf responses
 func convertProductToProto(product *entity.Product) *pb.ProductResponse {
-	return &pb.ProductResponse{
+	resp := &pb.ProductResponse{
 		Id:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
@@ -633,7 +795,84 @@ func convertProductToProto(product *entity.Product) *pb.ProductResponse {
 		Status:      product.Status,
 		CreatedAt:   timestamppb.New(product.CreatedAt),
 		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+		Options:     convertOptionsToProto(product.Options),
+	}
+	if product.PublishAt != nil {
+		resp.PublishAt = timestamppb.New(*product.PublishAt)
+	}
+	if product.UnpublishAt != nil {
+		resp.UnpublishAt = timestamppb.New(*product.UnpublishAt)
+	}
+
+	for i := range product.Variants {
+		variant := convertVariantToProto(&product.Variants[i])
+		price := product.Variants[i].EffectivePrice(product.Price)
+		variant.Price = &price
+		resp.Variants = append(resp.Variants, variant)
+	}
+	for i := range product.Media {
+		resp.Media = append(resp.Media, convertMediaToProto(&product.Media[i]))
+	}
+
+	return resp
+}
+
+func convertMediaToProto(media *entity.ProductMedia) *pb.ProductMedia {
+	resp := &pb.ProductMedia{
+		Id:          media.ID,
+		Url:         media.URL,
+		AltText:     media.AltText,
+		Position:    int32(media.Position),
+		ContentType: media.ContentType,
+		Width:       int32(media.Width),
+		Height:      int32(media.Height),
+		Thumbnails:  make([]*pb.MediaThumbnail, len(media.Thumbnails)),
+	}
+	for i, thumbnail := range media.Thumbnails {
+		resp.Thumbnails[i] = &pb.MediaThumbnail{
+			Width:  int32(thumbnail.Width),
+			Height: int32(thumbnail.Height),
+			Url:    thumbnail.URL,
+		}
+	}
+	return resp
+}
+
+func convertOptionsToProto(options []entity.ProductOption) []*pb.ProductOption {
+	protoOptions := make([]*pb.ProductOption, len(options))
+	for i, option := range options {
+		protoOptions[i] = &pb.ProductOption{
+			Name:     option.Name,
+			Values:   option.Values,
+			Position: int32(option.Position),
+		}
+	}
+	return protoOptions
+}
+
+func convertVariantToProto(variant *entity.ProductVariant) *pb.ProductVariant {
+	return &pb.ProductVariant{
+		Id:        variant.ID,
+		ProductId: variant.ProductID,
+		Sku:       variant.SKU,
+		Options:   variant.Options,
+		Price:     variant.Price,
+		ImageUrl:  variant.ImageURL,
+		CreatedAt: timestamppb.New(variant.CreatedAt),
+		UpdatedAt: timestamppb.New(variant.UpdatedAt),
+	}
+}
+
+func convertFacetsToProto(facets []entity.FacetCount) []*pb.FacetCount {
+	protoFacets := make([]*pb.FacetCount, len(facets))
+	for i, facet := range facets {
+		protoFacets[i] = &pb.FacetCount{
+			Value: facet.Value,
+			Label: facet.Label,
+			Count: int32(facet.Count),
+		}
 	}
+	return protoFacets
 }
 
 func convertCategoryToProto(category *entity.Category) *pb.CategoryResponse {
@@ -642,6 +881,7 @@ func convertCategoryToProto(category *entity.Category) *pb.CategoryResponse {
 		Name:        category.Name,
 		Description: category.Description,
 		Level:       int32(category.Level),
+		Path:        category.Path,
 		CreatedAt:   timestamppb.New(category.CreatedAt),
 		UpdatedAt:   timestamppb.New(category.UpdatedAt),
 	}
@@ -653,14 +893,32 @@ func convertCategoryToProto(category *entity.Category) *pb.CategoryResponse {
 	return resp
 }
 
+func convertCategoryTreeToProto(node *entity.CategoryNode) *pb.CategoryTreeNode {
+	resp := &pb.CategoryTreeNode{
+		Category: convertCategoryToProto(node.Category),
+		Children: make([]*pb.CategoryTreeNode, len(node.Children)),
+	}
+	for i, child := range node.Children {
+		resp.Children[i] = convertCategoryTreeToProto(child)
+	}
+	return resp
+}
+
 func convertInventoryToProto(inventory *entity.Inventory) *pb.InventoryResponse {
-	return &pb.InventoryResponse{
+	resp := &pb.InventoryResponse{
 		ProductId: inventory.ProductID,
+		Sku:       inventory.SKU,
 		Quantity:  int32(inventory.Quantity),
 		Reserved:  int32(inventory.Reserved),
-		Available: int32(inventory.Quantity - inventory.Reserved),
+		Available: int32(inventory.Available()),
 		UpdatedAt: timestamppb.New(inventory.UpdatedAt),
 	}
+
+	if inventory.VariantID != nil {
+		resp.VariantId = *inventory.VariantID
+	}
+
+	return resp
 }
 
 // handleError maps domain errors to appropriate gRPC status errors
@@ -681,12 +939,54 @@ func handleError(err error) error {
 	case errors.Is(err, entity.ErrProductSKUExists):
 		statusCode = codes.AlreadyExists
 		message = "Product SKU already exists"
+	case errors.Is(err, entity.ErrInvalidProductStatus):
+		statusCode = codes.InvalidArgument
+		message = "Invalid product status"
+	case errors.Is(err, entity.ErrInvalidStatusTransition):
+		statusCode = codes.FailedPrecondition
+		message = "Product status transition is not allowed"
+	case errors.Is(err, entity.ErrInvalidSchedule):
+		statusCode = codes.InvalidArgument
+		message = "Invalid publishing schedule"
+	case errors.Is(err, entity.ErrInvalidPrice) || errors.Is(err, entity.ErrInvalidSalePeriod):
+		statusCode = codes.InvalidArgument
+		message = "Invalid price data"
+	case errors.Is(err, entity.ErrScheduledPriceNotFound):
+		statusCode = codes.NotFound
+		message = "Scheduled price change not found"
+	case errors.Is(err, entity.ErrSaleNotFound):
+		statusCode = codes.NotFound
+		message = "Sale not found"
+	case errors.Is(err, entity.ErrScheduledPriceApplied) || errors.Is(err, entity.ErrSaleOverlap):
+		statusCode = codes.FailedPrecondition
+		message = "Price change conflicts with the price book"
 	case errors.Is(err, entity.ErrCategoryAlreadyExists):
 		statusCode = codes.AlreadyExists
 		message = "Category already exists"
+	case errors.Is(err, entity.ErrCategoryCycle):
+		statusCode = codes.FailedPrecondition
+		message = "Category cannot be moved below itself or its descendants"
+	case errors.Is(err, entity.ErrCategoryTooDeep):
+		statusCode = codes.FailedPrecondition
+		message = "Category tree is nested too deeply"
 	case errors.Is(err, entity.ErrInsufficientStock):
 		statusCode = codes.FailedPrecondition
 		message = "Insufficient stock"
+	case errors.Is(err, entity.ErrStockAlreadyExists):
+		statusCode = codes.AlreadyExists
+		message = "Inventory already has stock"
+	case errors.Is(err, entity.ErrVariantNotFound):
+		statusCode = codes.NotFound
+		message = "Product variant not found"
+	case errors.Is(err, entity.ErrVariantExists):
+		statusCode = codes.AlreadyExists
+		message = "A variant with these options already exists"
+	case errors.Is(err, entity.ErrInvalidVariantData) || errors.Is(err, entity.ErrInvalidVariantOptions):
+		statusCode = codes.InvalidArgument
+		message = "Invalid variant data"
+	case errors.Is(err, entity.ErrVariantRequired):
+		statusCode = codes.FailedPrecondition
+		message = "Product has variants; use a variant SKU"
 	case errors.Is(err, entity.ErrInvalidProductData) || errors.Is(err, entity.ErrInvalidCategoryData):
 		statusCode = codes.InvalidArgument
 		message = "Invalid data provided"
@@ -22,28 +22,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductService_CreateProduct_FullMethodName         = "/product.ProductService/CreateProduct"
-	ProductService_GetProduct_FullMethodName            = "/product.ProductService/GetProduct"
-	ProductService_GetProductBySKU_FullMethodName       = "/product.ProductService/GetProductBySKU"
-	ProductService_ListProducts_FullMethodName          = "/product.ProductService/ListProducts"
-	ProductService_UpdateProduct_FullMethodName         = "/product.ProductService/UpdateProduct"
-	ProductService_DeleteProduct_FullMethodName         = "/product.ProductService/DeleteProduct"
-	ProductService_GetProductsByCategory_FullMethodName = "/product.ProductService/GetProductsByCategory"
-	ProductService_CreateCategory_FullMethodName        = "/product.ProductService/CreateCategory"
-	ProductService_GetCategory_FullMethodName           = "/product.ProductService/GetCategory"
-	ProductService_ListCategories_FullMethodName        = "/product.ProductService/ListCategories"
-	ProductService_UpdateCategory_FullMethodName        = "/product.ProductService/UpdateCategory"
-	ProductService_DeleteCategory_FullMethodName        = "/product.ProductService/DeleteCategory"
-	ProductService_GetChildCategories_FullMethodName    = "/product.ProductService/GetChildCategories"
-	ProductService_GetInventory_FullMethodName          = "/product.ProductService/GetInventory"
-	ProductService_UpdateInventory_FullMethodName       = "/product.ProductService/UpdateInventory"
-	ProductService_ReserveStock_FullMethodName          = "/product.ProductService/ReserveStock"
-	ProductService_ConfirmReservation_FullMethodName    = "/product.ProductService/ConfirmReservation"
-	ProductService_CancelReservation_FullMethodName     = "/product.ProductService/CancelReservation"
-	ProductService_CheckStock_FullMethodName            = "/product.ProductService/CheckStock"
-	ProductService_PatchProduct_FullMethodName          = "/product.ProductService/PatchProduct"
-	ProductService_PatchCategory_FullMethodName         = "/product.ProductService/PatchCategory"
-	ProductService_PatchInventory_FullMethodName        = "/product.ProductService/PatchInventory"
+	ProductService_CreateProduct_FullMethodName          = "/product.ProductService/CreateProduct"
+	ProductService_GetProduct_FullMethodName             = "/product.ProductService/GetProduct"
+	ProductService_GetProductBySKU_FullMethodName        = "/product.ProductService/GetProductBySKU"
+	ProductService_ListProducts_FullMethodName           = "/product.ProductService/ListProducts"
+	ProductService_UpdateProduct_FullMethodName          = "/product.ProductService/UpdateProduct"
+	ProductService_DeleteProduct_FullMethodName          = "/product.ProductService/DeleteProduct"
+	ProductService_GetProductsByCategory_FullMethodName  = "/product.ProductService/GetProductsByCategory"
+	ProductService_SearchProducts_FullMethodName         = "/product.ProductService/SearchProducts"
+	ProductService_ChangeProductStatus_FullMethodName    = "/product.ProductService/ChangeProductStatus"
+	ProductService_ScheduleProduct_FullMethodName        = "/product.ProductService/ScheduleProduct"
+	ProductService_CreateCategory_FullMethodName         = "/product.ProductService/CreateCategory"
+	ProductService_GetCategory_FullMethodName            = "/product.ProductService/GetCategory"
+	ProductService_ListCategories_FullMethodName         = "/product.ProductService/ListCategories"
+	ProductService_UpdateCategory_FullMethodName         = "/product.ProductService/UpdateCategory"
+	ProductService_DeleteCategory_FullMethodName         = "/product.ProductService/DeleteCategory"
+	ProductService_GetChildCategories_FullMethodName     = "/product.ProductService/GetChildCategories"
+	ProductService_GetCategoryTree_FullMethodName        = "/product.ProductService/GetCategoryTree"
+	ProductService_GetCategoryBreadcrumbs_FullMethodName = "/product.ProductService/GetCategoryBreadcrumbs"
+	ProductService_MoveCategory_FullMethodName           = "/product.ProductService/MoveCategory"
+	ProductService_GetInventory_FullMethodName           = "/product.ProductService/GetInventory"
+	ProductService_ReserveStock_FullMethodName           = "/product.ProductService/ReserveStock"
+	ProductService_ConfirmReservation_FullMethodName     = "/product.ProductService/ConfirmReservation"
+	ProductService_CancelReservation_FullMethodName      = "/product.ProductService/CancelReservation"
+	ProductService_CheckStock_FullMethodName             = "/product.ProductService/CheckStock"
+	ProductService_PatchProduct_FullMethodName           = "/product.ProductService/PatchProduct"
+	ProductService_PatchCategory_FullMethodName          = "/product.ProductService/PatchCategory"
+	ProductService_SetProductOptions_FullMethodName      = "/product.ProductService/SetProductOptions"
+	ProductService_CreateVariant_FullMethodName          = "/product.ProductService/CreateVariant"
+	ProductService_GetVariant_FullMethodName             = "/product.ProductService/GetVariant"
+	ProductService_GetVariantBySKU_FullMethodName        = "/product.ProductService/GetVariantBySKU"
+	ProductService_ListVariants_FullMethodName           = "/product.ProductService/ListVariants"
+	ProductService_UpdateVariant_FullMethodName          = "/product.ProductService/UpdateVariant"
+	ProductService_DeleteVariant_FullMethodName          = "/product.ProductService/DeleteVariant"
+	ProductService_GetEffectivePrice_FullMethodName      = "/product.ProductService/GetEffectivePrice"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -58,6 +70,9 @@ type ProductServiceClient interface {
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*ProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetProductsByCategory(ctx context.Context, in *GetProductsByCategoryRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	ChangeProductStatus(ctx context.Context, in *ChangeProductStatusRequest, opts ...grpc.CallOption) (*ProductResponse, error)
+	ScheduleProduct(ctx context.Context, in *ScheduleProductRequest, opts ...grpc.CallOption) (*ProductResponse, error)
 	// Category operations
 	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
@@ -65,16 +80,27 @@ type ProductServiceClient interface {
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetChildCategories(ctx context.Context, in *GetChildCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	GetCategoryTree(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*CategoryTreeNode, error)
+	GetCategoryBreadcrumbs(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	// Inventory operations
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error)
-	UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error)
 	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	ConfirmReservation(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	CancelReservation(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfirmReservation(ctx context.Context, in *OrderReservationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelReservation(ctx context.Context, in *OrderReservationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CheckStock(ctx context.Context, in *CheckStockRequest, opts ...grpc.CallOption) (*CheckStockResponse, error)
 	PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*ProductResponse, error)
 	PatchCategory(ctx context.Context, in *PatchCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
-	PatchInventory(ctx context.Context, in *PatchInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error)
+	// Variant operations
+	SetProductOptions(ctx context.Context, in *SetProductOptionsRequest, opts ...grpc.CallOption) (*ProductOptionsResponse, error)
+	CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error)
+	GetVariant(ctx context.Context, in *GetVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error)
+	GetVariantBySKU(ctx context.Context, in *GetVariantBySKURequest, opts ...grpc.CallOption) (*ProductVariant, error)
+	ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error)
+	UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error)
+	DeleteVariant(ctx context.Context, in *DeleteVariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Price operations
+	GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePriceResponse, error)
 }
 
 type productServiceClient struct {
@@ -155,6 +181,36 @@ func (c *productServiceClient) GetProductsByCategory(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *productServiceClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SearchProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ChangeProductStatus(ctx context.Context, in *ChangeProductStatusRequest, opts ...grpc.CallOption) (*ProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_ChangeProductStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ScheduleProduct(ctx context.Context, in *ScheduleProductRequest, opts ...grpc.CallOption) (*ProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_ScheduleProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CategoryResponse)
@@ -215,20 +271,40 @@ func (c *productServiceClient) GetChildCategories(ctx context.Context, in *GetCh
 	return out, nil
 }
 
-func (c *productServiceClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error) {
+func (c *productServiceClient) GetCategoryTree(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*CategoryTreeNode, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(InventoryResponse)
-	err := c.cc.Invoke(ctx, ProductService_GetInventory_FullMethodName, in, out, cOpts...)
+	out := new(CategoryTreeNode)
+	err := c.cc.Invoke(ctx, ProductService_GetCategoryTree_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *productServiceClient) UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error) {
+func (c *productServiceClient) GetCategoryBreadcrumbs(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetCategoryBreadcrumbs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, ProductService_MoveCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InventoryResponse)
-	err := c.cc.Invoke(ctx, ProductService_UpdateInventory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ProductService_GetInventory_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +321,7 @@ func (c *productServiceClient) ReserveStock(ctx context.Context, in *ReserveStoc
 	return out, nil
 }
 
-func (c *productServiceClient) ConfirmReservation(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *productServiceClient) ConfirmReservation(ctx context.Context, in *OrderReservationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, ProductService_ConfirmReservation_FullMethodName, in, out, cOpts...)
@@ -255,7 +331,7 @@ func (c *productServiceClient) ConfirmReservation(ctx context.Context, in *Reser
 	return out, nil
 }
 
-func (c *productServiceClient) CancelReservation(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *productServiceClient) CancelReservation(ctx context.Context, in *OrderReservationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, ProductService_CancelReservation_FullMethodName, in, out, cOpts...)
@@ -295,10 +371,80 @@ func (c *productServiceClient) PatchCategory(ctx context.Context, in *PatchCateg
 	return out, nil
 }
 
-func (c *productServiceClient) PatchInventory(ctx context.Context, in *PatchInventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error) {
+func (c *productServiceClient) SetProductOptions(ctx context.Context, in *SetProductOptionsRequest, opts ...grpc.CallOption) (*ProductOptionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(InventoryResponse)
-	err := c.cc.Invoke(ctx, ProductService_PatchInventory_FullMethodName, in, out, cOpts...)
+	out := new(ProductOptionsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SetProductOptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductVariant)
+	err := c.cc.Invoke(ctx, ProductService_CreateVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetVariant(ctx context.Context, in *GetVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductVariant)
+	err := c.cc.Invoke(ctx, ProductService_GetVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetVariantBySKU(ctx context.Context, in *GetVariantBySKURequest, opts ...grpc.CallOption) (*ProductVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductVariant)
+	err := c.cc.Invoke(ctx, ProductService_GetVariantBySKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVariantsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*ProductVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductVariant)
+	err := c.cc.Invoke(ctx, ProductService_UpdateVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DeleteVariant(ctx context.Context, in *DeleteVariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProductService_DeleteVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EffectivePriceResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetEffectivePrice_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +463,9 @@ type ProductServiceServer interface {
 	UpdateProduct(context.Context, *UpdateProductRequest) (*ProductResponse, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
 	GetProductsByCategory(context.Context, *GetProductsByCategoryRequest) (*ListProductsResponse, error)
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	ChangeProductStatus(context.Context, *ChangeProductStatusRequest) (*ProductResponse, error)
+	ScheduleProduct(context.Context, *ScheduleProductRequest) (*ProductResponse, error)
 	// Category operations
 	CreateCategory(context.Context, *CreateCategoryRequest) (*CategoryResponse, error)
 	GetCategory(context.Context, *GetCategoryRequest) (*CategoryResponse, error)
@@ -324,16 +473,27 @@ type ProductServiceServer interface {
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*CategoryResponse, error)
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error)
 	GetChildCategories(context.Context, *GetChildCategoriesRequest) (*ListCategoriesResponse, error)
+	GetCategoryTree(context.Context, *GetCategoryRequest) (*CategoryTreeNode, error)
+	GetCategoryBreadcrumbs(context.Context, *GetCategoryRequest) (*ListCategoriesResponse, error)
+	MoveCategory(context.Context, *MoveCategoryRequest) (*CategoryResponse, error)
 	// Inventory operations
 	GetInventory(context.Context, *GetInventoryRequest) (*InventoryResponse, error)
-	UpdateInventory(context.Context, *UpdateInventoryRequest) (*InventoryResponse, error)
 	ReserveStock(context.Context, *ReserveStockRequest) (*emptypb.Empty, error)
-	ConfirmReservation(context.Context, *ReserveStockRequest) (*emptypb.Empty, error)
-	CancelReservation(context.Context, *ReserveStockRequest) (*emptypb.Empty, error)
+	ConfirmReservation(context.Context, *OrderReservationRequest) (*emptypb.Empty, error)
+	CancelReservation(context.Context, *OrderReservationRequest) (*emptypb.Empty, error)
 	CheckStock(context.Context, *CheckStockRequest) (*CheckStockResponse, error)
 	PatchProduct(context.Context, *PatchProductRequest) (*ProductResponse, error)
 	PatchCategory(context.Context, *PatchCategoryRequest) (*CategoryResponse, error)
-	PatchInventory(context.Context, *PatchInventoryRequest) (*InventoryResponse, error)
+	// Variant operations
+	SetProductOptions(context.Context, *SetProductOptionsRequest) (*ProductOptionsResponse, error)
+	CreateVariant(context.Context, *CreateVariantRequest) (*ProductVariant, error)
+	GetVariant(context.Context, *GetVariantRequest) (*ProductVariant, error)
+	GetVariantBySKU(context.Context, *GetVariantBySKURequest) (*ProductVariant, error)
+	ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error)
+	UpdateVariant(context.Context, *UpdateVariantRequest) (*ProductVariant, error)
+	DeleteVariant(context.Context, *DeleteVariantRequest) (*emptypb.Empty, error)
+	// Price operations
+	GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePriceResponse, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -365,6 +525,15 @@ func (UnimplementedProductServiceServer) DeleteProduct(context.Context, *DeleteP
 func (UnimplementedProductServiceServer) GetProductsByCategory(context.Context, *GetProductsByCategoryRequest) (*ListProductsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByCategory not implemented")
 }
+func (UnimplementedProductServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ChangeProductStatus(context.Context, *ChangeProductStatusRequest) (*ProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeProductStatus not implemented")
+}
+func (UnimplementedProductServiceServer) ScheduleProduct(context.Context, *ScheduleProductRequest) (*ProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleProduct not implemented")
+}
 func (UnimplementedProductServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
 }
@@ -383,19 +552,25 @@ func (UnimplementedProductServiceServer) DeleteCategory(context.Context, *Delete
 func (UnimplementedProductServiceServer) GetChildCategories(context.Context, *GetChildCategoriesRequest) (*ListCategoriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChildCategories not implemented")
 }
+func (UnimplementedProductServiceServer) GetCategoryTree(context.Context, *GetCategoryRequest) (*CategoryTreeNode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedProductServiceServer) GetCategoryBreadcrumbs(context.Context, *GetCategoryRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryBreadcrumbs not implemented")
+}
+func (UnimplementedProductServiceServer) MoveCategory(context.Context, *MoveCategoryRequest) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCategory not implemented")
+}
 func (UnimplementedProductServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*InventoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
 }
-func (UnimplementedProductServiceServer) UpdateInventory(context.Context, *UpdateInventoryRequest) (*InventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventory not implemented")
-}
 func (UnimplementedProductServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
 }
-func (UnimplementedProductServiceServer) ConfirmReservation(context.Context, *ReserveStockRequest) (*emptypb.Empty, error) {
+func (UnimplementedProductServiceServer) ConfirmReservation(context.Context, *OrderReservationRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReservation not implemented")
 }
-func (UnimplementedProductServiceServer) CancelReservation(context.Context, *ReserveStockRequest) (*emptypb.Empty, error) {
+func (UnimplementedProductServiceServer) CancelReservation(context.Context, *OrderReservationRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
 }
 func (UnimplementedProductServiceServer) CheckStock(context.Context, *CheckStockRequest) (*CheckStockResponse, error) {
@@ -407,8 +582,29 @@ func (UnimplementedProductServiceServer) PatchProduct(context.Context, *PatchPro
 func (UnimplementedProductServiceServer) PatchCategory(context.Context, *PatchCategoryRequest) (*CategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PatchCategory not implemented")
 }
-func (UnimplementedProductServiceServer) PatchInventory(context.Context, *PatchInventoryRequest) (*InventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PatchInventory not implemented")
+func (UnimplementedProductServiceServer) SetProductOptions(context.Context, *SetProductOptionsRequest) (*ProductOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductOptions not implemented")
+}
+func (UnimplementedProductServiceServer) CreateVariant(context.Context, *CreateVariantRequest) (*ProductVariant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (UnimplementedProductServiceServer) GetVariant(context.Context, *GetVariantRequest) (*ProductVariant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariant not implemented")
+}
+func (UnimplementedProductServiceServer) GetVariantBySKU(context.Context, *GetVariantBySKURequest) (*ProductVariant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariantBySKU not implemented")
+}
+func (UnimplementedProductServiceServer) ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVariants not implemented")
+}
+func (UnimplementedProductServiceServer) UpdateVariant(context.Context, *UpdateVariantRequest) (*ProductVariant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVariant not implemented")
+}
+func (UnimplementedProductServiceServer) DeleteVariant(context.Context, *DeleteVariantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVariant not implemented")
+}
+func (UnimplementedProductServiceServer) GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectivePrice not implemented")
 }
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
@@ -557,6 +753,60 @@ func _ProductService_GetProductsByCategory_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ChangeProductStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeProductStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ChangeProductStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ChangeProductStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ChangeProductStatus(ctx, req.(*ChangeProductStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ScheduleProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ScheduleProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ScheduleProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ScheduleProduct(ctx, req.(*ScheduleProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateCategoryRequest)
 	if err := dec(in); err != nil {
@@ -665,38 +915,74 @@ func _ProductService_GetChildCategories_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInventoryRequest)
+func _ProductService_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).GetInventory(ctx, in)
+		return srv.(ProductServiceServer).GetCategoryTree(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_GetInventory_FullMethodName,
+		FullMethod: ProductService_GetCategoryTree_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+		return srv.(ProductServiceServer).GetCategoryTree(ctx, req.(*GetCategoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_UpdateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInventoryRequest)
+func _ProductService_GetCategoryBreadcrumbs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetCategoryBreadcrumbs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetCategoryBreadcrumbs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetCategoryBreadcrumbs(ctx, req.(*GetCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_MoveCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCategoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).UpdateInventory(ctx, in)
+		return srv.(ProductServiceServer).MoveCategory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_UpdateInventory_FullMethodName,
+		FullMethod: ProductService_MoveCategory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).UpdateInventory(ctx, req.(*UpdateInventoryRequest))
+		return srv.(ProductServiceServer).MoveCategory(ctx, req.(*MoveCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -720,7 +1006,7 @@ func _ProductService_ReserveStock_Handler(srv interface{}, ctx context.Context,
 }
 
 func _ProductService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveStockRequest)
+	in := new(OrderReservationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -732,13 +1018,13 @@ func _ProductService_ConfirmReservation_Handler(srv interface{}, ctx context.Con
 		FullMethod: ProductService_ConfirmReservation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).ConfirmReservation(ctx, req.(*ReserveStockRequest))
+		return srv.(ProductServiceServer).ConfirmReservation(ctx, req.(*OrderReservationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _ProductService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveStockRequest)
+	in := new(OrderReservationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -750,7 +1036,7 @@ func _ProductService_CancelReservation_Handler(srv interface{}, ctx context.Cont
 		FullMethod: ProductService_CancelReservation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).CancelReservation(ctx, req.(*ReserveStockRequest))
+		return srv.(ProductServiceServer).CancelReservation(ctx, req.(*OrderReservationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -809,20 +1095,146 @@ func _ProductService_PatchCategory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_PatchInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PatchInventoryRequest)
+func _ProductService_SetProductOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductOptionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).PatchInventory(ctx, in)
+		return srv.(ProductServiceServer).SetProductOptions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_PatchInventory_FullMethodName,
+		FullMethod: ProductService_SetProductOptions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).PatchInventory(ctx, req.(*PatchInventoryRequest))
+		return srv.(ProductServiceServer).SetProductOptions(ctx, req.(*SetProductOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CreateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CreateVariant(ctx, req.(*CreateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetVariant(ctx, req.(*GetVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetVariantBySKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVariantBySKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetVariantBySKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetVariantBySKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetVariantBySKU(ctx, req.(*GetVariantBySKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListVariants(ctx, req.(*ListVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_UpdateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).UpdateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_UpdateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).UpdateVariant(ctx, req.(*UpdateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DeleteVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DeleteVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DeleteVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DeleteVariant(ctx, req.(*DeleteVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetEffectivePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectivePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetEffectivePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetEffectivePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetEffectivePrice(ctx, req.(*GetEffectivePriceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -862,6 +1274,18 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProductsByCategory",
 			Handler:    _ProductService_GetProductsByCategory_Handler,
 		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _ProductService_SearchProducts_Handler,
+		},
+		{
+			MethodName: "ChangeProductStatus",
+			Handler:    _ProductService_ChangeProductStatus_Handler,
+		},
+		{
+			MethodName: "ScheduleProduct",
+			Handler:    _ProductService_ScheduleProduct_Handler,
+		},
 		{
 			MethodName: "CreateCategory",
 			Handler:    _ProductService_CreateCategory_Handler,
@@ -887,12 +1311,20 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ProductService_GetChildCategories_Handler,
 		},
 		{
-			MethodName: "GetInventory",
-			Handler:    _ProductService_GetInventory_Handler,
+			MethodName: "GetCategoryTree",
+			Handler:    _ProductService_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "GetCategoryBreadcrumbs",
+			Handler:    _ProductService_GetCategoryBreadcrumbs_Handler,
+		},
+		{
+			MethodName: "MoveCategory",
+			Handler:    _ProductService_MoveCategory_Handler,
 		},
 		{
-			MethodName: "UpdateInventory",
-			Handler:    _ProductService_UpdateInventory_Handler,
+			MethodName: "GetInventory",
+			Handler:    _ProductService_GetInventory_Handler,
 		},
 		{
 			MethodName: "ReserveStock",
@@ -919,8 +1351,36 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ProductService_PatchCategory_Handler,
 		},
 		{
-			MethodName: "PatchInventory",
-			Handler:    _ProductService_PatchInventory_Handler,
+			MethodName: "SetProductOptions",
+			Handler:    _ProductService_SetProductOptions_Handler,
+		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _ProductService_CreateVariant_Handler,
+		},
+		{
+			MethodName: "GetVariant",
+			Handler:    _ProductService_GetVariant_Handler,
+		},
+		{
+			MethodName: "GetVariantBySKU",
+			Handler:    _ProductService_GetVariantBySKU_Handler,
+		},
+		{
+			MethodName: "ListVariants",
+			Handler:    _ProductService_ListVariants_Handler,
+		},
+		{
+			MethodName: "UpdateVariant",
+			Handler:    _ProductService_UpdateVariant_Handler,
+		},
+		{
+			MethodName: "DeleteVariant",
+			Handler:    _ProductService_DeleteVariant_Handler,
+		},
+		{
+			MethodName: "GetEffectivePrice",
+			Handler:    _ProductService_GetEffectivePrice_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,157 @@
+package grpcctl
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/controller/grpc/proto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// SetProductOptions replaces the option axes of a product
+func (s *ProductServer) SetProductOptions(ctx context.Context, req *pb.SetProductOptionsRequest) (*pb.ProductOptionsResponse, error) {
+	s.logger.Info("gRPC SetProductOptions request received", "productId", req.ProductId)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	options := make([]entity.ProductOption, len(req.Options))
+	for i, option := range req.Options {
+		options[i] = entity.ProductOption{
+			Name:   option.Name,
+			Values: option.Values,
+		}
+	}
+
+	result, err := s.variantUsecase.SetOptions(ctx, req.ProductId, options)
+	if err != nil {
+		s.logger.Error("Failed to set product options", "error", err)
+		return nil, handleError(err)
+	}
+
+	return &pb.ProductOptionsResponse{Options: convertOptionsToProto(result)}, nil
+}
+
+// CreateVariant creates a new variant of a product
+func (s *ProductServer) CreateVariant(ctx context.Context, req *pb.CreateVariantRequest) (*pb.ProductVariant, error) {
+	s.logger.Info("gRPC CreateVariant request received", "productId", req.ProductId, "sku", req.Sku)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	variant := entity.ProductVariant{
+		SKU:      req.Sku,
+		Options:  req.Options,
+		Price:    req.Price,
+		ImageURL: req.ImageUrl,
+	}
+
+	createdVariant, err := s.variantUsecase.CreateVariant(ctx, req.ProductId, &variant)
+	if err != nil {
+		s.logger.Error("Failed to create variant", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertVariantToProto(createdVariant), nil
+}
+
+// GetVariant gets a variant of a product by ID
+func (s *ProductServer) GetVariant(ctx context.Context, req *pb.GetVariantRequest) (*pb.ProductVariant, error) {
+	s.logger.Info("gRPC GetVariant request received", "productId", req.ProductId, "variantId", req.VariantId)
+
+	if req.ProductId == "" || req.VariantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID and variant ID are required")
+	}
+
+	variant, err := s.variantUsecase.GetVariant(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		s.logger.Error("Failed to get variant", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertVariantToProto(variant), nil
+}
+
+// GetVariantBySKU gets a variant by SKU
+func (s *ProductServer) GetVariantBySKU(ctx context.Context, req *pb.GetVariantBySKURequest) (*pb.ProductVariant, error) {
+	s.logger.Info("gRPC GetVariantBySKU request received", "sku", req.Sku)
+
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "variant SKU is required")
+	}
+
+	variant, err := s.variantUsecase.GetVariantBySKU(ctx, req.Sku)
+	if err != nil {
+		s.logger.Error("Failed to get variant by SKU", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertVariantToProto(variant), nil
+}
+
+// ListVariants lists the variants of a product
+func (s *ProductServer) ListVariants(ctx context.Context, req *pb.ListVariantsRequest) (*pb.ListVariantsResponse, error) {
+	s.logger.Info("gRPC ListVariants request received", "productId", req.ProductId)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	variants, err := s.variantUsecase.ListVariants(ctx, req.ProductId)
+	if err != nil {
+		s.logger.Error("Failed to list variants", "error", err)
+		return nil, handleError(err)
+	}
+
+	protoVariants := make([]*pb.ProductVariant, len(variants))
+	for i := range variants {
+		protoVariants[i] = convertVariantToProto(&variants[i])
+	}
+
+	return &pb.ListVariantsResponse{Variants: protoVariants}, nil
+}
+
+// UpdateVariant updates an existing variant
+func (s *ProductServer) UpdateVariant(ctx context.Context, req *pb.UpdateVariantRequest) (*pb.ProductVariant, error) {
+	s.logger.Info("gRPC UpdateVariant request received", "productId", req.ProductId, "variantId", req.VariantId)
+
+	if req.ProductId == "" || req.VariantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID and variant ID are required")
+	}
+
+	variant := entity.ProductVariant{
+		SKU:      req.Sku,
+		Options:  req.Options,
+		Price:    req.Price,
+		ImageURL: req.ImageUrl,
+	}
+
+	updatedVariant, err := s.variantUsecase.UpdateVariant(ctx, req.ProductId, req.VariantId, variant)
+	if err != nil {
+		s.logger.Error("Failed to update variant", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertVariantToProto(updatedVariant), nil
+}
+
+// DeleteVariant deletes a variant
+func (s *ProductServer) DeleteVariant(ctx context.Context, req *pb.DeleteVariantRequest) (*emptypb.Empty, error) {
+	s.logger.Info("gRPC DeleteVariant request received", "productId", req.ProductId, "variantId", req.VariantId)
+
+	if req.ProductId == "" || req.VariantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID and variant ID are required")
+	}
+
+	if err := s.variantUsecase.DeleteVariant(ctx, req.ProductId, req.VariantId); err != nil {
+		s.logger.Error("Failed to delete variant", "error", err)
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,166 @@
+package httpctl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/catalogfile"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// ImportProducts handles starting a bulk product import from a multipart/form-data upload.
+// The file is sent in the "file" field; its format is taken from the "format" field or the file extension.
+func (h *ProductHandler) ImportProducts(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error("Failed to read uploaded file", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	format := entity.FileFormat(strings.ToLower(c.FormValue("format")))
+	if format == "" {
+		format = formatFromFilename(fileHeader.Filename)
+	}
+	if !format.IsValid() {
+		return HandleError(c, entity.ErrUnsupportedFormat)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("Failed to open uploaded file", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+	defer file.Close()
+
+	rows, err := catalogfile.Read(format, file)
+	if err != nil {
+		h.logger.Error("Failed to parse import file", "error", err)
+		if errors.Is(err, entity.ErrInvalidImportFile) {
+			// Tell the client what is wrong with the file, e.g. a missing column
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Status: fiber.StatusBadRequest, Message: err.Error()})
+		}
+		return HandleError(c, err)
+	}
+
+	ctx := c.Context()
+	job, err := h.bulkUsecase.StartImport(ctx, format, rows)
+	if err != nil {
+		h.logger.Error("Failed to start import", "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ImportJobResponseFromEntity(job)
+	return SuccessResp(c, fiber.StatusAccepted, "Import started", response)
+}
+
+// GetImportJob handles retrieving the progress of an import job
+func (h *ProductHandler) GetImportJob(c *fiber.Ctx) error {
+	jobID := c.Params("jobId")
+	if jobID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	job, err := h.bulkUsecase.GetImportJob(ctx, jobID)
+	if err != nil {
+		h.logger.Error("Failed to get import job", "jobId", jobID, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ImportJobResponseFromEntity(job)
+	return SuccessResp(c, fiber.StatusOK, "Import job retrieved successfully", response)
+}
+
+// DownloadImportErrors handles downloading the rows an import job rejected as a CSV report
+func (h *ProductHandler) DownloadImportErrors(c *fiber.Ctx) error {
+	jobID := c.Params("jobId")
+	if jobID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	rowErrors, err := h.bulkUsecase.ListImportErrors(ctx, jobID)
+	if err != nil {
+		h.logger.Error("Failed to list import errors", "jobId", jobID, "error", err)
+		return HandleError(c, err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"line", "sku", "error"})
+	for _, rowError := range rowErrors {
+		_ = w.Write([]string{strconv.Itoa(rowError.Line), rowError.SKU, rowError.Message})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.logger.Error("Failed to write import error report", "jobId", jobID, "error", err)
+		return HandleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, catalogfile.ContentType(entity.FormatCSV))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="import-%s-errors.csv"`, jobID))
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// ExportProducts handles streaming all products matching the filters as a CSV or JSON Lines file.
+// Supported filters are status, category_id, name, price_min and price_max.
+func (h *ProductHandler) ExportProducts(c *fiber.Ctx) error {
+	format := entity.FileFormat(strings.ToLower(c.Query("format", string(entity.FormatCSV))))
+	if !format.IsValid() {
+		return HandleError(c, entity.ErrUnsupportedFormat)
+	}
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"status", "category_id", "name"} {
+		if value := c.Query(key); value != "" {
+			filters[key] = value
+		}
+	}
+	for _, key := range []string{"price_min", "price_max"} {
+		if value := c.Query(key); value != "" {
+			price, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return HandleError(c, ErrBadRequest)
+			}
+			filters[key] = price
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, catalogfile.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="products.%s"`, format))
+
+	// The body is written after the handler returns, so the export can't use the request context
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer, err := catalogfile.NewWriter(format, w)
+		if err == nil {
+			var count int
+			count, err = h.bulkUsecase.ExportProducts(context.Background(), filters, writer)
+			h.logger.Info("Products exported", "format", format, "count", count)
+		}
+		if err != nil {
+			h.logger.Error("Failed to export products", "error", err)
+		}
+	})
+	return nil
+}
+
+// formatFromFilename derives the file format from an uploaded file name
+func formatFromFilename(name string) entity.FileFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return entity.FormatCSV
+	case ".jsonl", ".ndjson":
+		return entity.FormatJSONL
+	default:
+		return ""
+	}
+}
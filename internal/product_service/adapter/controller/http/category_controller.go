@@ -5,6 +5,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 )
 
 // CreateCategory handles the creation of a new category
@@ -138,6 +139,70 @@ func (h *ProductHandler) GetChildCategories(c *fiber.Ctx) error {
 
 	return SuccessResp(c, fiber.StatusOK, "Child categories retrieved successfully", responseCategories)
 }
+
+// GetCategoryTree handles retrieving a category with all of its nested subcategories
+func (h *ProductHandler) GetCategoryTree(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	subtree, err := h.categoryUsecase.GetCategorySubtree(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get category tree", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.CategoryTreeResponseFromEntity(entity.BuildCategoryTree(subtree))
+	return SuccessResp(c, fiber.StatusOK, "Category tree retrieved successfully", response)
+}
+
+// GetCategoryBreadcrumbs handles retrieving the categories from the root down to a category
+func (h *ProductHandler) GetCategoryBreadcrumbs(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	breadcrumbs, err := h.categoryUsecase.GetBreadcrumbs(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get category breadcrumbs", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	responseCategories := make([]dto.CategoryResponse, len(breadcrumbs))
+	for i, category := range breadcrumbs {
+		responseCategories[i] = dto.CategoryResponseFromEntity(category)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Category breadcrumbs retrieved successfully", responseCategories)
+}
+
+// MoveCategory handles moving a category and its subtree below a new parent
+func (h *ProductHandler) MoveCategory(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.MoveCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	movedCategory, err := h.categoryUsecase.MoveCategory(ctx, id, req.ParentID)
+	if err != nil {
+		h.logger.Error("Failed to move category", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.CategoryResponseFromEntity(movedCategory)
+	return SuccessResp(c, fiber.StatusOK, "Category moved successfully", response)
+}
 func (h *ProductHandler) PatchCategory(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
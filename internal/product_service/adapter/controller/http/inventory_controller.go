@@ -25,79 +25,57 @@ func (h *ProductHandler) GetInventory(c *fiber.Ctx) error {
 	return SuccessResp(c, fiber.StatusOK, "Inventory retrieved successfully", response)
 }
 
-// UpdateInventory handles updating the inventory for a product
-func (h *ProductHandler) UpdateInventory(c *fiber.Ctx) error {
-	productId := c.Params("productId")
-	if productId == "" {
-		return HandleError(c, ErrBadRequest)
-	}
-
-	var req dto.InventoryUpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Error("Failed to parse request body", "error", err)
-		return HandleError(c, ErrBadRequest)
-	}
-
-	ctx := c.Context()
-	err := h.inventoryUsecase.UpdateInventory(ctx, productId, req.Quantity)
-	if err != nil {
-		h.logger.Error("Failed to update inventory", "productId", productId, "error", err)
-		return HandleError(c, err)
-	}
-
-	inventory, _ := h.inventoryUsecase.GetInventory(ctx, productId)
-	response := dto.InventoryResponseFromEntity(inventory)
-	return SuccessResp(c, fiber.StatusOK, "Inventory updated successfully", response)
-}
-
-// ReserveStock handles reserving stock for a product
+// ReserveStock handles reserving stock of a product for an order
 func (h *ProductHandler) ReserveStock(c *fiber.Ctx) error {
 	var req dto.ReservationRequest
-	if err := c.BodyParser(&req); err != nil {
+	if err := c.BodyParser(&req); err != nil || req.OrderID == "" || req.Quantity < 1 {
 		h.logger.Error("Failed to parse request body", "error", err)
 		return HandleError(c, ErrBadRequest)
 	}
 
 	ctx := c.Context()
-	err := h.inventoryUsecase.ReserveStock(ctx, req.ProductID, req.Quantity)
+	var err error
+	if req.SKU != "" {
+		err = h.inventoryUsecase.ReserveStockBySKU(ctx, req.OrderID, req.SKU, req.Quantity)
+	} else {
+		err = h.inventoryUsecase.ReserveStock(ctx, req.OrderID, req.ProductID, req.Quantity)
+	}
 	if err != nil {
-		h.logger.Error("Failed to reserve stock", "productId", req.ProductID, "quantity", req.Quantity, "error", err)
+		h.logger.Error("Failed to reserve stock", "orderId", req.OrderID, "productId", req.ProductID, "sku", req.SKU, "quantity", req.Quantity, "error", err)
 		return HandleError(c, err)
 	}
 
 	return SuccessResp(c, fiber.StatusOK, "Stock reserved successfully", nil)
 }
 
-// ReleaseStock handles releasing reserved stock for a product
+// ReleaseStock handles releasing the stock reserved for an order
 func (h *ProductHandler) ReleaseStock(c *fiber.Ctx) error {
-	var req dto.ReservationRequest
-	if err := c.BodyParser(&req); err != nil {
+	var req dto.OrderReservationRequest
+	if err := c.BodyParser(&req); err != nil || req.OrderID == "" {
 		h.logger.Error("Failed to parse request body", "error", err)
 		return HandleError(c, ErrBadRequest)
 	}
 
 	ctx := c.Context()
-	err := h.inventoryUsecase.CancelReservation(ctx, req.ProductID, req.Quantity)
-	if err != nil {
-		h.logger.Error("Failed to release stock", "productId", req.ProductID, "quantity", req.Quantity, "error", err)
+	if err := h.inventoryUsecase.CancelReservation(ctx, req.OrderID); err != nil {
+		h.logger.Error("Failed to release stock", "orderId", req.OrderID, "error", err)
 		return HandleError(c, err)
 	}
 
 	return SuccessResp(c, fiber.StatusOK, "Stock released successfully", nil)
 }
 
-// ConfirmReservation handles confirming a reservation and reducing the actual stock
+// ConfirmReservation handles confirming the reservations of an order and reducing the actual stock
 func (h *ProductHandler) ConfirmReservation(c *fiber.Ctx) error {
-	var req dto.ReservationRequest
-	if err := c.BodyParser(&req); err != nil {
+	var req dto.OrderReservationRequest
+	if err := c.BodyParser(&req); err != nil || req.OrderID == "" {
 		h.logger.Error("Failed to parse request body", "error", err)
 		return HandleError(c, ErrBadRequest)
 	}
 
 	ctx := c.Context()
-	err := h.inventoryUsecase.ConfirmReservation(ctx, req.ProductID, req.Quantity)
-	if err != nil {
-		h.logger.Error("Failed to confirm reservation", "productId", req.ProductID, "quantity", req.Quantity, "error", err)
+	if err := h.inventoryUsecase.ConfirmReservation(ctx, req.OrderID); err != nil {
+		h.logger.Error("Failed to confirm reservation", "orderId", req.OrderID, "error", err)
 		return HandleError(c, err)
 	}
 
@@ -129,26 +107,68 @@ func (h *ProductHandler) CheckStock(c *fiber.Ctx) error {
 		"in_stock":   inStock,
 	})
 }
-func (h *ProductHandler) PatchInventory(c *fiber.Ctx) error {
-	productId := c.Params("productId")
-	if productId == "" {
+
+// ListProductInventory handles retrieving the inventory of a product and its variants
+func (h *ProductHandler) ListProductInventory(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
 		return HandleError(c, ErrBadRequest)
 	}
 
-	// Parse request into a map for flexible partial updates
-	var patchData map[string]interface{}
-	if err := c.BodyParser(&patchData); err != nil {
-		h.logger.Error("Failed to parse patch request body", "error", err)
+	ctx := c.Context()
+	inventories, err := h.inventoryUsecase.ListProductInventory(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to list product inventory", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.InventoryResponse, len(inventories))
+	for i, inventory := range inventories {
+		response[i] = dto.InventoryResponseFromEntity(inventory)
+	}
+	return SuccessResp(c, fiber.StatusOK, "Inventory retrieved successfully", response)
+}
+
+// GetInventoryBySKU handles retrieving inventory for a product or variant SKU
+func (h *ProductHandler) GetInventoryBySKU(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	inventory, err := h.inventoryUsecase.GetInventoryBySKU(ctx, sku)
+	if err != nil {
+		h.logger.Error("Failed to get inventory by SKU", "sku", sku, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.InventoryResponseFromEntity(inventory)
+	return SuccessResp(c, fiber.StatusOK, "Inventory retrieved successfully", response)
+}
+
+// CheckStockBySKU checks if a product or variant SKU is in stock
+func (h *ProductHandler) CheckStockBySKU(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
 		return HandleError(c, ErrBadRequest)
 	}
 
+	quantity, _ := strconv.Atoi(c.Query("quantity", "1"))
+	if quantity < 1 {
+		quantity = 1
+	}
+
 	ctx := c.Context()
-	updatedInventory, err := h.inventoryUsecase.UpdateInventoryPartial(ctx, productId, patchData)
+	inStock, err := h.inventoryUsecase.IsInStockBySKU(ctx, sku, quantity)
 	if err != nil {
-		h.logger.Error("Failed to patch inventory", "productId", productId, "error", err)
+		h.logger.Error("Failed to check stock by SKU", "sku", sku, "quantity", quantity, "error", err)
 		return HandleError(c, err)
 	}
 
-	response := dto.InventoryResponseFromEntity(updatedInventory)
-	return SuccessResp(c, fiber.StatusOK, "Inventory updated successfully", response)
+	return SuccessResp(c, fiber.StatusOK, "Stock check completed", fiber.Map{
+		"sku":      sku,
+		"quantity": quantity,
+		"in_stock": inStock,
+	})
 }
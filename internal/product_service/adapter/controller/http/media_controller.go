@@ -0,0 +1,135 @@
+package httpctl
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+)
+
+// UploadMedia handles uploading an image for a product as multipart/form-data.
+// The image is sent in the "file" field with an optional "alt_text" field.
+func (h *ProductHandler) UploadMedia(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error("Failed to read uploaded file", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("Failed to open uploaded file", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.logger.Error("Failed to read uploaded file", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	media, err := h.mediaUsecase.UploadMedia(ctx, id, data, c.FormValue("alt_text"))
+	if err != nil {
+		h.logger.Error("Failed to upload media", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductMediaResponseFromEntity(media)
+	return SuccessResp(c, fiber.StatusCreated, "Media uploaded successfully", response)
+}
+
+// ListMedia handles retrieving the images of a product in display order
+func (h *ProductHandler) ListMedia(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	media, err := h.mediaUsecase.ListMedia(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to list media", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.ProductMediaResponse, len(media))
+	for i := range media {
+		response[i] = dto.ProductMediaResponseFromEntity(&media[i])
+	}
+	return SuccessResp(c, fiber.StatusOK, "Media retrieved successfully", response)
+}
+
+// UpdateMedia handles changing the alt text of a product image
+func (h *ProductHandler) UpdateMedia(c *fiber.Ctx) error {
+	id := c.Params("id")
+	mediaID := c.Params("mediaId")
+	if id == "" || mediaID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.MediaUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	media, err := h.mediaUsecase.UpdateMedia(ctx, id, mediaID, req.AltText)
+	if err != nil {
+		h.logger.Error("Failed to update media", "id", id, "mediaId", mediaID, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductMediaResponseFromEntity(media)
+	return SuccessResp(c, fiber.StatusOK, "Media updated successfully", response)
+}
+
+// ReorderMedia handles setting the display order of the images of a product
+func (h *ProductHandler) ReorderMedia(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.MediaOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	media, err := h.mediaUsecase.ReorderMedia(ctx, id, req.MediaIDs)
+	if err != nil {
+		h.logger.Error("Failed to reorder media", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.ProductMediaResponse, len(media))
+	for i := range media {
+		response[i] = dto.ProductMediaResponseFromEntity(&media[i])
+	}
+	return SuccessResp(c, fiber.StatusOK, "Media reordered successfully", response)
+}
+
+// DeleteMedia handles removing a product image
+func (h *ProductHandler) DeleteMedia(c *fiber.Ctx) error {
+	id := c.Params("id")
+	mediaID := c.Params("mediaId")
+	if id == "" || mediaID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	if err := h.mediaUsecase.DeleteMedia(ctx, id, mediaID); err != nil {
+		h.logger.Error("Failed to delete media", "id", id, "mediaId", mediaID, "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Media deleted successfully", nil)
+}
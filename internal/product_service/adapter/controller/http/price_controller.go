@@ -0,0 +1,193 @@
+package httpctl
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+)
+
+// GetPriceHistory handles retrieving the price changes of a product, newest first
+func (h *ProductHandler) GetPriceHistory(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	ctx := c.Context()
+	changes, total, err := h.priceUsecase.GetPriceHistory(ctx, id, page, pageSize)
+	if err != nil {
+		h.logger.Error("Failed to get price history", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.PriceChangeResponse, len(changes))
+	for i := range changes {
+		response[i] = dto.PriceChangeResponseFromEntity(&changes[i])
+	}
+	paginatedResponse := dto.NewPaginatedResponse(total, page, pageSize, response)
+	return SuccessResp(c, fiber.StatusOK, "Price history retrieved successfully", paginatedResponse)
+}
+
+// SchedulePriceChange handles scheduling a future price change of a product
+func (h *ProductHandler) SchedulePriceChange(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.ScheduledPriceRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	scheduled, err := h.priceUsecase.SchedulePriceChange(ctx, id, req.Price, req.EffectiveAt)
+	if err != nil {
+		h.logger.Error("Failed to schedule price change", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ScheduledPriceResponseFromEntity(scheduled)
+	return SuccessResp(c, fiber.StatusCreated, "Price change scheduled successfully", response)
+}
+
+// ListScheduledPrices handles retrieving the scheduled price changes of a product
+func (h *ProductHandler) ListScheduledPrices(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	scheduled, err := h.priceUsecase.ListScheduledPrices(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to list scheduled prices", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.ScheduledPriceResponse, len(scheduled))
+	for i := range scheduled {
+		response[i] = dto.ScheduledPriceResponseFromEntity(&scheduled[i])
+	}
+	return SuccessResp(c, fiber.StatusOK, "Scheduled prices retrieved successfully", response)
+}
+
+// CancelScheduledPrice handles removing a pending scheduled price change
+func (h *ProductHandler) CancelScheduledPrice(c *fiber.Ctx) error {
+	id := c.Params("id")
+	scheduleID := c.Params("scheduleId")
+	if id == "" || scheduleID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	if err := h.priceUsecase.CancelScheduledPrice(ctx, id, scheduleID); err != nil {
+		h.logger.Error("Failed to cancel scheduled price", "id", id, "scheduleId", scheduleID, "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Scheduled price change cancelled successfully", nil)
+}
+
+// CreateSale handles putting a product on sale
+func (h *ProductHandler) CreateSale(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.SaleRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	sale, err := h.priceUsecase.CreateSale(ctx, req.ToEntity(id))
+	if err != nil {
+		h.logger.Error("Failed to create sale", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.SaleResponseFromEntity(sale)
+	return SuccessResp(c, fiber.StatusCreated, "Sale created successfully", response)
+}
+
+// ListSales handles retrieving the sales of a product
+func (h *ProductHandler) ListSales(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	sales, err := h.priceUsecase.ListSales(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to list sales", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.SaleResponse, len(sales))
+	for i := range sales {
+		response[i] = dto.SaleResponseFromEntity(&sales[i])
+	}
+	return SuccessResp(c, fiber.StatusOK, "Sales retrieved successfully", response)
+}
+
+// DeleteSale handles removing a sale
+func (h *ProductHandler) DeleteSale(c *fiber.Ctx) error {
+	id := c.Params("id")
+	saleID := c.Params("saleId")
+	if id == "" || saleID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	if err := h.priceUsecase.DeleteSale(ctx, id, saleID); err != nil {
+		h.logger.Error("Failed to delete sale", "id", id, "saleId", saleID, "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Sale deleted successfully", nil)
+}
+
+// GetEffectivePrice handles resolving the price of a product at a point in time.
+// The optional variant_id query selects a variant and at takes an RFC 3339 time, defaulting to now.
+func (h *ProductHandler) GetEffectivePrice(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+		at = parsed
+	}
+
+	ctx := c.Context()
+	price, err := h.priceUsecase.GetEffectivePrice(ctx, id, c.Query("variant_id"), at)
+	if err != nil {
+		h.logger.Error("Failed to get effective price", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.EffectivePriceResponseFromEntity(price)
+	return SuccessResp(c, fiber.StatusOK, "Price retrieved successfully", response)
+}
@@ -14,6 +14,11 @@ type ProductHandler struct {
 	productUsecase   usecase.ProductUsecase
 	categoryUsecase  usecase.CategoryUsecase
 	inventoryUsecase usecase.InventoryUsecase
+	variantUsecase   usecase.VariantUsecase
+	searchUsecase    usecase.SearchUsecase
+	mediaUsecase     usecase.MediaUsecase
+	bulkUsecase      usecase.BulkProductUsecase
+	priceUsecase     usecase.PriceUsecase
 	logger           logger.Logger
 }
 
@@ -22,12 +27,22 @@ func NewProductHandler(
 	pu usecase.ProductUsecase,
 	cu usecase.CategoryUsecase,
 	iu usecase.InventoryUsecase,
+	vu usecase.VariantUsecase,
+	su usecase.SearchUsecase,
+	mu usecase.MediaUsecase,
+	bu usecase.BulkProductUsecase,
+	pru usecase.PriceUsecase,
 	l logger.Logger,
 ) *ProductHandler {
 	return &ProductHandler{
 		productUsecase:   pu,
 		categoryUsecase:  cu,
 		inventoryUsecase: iu,
+		variantUsecase:   vu,
+		searchUsecase:    su,
+		mediaUsecase:     mu,
+		bulkUsecase:      bu,
+		priceUsecase:     pru,
 		logger:           l,
 	}
 }
@@ -39,12 +54,46 @@ func (h *ProductHandler) RegisterRoutes(r fiber.Router) {
 	// Product routes
 	api.Post("/", h.CreateProduct)
 	api.Get("/", h.ListProducts)
+	api.Get("/search", h.SearchProducts)
+	api.Get("/export", h.ExportProducts)
+	api.Post("/import", h.ImportProducts)
+	api.Get("/import/:jobId", h.GetImportJob)
+	api.Get("/import/:jobId/errors", h.DownloadImportErrors)
 	api.Get("/:id", h.GetProduct)
 	api.Put("/:id", h.UpdateProduct)
 	api.Patch("/:id", h.PatchProduct)
 	api.Delete("/:id", h.DeleteProduct)
 	api.Get("/sku/:sku", h.GetProductBySKU)
 	api.Get("/category/:categoryId", h.GetProductsByCategory)
+	api.Get("/:id/inventory", h.ListProductInventory)
+	api.Post("/:id/status", h.ChangeProductStatus)
+	api.Put("/:id/schedule", h.ScheduleProduct)
+
+	// Variant routes
+	api.Get("/variants/sku/:sku", h.GetVariantBySKU)
+	api.Put("/:id/options", h.SetProductOptions)
+	api.Post("/:id/variants", h.CreateVariant)
+	api.Get("/:id/variants", h.ListVariants)
+	api.Get("/:id/variants/:variantId", h.GetVariant)
+	api.Put("/:id/variants/:variantId", h.UpdateVariant)
+	api.Delete("/:id/variants/:variantId", h.DeleteVariant)
+
+	// Media routes
+	api.Post("/:id/media", h.UploadMedia)
+	api.Get("/:id/media", h.ListMedia)
+	api.Put("/:id/media/order", h.ReorderMedia)
+	api.Patch("/:id/media/:mediaId", h.UpdateMedia)
+	api.Delete("/:id/media/:mediaId", h.DeleteMedia)
+
+	// Price routes
+	api.Get("/:id/price", h.GetEffectivePrice)
+	api.Get("/:id/prices/history", h.GetPriceHistory)
+	api.Post("/:id/prices/scheduled", h.SchedulePriceChange)
+	api.Get("/:id/prices/scheduled", h.ListScheduledPrices)
+	api.Delete("/:id/prices/scheduled/:scheduleId", h.CancelScheduledPrice)
+	api.Post("/:id/sales", h.CreateSale)
+	api.Get("/:id/sales", h.ListSales)
+	api.Delete("/:id/sales/:saleId", h.DeleteSale)
 
 	// Category routes
 	categoryGroup := r.Group("/categories")
@@ -55,12 +104,15 @@ func (h *ProductHandler) RegisterRoutes(r fiber.Router) {
 	categoryGroup.Patch("/:id", h.PatchCategory)
 	categoryGroup.Delete("/:id", h.DeleteCategory)
 	categoryGroup.Get("/:id/children", h.GetChildCategories)
+	categoryGroup.Get("/:id/tree", h.GetCategoryTree)
+	categoryGroup.Get("/:id/breadcrumbs", h.GetCategoryBreadcrumbs)
+	categoryGroup.Post("/:id/move", h.MoveCategory)
 
 	// Inventory routes
 	inventoryGroup := r.Group("/inventory")
+	inventoryGroup.Get("/sku/:sku", h.GetInventoryBySKU)
+	inventoryGroup.Get("/sku/:sku/stock", h.CheckStockBySKU)
 	inventoryGroup.Get("/:productId", h.GetInventory)
-	inventoryGroup.Put("/:productId", h.UpdateInventory)
-	inventoryGroup.Patch("/:productId", h.PatchInventory)
 	inventoryGroup.Post("/reserve", h.ReserveStock)
 	inventoryGroup.Post("/release", h.ReleaseStock)
 	inventoryGroup.Post("/confirm", h.ConfirmReservation)
@@ -217,8 +269,10 @@ func (h *ProductHandler) GetProductsByCategory(c *fiber.Ctx) error {
 		pageSize = 10
 	}
 
+	includeDescendants := c.QueryBool("includeDescendants")
+
 	ctx := c.Context()
-	products, total, err := h.productUsecase.GetProductsByCategory(ctx, categoryId, page, pageSize)
+	products, total, err := h.productUsecase.GetProductsByCategory(ctx, categoryId, includeDescendants, page, pageSize)
 	if err != nil {
 		h.logger.Error("Failed to get products by category", "categoryId", categoryId, "error", err)
 		return HandleError(c, err)
@@ -258,3 +312,51 @@ func (h *ProductHandler) PatchProduct(c *fiber.Ctx) error {
 	response := dto.ProductResponseFromEntity(updatedProduct)
 	return SuccessResp(c, fiber.StatusOK, "Product updated successfully", response)
 }
+
+// ChangeProductStatus handles moving a product to another lifecycle status
+func (h *ProductHandler) ChangeProductStatus(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.ProductStatusRequest
+	if err := c.BodyParser(&req); err != nil || req.Status == "" {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	product, err := h.productUsecase.ChangeStatus(ctx, id, req.Status)
+	if err != nil {
+		h.logger.Error("Failed to change product status", "id", id, "status", req.Status, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductResponseFromEntity(product)
+	return SuccessResp(c, fiber.StatusOK, "Product status changed successfully", response)
+}
+
+// ScheduleProduct handles setting the publish and unpublish times of a product
+func (h *ProductHandler) ScheduleProduct(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.ProductScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	product, err := h.productUsecase.ScheduleProduct(ctx, id, req.PublishAt, req.UnpublishAt)
+	if err != nil {
+		h.logger.Error("Failed to schedule product", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductResponseFromEntity(product)
+	return SuccessResp(c, fiber.StatusOK, "Product schedule updated successfully", response)
+}
@@ -53,12 +53,87 @@ func HandleError(c *fiber.Ctx, err error) error {
 	case errors.Is(err, entity.ErrProductSKUExists):
 		statusCode = http.StatusConflict
 		message = "Product SKU already exists"
+	case errors.Is(err, entity.ErrInvalidProductStatus):
+		statusCode = http.StatusBadRequest
+		message = "Invalid product status"
+	case errors.Is(err, entity.ErrInvalidStatusTransition):
+		statusCode = http.StatusConflict
+		message = "Product status transition is not allowed"
+	case errors.Is(err, entity.ErrInvalidSchedule):
+		statusCode = http.StatusBadRequest
+		message = "Invalid publishing schedule"
+	case errors.Is(err, entity.ErrInvalidPrice):
+		statusCode = http.StatusBadRequest
+		message = "Price must be greater than zero"
+	case errors.Is(err, entity.ErrScheduledPriceNotFound):
+		statusCode = http.StatusNotFound
+		message = "Scheduled price change not found"
+	case errors.Is(err, entity.ErrScheduledPriceApplied):
+		statusCode = http.StatusConflict
+		message = "Scheduled price change has already been applied"
+	case errors.Is(err, entity.ErrInvalidSalePeriod):
+		statusCode = http.StatusBadRequest
+		message = "Sale must end after it starts"
+	case errors.Is(err, entity.ErrSaleOverlap):
+		statusCode = http.StatusConflict
+		message = "Sale overlaps another sale of the product"
+	case errors.Is(err, entity.ErrSaleNotFound):
+		statusCode = http.StatusNotFound
+		message = "Sale not found"
 	case errors.Is(err, entity.ErrCategoryAlreadyExists):
 		statusCode = http.StatusConflict
 		message = "Category already exists"
+	case errors.Is(err, entity.ErrVariantNotFound):
+		statusCode = http.StatusNotFound
+		message = "Product variant not found"
+	case errors.Is(err, entity.ErrVariantExists):
+		statusCode = http.StatusConflict
+		message = "A variant with these options already exists"
+	case errors.Is(err, entity.ErrInvalidVariantData):
+		statusCode = http.StatusBadRequest
+		message = "Invalid variant data"
+	case errors.Is(err, entity.ErrInvalidVariantOptions):
+		statusCode = http.StatusBadRequest
+		message = "Variant options do not match the product options"
+	case errors.Is(err, entity.ErrVariantRequired):
+		statusCode = http.StatusBadRequest
+		message = "Product has variants; use a variant SKU"
+	case errors.Is(err, entity.ErrMediaNotFound):
+		statusCode = http.StatusNotFound
+		message = "Product media not found"
+	case errors.Is(err, entity.ErrInvalidMedia):
+		statusCode = http.StatusBadRequest
+		message = "Unsupported or corrupt image"
+	case errors.Is(err, entity.ErrMediaTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+		message = "Image exceeds the maximum upload size"
+	case errors.Is(err, entity.ErrInvalidMediaOrder):
+		statusCode = http.StatusBadRequest
+		message = "Media order must list every image of the product exactly once"
+	case errors.Is(err, entity.ErrImportJobNotFound):
+		statusCode = http.StatusNotFound
+		message = "Import job not found"
+	case errors.Is(err, entity.ErrInvalidImportFile):
+		statusCode = http.StatusBadRequest
+		message = "Import file could not be read"
+	case errors.Is(err, entity.ErrUnsupportedFormat):
+		statusCode = http.StatusBadRequest
+		message = "Unsupported file format; use csv or jsonl"
+	case errors.Is(err, entity.ErrImportFileTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+		message = "Import file has too many rows"
+	case errors.Is(err, entity.ErrCategoryCycle):
+		statusCode = http.StatusBadRequest
+		message = "Category cannot be moved below itself or its descendants"
+	case errors.Is(err, entity.ErrCategoryTooDeep):
+		statusCode = http.StatusBadRequest
+		message = "Category tree is nested too deeply"
 	case errors.Is(err, entity.ErrInsufficientStock):
 		statusCode = http.StatusBadRequest
 		message = "Insufficient stock"
+	case errors.Is(err, entity.ErrStockAlreadyExists):
+		statusCode = http.StatusConflict
+		message = "Inventory already has stock"
 	case errors.Is(err, entity.ErrInternalServerError):
 		statusCode = http.StatusInternalServerError
 		message = "Internal server error"
@@ -0,0 +1,52 @@
+package httpctl
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// SearchProducts handles full-text product search with facet counts
+func (h *ProductHandler) SearchProducts(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	query := entity.SearchQuery{
+		Text:       c.Query("q"),
+		CategoryID: c.Query("category_id"),
+		Status:     c.Query("status"),
+	}
+	if value := c.Query("price_min"); value != "" {
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+		query.PriceMin = &price
+	}
+	if value := c.Query("price_max"); value != "" {
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+		query.PriceMax = &price
+	}
+
+	ctx := c.Context()
+	result, err := h.searchUsecase.SearchProducts(ctx, query, page, pageSize)
+	if err != nil {
+		h.logger.Error("Failed to search products", "query", query.Text, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.SearchResponseFromEntity(result, page, pageSize)
+	return SuccessResp(c, fiber.StatusOK, "Products retrieved successfully", response)
+}
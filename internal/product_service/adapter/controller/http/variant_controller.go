@@ -0,0 +1,155 @@
+package httpctl
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/dto"
+)
+
+// SetProductOptions handles replacing the option axes of a product
+func (h *ProductHandler) SetProductOptions(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.ProductOptionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	options, err := h.variantUsecase.SetOptions(ctx, id, req.ToEntities())
+	if err != nil {
+		h.logger.Error("Failed to set product options", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductOptionResponsesFromEntities(options)
+	return SuccessResp(c, fiber.StatusOK, "Product options updated successfully", response)
+}
+
+// CreateVariant handles the creation of a new product variant
+func (h *ProductHandler) CreateVariant(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.VariantRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	variant := req.ToEntity()
+	createdVariant, err := h.variantUsecase.CreateVariant(ctx, id, &variant)
+	if err != nil {
+		h.logger.Error("Failed to create variant", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductVariantResponseFromEntity(createdVariant, 0)
+	return SuccessResp(c, fiber.StatusCreated, "Variant created successfully", response)
+}
+
+// ListVariants handles retrieving the variants of a product
+func (h *ProductHandler) ListVariants(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	variants, err := h.variantUsecase.ListVariants(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to list variants", "id", id, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := make([]dto.ProductVariantResponse, len(variants))
+	for i := range variants {
+		response[i] = dto.ProductVariantResponseFromEntity(&variants[i], 0)
+	}
+	return SuccessResp(c, fiber.StatusOK, "Variants retrieved successfully", response)
+}
+
+// GetVariant handles retrieving a product variant by ID
+func (h *ProductHandler) GetVariant(c *fiber.Ctx) error {
+	id := c.Params("id")
+	variantId := c.Params("variantId")
+	if id == "" || variantId == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	variant, err := h.variantUsecase.GetVariant(ctx, id, variantId)
+	if err != nil {
+		h.logger.Error("Failed to get variant", "id", id, "variantId", variantId, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductVariantResponseFromEntity(variant, 0)
+	return SuccessResp(c, fiber.StatusOK, "Variant retrieved successfully", response)
+}
+
+// GetVariantBySKU handles retrieving a product variant by SKU
+func (h *ProductHandler) GetVariantBySKU(c *fiber.Ctx) error {
+	sku := c.Params("sku")
+	if sku == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	variant, err := h.variantUsecase.GetVariantBySKU(ctx, sku)
+	if err != nil {
+		h.logger.Error("Failed to get variant by SKU", "sku", sku, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductVariantResponseFromEntity(variant, 0)
+	return SuccessResp(c, fiber.StatusOK, "Variant retrieved successfully", response)
+}
+
+// UpdateVariant handles updating an existing product variant
+func (h *ProductHandler) UpdateVariant(c *fiber.Ctx) error {
+	id := c.Params("id")
+	variantId := c.Params("variantId")
+	if id == "" || variantId == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.VariantRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.logger.Error("Failed to parse request body", "error", err)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	updatedVariant, err := h.variantUsecase.UpdateVariant(ctx, id, variantId, req.ToEntity())
+	if err != nil {
+		h.logger.Error("Failed to update variant", "id", id, "variantId", variantId, "error", err)
+		return HandleError(c, err)
+	}
+
+	response := dto.ProductVariantResponseFromEntity(updatedVariant, 0)
+	return SuccessResp(c, fiber.StatusOK, "Variant updated successfully", response)
+}
+
+// DeleteVariant handles deleting a product variant
+func (h *ProductHandler) DeleteVariant(c *fiber.Ctx) error {
+	id := c.Params("id")
+	variantId := c.Params("variantId")
+	if id == "" || variantId == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	ctx := c.Context()
+	if err := h.variantUsecase.DeleteVariant(ctx, id, variantId); err != nil {
+		h.logger.Error("Failed to delete variant", "id", id, "variantId", variantId, "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Variant deleted successfully", nil)
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 )
 
@@ -44,13 +46,113 @@ func (c CategoryRequest) ToEntity() entity.Category {
 	}
 }
 
-// InventoryUpdateRequest represents a request to update product inventory
-type InventoryUpdateRequest struct {
-	Quantity int `json:"quantity" validate:"gte=0"`
+// ProductStatusRequest represents a request to move a product to another lifecycle status
+type ProductStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+}
+
+// ProductScheduleRequest represents a request to schedule the publication of a product.
+// A null or missing time clears that part of the schedule.
+type ProductScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// ScheduledPriceRequest represents a request to change the price of a product at a future time
+type ScheduledPriceRequest struct {
+	Price       float64   `json:"price" validate:"required,gt=0"`
+	EffectiveAt time.Time `json:"effective_at" validate:"required"`
+}
+
+// SaleRequest represents a request to put a product on sale.
+// A missing start time starts the sale now; a missing end time runs it until it is deleted.
+type SaleRequest struct {
+	Price    float64    `json:"price" validate:"required,gt=0"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// ToEntity converts SaleRequest to a sale entity of a product
+func (r *SaleRequest) ToEntity(productID string) *entity.Sale {
+	sale := &entity.Sale{
+		ProductID: productID,
+		Price:     r.Price,
+		EndsAt:    r.EndsAt,
+	}
+	if r.StartsAt != nil {
+		sale.StartsAt = *r.StartsAt
+	}
+	return sale
+}
+
+// MediaUpdateRequest represents a request to change the alt text of a product image
+type MediaUpdateRequest struct {
+	AltText string `json:"alt_text"`
+}
+
+// MediaOrderRequest represents a request to reorder the images of a product.
+// It must list every image of the product exactly once.
+type MediaOrderRequest struct {
+	MediaIDs []string `json:"media_ids"`
 }
 
-// ReservationRequest represents a request to reserve inventory
+// MoveCategoryRequest represents a request to move a category below a new parent.
+// A null or missing parent_id moves the category to the top level.
+type MoveCategoryRequest struct {
+	ParentID *string `json:"parent_id"`
+}
+
+// ReservationRequest represents a request to reserve inventory for an order.
+// Products with variants are addressed by variant SKU.
 type ReservationRequest struct {
-	ProductID string `json:"product_id" validate:"required"`
+	OrderID   string `json:"order_id" validate:"required"`
+	ProductID string `json:"product_id" validate:"required_without=SKU"`
+	SKU       string `json:"sku,omitempty"`
 	Quantity  int    `json:"quantity" validate:"required,gt=0"`
 }
+
+// OrderReservationRequest represents a request to confirm or release the reservations of an order
+type OrderReservationRequest struct {
+	OrderID string `json:"order_id" validate:"required"`
+}
+
+// ProductOptionRequest represents an option axis of a product
+type ProductOptionRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Values []string `json:"values" validate:"required,min=1"`
+}
+
+// ProductOptionsRequest represents a request to replace the option axes of a product
+type ProductOptionsRequest struct {
+	Options []ProductOptionRequest `json:"options"`
+}
+
+// ToEntities converts ProductOptionsRequest to option entities
+func (r ProductOptionsRequest) ToEntities() []entity.ProductOption {
+	options := make([]entity.ProductOption, len(r.Options))
+	for i, option := range r.Options {
+		options[i] = entity.ProductOption{
+			Name:   option.Name,
+			Values: option.Values,
+		}
+	}
+	return options
+}
+
+// VariantRequest represents a request to create or update a product variant
+type VariantRequest struct {
+	SKU      string            `json:"sku" validate:"required"`
+	Options  map[string]string `json:"options" validate:"required"`
+	Price    *float64          `json:"price,omitempty" validate:"omitempty,gt=0"`
+	ImageURL string            `json:"image_url"`
+}
+
+// ToEntity converts VariantRequest to a variant entity
+func (v VariantRequest) ToEntity() entity.ProductVariant {
+	return entity.ProductVariant{
+		SKU:      v.SKU,
+		Options:  v.Options,
+		Price:    v.Price,
+		ImageURL: v.ImageURL,
+	}
+}
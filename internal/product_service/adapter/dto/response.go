@@ -8,21 +8,28 @@ import (
 
 // ProductResponse represents a product response
 type ProductResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	CategoryID  string    `json:"category_id"`
-	ImageURL    string    `json:"image_url"`
-	SKU         string    `json:"sku"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price"`
+	CategoryID  string     `json:"category_id"`
+	ImageURL    string     `json:"image_url"`
+	SKU         string     `json:"sku"`
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Only set when retrieving a single product
+	Options  []ProductOptionResponse  `json:"options,omitempty"`
+	Variants []ProductVariantResponse `json:"variants,omitempty"`
+	Media    []ProductMediaResponse   `json:"media,omitempty"`
 }
 
 // FromEntity converts a product entity to ProductResponse
 func ProductResponseFromEntity(product *entity.Product) ProductResponse {
-	return ProductResponse{
+	response := ProductResponse{
 		ID:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
@@ -31,9 +38,114 @@ func ProductResponseFromEntity(product *entity.Product) ProductResponse {
 		ImageURL:    product.ImageURL,
 		SKU:         product.SKU,
 		Status:      product.Status,
+		PublishAt:   product.PublishAt,
+		UnpublishAt: product.UnpublishAt,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 	}
+	if len(product.Options) > 0 {
+		response.Options = ProductOptionResponsesFromEntities(product.Options)
+	}
+	for i := range product.Variants {
+		response.Variants = append(response.Variants, ProductVariantResponseFromEntity(&product.Variants[i], product.Price))
+	}
+	for i := range product.Media {
+		response.Media = append(response.Media, ProductMediaResponseFromEntity(&product.Media[i]))
+	}
+	return response
+}
+
+// ProductMediaResponse represents a product image
+type ProductMediaResponse struct {
+	ID          string                   `json:"id"`
+	URL         string                   `json:"url"`
+	AltText     string                   `json:"alt_text"`
+	Position    int                      `json:"position"`
+	ContentType string                   `json:"content_type"`
+	Width       int                      `json:"width"`
+	Height      int                      `json:"height"`
+	Size        int64                    `json:"size"`
+	Thumbnails  []MediaThumbnailResponse `json:"thumbnails"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// MediaThumbnailResponse represents a scaled-down copy of a product image
+type MediaThumbnailResponse struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
+
+// ProductMediaResponseFromEntity converts a media entity to ProductMediaResponse
+func ProductMediaResponseFromEntity(media *entity.ProductMedia) ProductMediaResponse {
+	response := ProductMediaResponse{
+		ID:          media.ID,
+		URL:         media.URL,
+		AltText:     media.AltText,
+		Position:    media.Position,
+		ContentType: media.ContentType,
+		Width:       media.Width,
+		Height:      media.Height,
+		Size:        media.Size,
+		Thumbnails:  make([]MediaThumbnailResponse, len(media.Thumbnails)),
+		CreatedAt:   media.CreatedAt,
+	}
+	for i, thumbnail := range media.Thumbnails {
+		response.Thumbnails[i] = MediaThumbnailResponse{Width: thumbnail.Width, Height: thumbnail.Height, URL: thumbnail.URL}
+	}
+	return response
+}
+
+// ProductOptionResponse represents an option axis of a product
+type ProductOptionResponse struct {
+	Name     string   `json:"name"`
+	Values   []string `json:"values"`
+	Position int      `json:"position"`
+}
+
+// ProductOptionResponsesFromEntities converts option entities to ProductOptionResponses
+func ProductOptionResponsesFromEntities(options []entity.ProductOption) []ProductOptionResponse {
+	responses := make([]ProductOptionResponse, len(options))
+	for i, option := range options {
+		responses[i] = ProductOptionResponse{
+			Name:     option.Name,
+			Values:   option.Values,
+			Position: option.Position,
+		}
+	}
+	return responses
+}
+
+// ProductVariantResponse represents a product variant response
+type ProductVariantResponse struct {
+	ID        string            `json:"id"`
+	ProductID string            `json:"product_id"`
+	SKU       string            `json:"sku"`
+	Options   map[string]string `json:"options"`
+	Price     *float64          `json:"price,omitempty"`
+	ImageURL  string            `json:"image_url"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ProductVariantResponseFromEntity converts a variant entity to ProductVariantResponse.
+// The price is resolved against productPrice when it is known.
+func ProductVariantResponseFromEntity(variant *entity.ProductVariant, productPrice float64) ProductVariantResponse {
+	response := ProductVariantResponse{
+		ID:        variant.ID,
+		ProductID: variant.ProductID,
+		SKU:       variant.SKU,
+		Options:   variant.Options,
+		Price:     variant.Price,
+		ImageURL:  variant.ImageURL,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}
+	if productPrice > 0 {
+		price := variant.EffectivePrice(productPrice)
+		response.Price = &price
+	}
+	return response
 }
 
 // CategoryResponse represents a category response
@@ -43,6 +155,7 @@ type CategoryResponse struct {
 	Description string    `json:"description"`
 	ParentID    *string   `json:"parent_id,omitempty"`
 	Level       int       `json:"level"`
+	Path        string    `json:"path"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -55,14 +168,35 @@ func CategoryResponseFromEntity(category *entity.Category) CategoryResponse {
 		Description: category.Description,
 		ParentID:    category.ParentID,
 		Level:       category.Level,
+		Path:        category.Path,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}
 }
 
+// CategoryTreeResponse represents a category with its nested subcategories
+type CategoryTreeResponse struct {
+	CategoryResponse
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+// CategoryTreeResponseFromEntity converts a category tree node to CategoryTreeResponse
+func CategoryTreeResponseFromEntity(node *entity.CategoryNode) CategoryTreeResponse {
+	response := CategoryTreeResponse{
+		CategoryResponse: CategoryResponseFromEntity(node.Category),
+		Children:         make([]CategoryTreeResponse, len(node.Children)),
+	}
+	for i, child := range node.Children {
+		response.Children[i] = CategoryTreeResponseFromEntity(child)
+	}
+	return response
+}
+
 // InventoryResponse represents an inventory response
 type InventoryResponse struct {
 	ProductID string    `json:"product_id"`
+	VariantID *string   `json:"variant_id,omitempty"`
+	SKU       string    `json:"sku"`
 	Quantity  int       `json:"quantity"`
 	Reserved  int       `json:"reserved"`
 	Available int       `json:"available"`
@@ -73,13 +207,139 @@ type InventoryResponse struct {
 func InventoryResponseFromEntity(inventory *entity.Inventory) InventoryResponse {
 	return InventoryResponse{
 		ProductID: inventory.ProductID,
+		VariantID: inventory.VariantID,
+		SKU:       inventory.SKU,
 		Quantity:  inventory.Quantity,
 		Reserved:  inventory.Reserved,
-		Available: inventory.Quantity - inventory.Reserved,
+		Available: inventory.Available(),
 		UpdatedAt: inventory.UpdatedAt,
 	}
 }
 
+// PriceChangeResponse represents an entry in the price history of a product
+type PriceChangeResponse struct {
+	ID        string    `json:"id"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	Source    string    `json:"source"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// PriceChangeResponseFromEntity converts a price change entity to PriceChangeResponse
+func PriceChangeResponseFromEntity(change *entity.PriceChange) PriceChangeResponse {
+	return PriceChangeResponse{
+		ID:        change.ID,
+		OldPrice:  change.OldPrice,
+		NewPrice:  change.NewPrice,
+		Source:    string(change.Source),
+		ChangedAt: change.ChangedAt,
+	}
+}
+
+// ScheduledPriceResponse represents a scheduled price change of a product
+type ScheduledPriceResponse struct {
+	ID          string     `json:"id"`
+	ProductID   string     `json:"product_id"`
+	Price       float64    `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ScheduledPriceResponseFromEntity converts a scheduled price entity to ScheduledPriceResponse
+func ScheduledPriceResponseFromEntity(scheduled *entity.ScheduledPrice) ScheduledPriceResponse {
+	return ScheduledPriceResponse{
+		ID:          scheduled.ID,
+		ProductID:   scheduled.ProductID,
+		Price:       scheduled.Price,
+		EffectiveAt: scheduled.EffectiveAt,
+		AppliedAt:   scheduled.AppliedAt,
+		CreatedAt:   scheduled.CreatedAt,
+	}
+}
+
+// SaleResponse represents a sale of a product
+type SaleResponse struct {
+	ID        string     `json:"id"`
+	ProductID string     `json:"product_id"`
+	Price     float64    `json:"price"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SaleResponseFromEntity converts a sale entity to SaleResponse
+func SaleResponseFromEntity(sale *entity.Sale) SaleResponse {
+	return SaleResponse{
+		ID:        sale.ID,
+		ProductID: sale.ProductID,
+		Price:     sale.Price,
+		StartsAt:  sale.StartsAt,
+		EndsAt:    sale.EndsAt,
+		CreatedAt: sale.CreatedAt,
+	}
+}
+
+// EffectivePriceResponse represents the price of a product or variant at a point in time
+type EffectivePriceResponse struct {
+	ProductID      string    `json:"product_id"`
+	VariantID      string    `json:"variant_id,omitempty"`
+	SKU            string    `json:"sku"`
+	At             time.Time `json:"at"`
+	Price          float64   `json:"price"`
+	BasePrice      float64   `json:"base_price"`
+	CompareAtPrice *float64  `json:"compare_at_price,omitempty"`
+	SaleID         string    `json:"sale_id,omitempty"`
+}
+
+// EffectivePriceResponseFromEntity converts an effective price entity to EffectivePriceResponse
+func EffectivePriceResponseFromEntity(price *entity.EffectivePrice) EffectivePriceResponse {
+	return EffectivePriceResponse{
+		ProductID:      price.ProductID,
+		VariantID:      price.VariantID,
+		SKU:            price.SKU,
+		At:             price.At,
+		Price:          price.Price,
+		BasePrice:      price.BasePrice,
+		CompareAtPrice: price.CompareAtPrice,
+		SaleID:         price.SaleID,
+	}
+}
+
+// ImportJobResponse represents the progress of a bulk product import
+type ImportJobResponse struct {
+	ID            string     `json:"id"`
+	Format        string     `json:"format"`
+	Status        string     `json:"status"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	CreatedCount  int        `json:"created_count"`
+	UpdatedCount  int        `json:"updated_count"`
+	FailedCount   int        `json:"failed_count"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// ImportJobResponseFromEntity converts an import job entity to ImportJobResponse
+func ImportJobResponseFromEntity(job *entity.ImportJob) ImportJobResponse {
+	return ImportJobResponse{
+		ID:            job.ID,
+		Format:        string(job.Format),
+		Status:        string(job.Status),
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		UpdatedCount:  job.UpdatedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
+
 // PaginatedResponse represents a paginated response
 type PaginatedResponse struct {
 	Total      int         `json:"total"`
@@ -104,3 +364,31 @@ func NewPaginatedResponse(total, page, pageSize int, data interface{}) Paginated
 		Data:       data,
 	}
 }
+
+// SearchHitResponse represents a matching product with its relevance score
+type SearchHitResponse struct {
+	Product ProductResponse `json:"product"`
+	Score   float64         `json:"score"`
+}
+
+// SearchResponse represents a page of search results with facet counts
+type SearchResponse struct {
+	PaginatedResponse
+	Facets entity.SearchFacets `json:"facets"`
+}
+
+// SearchResponseFromEntity converts a search result to SearchResponse
+func SearchResponseFromEntity(result *entity.SearchResult, page, pageSize int) SearchResponse {
+	hits := make([]SearchHitResponse, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = SearchHitResponse{
+			Product: ProductResponseFromEntity(hit.Product),
+			Score:   hit.Score,
+		}
+	}
+
+	return SearchResponse{
+		PaginatedResponse: NewPaginatedResponse(result.Total, page, pageSize, hits),
+		Facets:            result.Facets,
+	}
+}
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConfig holds the configuration for Kafka connection
+type KafkaConfig struct {
+	Brokers      []string `yaml:"brokers"`
+	ProductTopic string   `yaml:"product_topic"`
+}
+
+// KafkaEventPublisher implements the EventPublisherService interface using Kafka
+type KafkaEventPublisher struct {
+	writer      *kafka.Writer
+	kafkaConfig *KafkaConfig
+}
+
+// NewKafkaEventPublisher creates a new Kafka event publisher
+func NewKafkaEventPublisher(config *KafkaConfig) (*KafkaEventPublisher, error) {
+	w := &kafka.Writer{
+		Addr:                   kafka.TCP(config.Brokers...),
+		Topic:                  config.ProductTopic,
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           50 * time.Millisecond,
+		RequiredAcks:           kafka.RequireAll,
+	}
+
+	return &KafkaEventPublisher{
+		writer:      w,
+		kafkaConfig: config,
+	}, nil
+}
+
+// Publish publishes outbox events in order.
+// Events are keyed by product ID so each product's events land on one partition and stay in order.
+func (k *KafkaEventPublisher) Publish(ctx context.Context, events []entity.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]kafka.Message, len(events))
+	for i, event := range events {
+		messages[i] = kafka.Message{
+			Key:   []byte(event.AggregateID),
+			Value: event.Payload,
+			Headers: []kafka.Header{
+				{Key: "event_id", Value: []byte(event.ID)},
+				{Key: "event_type", Value: []byte(event.EventType)},
+			},
+			Time: event.CreatedAt,
+		}
+	}
+
+	if err := k.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to write messages to Kafka: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the Kafka writer connection
+func (k *KafkaEventPublisher) Close() error {
+	if err := k.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close product topic writer: %w", err)
+	}
+	return nil
+}
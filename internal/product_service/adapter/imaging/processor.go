@@ -0,0 +1,128 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+)
+
+// maxPixels bounds the decoded size of an image so a small, highly compressed upload cannot exhaust memory
+const maxPixels = 40_000_000
+
+// jpegQuality is the encoder quality used for JPEG thumbnails
+const jpegQuality = 85
+
+// contentTypes maps the decoder format names to MIME types
+var contentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// Processor implements interfaces.ImageProcessor with the standard library codecs.
+// It accepts JPEG, PNG and GIF images. JPEG thumbnails stay JPEG and all others become PNG.
+type Processor struct{}
+
+// NewProcessor creates a new image processor
+func NewProcessor() *Processor {
+	return &Processor{}
+}
+
+// Inspect decodes the image header and returns its MIME type and dimensions
+func (p *Processor) Inspect(data []byte) (interfaces.ImageInfo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return interfaces.ImageInfo{}, entity.ErrInvalidMedia
+	}
+	contentType, ok := contentTypes[format]
+	if !ok || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return interfaces.ImageInfo{}, entity.ErrInvalidMedia
+	}
+	return interfaces.ImageInfo{ContentType: contentType, Width: config.Width, Height: config.Height}, nil
+}
+
+// Thumbnails decodes an image once and scales it down to each of the given widths, keeping its aspect ratio.
+// Each thumbnail pixel is the average of the source pixels it covers.
+func (p *Processor) Thumbnails(data []byte, widths []int) ([]interfaces.Thumbnail, error) {
+	info, err := p.Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, width := range widths {
+		if width <= 0 || width > info.Width {
+			return nil, errors.New("thumbnail width must be positive and not exceed the image width")
+		}
+	}
+	if len(widths) == 0 {
+		return nil, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, entity.ErrInvalidMedia
+	}
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	thumbnails := make([]interfaces.Thumbnail, len(widths))
+	for i, width := range widths {
+		height := max(1, (info.Height*width+info.Width/2)/info.Width)
+		thumbnail := resize(src, width, height)
+
+		var buf bytes.Buffer
+		result := interfaces.ImageInfo{Width: width, Height: height}
+		if info.ContentType == "image/jpeg" {
+			result.ContentType = "image/jpeg"
+			err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			result.ContentType = "image/png"
+			err = png.Encode(&buf, thumbnail)
+		}
+		if err != nil {
+			return nil, err
+		}
+		thumbnails[i] = interfaces.Thumbnail{Data: buf.Bytes(), Info: result}
+	}
+	return thumbnails, nil
+}
+
+// resize downsamples src to width x height with a box filter
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcH/height, max((y+1)*srcH/height, y*srcH/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcW/width, max((x+1)*srcW/width, x*srcW/width+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					px := row[sx*4 : sx*4+4]
+					r += int(px[0])
+					g += int(px[1])
+					b += int(px[2])
+					a += int(px[3])
+					n++
+				}
+			}
+
+			offset := y*dst.Stride + x*4
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
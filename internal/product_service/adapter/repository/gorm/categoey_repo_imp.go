@@ -8,6 +8,7 @@ import (
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GormCategoryRepository implements CategoryRepository interface using GORM
@@ -23,7 +24,7 @@ func NewGormCategoryRepository(db *gorm.DB) *GormCategoryRepository {
 // Create stores a new category
 func (r *GormCategoryRepository) Create(ctx context.Context, category entity.Category) (*entity.Category, error) {
 	categoryModel := model.NewCategoryModel(&category)
-	err := r.db.WithContext(ctx).Create(categoryModel).Error
+	err := conn(ctx, r.db).Create(categoryModel).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "name") {
 			return nil, entity.ErrCategoryAlreadyExists
@@ -36,7 +37,7 @@ func (r *GormCategoryRepository) Create(ctx context.Context, category entity.Cat
 // GetByID retrieves a category by ID
 func (r *GormCategoryRepository) GetByID(ctx context.Context, id string) (*entity.Category, error) {
 	var categoryModel model.Category
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&categoryModel).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&categoryModel).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrCategoryNotFound
@@ -49,7 +50,7 @@ func (r *GormCategoryRepository) GetByID(ctx context.Context, id string) (*entit
 // GetByName retrieves a category by name
 func (r *GormCategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
 	var categoryModel model.Category
-	err := r.db.WithContext(ctx).Where("name = ?", name).First(&categoryModel).Error
+	err := conn(ctx, r.db).Where("name = ?", name).First(&categoryModel).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrCategoryNotFound
@@ -64,7 +65,7 @@ func (r *GormCategoryRepository) List(ctx context.Context, offset, limit int) ([
 	var categoryModels []model.Category
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Category{})
+	query := conn(ctx, r.db).Model(&model.Category{})
 
 	// Count total records
 	if err := query.Count(&total).Error; err != nil {
@@ -89,7 +90,7 @@ func (r *GormCategoryRepository) List(ctx context.Context, offset, limit int) ([
 func (r *GormCategoryRepository) GetChildren(ctx context.Context, parentID string) ([]*entity.Category, error) {
 	var categoryModels []model.Category
 
-	if err := r.db.WithContext(ctx).Where("parent_id = ?", parentID).Order("name ASC").Find(&categoryModels).Error; err != nil {
+	if err := conn(ctx, r.db).Where("parent_id = ?", parentID).Order("name ASC").Find(&categoryModels).Error; err != nil {
 		return nil, err
 	}
 
@@ -102,6 +103,147 @@ func (r *GormCategoryRepository) GetChildren(ctx context.Context, parentID strin
 	return categories, nil
 }
 
+// GetSubtree retrieves a category and all of its descendants ordered by level
+func (r *GormCategoryRepository) GetSubtree(ctx context.Context, id string) ([]*entity.Category, error) {
+	root, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findSubtree(conn(ctx, r.db), root.Path)
+}
+
+// LockByID retrieves a category by ID and locks it until the transaction in ctx ends
+func (r *GormCategoryRepository) LockByID(ctx context.Context, id string) (*entity.Category, error) {
+	var categoryModel model.Category
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&categoryModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return categoryModel.ToEntity(), nil
+}
+
+// LockSubtree retrieves a category and all of its descendants ordered by level and locks them until
+// the transaction in ctx ends. The path range is locked too, so no category can be added below them.
+func (r *GormCategoryRepository) LockSubtree(ctx context.Context, id string) ([]*entity.Category, error) {
+	root, err := r.LockByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findSubtree(conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), root.Path)
+}
+
+// findSubtree retrieves the categories whose path starts with path, ordered by level
+func (r *GormCategoryRepository) findSubtree(db *gorm.DB, path string) ([]*entity.Category, error) {
+	var categoryModels []model.Category
+	if err := db.Where("path LIKE ?", path+"%").Order("level ASC, name ASC").Find(&categoryModels).Error; err != nil {
+		return nil, err
+	}
+
+	categories := make([]*entity.Category, len(categoryModels))
+	for i, categoryModel := range categoryModels {
+		categories[i] = categoryModel.ToEntity()
+	}
+	return categories, nil
+}
+
+// GetByIDs retrieves the categories with the given IDs ordered by level
+func (r *GormCategoryRepository) GetByIDs(ctx context.Context, ids []string) ([]*entity.Category, error) {
+	var categoryModels []model.Category
+	if err := conn(ctx, r.db).Where("id IN ?", ids).Order("level ASC").Find(&categoryModels).Error; err != nil {
+		return nil, err
+	}
+
+	categories := make([]*entity.Category, len(categoryModels))
+	for i, categoryModel := range categoryModels {
+		categories[i] = categoryModel.ToEntity()
+	}
+	return categories, nil
+}
+
+// MoveSubtree updates a category that changed position in the tree,
+// rewriting the path and level of all its descendants in the same transaction.
+// It joins the transaction in ctx, if any.
+func (r *GormCategoryRepository) MoveSubtree(ctx context.Context, category entity.Category, oldPath string, oldLevel int) (*entity.Category, error) {
+	existingCategory, err := r.GetByID(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryModel := model.NewCategoryModel(&category)
+	categoryModel.CreatedAt = existingCategory.CreatedAt
+
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(categoryModel).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Category{}).
+			Where("path LIKE ? AND id <> ?", oldPath+"%", category.ID).
+			Updates(map[string]interface{}{
+				"path":  gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", category.Path, len(oldPath)+1),
+				"level": gorm.Expr("level + ?", category.Level-oldLevel),
+			}).Error
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "name") {
+			return nil, entity.ErrCategoryAlreadyExists
+		}
+		return nil, err
+	}
+
+	return categoryModel.ToEntity(), nil
+}
+
+// RebuildPaths recomputes the path and level of every category from the parent links.
+// Categories whose parent is missing are treated as top-level.
+func (r *GormCategoryRepository) RebuildPaths(ctx context.Context) error {
+	var categoryModels []model.Category
+	if err := conn(ctx, r.db).Find(&categoryModels).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[string]*entity.Category, len(categoryModels))
+	children := make(map[string][]*entity.Category)
+	for _, categoryModel := range categoryModels {
+		category := categoryModel.ToEntity()
+		byID[category.ID] = category
+	}
+	var queue []*entity.Category
+	for _, category := range byID {
+		if category.ParentID != nil && byID[*category.ParentID] != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			queue = append(queue, category)
+		}
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Walk the tree top-down so every parent path is final before its children
+		for len(queue) > 0 {
+			category := queue[0]
+			queue = queue[1:]
+
+			var parent *entity.Category
+			if category.ParentID != nil {
+				parent = byID[*category.ParentID]
+			}
+			path, level := category.Path, category.Level
+			category.SetParent(parent)
+			if category.Path != path || category.Level != level {
+				err := tx.Model(&model.Category{}).Where("id = ?", category.ID).
+					Updates(map[string]interface{}{"parent_id": category.ParentID, "path": category.Path, "level": category.Level}).Error
+				if err != nil {
+					return err
+				}
+			}
+			queue = append(queue, children[category.ID]...)
+		}
+		return nil
+	})
+}
+
 // Update updates an existing category
 func (r *GormCategoryRepository) Update(ctx context.Context, category entity.Category) (*entity.Category, error) {
 	// Check if category exists
@@ -117,7 +259,7 @@ func (r *GormCategoryRepository) Update(ctx context.Context, category entity.Cat
 	categoryModel.CreatedAt = existingCategory.CreatedAt
 
 	// Update category
-	err = r.db.WithContext(ctx).Save(categoryModel).Error
+	err = conn(ctx, r.db).Save(categoryModel).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "name") {
 			return nil, entity.ErrCategoryAlreadyExists
@@ -130,7 +272,7 @@ func (r *GormCategoryRepository) Update(ctx context.Context, category entity.Cat
 
 // Delete removes a category by ID (soft delete)
 func (r *GormCategoryRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&model.Category{}, "id = ?", id)
+	result := conn(ctx, r.db).Delete(&model.Category{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"gorm.io/gorm"
+)
+
+// importErrorBatchSize is the number of rejected rows inserted per statement
+const importErrorBatchSize = 500
+
+// GormImportJobRepository implements ImportJobRepository interface using GORM
+type GormImportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewGormImportJobRepository creates a new instance of GormImportJobRepository
+func NewGormImportJobRepository(db *gorm.DB) *GormImportJobRepository {
+	return &GormImportJobRepository{db: db}
+}
+
+// Create stores a new import job
+func (r *GormImportJobRepository) Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	jobModel := model.NewImportJobModel(&job)
+	if err := r.db.WithContext(ctx).Create(jobModel).Error; err != nil {
+		return nil, err
+	}
+	return jobModel.ToEntity(), nil
+}
+
+// GetByID retrieves an import job by ID
+func (r *GormImportJobRepository) GetByID(ctx context.Context, id string) (*entity.ImportJob, error) {
+	var jobModel model.ImportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&jobModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return jobModel.ToEntity(), nil
+}
+
+// Update stores the progress of an import job
+func (r *GormImportJobRepository) Update(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	jobModel := model.NewImportJobModel(&job)
+	result := r.db.WithContext(ctx).Model(&model.ImportJob{}).Where("id = ?", job.ID).
+		Select("status", "total_rows", "processed_rows", "created_count", "updated_count", "failed_count", "error", "started_at", "finished_at").
+		Updates(jobModel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, entity.ErrImportJobNotFound
+	}
+	return r.GetByID(ctx, job.ID)
+}
+
+// AddErrors stores rejected rows of an import job
+func (r *GormImportJobRepository) AddErrors(ctx context.Context, rowErrors []entity.ImportRowError) error {
+	if len(rowErrors) == 0 {
+		return nil
+	}
+	errorModels := make([]*model.ImportRowError, len(rowErrors))
+	for i := range rowErrors {
+		errorModels[i] = model.NewImportRowErrorModel(&rowErrors[i])
+	}
+	return r.db.WithContext(ctx).CreateInBatches(errorModels, importErrorBatchSize).Error
+}
+
+// ListErrors retrieves the rejected rows of an import job ordered by line
+func (r *GormImportJobRepository) ListErrors(ctx context.Context, jobID string) ([]entity.ImportRowError, error) {
+	var errorModels []model.ImportRowError
+	if err := r.db.WithContext(ctx).Where("job_id = ?", jobID).Order("line, id").Find(&errorModels).Error; err != nil {
+		return nil, err
+	}
+
+	rowErrors := make([]entity.ImportRowError, len(errorModels))
+	for i := range errorModels {
+		rowErrors[i] = errorModels[i].ToEntity()
+	}
+	return rowErrors, nil
+}
+
+// FailUnfinished marks every pending or running job of the owner as failed with the given message
+func (r *GormImportJobRepository) FailUnfinished(ctx context.Context, owner, message string) (int, error) {
+	result := r.db.WithContext(ctx).Model(&model.ImportJob{}).
+		Where("owner = ? AND status IN ?", owner, []string{string(entity.ImportPending), string(entity.ImportRunning)}).
+		Updates(map[string]interface{}{
+			"status":      string(entity.ImportFailed),
+			"error":       message,
+			"finished_at": time.Now(),
+		})
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"gorm.io/gorm"
+)
+
+// GormMediaRepository implements MediaRepository interface using GORM
+type GormMediaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMediaRepository creates a new instance of GormMediaRepository
+func NewGormMediaRepository(db *gorm.DB) *GormMediaRepository {
+	return &GormMediaRepository{db: db}
+}
+
+// Create stores a new media record
+func (r *GormMediaRepository) Create(ctx context.Context, media entity.ProductMedia) (*entity.ProductMedia, error) {
+	mediaModel := model.NewProductMediaModel(&media)
+	if err := r.db.WithContext(ctx).Create(mediaModel).Error; err != nil {
+		return nil, err
+	}
+	return mediaModel.ToEntity(), nil
+}
+
+// GetByID retrieves a media record by ID
+func (r *GormMediaRepository) GetByID(ctx context.Context, id string) (*entity.ProductMedia, error) {
+	var mediaModel model.ProductMedia
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&mediaModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return mediaModel.ToEntity(), nil
+}
+
+// ListByProductID retrieves the media of a product ordered by position
+func (r *GormMediaRepository) ListByProductID(ctx context.Context, productID string) ([]entity.ProductMedia, error) {
+	var mediaModels []model.ProductMedia
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).Order("position, created_at").Find(&mediaModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	media := make([]entity.ProductMedia, len(mediaModels))
+	for i := range mediaModels {
+		media[i] = *mediaModels[i].ToEntity()
+	}
+	return media, nil
+}
+
+// Update updates an existing media record
+func (r *GormMediaRepository) Update(ctx context.Context, media entity.ProductMedia) (*entity.ProductMedia, error) {
+	// Check if media exists
+	existingMedia, err := r.GetByID(ctx, media.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaModel := model.NewProductMediaModel(&media)
+
+	// Preserve created_at timestamp
+	mediaModel.CreatedAt = existingMedia.CreatedAt
+
+	if err := r.db.WithContext(ctx).Save(mediaModel).Error; err != nil {
+		return nil, err
+	}
+	return mediaModel.ToEntity(), nil
+}
+
+// SetPositions stores the position of each media record, keyed by media ID, in one transaction
+func (r *GormMediaRepository) SetPositions(ctx context.Context, productID string, positions map[string]int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, position := range positions {
+			err := tx.Model(&model.ProductMedia{}).
+				Where("id = ? AND product_id = ?", id, productID).
+				Update("position", position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a media record by ID
+func (r *GormMediaRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&model.ProductMedia{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrMediaNotFound
+	}
+	return nil
+}
+
+// DeleteByProductID removes all media records of a product
+func (r *GormMediaRepository) DeleteByProductID(ctx context.Context, productID string) error {
+	return r.db.WithContext(ctx).Delete(&model.ProductMedia{}, "product_id = ?", productID).Error
+}
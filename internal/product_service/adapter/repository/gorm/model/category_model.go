@@ -14,6 +14,7 @@ type Category struct {
 	Description string          `gorm:"type:text" json:"description"`
 	ParentID    *string         `gorm:"index;type:char(36)" json:"parent_id"`
 	Level       int             `gorm:"not null;default:1" json:"level"`
+	Path        string          `gorm:"index;size:760;not null;default:''" json:"path"`
 	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   *gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -31,6 +32,7 @@ func (c *Category) ToEntity() *entity.Category {
 		Description: c.Description,
 		ParentID:    c.ParentID,
 		Level:       c.Level,
+		Path:        c.Path,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 		DeletedAt:   utils.DeletedAtPtrToTimePtr(c.DeletedAt),
@@ -45,6 +47,7 @@ func NewCategoryModel(category *entity.Category) *Category {
 		Description: category.Description,
 		ParentID:    category.ParentID,
 		Level:       category.Level,
+		Path:        category.Path,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 		DeletedAt:   utils.TimePtrToDeletedAt(category.DeletedAt),
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type ImportJob struct {
+	ID            string     `gorm:"primaryKey;type:char(36)" json:"id"`
+	Format        string     `gorm:"size:10;not null" json:"format"`
+	Owner         string     `gorm:"index;size:100;not null" json:"owner"`
+	Status        string     `gorm:"index;size:20;not null" json:"status"`
+	TotalRows     int        `gorm:"not null;default:0" json:"total_rows"`
+	ProcessedRows int        `gorm:"not null;default:0" json:"processed_rows"`
+	CreatedCount  int        `gorm:"not null;default:0" json:"created_count"`
+	UpdatedCount  int        `gorm:"not null;default:0" json:"updated_count"`
+	FailedCount   int        `gorm:"not null;default:0" json:"failed_count"`
+	Error         string     `gorm:"type:text" json:"error"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+}
+
+func (j *ImportJob) TableName() string {
+	return "product_import_jobs"
+}
+
+// ToEntity converts the GORM ImportJob model to the domain entity ImportJob
+func (j *ImportJob) ToEntity() *entity.ImportJob {
+	return &entity.ImportJob{
+		ID:            j.ID,
+		Format:        entity.FileFormat(j.Format),
+		Owner:         j.Owner,
+		Status:        entity.ImportStatus(j.Status),
+		TotalRows:     j.TotalRows,
+		ProcessedRows: j.ProcessedRows,
+		CreatedCount:  j.CreatedCount,
+		UpdatedCount:  j.UpdatedCount,
+		FailedCount:   j.FailedCount,
+		Error:         j.Error,
+		CreatedAt:     j.CreatedAt,
+		StartedAt:     j.StartedAt,
+		FinishedAt:    j.FinishedAt,
+	}
+}
+
+// NewImportJobModel creates a new GORM ImportJob model from a domain entity ImportJob
+func NewImportJobModel(job *entity.ImportJob) *ImportJob {
+	return &ImportJob{
+		ID:            job.ID,
+		Format:        string(job.Format),
+		Owner:         job.Owner,
+		Status:        string(job.Status),
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		UpdatedCount:  job.UpdatedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
+
+type ImportRowError struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	JobID   string `gorm:"index:idx_import_errors_job_line,priority:1;type:char(36);not null" json:"job_id"`
+	Line    int    `gorm:"index:idx_import_errors_job_line,priority:2;not null" json:"line"`
+	SKU     string `gorm:"size:100" json:"sku"`
+	Message string `gorm:"type:text" json:"message"`
+}
+
+func (e *ImportRowError) TableName() string {
+	return "product_import_errors"
+}
+
+// ToEntity converts the GORM ImportRowError model to the domain entity ImportRowError
+func (e *ImportRowError) ToEntity() entity.ImportRowError {
+	return entity.ImportRowError{
+		JobID:   e.JobID,
+		Line:    e.Line,
+		SKU:     e.SKU,
+		Message: e.Message,
+	}
+}
+
+// NewImportRowErrorModel creates a new GORM ImportRowError model from a domain entity ImportRowError
+func NewImportRowErrorModel(rowError *entity.ImportRowError) *ImportRowError {
+	return &ImportRowError{
+		JobID:   rowError.JobID,
+		Line:    rowError.Line,
+		SKU:     rowError.SKU,
+		Message: rowError.Message,
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
-	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
 	"gorm.io/gorm"
 )
 
+// Inventory is a stock record kept before stock moved to the inventory service. Records are deleted as
+// they are migrated there.
 type Inventory struct {
 	ID        string          `gorm:"primaryKey;type:char(36)" json:"id"`
-	ProductID string          `gorm:"uniqueIndex;type:char(36);not null" json:"product_id"`
+	ProductID string          `gorm:"index:idx_inventories_product;type:char(36);not null" json:"product_id"`
+	VariantID *string         `gorm:"uniqueIndex;type:char(36)" json:"variant_id"`
+	SKU       string          `gorm:"index;size:100" json:"sku"`
 	Quantity  int             `gorm:"not null;default:0" json:"quantity"`
 	Reserved  int             `gorm:"not null;default:0" json:"reserved"`
 	CreatedAt time.Time       `gorm:"autoCreateTime" json:"created_at"`
@@ -22,28 +25,14 @@ func (i *Inventory) TableName() string {
 	return "inventories"
 }
 
-// ToEntity converts the GORM Inventory model to the domain entity Inventory
-func (i *Inventory) ToEntity() *entity.Inventory {
-	return &entity.Inventory{
+// ToEntity converts the GORM Inventory model to the domain entity LegacyInventory
+func (i *Inventory) ToEntity() *entity.LegacyInventory {
+	return &entity.LegacyInventory{
 		ID:        i.ID,
 		ProductID: i.ProductID,
+		VariantID: i.VariantID,
+		SKU:       i.SKU,
 		Quantity:  i.Quantity,
 		Reserved:  i.Reserved,
-		CreatedAt: i.CreatedAt,
-		UpdatedAt: i.UpdatedAt,
-		DeletedAt: utils.DeletedAtPtrToTimePtr(i.DeletedAt),
-	}
-}
-
-// NewInventoryModel creates a new GORM Inventory model from a domain entity Inventory
-func NewInventoryModel(inventory *entity.Inventory) *Inventory {
-	return &Inventory{
-		ID:        inventory.ID,
-		ProductID: inventory.ProductID,
-		Quantity:  inventory.Quantity,
-		Reserved:  inventory.Reserved,
-		CreatedAt: inventory.CreatedAt,
-		UpdatedAt: inventory.UpdatedAt,
-		DeletedAt: utils.TimePtrToDeletedAt(inventory.DeletedAt),
 	}
 }
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type ProductMedia struct {
+	ID          string                  `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID   string                  `gorm:"index:idx_product_media_position,priority:1;type:char(36);not null" json:"product_id"`
+	URL         string                  `gorm:"size:512;not null" json:"url"`
+	StorageKey  string                  `gorm:"size:255;not null" json:"storage_key"`
+	AltText     string                  `gorm:"size:255" json:"alt_text"`
+	Position    int                     `gorm:"index:idx_product_media_position,priority:2;not null;default:0" json:"position"`
+	ContentType string                  `gorm:"size:50;not null" json:"content_type"`
+	Width       int                     `json:"width"`
+	Height      int                     `json:"height"`
+	Size        int64                   `json:"size"`
+	Thumbnails  []entity.MediaThumbnail `gorm:"type:text;serializer:json" json:"thumbnails"`
+	CreatedAt   time.Time               `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time               `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (m *ProductMedia) TableName() string {
+	return "product_media"
+}
+
+// ToEntity converts the GORM ProductMedia model to the domain entity ProductMedia
+func (m *ProductMedia) ToEntity() *entity.ProductMedia {
+	return &entity.ProductMedia{
+		ID:          m.ID,
+		ProductID:   m.ProductID,
+		URL:         m.URL,
+		StorageKey:  m.StorageKey,
+		AltText:     m.AltText,
+		Position:    m.Position,
+		ContentType: m.ContentType,
+		Width:       m.Width,
+		Height:      m.Height,
+		Size:        m.Size,
+		Thumbnails:  m.Thumbnails,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// NewProductMediaModel creates a new GORM ProductMedia model from a domain entity ProductMedia
+func NewProductMediaModel(media *entity.ProductMedia) *ProductMedia {
+	return &ProductMedia{
+		ID:          media.ID,
+		ProductID:   media.ProductID,
+		URL:         media.URL,
+		StorageKey:  media.StorageKey,
+		AltText:     media.AltText,
+		Position:    media.Position,
+		ContentType: media.ContentType,
+		Width:       media.Width,
+		Height:      media.Height,
+		Size:        media.Size,
+		Thumbnails:  media.Thumbnails,
+		CreatedAt:   media.CreatedAt,
+		UpdatedAt:   media.UpdatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type OutboxEvent struct {
+	Sequence    int64      `gorm:"primaryKey;autoIncrement" json:"sequence"`
+	ID          string     `gorm:"uniqueIndex;type:char(36);not null" json:"id"`
+	AggregateID string     `gorm:"type:char(36);not null" json:"aggregate_id"`
+	EventType   string     `gorm:"size:50;not null" json:"event_type"`
+	Payload     []byte     `gorm:"type:blob;not null" json:"payload"`
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	LastError   string     `gorm:"type:text" json:"last_error"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	PublishedAt *time.Time `gorm:"index" json:"published_at"`
+}
+
+func (e *OutboxEvent) TableName() string {
+	return "product_outbox_events"
+}
+
+// ToEntity converts the GORM OutboxEvent model to the domain entity OutboxEvent
+func (e *OutboxEvent) ToEntity() entity.OutboxEvent {
+	return entity.OutboxEvent{
+		ID:          e.ID,
+		Sequence:    e.Sequence,
+		AggregateID: e.AggregateID,
+		EventType:   e.EventType,
+		Payload:     e.Payload,
+		Attempts:    e.Attempts,
+		LastError:   e.LastError,
+		CreatedAt:   e.CreatedAt,
+		PublishedAt: e.PublishedAt,
+	}
+}
+
+// NewOutboxEventModel creates a new GORM OutboxEvent model from a domain entity OutboxEvent
+func NewOutboxEventModel(event *entity.OutboxEvent) *OutboxEvent {
+	return &OutboxEvent{
+		Sequence:    event.Sequence,
+		ID:          event.ID,
+		AggregateID: event.AggregateID,
+		EventType:   event.EventType,
+		Payload:     event.Payload,
+		Attempts:    event.Attempts,
+		LastError:   event.LastError,
+		CreatedAt:   event.CreatedAt,
+		PublishedAt: event.PublishedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type PriceChange struct {
+	ID        string    `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID string    `gorm:"index:idx_price_change_product,priority:1;type:char(36);not null" json:"product_id"`
+	OldPrice  float64   `gorm:"not null" json:"old_price"`
+	NewPrice  float64   `gorm:"not null" json:"new_price"`
+	Source    string    `gorm:"size:20;not null" json:"source"`
+	ChangedAt time.Time `gorm:"index:idx_price_change_product,priority:2;not null" json:"changed_at"`
+}
+
+func (p *PriceChange) TableName() string {
+	return "product_price_changes"
+}
+
+// ToEntity converts the GORM PriceChange model to the domain entity PriceChange
+func (p *PriceChange) ToEntity() entity.PriceChange {
+	return entity.PriceChange{
+		ID:        p.ID,
+		ProductID: p.ProductID,
+		OldPrice:  p.OldPrice,
+		NewPrice:  p.NewPrice,
+		Source:    entity.PriceSource(p.Source),
+		ChangedAt: p.ChangedAt,
+	}
+}
+
+// NewPriceChangeModel creates a new GORM PriceChange model from a domain entity PriceChange
+func NewPriceChangeModel(change *entity.PriceChange) *PriceChange {
+	return &PriceChange{
+		ID:        change.ID,
+		ProductID: change.ProductID,
+		OldPrice:  change.OldPrice,
+		NewPrice:  change.NewPrice,
+		Source:    string(change.Source),
+		ChangedAt: change.ChangedAt,
+	}
+}
+
+type ScheduledPrice struct {
+	ID          string     `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID   string     `gorm:"index;type:char(36);not null" json:"product_id"`
+	Price       float64    `gorm:"not null" json:"price"`
+	EffectiveAt time.Time  `gorm:"index:idx_scheduled_price_due,priority:2;not null" json:"effective_at"`
+	AppliedAt   *time.Time `gorm:"index:idx_scheduled_price_due,priority:1" json:"applied_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (s *ScheduledPrice) TableName() string {
+	return "product_scheduled_prices"
+}
+
+// ToEntity converts the GORM ScheduledPrice model to the domain entity ScheduledPrice
+func (s *ScheduledPrice) ToEntity() *entity.ScheduledPrice {
+	return &entity.ScheduledPrice{
+		ID:          s.ID,
+		ProductID:   s.ProductID,
+		Price:       s.Price,
+		EffectiveAt: s.EffectiveAt,
+		AppliedAt:   s.AppliedAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+// NewScheduledPriceModel creates a new GORM ScheduledPrice model from a domain entity ScheduledPrice
+func NewScheduledPriceModel(scheduled *entity.ScheduledPrice) *ScheduledPrice {
+	return &ScheduledPrice{
+		ID:          scheduled.ID,
+		ProductID:   scheduled.ProductID,
+		Price:       scheduled.Price,
+		EffectiveAt: scheduled.EffectiveAt,
+		AppliedAt:   scheduled.AppliedAt,
+		CreatedAt:   scheduled.CreatedAt,
+	}
+}
+
+type Sale struct {
+	ID        string     `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID string     `gorm:"index:idx_sale_product,priority:1;type:char(36);not null" json:"product_id"`
+	Price     float64    `gorm:"not null" json:"price"`
+	StartsAt  time.Time  `gorm:"index:idx_sale_product,priority:2;not null" json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (s *Sale) TableName() string {
+	return "product_sales"
+}
+
+// ToEntity converts the GORM Sale model to the domain entity Sale
+func (s *Sale) ToEntity() *entity.Sale {
+	return &entity.Sale{
+		ID:        s.ID,
+		ProductID: s.ProductID,
+		Price:     s.Price,
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// NewSaleModel creates a new GORM Sale model from a domain entity Sale
+func NewSaleModel(sale *entity.Sale) *Sale {
+	return &Sale{
+		ID:        sale.ID,
+		ProductID: sale.ProductID,
+		Price:     sale.Price,
+		StartsAt:  sale.StartsAt,
+		EndsAt:    sale.EndsAt,
+		CreatedAt: sale.CreatedAt,
+	}
+}
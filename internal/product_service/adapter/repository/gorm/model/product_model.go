@@ -16,7 +16,9 @@ type Product struct {
 	CategoryID  string          `gorm:"index;type:char(36)" json:"category_id"`
 	ImageURL    string          `gorm:"size:255" json:"image_url"`
 	SKU         string          `gorm:"uniqueIndex;size:100;not null" json:"sku"`
-	Status      string          `gorm:"size:20;not null" json:"status"`
+	Status      string          `gorm:"size:20;not null;index" json:"status"`
+	PublishAt   *time.Time      `gorm:"index" json:"publish_at"`
+	UnpublishAt *time.Time      `gorm:"index" json:"unpublish_at"`
 	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   *gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -37,6 +39,8 @@ func (p *Product) ToEntity() *entity.Product {
 		ImageURL:    p.ImageURL,
 		SKU:         p.SKU,
 		Status:      p.Status,
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
 		DeletedAt:   utils.DeletedAtPtrToTimePtr(p.DeletedAt),
@@ -54,6 +58,8 @@ func NewProductModel(product *entity.Product) *Product {
 		ImageURL:    product.ImageURL,
 		SKU:         product.SKU,
 		Status:      product.Status,
+		PublishAt:   product.PublishAt,
+		UnpublishAt: product.UnpublishAt,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 		DeletedAt:   utils.TimePtrToDeletedAt(product.DeletedAt),
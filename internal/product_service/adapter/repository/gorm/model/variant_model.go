@@ -0,0 +1,89 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type ProductOption struct {
+	ID        string   `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID string   `gorm:"index;type:char(36);not null" json:"product_id"`
+	Name      string   `gorm:"size:100;not null" json:"name"`
+	Values    []string `gorm:"type:text;serializer:json" json:"values"`
+	Position  int      `gorm:"not null;default:0" json:"position"`
+}
+
+func (o *ProductOption) TableName() string {
+	return "product_options"
+}
+
+// ToEntity converts the GORM ProductOption model to the domain entity ProductOption
+func (o *ProductOption) ToEntity() entity.ProductOption {
+	return entity.ProductOption{
+		ID:        o.ID,
+		ProductID: o.ProductID,
+		Name:      o.Name,
+		Values:    o.Values,
+		Position:  o.Position,
+	}
+}
+
+// NewProductOptionModel creates a new GORM ProductOption model from a domain entity ProductOption
+func NewProductOptionModel(option *entity.ProductOption) *ProductOption {
+	return &ProductOption{
+		ID:        option.ID,
+		ProductID: option.ProductID,
+		Name:      option.Name,
+		Values:    option.Values,
+		Position:  option.Position,
+	}
+}
+
+type ProductVariant struct {
+	ID        string            `gorm:"primaryKey;type:char(36)" json:"id"`
+	ProductID string            `gorm:"index;type:char(36);not null" json:"product_id"`
+	SKU       string            `gorm:"uniqueIndex;size:100;not null" json:"sku"`
+	Options   map[string]string `gorm:"type:text;serializer:json" json:"options"`
+	Price     *float64          `json:"price"`
+	ImageURL  string            `gorm:"size:255" json:"image_url"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt *gorm.DeletedAt   `gorm:"index" json:"deleted_at"`
+}
+
+func (v *ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// ToEntity converts the GORM ProductVariant model to the domain entity ProductVariant
+func (v *ProductVariant) ToEntity() *entity.ProductVariant {
+	return &entity.ProductVariant{
+		ID:        v.ID,
+		ProductID: v.ProductID,
+		SKU:       v.SKU,
+		Options:   v.Options,
+		Price:     v.Price,
+		ImageURL:  v.ImageURL,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+		DeletedAt: utils.DeletedAtPtrToTimePtr(v.DeletedAt),
+	}
+}
+
+// NewProductVariantModel creates a new GORM ProductVariant model from a domain entity ProductVariant
+func NewProductVariantModel(variant *entity.ProductVariant) *ProductVariant {
+	return &ProductVariant{
+		ID:        variant.ID,
+		ProductID: variant.ProductID,
+		SKU:       variant.SKU,
+		Options:   variant.Options,
+		Price:     variant.Price,
+		ImageURL:  variant.ImageURL,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+		DeletedAt: utils.TimePtrToDeletedAt(variant.DeletedAt),
+	}
+}
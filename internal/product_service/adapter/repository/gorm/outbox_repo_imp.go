@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormOutboxRepository implements OutboxRepository interface using GORM
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOutboxRepository creates a new instance of GormOutboxRepository
+func NewGormOutboxRepository(db *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+// Add stores events; call it in the transaction of the change they describe
+func (r *GormOutboxRepository) Add(ctx context.Context, events []entity.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	eventModels := make([]*model.OutboxEvent, len(events))
+	for i := range events {
+		eventModels[i] = model.NewOutboxEventModel(&events[i])
+	}
+	return conn(ctx, r.db).Create(eventModels).Error
+}
+
+// ListPending retrieves unpublished events in the order they were added and locks them until
+// the transaction in ctx ends. Events locked by another relay are skipped; while an earlier
+// event is locked, nothing is returned so that events are still published in order.
+func (r *GormOutboxRepository) ListPending(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	// A plain read is not blocked by locks, so it sees the oldest pending event even if it is claimed
+	var oldest []model.OutboxEvent
+	err := conn(ctx, r.db).Select("sequence").Where("published_at IS NULL").Order("sequence").Limit(1).Find(&oldest).Error
+	if err != nil || len(oldest) == 0 {
+		return nil, err
+	}
+
+	var eventModels []model.OutboxEvent
+	err = conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("published_at IS NULL").
+		Order("sequence").
+		Limit(limit).
+		Find(&eventModels).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(eventModels) == 0 || eventModels[0].Sequence != oldest[0].Sequence {
+		return nil, nil
+	}
+
+	events := make([]entity.OutboxEvent, len(eventModels))
+	for i := range eventModels {
+		events[i] = eventModels[i].ToEntity()
+	}
+	return events, nil
+}
+
+// MarkPublished records that events were published
+func (r *GormOutboxRepository) MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Model(&model.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("published_at", publishedAt).Error
+}
+
+// MarkFailed records a failed attempt to publish events
+func (r *GormOutboxRepository) MarkFailed(ctx context.Context, ids []string, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Model(&model.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": reason,
+		}).Error
+}
+
+// DeletePublishedBefore removes events published before the given time
+func (r *GormOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int, error) {
+	result := conn(ctx, r.db).Where("published_at < ?", before).Delete(&model.OutboxEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
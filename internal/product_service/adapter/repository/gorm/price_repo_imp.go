@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"gorm.io/gorm"
+)
+
+// GormPriceRepository implements PriceRepository interface using GORM
+type GormPriceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPriceRepository creates a new instance of GormPriceRepository
+func NewGormPriceRepository(db *gorm.DB) *GormPriceRepository {
+	return &GormPriceRepository{db: db}
+}
+
+// AddChange records a change in the price history
+func (r *GormPriceRepository) AddChange(ctx context.Context, change entity.PriceChange) error {
+	return conn(ctx, r.db).Create(model.NewPriceChangeModel(&change)).Error
+}
+
+// ListChanges retrieves the price history of a product, newest first
+func (r *GormPriceRepository) ListChanges(ctx context.Context, productID string, offset, limit int) ([]entity.PriceChange, int, error) {
+	var changeModels []model.PriceChange
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.PriceChange{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("changed_at DESC").Offset(offset).Limit(limit).Find(&changeModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	changes := make([]entity.PriceChange, len(changeModels))
+	for i := range changeModels {
+		changes[i] = changeModels[i].ToEntity()
+	}
+	return changes, int(total), nil
+}
+
+// GetChangeAt retrieves the latest change at or before at, falling back to the earliest change.
+// It returns nil when the product has no price history.
+func (r *GormPriceRepository) GetChangeAt(ctx context.Context, productID string, at time.Time) (*entity.PriceChange, error) {
+	var changeModel model.PriceChange
+	err := conn(ctx, r.db).
+		Where("product_id = ? AND changed_at <= ?", productID, at).
+		Order("changed_at DESC").
+		First(&changeModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = conn(ctx, r.db).
+			Where("product_id = ?", productID).
+			Order("changed_at").
+			First(&changeModel).Error
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	change := changeModel.ToEntity()
+	return &change, nil
+}
+
+// BackfillHistory records the current price of products that have no price history,
+// dated at their creation so prices created before the price book existed can be looked up
+func (r *GormPriceRepository) BackfillHistory(ctx context.Context) (int, error) {
+	result := conn(ctx, r.db).Exec(`
+		INSERT INTO product_price_changes (id, product_id, old_price, new_price, source, changed_at)
+		SELECT UUID(), p.id, 0, p.price, ?, p.created_at
+		FROM products p
+		WHERE p.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM product_price_changes c WHERE c.product_id = p.id)`,
+		string(entity.PriceSourceManual))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// CreateScheduled stores a scheduled price change
+func (r *GormPriceRepository) CreateScheduled(ctx context.Context, scheduled entity.ScheduledPrice) (*entity.ScheduledPrice, error) {
+	scheduledModel := model.NewScheduledPriceModel(&scheduled)
+	if err := conn(ctx, r.db).Create(scheduledModel).Error; err != nil {
+		return nil, err
+	}
+	return scheduledModel.ToEntity(), nil
+}
+
+// GetScheduled retrieves a scheduled price change by ID
+func (r *GormPriceRepository) GetScheduled(ctx context.Context, id string) (*entity.ScheduledPrice, error) {
+	var scheduledModel model.ScheduledPrice
+	err := conn(ctx, r.db).Where("id = ?", id).First(&scheduledModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrScheduledPriceNotFound
+		}
+		return nil, err
+	}
+	return scheduledModel.ToEntity(), nil
+}
+
+// ListScheduled retrieves the scheduled price changes of a product in effective order
+func (r *GormPriceRepository) ListScheduled(ctx context.Context, productID string) ([]entity.ScheduledPrice, error) {
+	var scheduledModels []model.ScheduledPrice
+	err := conn(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("effective_at").
+		Find(&scheduledModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make([]entity.ScheduledPrice, len(scheduledModels))
+	for i := range scheduledModels {
+		scheduled[i] = *scheduledModels[i].ToEntity()
+	}
+	return scheduled, nil
+}
+
+// GetPendingAt retrieves the latest pending change of a product effective at or before at.
+// It returns nil when there is none.
+func (r *GormPriceRepository) GetPendingAt(ctx context.Context, productID string, at time.Time) (*entity.ScheduledPrice, error) {
+	var scheduledModel model.ScheduledPrice
+	err := conn(ctx, r.db).
+		Where("product_id = ? AND applied_at IS NULL AND effective_at <= ?", productID, at).
+		Order("effective_at DESC").
+		First(&scheduledModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return scheduledModel.ToEntity(), nil
+}
+
+// ListDueScheduled retrieves pending changes effective at or before now, oldest first
+func (r *GormPriceRepository) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledPrice, error) {
+	var scheduledModels []model.ScheduledPrice
+	err := conn(ctx, r.db).
+		Where("applied_at IS NULL AND effective_at <= ?", now).
+		Order("effective_at, id").
+		Limit(limit).
+		Find(&scheduledModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make([]entity.ScheduledPrice, len(scheduledModels))
+	for i := range scheduledModels {
+		scheduled[i] = *scheduledModels[i].ToEntity()
+	}
+	return scheduled, nil
+}
+
+// MarkScheduledApplied records that a scheduled change was applied.
+// It reports false when the change was already applied.
+func (r *GormPriceRepository) MarkScheduledApplied(ctx context.Context, id string, appliedAt time.Time) (bool, error) {
+	result := conn(ctx, r.db).Model(&model.ScheduledPrice{}).
+		Where("id = ? AND applied_at IS NULL", id).
+		Update("applied_at", appliedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteScheduled removes a scheduled price change
+func (r *GormPriceRepository) DeleteScheduled(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&model.ScheduledPrice{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrScheduledPriceNotFound
+	}
+	return nil
+}
+
+// CreateSale stores a sale
+func (r *GormPriceRepository) CreateSale(ctx context.Context, sale entity.Sale) (*entity.Sale, error) {
+	saleModel := model.NewSaleModel(&sale)
+	if err := conn(ctx, r.db).Create(saleModel).Error; err != nil {
+		return nil, err
+	}
+	return saleModel.ToEntity(), nil
+}
+
+// GetSale retrieves a sale by ID
+func (r *GormPriceRepository) GetSale(ctx context.Context, id string) (*entity.Sale, error) {
+	var saleModel model.Sale
+	err := conn(ctx, r.db).Where("id = ?", id).First(&saleModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrSaleNotFound
+		}
+		return nil, err
+	}
+	return saleModel.ToEntity(), nil
+}
+
+// ListSales retrieves the sales of a product by start time
+func (r *GormPriceRepository) ListSales(ctx context.Context, productID string) ([]entity.Sale, error) {
+	var saleModels []model.Sale
+	err := conn(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("starts_at").
+		Find(&saleModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sales := make([]entity.Sale, len(saleModels))
+	for i := range saleModels {
+		sales[i] = *saleModels[i].ToEntity()
+	}
+	return sales, nil
+}
+
+// GetSaleAt retrieves the sale of a product running at at.
+// It returns nil when there is none.
+func (r *GormPriceRepository) GetSaleAt(ctx context.Context, productID string, at time.Time) (*entity.Sale, error) {
+	var saleModel model.Sale
+	err := conn(ctx, r.db).
+		Where("product_id = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", productID, at, at).
+		Order("starts_at DESC").
+		First(&saleModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return saleModel.ToEntity(), nil
+}
+
+// DeleteSale removes a sale
+func (r *GormPriceRepository) DeleteSale(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&model.Sale{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrSaleNotFound
+	}
+	return nil
+}
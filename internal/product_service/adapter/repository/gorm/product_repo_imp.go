@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/valueobject"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GormProductRepository implements ProductRepository interface using GORM
@@ -24,7 +27,7 @@ func NewGormProductRepository(db *gorm.DB) *GormProductRepository {
 // Create stores a new product
 func (r *GormProductRepository) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	productModel := model.NewProductModel(&product)
-	err := r.db.WithContext(ctx).Create(productModel).Error
+	err := conn(ctx, r.db).Create(productModel).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "sku") {
 			return nil, entity.ErrProductSKUExists
@@ -37,7 +40,20 @@ func (r *GormProductRepository) Create(ctx context.Context, product entity.Produ
 // GetByID retrieves a product by ID
 func (r *GormProductRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
 	var productModel model.Product
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&productModel).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&productModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, err
+	}
+	return productModel.ToEntity(), nil
+}
+
+// LockByID retrieves a product by ID and locks it until the transaction in ctx ends
+func (r *GormProductRepository) LockByID(ctx context.Context, id string) (*entity.Product, error) {
+	var productModel model.Product
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&productModel).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrProductNotFound
@@ -50,7 +66,7 @@ func (r *GormProductRepository) GetByID(ctx context.Context, id string) (*entity
 // GetBySKU retrieves a product by SKU
 func (r *GormProductRepository) GetBySKU(ctx context.Context, sku string) (*entity.Product, error) {
 	var productModel model.Product
-	err := r.db.WithContext(ctx).Where("sku = ?", sku).First(&productModel).Error
+	err := conn(ctx, r.db).Where("sku = ?", sku).First(&productModel).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, entity.ErrProductNotFound
@@ -60,12 +76,30 @@ func (r *GormProductRepository) GetBySKU(ctx context.Context, sku string) (*enti
 	return productModel.ToEntity(), nil
 }
 
+// GetBySKUs retrieves the products with any of the given SKUs
+func (r *GormProductRepository) GetBySKUs(ctx context.Context, skus []string) ([]*entity.Product, error) {
+	if len(skus) == 0 {
+		return nil, nil
+	}
+
+	var productModels []model.Product
+	if err := conn(ctx, r.db).Where("sku IN ?", skus).Find(&productModels).Error; err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, len(productModels))
+	for i, productModel := range productModels {
+		products[i] = productModel.ToEntity()
+	}
+	return products, nil
+}
+
 // List retrieves products with optional filtering
 func (r *GormProductRepository) List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*entity.Product, int, error) {
 	var productModels []model.Product
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Product{})
+	query := conn(ctx, r.db).Model(&model.Product{})
 
 	// Apply filters if any
 	if len(filters) > 0 {
@@ -89,8 +123,8 @@ func (r *GormProductRepository) List(ctx context.Context, offset, limit int, fil
 		return nil, 0, err
 	}
 
-	// Get paginated results
-	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&productModels).Error; err != nil {
+	// Get paginated results; the ID breaks ties so pages don't overlap
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC, id").Find(&productModels).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -103,6 +137,93 @@ func (r *GormProductRepository) List(ctx context.Context, offset, limit int, fil
 	return products, int(total), nil
 }
 
+// UpsertBySKU creates or updates products matched by SKU in a single transaction.
+// Existing products keep their ID, creation time and, when none is given, their image URL.
+// Soft-deleted products with a matching SKU are restored.
+func (r *GormProductRepository) UpsertBySKU(ctx context.Context, products []entity.Product) ([]*entity.Product, []*entity.Product, error) {
+	if len(products) == 0 {
+		return nil, nil, nil
+	}
+
+	skus := make([]string, len(products))
+	for i := range products {
+		skus[i] = products[i].SKU
+	}
+
+	var created, updated []*entity.Product
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existingModels []model.Product
+		if err := tx.Unscoped().Where("sku IN ?", skus).Find(&existingModels).Error; err != nil {
+			return err
+		}
+		existingBySKU := make(map[string]*model.Product, len(existingModels))
+		for i := range existingModels {
+			existingBySKU[existingModels[i].SKU] = &existingModels[i]
+		}
+
+		for i := range products {
+			productModel := model.NewProductModel(&products[i])
+			existing, ok := existingBySKU[productModel.SKU]
+			if !ok {
+				if err := tx.Create(productModel).Error; err != nil {
+					return err
+				}
+				created = append(created, productModel.ToEntity())
+				continue
+			}
+
+			productModel.ID = existing.ID
+			productModel.CreatedAt = existing.CreatedAt
+			productModel.DeletedAt = nil
+			if productModel.ImageURL == "" {
+				productModel.ImageURL = existing.ImageURL
+			}
+			if err := tx.Unscoped().Save(productModel).Error; err != nil {
+				return err
+			}
+			updated = append(updated, productModel.ToEntity())
+		}
+		return nil
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "sku") {
+			return nil, nil, entity.ErrProductSKUExists
+		}
+		return nil, nil, err
+	}
+
+	return created, updated, nil
+}
+
+// ListDueForPublish retrieves products in review whose publish time is at or before now
+func (r *GormProductRepository) ListDueForPublish(ctx context.Context, now time.Time, limit int) ([]*entity.Product, error) {
+	return r.listDue(ctx, "publish_at", valueobject.Review, now, limit)
+}
+
+// ListDueForUnpublish retrieves published products whose unpublish time is at or before now
+func (r *GormProductRepository) ListDueForUnpublish(ctx context.Context, now time.Time, limit int) ([]*entity.Product, error) {
+	return r.listDue(ctx, "unpublish_at", valueobject.Published, now, limit)
+}
+
+// listDue retrieves products in status whose scheduled column is at or before now, oldest first
+func (r *GormProductRepository) listDue(ctx context.Context, column string, status valueobject.ProductStatus, now time.Time, limit int) ([]*entity.Product, error) {
+	var productModels []model.Product
+	err := conn(ctx, r.db).
+		Where("status = ? AND "+column+" <= ?", status.String(), now).
+		Order(column + ", id").
+		Limit(limit).
+		Find(&productModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, len(productModels))
+	for i, productModel := range productModels {
+		products[i] = productModel.ToEntity()
+	}
+	return products, nil
+}
+
 // Update updates an existing product
 func (r *GormProductRepository) Update(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	// Check if product exists
@@ -118,7 +239,7 @@ func (r *GormProductRepository) Update(ctx context.Context, product entity.Produ
 	productModel.CreatedAt = existingProduct.CreatedAt
 
 	// Update product
-	err = r.db.WithContext(ctx).Save(productModel).Error
+	err = conn(ctx, r.db).Save(productModel).Error
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "sku") {
 			return nil, entity.ErrProductSKUExists
@@ -131,7 +252,7 @@ func (r *GormProductRepository) Update(ctx context.Context, product entity.Produ
 
 // Delete removes a product by ID (soft delete)
 func (r *GormProductRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&model.Product{}, "id = ?", id)
+	result := conn(ctx, r.db).Delete(&model.Product{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -146,7 +267,33 @@ func (r *GormProductRepository) GetByCategory(ctx context.Context, categoryID st
 	var productModels []model.Product
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Product{}).Where("category_id = ?", categoryID)
+	query := conn(ctx, r.db).Model(&model.Product{}).Where("category_id = ?", categoryID)
+
+	// Count total matching records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&productModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Convert to entities
+	products := make([]*entity.Product, len(productModels))
+	for i, productModel := range productModels {
+		products[i] = productModel.ToEntity()
+	}
+
+	return products, int(total), nil
+}
+
+// GetByCategories retrieves products in any of the given categories
+func (r *GormProductRepository) GetByCategories(ctx context.Context, categoryIDs []string, offset, limit int) ([]*entity.Product, int, error) {
+	var productModels []model.Product
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.Product{}).Where("category_id IN ?", categoryIDs)
 
 	// Count total matching records
 	if err := query.Count(&total).Error; err != nil {
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"gorm.io/gorm"
+)
+
+// GormVariantRepository implements VariantRepository interface using GORM
+type GormVariantRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVariantRepository creates a new instance of GormVariantRepository
+func NewGormVariantRepository(db *gorm.DB) *GormVariantRepository {
+	return &GormVariantRepository{db: db}
+}
+
+// ListOptions retrieves the option axes of a product ordered by position
+func (r *GormVariantRepository) ListOptions(ctx context.Context, productID string) ([]entity.ProductOption, error) {
+	var optionModels []model.ProductOption
+	err := conn(ctx, r.db).Where("product_id = ?", productID).Order("position").Find(&optionModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]entity.ProductOption, len(optionModels))
+	for i := range optionModels {
+		options[i] = optionModels[i].ToEntity()
+	}
+	return options, nil
+}
+
+// ReplaceOptions replaces all option axes of a product
+func (r *GormVariantRepository) ReplaceOptions(ctx context.Context, productID string, options []entity.ProductOption) ([]entity.ProductOption, error) {
+	optionModels := make([]*model.ProductOption, len(options))
+	for i := range options {
+		options[i].ProductID = productID
+		optionModels[i] = model.NewProductOptionModel(&options[i])
+	}
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&model.ProductOption{}).Error; err != nil {
+			return err
+		}
+		if len(optionModels) == 0 {
+			return nil
+		}
+		return tx.Create(optionModels).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.ProductOption, len(optionModels))
+	for i, optionModel := range optionModels {
+		result[i] = optionModel.ToEntity()
+	}
+	return result, nil
+}
+
+// Create stores a new variant
+func (r *GormVariantRepository) Create(ctx context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error) {
+	variantModel := model.NewProductVariantModel(&variant)
+	err := conn(ctx, r.db).Create(variantModel).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "sku") {
+			return nil, entity.ErrProductSKUExists
+		}
+		return nil, err
+	}
+	return variantModel.ToEntity(), nil
+}
+
+// GetByID retrieves a variant by ID
+func (r *GormVariantRepository) GetByID(ctx context.Context, id string) (*entity.ProductVariant, error) {
+	var variantModel model.ProductVariant
+	err := conn(ctx, r.db).Where("id = ?", id).First(&variantModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrVariantNotFound
+		}
+		return nil, err
+	}
+	return variantModel.ToEntity(), nil
+}
+
+// GetBySKU retrieves a variant by SKU
+func (r *GormVariantRepository) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	var variantModel model.ProductVariant
+	err := conn(ctx, r.db).Where("sku = ?", sku).First(&variantModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrVariantNotFound
+		}
+		return nil, err
+	}
+	return variantModel.ToEntity(), nil
+}
+
+// ListByProductID retrieves all variants of a product
+func (r *GormVariantRepository) ListByProductID(ctx context.Context, productID string) ([]entity.ProductVariant, error) {
+	var variantModels []model.ProductVariant
+	err := conn(ctx, r.db).Where("product_id = ?", productID).Order("created_at").Find(&variantModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]entity.ProductVariant, len(variantModels))
+	for i := range variantModels {
+		variants[i] = *variantModels[i].ToEntity()
+	}
+	return variants, nil
+}
+
+// Update updates an existing variant
+func (r *GormVariantRepository) Update(ctx context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error) {
+	// Check if variant exists
+	existingVariant, err := r.GetByID(ctx, variant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variantModel := model.NewProductVariantModel(&variant)
+
+	// Preserve created_at timestamp
+	variantModel.CreatedAt = existingVariant.CreatedAt
+
+	err = conn(ctx, r.db).Save(variantModel).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "sku") {
+			return nil, entity.ErrProductSKUExists
+		}
+		return nil, err
+	}
+	return variantModel.ToEntity(), nil
+}
+
+// Delete removes a variant by ID (soft delete)
+func (r *GormVariantRepository) Delete(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&model.ProductVariant{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrVariantNotFound
+	}
+	return nil
+}
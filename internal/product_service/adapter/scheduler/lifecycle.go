@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// LifecycleScheduler periodically runs the scheduled publish and unpublish transitions
+type LifecycleScheduler struct {
+	productUsecase usecase.ProductUsecase
+	interval       time.Duration
+	logger         logger.Logger
+}
+
+// NewLifecycleScheduler creates a new instance of LifecycleScheduler
+func NewLifecycleScheduler(pu usecase.ProductUsecase, interval time.Duration, l logger.Logger) *LifecycleScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &LifecycleScheduler{
+		productUsecase: pu,
+		interval:       interval,
+		logger:         l,
+	}
+}
+
+// Start runs the transitions once and then on every tick until ctx is cancelled
+func (s *LifecycleScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// run applies the transitions that are due; failures are retried on the next tick
+func (s *LifecycleScheduler) run(ctx context.Context) {
+	changed, err := s.productUsecase.RunScheduledTransitions(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to run scheduled product transitions", "error", err)
+	}
+	if changed > 0 {
+		s.logger.Info("Applied scheduled product transitions", "count", changed)
+	}
+}
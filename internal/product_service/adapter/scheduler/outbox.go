@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// OutboxRelay periodically publishes the events waiting in the outbox
+type OutboxRelay struct {
+	outboxUsecase usecase.OutboxUsecase
+	interval      time.Duration
+	logger        logger.Logger
+}
+
+// NewOutboxRelay creates a new instance of OutboxRelay
+func NewOutboxRelay(ou usecase.OutboxUsecase, interval time.Duration, l logger.Logger) *OutboxRelay {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &OutboxRelay{
+		outboxUsecase: ou,
+		interval:      interval,
+		logger:        l,
+	}
+}
+
+// Start relays pending events on every tick until ctx is cancelled
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// run publishes pending events and purges old published ones; failures are retried on the next tick
+func (r *OutboxRelay) run(ctx context.Context) {
+	if _, err := r.outboxUsecase.RelayPending(ctx); err != nil {
+		r.logger.Warn("Failed to relay product events", "error", err)
+		return
+	}
+	if _, err := r.outboxUsecase.PurgePublished(ctx); err != nil {
+		r.logger.Warn("Failed to purge published product events", "error", err)
+	}
+}
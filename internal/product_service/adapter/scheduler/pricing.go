@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// PriceScheduler periodically applies scheduled price changes
+type PriceScheduler struct {
+	priceUsecase usecase.PriceUsecase
+	interval     time.Duration
+	logger       logger.Logger
+}
+
+// NewPriceScheduler creates a new instance of PriceScheduler
+func NewPriceScheduler(pu usecase.PriceUsecase, interval time.Duration, l logger.Logger) *PriceScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &PriceScheduler{
+		priceUsecase: pu,
+		interval:     interval,
+		logger:       l,
+	}
+}
+
+// Start applies the due price changes once and then on every tick until ctx is cancelled
+func (s *PriceScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// run applies the price changes that are due; failures are retried on the next tick
+func (s *PriceScheduler) run(ctx context.Context) {
+	applied, err := s.priceUsecase.ApplyScheduledPrices(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to apply scheduled price changes", "error", err)
+	}
+	if applied > 0 {
+		s.logger.Info("Applied scheduled price changes", "count", applied)
+	}
+}
@@ -0,0 +1,430 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// field identifies an indexed product field
+type field int
+
+const (
+	fieldName field = iota
+	fieldDescription
+	fieldSKU
+	numFields
+)
+
+// fieldWeights boosts matches in the name and SKU over the description
+var fieldWeights = [numFields]float64{
+	fieldName:        3,
+	fieldDescription: 1,
+	fieldSKU:         4,
+}
+
+const (
+	// BM25 term frequency saturation and length normalisation
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// minPrefixLength is the shortest query term that also matches longer terms starting with it
+	minPrefixLength = 3
+	prefixWeight    = 0.7
+
+	// exactSKUBoost is added when the whole query is a product SKU
+	exactSKUBoost = 10
+)
+
+// DefaultPriceBuckets are the upper bounds of the price facet ranges
+var DefaultPriceBuckets = []float64{25, 50, 100, 250, 500}
+
+// document is an indexed product with its per-field term frequencies
+type document struct {
+	product *entity.Product
+	terms   [numFields]map[string]int
+	length  [numFields]int
+}
+
+// expansion is an indexed term matched by a query term, weighted by how closely it matches
+type expansion struct {
+	term   string
+	weight float64
+}
+
+// MemoryIndex is an embedded, in-process inverted index implementing SearchIndex.
+// It ranks with BM25 over name, description and SKU and tolerates typos and prefixes.
+type MemoryIndex struct {
+	mu           sync.RWMutex
+	docs         map[string]*document
+	postings     map[string]map[string]struct{} // term -> product IDs
+	totalLength  [numFields]int
+	priceBuckets []float64
+	priceLabels  []string
+}
+
+// NewMemoryIndex creates an empty in-process index.
+// priceBuckets are the ascending upper bounds of the price facet ranges.
+func NewMemoryIndex(priceBuckets []float64) *MemoryIndex {
+	if len(priceBuckets) == 0 {
+		priceBuckets = DefaultPriceBuckets
+	}
+	buckets := append([]float64(nil), priceBuckets...)
+	sort.Float64s(buckets)
+
+	labels := make([]string, len(buckets)+1)
+	lower := "0"
+	for i, upper := range buckets {
+		bound := strconv.FormatFloat(upper, 'f', -1, 64)
+		labels[i] = lower + "-" + bound
+		lower = bound
+	}
+	labels[len(buckets)] = lower + "+"
+
+	return &MemoryIndex{
+		docs:         make(map[string]*document),
+		postings:     make(map[string]map[string]struct{}),
+		priceBuckets: buckets,
+		priceLabels:  labels,
+	}
+}
+
+// Index adds a product to the index or replaces its previous version
+func (ix *MemoryIndex) Index(ctx context.Context, product *entity.Product) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(product.ID)
+	ix.add(product)
+	return nil
+}
+
+// Remove deletes a product from the index
+func (ix *MemoryIndex) Remove(ctx context.Context, productID string) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(productID)
+	return nil
+}
+
+// Rebuild replaces the whole index with the given products
+func (ix *MemoryIndex) Rebuild(ctx context.Context, products []*entity.Product) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.docs = make(map[string]*document, len(products))
+	ix.postings = make(map[string]map[string]struct{})
+	ix.totalLength = [numFields]int{}
+	for _, product := range products {
+		ix.add(product)
+	}
+	return nil
+}
+
+// Search returns products ranked by relevance along with facet counts.
+// Every query term must match, either exactly, as a prefix or within a small edit distance.
+// An empty query matches all products, newest first.
+func (ix *MemoryIndex) Search(ctx context.Context, query entity.SearchQuery) (*entity.SearchResult, error) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := ix.match(query.Text)
+
+	categoryCounts := make(map[string]int)
+	statusCounts := make(map[string]int)
+	priceCounts := make([]int, len(ix.priceLabels))
+	hits := make([]entity.SearchHit, 0, len(scores))
+
+	for id, score := range scores {
+		product := ix.docs[id].product
+		categoryOK := query.CategoryID == "" || product.CategoryID == query.CategoryID
+		statusOK := query.Status == "" || product.Status == query.Status
+		priceOK := (query.PriceMin == nil || product.Price >= *query.PriceMin) &&
+			(query.PriceMax == nil || product.Price <= *query.PriceMax)
+
+		// Each facet is counted with every filter applied except its own
+		if statusOK && priceOK {
+			categoryCounts[product.CategoryID]++
+		}
+		if categoryOK && priceOK {
+			statusCounts[product.Status]++
+		}
+		if categoryOK && statusOK {
+			priceCounts[ix.priceBucket(product.Price)]++
+		}
+
+		if categoryOK && statusOK && priceOK {
+			p := *product
+			hits = append(hits, entity.SearchHit{Product: &p, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Product.CreatedAt.Equal(hits[j].Product.CreatedAt) {
+			return hits[i].Product.CreatedAt.After(hits[j].Product.CreatedAt)
+		}
+		return hits[i].Product.ID < hits[j].Product.ID
+	})
+
+	total := len(hits)
+	start := min(max(query.Offset, 0), total)
+	end := total
+	if query.Limit > 0 {
+		end = min(start+query.Limit, total)
+	}
+
+	priceRanges := make([]entity.FacetCount, len(ix.priceLabels))
+	for i, label := range ix.priceLabels {
+		priceRanges[i] = entity.FacetCount{Value: label, Count: priceCounts[i]}
+	}
+
+	return &entity.SearchResult{
+		Hits:  hits[start:end],
+		Total: total,
+		Facets: entity.SearchFacets{
+			Categories:  sortedFacets(categoryCounts),
+			PriceRanges: priceRanges,
+			Statuses:    sortedFacets(statusCounts),
+		},
+	}, nil
+}
+
+// add indexes a copy of the product. The caller must hold the write lock.
+func (ix *MemoryIndex) add(product *entity.Product) {
+	p := *product
+	doc := &document{product: &p}
+
+	fields := [numFields]string{
+		fieldName:        p.Name,
+		fieldDescription: p.Description,
+		fieldSKU:         p.SKU,
+	}
+	for f, text := range fields {
+		tokens := tokenize(text)
+		doc.terms[f] = make(map[string]int, len(tokens))
+		doc.length[f] = len(tokens)
+		ix.totalLength[f] += len(tokens)
+
+		for _, token := range tokens {
+			doc.terms[f][token]++
+			if ix.postings[token] == nil {
+				ix.postings[token] = make(map[string]struct{})
+			}
+			ix.postings[token][p.ID] = struct{}{}
+		}
+	}
+
+	ix.docs[p.ID] = doc
+}
+
+// remove drops a product from the index. The caller must hold the write lock.
+func (ix *MemoryIndex) remove(productID string) {
+	doc, ok := ix.docs[productID]
+	if !ok {
+		return
+	}
+
+	for f := range doc.terms {
+		ix.totalLength[f] -= doc.length[f]
+		for term := range doc.terms[f] {
+			delete(ix.postings[term], productID)
+			if len(ix.postings[term]) == 0 {
+				delete(ix.postings, term)
+			}
+		}
+	}
+
+	delete(ix.docs, productID)
+}
+
+// match scores every product matching all terms of the query text
+func (ix *MemoryIndex) match(text string) map[string]float64 {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		scores := make(map[string]float64, len(ix.docs))
+		for id := range ix.docs {
+			scores[id] = 0
+		}
+		return scores
+	}
+
+	var scores map[string]float64
+	for i, token := range tokens {
+		// Keep the best matching expansion of the token per product
+		tokenScores := make(map[string]float64)
+		for _, exp := range ix.expand(token) {
+			for id := range ix.postings[exp.term] {
+				score := ix.score(ix.docs[id], exp.term) * exp.weight
+				if score > tokenScores[id] {
+					tokenScores[id] = score
+				}
+			}
+		}
+
+		if i == 0 {
+			scores = tokenScores
+			continue
+		}
+		for id := range scores {
+			if score, ok := tokenScores[id]; ok {
+				scores[id] += score
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	sku := strings.ToLower(strings.TrimSpace(text))
+	for id := range scores {
+		if strings.ToLower(ix.docs[id].product.SKU) == sku {
+			scores[id] += exactSKUBoost
+		}
+	}
+
+	return scores
+}
+
+// expand returns the indexed terms a query term matches.
+// Prefix matches always apply; typo matches are only used when the term itself is not indexed.
+func (ix *MemoryIndex) expand(token string) []expansion {
+	var expansions []expansion
+	_, exact := ix.postings[token]
+	if exact {
+		expansions = append(expansions, expansion{term: token, weight: 1})
+	}
+
+	length := len([]rune(token))
+	maxEdits := 0
+	if !exact {
+		maxEdits = allowedEdits(length)
+	}
+
+	for term := range ix.postings {
+		if term == token {
+			continue
+		}
+		if length >= minPrefixLength && strings.HasPrefix(term, token) {
+			expansions = append(expansions, expansion{term: term, weight: prefixWeight})
+			continue
+		}
+		if maxEdits > 0 {
+			if d := editDistance(token, term, maxEdits); d <= maxEdits {
+				expansions = append(expansions, expansion{term: term, weight: 1 / float64(1+d)})
+			}
+		}
+	}
+	return expansions
+}
+
+// score computes the weighted BM25 score of a term in a document
+func (ix *MemoryIndex) score(doc *document, term string) float64 {
+	n := float64(len(ix.docs))
+	df := float64(len(ix.postings[term]))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+	var score float64
+	for f := range doc.terms {
+		tf := float64(doc.terms[f][term])
+		if tf == 0 {
+			continue
+		}
+		avgLength := float64(ix.totalLength[f]) / n
+		if avgLength == 0 {
+			avgLength = 1
+		}
+		norm := 1 - bm25B + bm25B*float64(doc.length[f])/avgLength
+		score += fieldWeights[f] * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return idf * score
+}
+
+// priceBucket returns the index of the price facet range containing price
+func (ix *MemoryIndex) priceBucket(price float64) int {
+	for i, upper := range ix.priceBuckets {
+		if price < upper {
+			return i
+		}
+	}
+	return len(ix.priceBuckets)
+}
+
+// tokenize lowercases text and splits it into letter and digit runs
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// allowedEdits is the number of typos tolerated for a query term of the given length
+func allowedEdits(length int) int {
+	switch {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// counting adjacent transpositions as one edit. It returns maxEdits+1 once the distance exceeds maxEdits.
+func editDistance(a, b string, maxEdits int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > maxEdits || -diff > maxEdits {
+		return maxEdits + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > maxEdits {
+			return maxEdits + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// sortedFacets orders facet counts by count, then value
+func sortedFacets(counts map[string]int) []entity.FacetCount {
+	facets := make([]entity.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, entity.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -11,9 +12,17 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	GRPC     GRPCConfig     `yaml:"grpc"`
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Search    SearchConfig    `yaml:"search"`
+	Media     MediaConfig     `yaml:"media"`
+	Import    ImportConfig    `yaml:"import"`
+	Lifecycle LifecycleConfig `yaml:"lifecycle"`
+	Kafka     KafkaConfig     `yaml:"kafka"`
+	Outbox    OutboxConfig    `yaml:"outbox"`
+	Pricing   PricingConfig   `yaml:"pricing"`
+	Services  ServicesConfig  `yaml:"services"`
 }
 
 // ServerConfig contains HTTP server configuration
@@ -22,6 +31,7 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `yaml:"readTimeout"`
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
 	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+	BodyLimit    int           `yaml:"bodyLimit"` // bytes; must leave room for media.maxUploadSize plus multipart overhead
 }
 
 // DatabaseConfig contains database configuration
@@ -41,6 +51,59 @@ type GRPCConfig struct {
 	Port string `yaml:"port"`
 }
 
+// SearchConfig contains product search configuration
+type SearchConfig struct {
+	Backend      string    `yaml:"backend"`      // "memory" (embedded in-process index)
+	PriceBuckets []float64 `yaml:"priceBuckets"` // upper bounds of the price facet ranges
+}
+
+// MediaConfig contains product media storage configuration
+type MediaConfig struct {
+	Backend         string `yaml:"backend"`         // "local" (filesystem)
+	LocalDir        string `yaml:"localDir"`        // root directory of the local backend
+	BaseURL         string `yaml:"baseURL"`         // URL prefix the stored files are served from
+	ThumbnailWidths []int  `yaml:"thumbnailWidths"` // widths of the generated thumbnails
+	MaxUploadSize   int64  `yaml:"maxUploadSize"`   // bytes
+}
+
+// ImportConfig contains bulk product import configuration
+type ImportConfig struct {
+	BatchSize int `yaml:"batchSize"` // rows upserted per transaction
+	MaxRows   int `yaml:"maxRows"`   // largest accepted file; uploads are also bounded by server.bodyLimit
+
+	// InstanceID names this instance on the imports it runs, so that a restart only fails its own
+	// interrupted imports. It must be stable across restarts; defaults to the host name.
+	InstanceID string `yaml:"instanceId"`
+}
+
+// LifecycleConfig contains product lifecycle configuration
+type LifecycleConfig struct {
+	SchedulerInterval time.Duration `yaml:"schedulerInterval"` // how often scheduled publishing runs
+}
+
+// KafkaConfig contains event publishing configuration
+type KafkaConfig struct {
+	Brokers      []string `yaml:"brokers"`
+	ProductTopic string   `yaml:"product_topic"`
+}
+
+// OutboxConfig contains product event relay configuration
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"pollInterval"` // how often pending events are published
+	BatchSize    int           `yaml:"batchSize"`    // events published per Kafka write
+	Retention    time.Duration `yaml:"retention"`    // how long published events are kept
+}
+
+// PricingConfig contains price book configuration
+type PricingConfig struct {
+	SchedulerInterval time.Duration `yaml:"schedulerInterval"` // how often scheduled price changes are applied
+}
+
+// ServicesConfig contains the addresses of downstream gRPC services
+type ServicesConfig struct {
+	InventoryService string `yaml:"inventoryService"`
+}
+
 // LoadConfig loads configuration from a YAML file
 func LoadConfig(configPath string) (*Config, error) {
 	// Set default configuration
@@ -50,6 +113,7 @@ func LoadConfig(configPath string) (*Config, error) {
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
 			IdleTimeout:  60 * time.Second,
+			BodyLimit:    12 * 1024 * 1024,
 		},
 		Database: DatabaseConfig{
 			User:     "root",
@@ -64,6 +128,44 @@ func LoadConfig(configPath string) (*Config, error) {
 		GRPC: GRPCConfig{
 			Port: "50052", // Different port from user service
 		},
+		Search: SearchConfig{
+			Backend:      "memory",
+			PriceBuckets: []float64{25, 50, 100, 250, 500},
+		},
+		Media: MediaConfig{
+			Backend:         "local",
+			LocalDir:        "./data/media",
+			BaseURL:         "/media",
+			ThumbnailWidths: []int{160, 480, 960},
+			MaxUploadSize:   10 * 1024 * 1024,
+		},
+		Import: ImportConfig{
+			BatchSize: 200,
+			MaxRows:   50000,
+		},
+		Lifecycle: LifecycleConfig{
+			SchedulerInterval: time.Minute,
+		},
+		Kafka: KafkaConfig{
+			Brokers:      []string{"localhost:9092"},
+			ProductTopic: "product_events",
+		},
+		Outbox: OutboxConfig{
+			PollInterval: time.Second,
+			BatchSize:    100,
+			Retention:    7 * 24 * time.Hour,
+		},
+		Pricing: PricingConfig{
+			SchedulerInterval: time.Minute,
+		},
+		Services: ServicesConfig{
+			InventoryService: "127.0.0.1:50054",
+		},
+	}
+
+	// Imports are owned by the instance that runs them
+	if hostname, err := os.Hostname(); err == nil {
+		config.Import.InstanceID = hostname
 	}
 
 	// Read config file
@@ -113,5 +215,28 @@ func overrideWithEnv(config *Config) *Config {
 		config.GRPC.Port = value
 	}
 
+	// Media
+	if value := os.Getenv("PRODUCT_MEDIA_DIR"); value != "" {
+		config.Media.LocalDir = value
+	}
+	if value := os.Getenv("PRODUCT_MEDIA_BASE_URL"); value != "" {
+		config.Media.BaseURL = value
+	}
+
+	// Import
+	if value := os.Getenv("PRODUCT_INSTANCE_ID"); value != "" {
+		config.Import.InstanceID = value
+	}
+
+	// Kafka
+	if value := os.Getenv("PRODUCT_KAFKA_BROKERS"); value != "" {
+		config.Kafka.Brokers = strings.Split(value, ",")
+	}
+
+	// Downstream services
+	if value := os.Getenv("INVENTORY_SERVICE_ADDR"); value != "" {
+		config.Services.InventoryService = value
+	}
+
 	return config
 }
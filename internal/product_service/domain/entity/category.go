@@ -1,14 +1,75 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// MaxCategoryDepth is the deepest level a category may be nested at
+const MaxCategoryDepth = 20
 
 type Category struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	ParentID    *string    `json:"parent_id,omitempty"`
-	Level       int        `json:"level"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at"`
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	ParentID    *string `json:"parent_id,omitempty"`
+	Level       int     `json:"level"`
+	// Path is the materialised path of ancestor IDs ending with the category itself, e.g. "/root/child/"
+	Path      string     `json:"path"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// SetParent places the category under parent, or at the top level when parent is nil
+func (c *Category) SetParent(parent *Category) {
+	if parent == nil {
+		c.ParentID = nil
+		c.Level = 1
+		c.Path = "/" + c.ID + "/"
+		return
+	}
+	parentID := parent.ID
+	c.ParentID = &parentID
+	c.Level = parent.Level + 1
+	c.Path = parent.Path + c.ID + "/"
+}
+
+// IsAncestorOf reports whether other is in the subtree below the category
+func (c *Category) IsAncestorOf(other *Category) bool {
+	return other.ID != c.ID && strings.HasPrefix(other.Path, c.Path)
+}
+
+// AncestorIDs returns the IDs on the path from the root down to the category itself
+func (c *Category) AncestorIDs() []string {
+	return strings.FieldsFunc(c.Path, func(r rune) bool { return r == '/' })
+}
+
+// CategoryNode is a category together with its nested subcategories
+type CategoryNode struct {
+	Category *Category
+	Children []*CategoryNode
+}
+
+// BuildCategoryTree nests a subtree ordered by level below its root, the first category.
+// Categories whose parent is not in the subtree are ignored.
+func BuildCategoryTree(categories []*Category) *CategoryNode {
+	if len(categories) == 0 {
+		return nil
+	}
+	root := &CategoryNode{Category: categories[0]}
+	nodes := map[string]*CategoryNode{root.Category.ID: root}
+	for _, category := range categories[1:] {
+		if category.ParentID == nil {
+			continue
+		}
+		parent, ok := nodes[*category.ParentID]
+		if !ok {
+			continue
+		}
+		node := &CategoryNode{Category: category}
+		parent.Children = append(parent.Children, node)
+		nodes[category.ID] = node
+	}
+	return root
 }
@@ -9,14 +9,49 @@ var (
 	ErrProductSKUExists     = errors.New("product SKU already exists")
 	ErrInvalidProductData   = errors.New("invalid product data")
 
+	// Lifecycle errors
+	ErrInvalidProductStatus    = errors.New("invalid product status")
+	ErrInvalidStatusTransition = errors.New("product status transition is not allowed")
+	ErrInvalidSchedule         = errors.New("invalid publishing schedule")
+
+	// Price errors
+	ErrInvalidPrice           = errors.New("price must be greater than zero")
+	ErrScheduledPriceNotFound = errors.New("scheduled price change not found")
+	ErrScheduledPriceApplied  = errors.New("scheduled price change has already been applied")
+	ErrInvalidSalePeriod      = errors.New("sale must end after it starts")
+	ErrSaleOverlap            = errors.New("sale overlaps another sale of the product")
+	ErrSaleNotFound           = errors.New("sale not found")
+
+	// Variant errors
+	ErrVariantNotFound       = errors.New("product variant not found")
+	ErrVariantExists         = errors.New("a variant with these options already exists")
+	ErrInvalidVariantData    = errors.New("invalid variant data")
+	ErrInvalidVariantOptions = errors.New("variant options do not match the product options")
+	ErrVariantRequired       = errors.New("product has variants; a variant SKU is required")
+
+	// Media errors
+	ErrMediaNotFound     = errors.New("product media not found")
+	ErrInvalidMedia      = errors.New("unsupported or corrupt image")
+	ErrMediaTooLarge     = errors.New("image exceeds the maximum upload size")
+	ErrInvalidMediaOrder = errors.New("media order must list every image of the product exactly once")
+
+	// Import errors
+	ErrImportJobNotFound  = errors.New("import job not found")
+	ErrInvalidImportFile  = errors.New("import file could not be read")
+	ErrUnsupportedFormat  = errors.New("unsupported file format")
+	ErrImportFileTooLarge = errors.New("import file has too many rows")
+
 	// Category errors
 	ErrCategoryNotFound      = errors.New("category not found")
 	ErrCategoryAlreadyExists = errors.New("category already exists")
 	ErrInvalidCategoryData   = errors.New("invalid category data")
+	ErrCategoryCycle         = errors.New("category cannot be moved below itself or its descendants")
+	ErrCategoryTooDeep       = errors.New("category tree is nested too deeply")
 
 	// Inventory errors
-	ErrInventoryNotFound = errors.New("inventory not found")
-	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrInventoryNotFound  = errors.New("inventory not found")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrStockAlreadyExists = errors.New("inventory already has stock")
 
 	// Generic errors
 	ErrInternalServerError = errors.New("internal server error")
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// FileFormat is a bulk product file format
+type FileFormat string
+
+const (
+	FormatCSV   FileFormat = "csv"
+	FormatJSONL FileFormat = "jsonl"
+)
+
+// IsValid reports whether the format is supported
+func (f FileFormat) IsValid() bool {
+	return f == FormatCSV || f == FormatJSONL
+}
+
+// ImportStatus is the state of a bulk import job
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportCompleted ImportStatus = "completed"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportJob tracks an asynchronous bulk product import
+type ImportJob struct {
+	ID            string       `json:"id"`
+	Format        FileFormat   `json:"format"`
+	Owner         string       `json:"-"` // instance running the job
+	Status        ImportStatus `json:"status"`
+	TotalRows     int          `json:"total_rows"`
+	ProcessedRows int          `json:"processed_rows"`
+	CreatedCount  int          `json:"created_count"`
+	UpdatedCount  int          `json:"updated_count"`
+	FailedCount   int          `json:"failed_count"`
+	Error         string       `json:"error,omitempty"` // set when the job as a whole failed
+	CreatedAt     time.Time    `json:"created_at"`
+	StartedAt     *time.Time   `json:"started_at,omitempty"`
+	FinishedAt    *time.Time   `json:"finished_at,omitempty"`
+}
+
+// IsFinished reports whether the job has stopped processing rows
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportCompleted || j.Status == ImportFailed
+}
+
+// ImportRowError describes why a row of an import file was rejected
+type ImportRowError struct {
+	JobID   string `json:"job_id"`
+	Line    int    `json:"line"`
+	SKU     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+// ProductImportRow is a parsed row of an import file.
+// Err is set when the row could not be parsed; Product then holds whatever fields were read.
+type ProductImportRow struct {
+	Line    int
+	Product Product
+	Err     error
+}
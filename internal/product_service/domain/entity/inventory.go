@@ -2,13 +2,50 @@ package entity
 
 import "time"
 
-// Inventory tracks product stock information
+// Inventory is the stock of a product without variants, or of a single variant, held by the inventory
+// service. Quantity is the stock on hand, of which Reserved is held for orders.
 type Inventory struct {
-	ID        string     `json:"id"`
-	ProductID string     `json:"product_id"`
-	Quantity  int        `json:"quantity"`
-	Reserved  int        `json:"reserved"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	ProductID string    `json:"product_id"`
+	VariantID *string   `json:"variant_id,omitempty"`
+	SKU       string    `json:"sku"`
+	Quantity  int       `json:"quantity"`
+	Reserved  int       `json:"reserved"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Available returns the stock that is not reserved
+func (i *Inventory) Available() int {
+	return i.Quantity - i.Reserved
+}
+
+// StockID returns the key of the stock in the inventory service
+func (i *Inventory) StockID() string {
+	return StockID(i.ProductID, i.VariantID)
+}
+
+// StockID returns the key the inventory service holds stock under: the variant ID for a variant and the
+// product ID for a product without variants
+func StockID(productID string, variantID *string) string {
+	if variantID != nil {
+		return *variantID
+	}
+	return productID
+}
+
+// LegacyInventory is a stock record this service kept before stock moved to the inventory service,
+// waiting to be migrated there
+type LegacyInventory struct {
+	ID        string  `json:"id"`
+	ProductID string  `json:"product_id"`
+	VariantID *string `json:"variant_id,omitempty"`
+	SKU       string  `json:"sku"`
+	Quantity  int     `json:"quantity"`
+	Reserved  int     `json:"reserved"`
+}
+
+// InventoryMigration reports a migration of legacy stock records to the inventory service
+type InventoryMigration struct {
+	Migrated         int      `json:"migrated"`
+	Skipped          []string `json:"skipped"`           // IDs of records whose stock the inventory service already had
+	ReleasedReserved int      `json:"released_reserved"` // units reserved on migrated records, which became available
 }
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// ProductMedia is an image attached to a product; a product's images are shown in position order
+type ProductMedia struct {
+	ID          string           `json:"id"`
+	ProductID   string           `json:"product_id"`
+	URL         string           `json:"url"`
+	StorageKey  string           `json:"storage_key"`
+	AltText     string           `json:"alt_text"`
+	Position    int              `json:"position"`
+	ContentType string           `json:"content_type"`
+	Width       int              `json:"width"`
+	Height      int              `json:"height"`
+	Size        int64            `json:"size"`
+	Thumbnails  []MediaThumbnail `json:"thumbnails"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// MediaThumbnail is a scaled-down copy of a product image
+type MediaThumbnail struct {
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	URL        string `json:"url"`
+	StorageKey string `json:"storage_key"`
+}
+
+// StorageKeys returns the storage keys of the original image and all of its thumbnails
+func (m *ProductMedia) StorageKeys() []string {
+	keys := make([]string, 0, len(m.Thumbnails)+1)
+	keys = append(keys, m.StorageKey)
+	for _, thumbnail := range m.Thumbnails {
+		keys = append(keys, thumbnail.StorageKey)
+	}
+	return keys
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// OutboxEvent is an event stored with the change it describes and published afterwards.
+// Events are published in Sequence order, at least once.
+type OutboxEvent struct {
+	ID          string     `json:"id"`
+	Sequence    int64      `json:"sequence"`
+	AggregateID string     `json:"aggregate_id"` // the product the event is about; used as the message key
+	EventType   string     `json:"event_type"`
+	Payload     []byte     `json:"payload"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// ProductEvent is the payload of the events published about a product
+type ProductEvent struct {
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Timestamp      time.Time  `json:"timestamp"`
+	ProductID      string     `json:"product_id"`
+	VariantID      string     `json:"variant_id,omitempty"`
+	SKU            string     `json:"sku"`
+	Name           string     `json:"name,omitempty"`
+	CategoryID     string     `json:"category_id,omitempty"`
+	Price          float64    `json:"price"`
+	PreviousPrice  *float64   `json:"previous_price,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	PublishAt      *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt    *time.Time `json:"unpublish_at,omitempty"`
+}
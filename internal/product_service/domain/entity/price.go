@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// PriceSource says what changed a product price
+type PriceSource string
+
+const (
+	PriceSourceManual    PriceSource = "manual"
+	PriceSourceImport    PriceSource = "import"
+	PriceSourceScheduled PriceSource = "scheduled"
+)
+
+// PriceChange is an entry in the price history of a product.
+// The first entry of a product records its initial price with an OldPrice of zero.
+type PriceChange struct {
+	ID        string      `json:"id"`
+	ProductID string      `json:"product_id"`
+	OldPrice  float64     `json:"old_price"`
+	NewPrice  float64     `json:"new_price"`
+	Source    PriceSource `json:"source"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// ScheduledPrice is a future change of the base price of a product
+type ScheduledPrice struct {
+	ID          string     `json:"id"`
+	ProductID   string     `json:"product_id"`
+	Price       float64    `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// IsPending reports whether the change has not been applied yet
+func (s *ScheduledPrice) IsPending() bool {
+	return s.AppliedAt == nil
+}
+
+// Sale is a discounted price of a product for a period.
+// While it runs, the base price is shown as the compare-at price.
+type Sale struct {
+	ID        string     `json:"id"`
+	ProductID string     `json:"product_id"`
+	Price     float64    `json:"price"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"` // nil runs the sale until it is deleted
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ActiveAt reports whether the sale runs at t
+func (s *Sale) ActiveAt(t time.Time) bool {
+	return !t.Before(s.StartsAt) && (s.EndsAt == nil || t.Before(*s.EndsAt))
+}
+
+// Overlaps reports whether two sales run at the same time at any point
+func (s *Sale) Overlaps(other *Sale) bool {
+	startsBeforeOtherEnds := other.EndsAt == nil || s.StartsAt.Before(*other.EndsAt)
+	otherStartsBeforeEnd := s.EndsAt == nil || other.StartsAt.Before(*s.EndsAt)
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// EffectivePrice is the price a product or variant sells for at a point in time
+type EffectivePrice struct {
+	ProductID      string    `json:"product_id"`
+	VariantID      string    `json:"variant_id,omitempty"`
+	SKU            string    `json:"sku"`
+	At             time.Time `json:"at"`
+	Price          float64   `json:"price"`                      // what the customer pays
+	BasePrice      float64   `json:"base_price"`                 // price before any sale
+	CompareAtPrice *float64  `json:"compare_at_price,omitempty"` // set while a sale lowers the price
+	SaleID         string    `json:"sale_id,omitempty"`
+}
@@ -3,15 +3,22 @@ package entity
 import "time"
 
 type Product struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Price       float64    `json:"price"`
-	CategoryID  string     `json:"category_id"`
-	ImageURL    string     `json:"image_url"`
-	SKU         string     `json:"sku"`
-	Status      string     `json:"status"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at"`
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	CategoryID  string  `json:"category_id"`
+	ImageURL    string  `json:"image_url"`
+	SKU         string  `json:"sku"`
+	Status      string  `json:"status"`
+	// PublishAt and UnpublishAt schedule the next lifecycle transitions, if any
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	// Options, Variants and Media are only loaded for single-product lookups
+	Options   []ProductOption  `json:"options,omitempty"`
+	Variants  []ProductVariant `json:"variants,omitempty"`
+	Media     []ProductMedia   `json:"media,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt *time.Time       `json:"deleted_at"`
 }
@@ -0,0 +1,40 @@
+package entity
+
+// SearchQuery describes a full-text product search with optional filters
+type SearchQuery struct {
+	Text       string
+	CategoryID string
+	Status     string
+	PriceMin   *float64
+	PriceMax   *float64
+	Offset     int
+	Limit      int
+}
+
+// SearchHit is a matching product with its relevance score
+type SearchHit struct {
+	Product *Product `json:"product"`
+	Score   float64  `json:"score"`
+}
+
+// FacetCount is the number of matching products sharing a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets holds facet counts for a search.
+// Each facet ignores its own filter so clients can offer the alternatives.
+type SearchFacets struct {
+	Categories  []FacetCount `json:"categories"`
+	PriceRanges []FacetCount `json:"price_ranges"`
+	Statuses    []FacetCount `json:"statuses"`
+}
+
+// SearchResult is a page of search hits along with facet counts
+type SearchResult struct {
+	Hits   []SearchHit  `json:"hits"`
+	Total  int          `json:"total"`
+	Facets SearchFacets `json:"facets"`
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProductOption is an axis along which a product varies, such as size or colour
+type ProductOption struct {
+	ID        string   `json:"id"`
+	ProductID string   `json:"product_id"`
+	Name      string   `json:"name"`
+	Values    []string `json:"values"`
+	Position  int      `json:"position"`
+}
+
+// HasValue reports whether value is one of the option's allowed values
+func (o *ProductOption) HasValue(value string) bool {
+	for _, v := range o.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductVariant is a purchasable combination of option values with its own SKU and stock
+type ProductVariant struct {
+	ID        string            `json:"id"`
+	ProductID string            `json:"product_id"`
+	SKU       string            `json:"sku"`
+	Options   map[string]string `json:"options"`         // option name -> value
+	Price     *float64          `json:"price,omitempty"` // overrides the product price when set
+	ImageURL  string            `json:"image_url"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt *time.Time        `json:"deleted_at"`
+}
+
+// EffectivePrice returns the variant price override, or the product price
+func (v *ProductVariant) EffectivePrice(productPrice float64) float64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return productPrice
+}
+
+// OptionsKey returns a canonical representation of the option values, used to detect duplicate variants
+func (v *ProductVariant) OptionsKey() string {
+	names := make([]string, 0, len(v.Options))
+	for name := range v.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + v.Options[name]
+	}
+	return strings.Join(parts, ";")
+}
+
+// Validate checks the variant against the product's option axes.
+// Every axis must be given exactly one of its allowed values.
+func (v *ProductVariant) Validate(options []ProductOption) error {
+	if strings.TrimSpace(v.SKU) == "" {
+		return ErrInvalidVariantData
+	}
+	if v.Price != nil && *v.Price <= 0 {
+		return ErrInvalidVariantData
+	}
+	if len(options) == 0 || len(v.Options) != len(options) {
+		return ErrInvalidVariantOptions
+	}
+	for i := range options {
+		value, ok := v.Options[options[i].Name]
+		if !ok || !options[i].HasValue(value) {
+			return ErrInvalidVariantOptions
+		}
+	}
+	return nil
+}
+
+// ValidateOptions checks that option axes have unique names and non-empty, unique values
+func ValidateOptions(options []ProductOption) error {
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		name := strings.TrimSpace(option.Name)
+		if name == "" || names[name] || len(option.Values) == 0 {
+			return ErrInvalidVariantOptions
+		}
+		names[name] = true
+
+		values := make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			if strings.TrimSpace(value) == "" || values[value] {
+				return ErrInvalidVariantOptions
+			}
+			values[value] = true
+		}
+	}
+	return nil
+}
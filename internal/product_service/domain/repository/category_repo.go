@@ -22,9 +22,30 @@ type CategoryRepository interface {
 	// GetChildren retrieves child categories for a parent category
 	GetChildren(ctx context.Context, parentID string) ([]*entity.Category, error)
 
+	// GetSubtree retrieves a category and all of its descendants ordered by level
+	GetSubtree(ctx context.Context, id string) ([]*entity.Category, error)
+
+	// LockByID retrieves a category by ID and locks it until the transaction in ctx ends
+	LockByID(ctx context.Context, id string) (*entity.Category, error)
+
+	// LockSubtree retrieves a category and all of its descendants ordered by level
+	// and locks them until the transaction in ctx ends
+	LockSubtree(ctx context.Context, id string) ([]*entity.Category, error)
+
+	// GetByIDs retrieves the categories with the given IDs ordered by level
+	GetByIDs(ctx context.Context, ids []string) ([]*entity.Category, error)
+
 	// Update updates an existing category
 	Update(ctx context.Context, category entity.Category) (*entity.Category, error)
 
+	// MoveSubtree updates a category that changed position in the tree,
+	// rewriting the path and level of all its descendants in the same transaction.
+	// It joins the transaction in ctx, if any.
+	MoveSubtree(ctx context.Context, category entity.Category, oldPath string, oldLevel int) (*entity.Category, error)
+
+	// RebuildPaths recomputes the path and level of every category from the parent links
+	RebuildPaths(ctx context.Context) error
+
 	// Delete removes a category by ID (soft delete)
 	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type ImportJobRepository interface {
+	// Create stores a new import job
+	Create(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error)
+
+	// GetByID retrieves an import job by ID
+	GetByID(ctx context.Context, id string) (*entity.ImportJob, error)
+
+	// Update stores the progress of an import job
+	Update(ctx context.Context, job entity.ImportJob) (*entity.ImportJob, error)
+
+	// AddErrors stores rejected rows of an import job
+	AddErrors(ctx context.Context, rowErrors []entity.ImportRowError) error
+
+	// ListErrors retrieves the rejected rows of an import job ordered by line
+	ListErrors(ctx context.Context, jobID string) ([]entity.ImportRowError, error)
+
+	// FailUnfinished marks every pending or running job of the owner as failed with the given message
+	FailUnfinished(ctx context.Context, owner, message string) (int, error)
+}
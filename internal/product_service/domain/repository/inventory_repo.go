@@ -6,22 +6,12 @@ import (
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 )
 
+// InventoryRepository reads the stock records this service kept before stock moved to the inventory
+// service, so they can be migrated there
 type InventoryRepository interface {
-	// Create stores a new inventory record
-	Create(ctx context.Context, inventory entity.Inventory) (*entity.Inventory, error)
+	// ListLegacy retrieves up to limit legacy records ordered by ID, starting after the record afterID
+	ListLegacy(ctx context.Context, afterID string, limit int) ([]*entity.LegacyInventory, error)
 
-	// GetByProductID retrieves inventory by product ID
-	GetByProductID(ctx context.Context, productID string) (*entity.Inventory, error)
-
-	// Update updates an existing inventory
-	Update(ctx context.Context, inventory entity.Inventory) (*entity.Inventory, error)
-
-	// UpdateQuantity updates just the quantity of a product
-	UpdateQuantity(ctx context.Context, productID string, quantity int) error
-
-	// ReserveStock reserves stock for a product (for order processing)
-	ReserveStock(ctx context.Context, productID string, quantity int) error
-
-	// ReleaseReservedStock releases reserved stock back to available
-	ReleaseReservedStock(ctx context.Context, productID string, quantity int) error
+	// Delete removes a legacy record that has been migrated
+	Delete(ctx context.Context, id string) error
 }
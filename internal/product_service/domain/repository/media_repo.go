@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type MediaRepository interface {
+	// Create stores a new media record
+	Create(ctx context.Context, media entity.ProductMedia) (*entity.ProductMedia, error)
+
+	// GetByID retrieves a media record by ID
+	GetByID(ctx context.Context, id string) (*entity.ProductMedia, error)
+
+	// ListByProductID retrieves the media of a product ordered by position
+	ListByProductID(ctx context.Context, productID string) ([]entity.ProductMedia, error)
+
+	// Update updates an existing media record
+	Update(ctx context.Context, media entity.ProductMedia) (*entity.ProductMedia, error)
+
+	// SetPositions stores the position of each media record, keyed by media ID, in one transaction
+	SetPositions(ctx context.Context, productID string, positions map[string]int) error
+
+	// Delete removes a media record by ID
+	Delete(ctx context.Context, id string) error
+
+	// DeleteByProductID removes all media records of a product
+	DeleteByProductID(ctx context.Context, productID string) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// OutboxRepository stores events until they have been published
+type OutboxRepository interface {
+	// Add stores events; call it in the transaction of the change they describe
+	Add(ctx context.Context, events []entity.OutboxEvent) error
+
+	// ListPending retrieves unpublished events in the order they were added and locks them until
+	// the transaction in ctx ends. Events locked by another relay are skipped; while an earlier
+	// event is locked, nothing is returned so that events are still published in order.
+	ListPending(ctx context.Context, limit int) ([]entity.OutboxEvent, error)
+
+	// MarkPublished records that events were published
+	MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error
+
+	// MarkFailed records a failed attempt to publish events
+	MarkFailed(ctx context.Context, ids []string, reason string) error
+
+	// DeletePublishedBefore removes events published before the given time
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int, error)
+}
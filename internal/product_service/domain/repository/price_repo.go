@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// PriceRepository stores the price book of products: price history, scheduled changes and sales
+type PriceRepository interface {
+	// AddChange records a change in the price history
+	AddChange(ctx context.Context, change entity.PriceChange) error
+
+	// ListChanges retrieves the price history of a product, newest first
+	ListChanges(ctx context.Context, productID string, offset, limit int) ([]entity.PriceChange, int, error)
+
+	// GetChangeAt retrieves the latest change at or before at.
+	// When the history starts after at, the earliest change is returned; nil when there is no history.
+	GetChangeAt(ctx context.Context, productID string, at time.Time) (*entity.PriceChange, error)
+
+	// BackfillHistory records the current price of products that have no price history
+	BackfillHistory(ctx context.Context) (int, error)
+
+	// CreateScheduled stores a scheduled price change
+	CreateScheduled(ctx context.Context, scheduled entity.ScheduledPrice) (*entity.ScheduledPrice, error)
+
+	// GetScheduled retrieves a scheduled price change by ID
+	GetScheduled(ctx context.Context, id string) (*entity.ScheduledPrice, error)
+
+	// ListScheduled retrieves the scheduled price changes of a product in effective order
+	ListScheduled(ctx context.Context, productID string) ([]entity.ScheduledPrice, error)
+
+	// GetPendingAt retrieves the latest pending change of a product effective at or before at, or nil
+	GetPendingAt(ctx context.Context, productID string, at time.Time) (*entity.ScheduledPrice, error)
+
+	// ListDueScheduled retrieves pending changes effective at or before now, oldest first
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledPrice, error)
+
+	// MarkScheduledApplied records that a scheduled change was applied.
+	// It reports false when the change was already applied.
+	MarkScheduledApplied(ctx context.Context, id string, appliedAt time.Time) (bool, error)
+
+	// DeleteScheduled removes a scheduled price change
+	DeleteScheduled(ctx context.Context, id string) error
+
+	// CreateSale stores a sale
+	CreateSale(ctx context.Context, sale entity.Sale) (*entity.Sale, error)
+
+	// GetSale retrieves a sale by ID
+	GetSale(ctx context.Context, id string) (*entity.Sale, error)
+
+	// ListSales retrieves the sales of a product by start time
+	ListSales(ctx context.Context, productID string) ([]entity.Sale, error)
+
+	// GetSaleAt retrieves the sale of a product running at at, or nil
+	GetSaleAt(ctx context.Context, productID string, at time.Time) (*entity.Sale, error)
+
+	// DeleteSale removes a sale
+	DeleteSale(ctx context.Context, id string) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 )
@@ -13,12 +14,28 @@ type ProductRepository interface {
 	// GetByID retrieves a product by ID
 	GetByID(ctx context.Context, id string) (*entity.Product, error)
 
+	// LockByID retrieves a product by ID and locks it until the transaction in ctx ends
+	LockByID(ctx context.Context, id string) (*entity.Product, error)
+
 	// GetBySKU retrieves a product by SKU
 	GetBySKU(ctx context.Context, sku string) (*entity.Product, error)
 
+	// GetBySKUs retrieves the products with any of the given SKUs
+	GetBySKUs(ctx context.Context, skus []string) ([]*entity.Product, error)
+
 	// List retrieves products with optional filtering
 	List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*entity.Product, int, error)
 
+	// UpsertBySKU creates or updates products matched by SKU in a single transaction.
+	// It returns the created and the updated products.
+	UpsertBySKU(ctx context.Context, products []entity.Product) ([]*entity.Product, []*entity.Product, error)
+
+	// ListDueForPublish retrieves products in review whose publish time is at or before now
+	ListDueForPublish(ctx context.Context, now time.Time, limit int) ([]*entity.Product, error)
+
+	// ListDueForUnpublish retrieves published products whose unpublish time is at or before now
+	ListDueForUnpublish(ctx context.Context, now time.Time, limit int) ([]*entity.Product, error)
+
 	// Update updates an existing product
 	Update(ctx context.Context, product entity.Product) (*entity.Product, error)
 
@@ -27,4 +44,7 @@ type ProductRepository interface {
 
 	// GetByCategory retrieves products by category ID
 	GetByCategory(ctx context.Context, categoryID string, offset, limit int) ([]*entity.Product, int, error)
+
+	// GetByCategories retrieves products in any of the given categories
+	GetByCategories(ctx context.Context, categoryIDs []string, offset, limit int) ([]*entity.Product, int, error)
 }
@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// Transactor runs work in a single database transaction.
+// Repository calls made with the context passed to fn take part in the transaction.
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction, committing when it returns nil.
+	// Calls nested in an outer transaction join it.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
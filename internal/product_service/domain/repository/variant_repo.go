@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+type VariantRepository interface {
+	// ListOptions retrieves the option axes of a product ordered by position
+	ListOptions(ctx context.Context, productID string) ([]entity.ProductOption, error)
+
+	// ReplaceOptions replaces all option axes of a product
+	ReplaceOptions(ctx context.Context, productID string, options []entity.ProductOption) ([]entity.ProductOption, error)
+
+	// Create stores a new variant
+	Create(ctx context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error)
+
+	// GetByID retrieves a variant by ID
+	GetByID(ctx context.Context, id string) (*entity.ProductVariant, error)
+
+	// GetBySKU retrieves a variant by SKU
+	GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error)
+
+	// ListByProductID retrieves all variants of a product
+	ListByProductID(ctx context.Context, productID string) ([]entity.ProductVariant, error)
+
+	// Update updates an existing variant
+	Update(ctx context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error)
+
+	// Delete removes a variant by ID (soft delete)
+	Delete(ctx context.Context, id string) error
+}
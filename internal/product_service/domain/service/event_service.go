@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// Event types for product service
+const (
+	EventTypeProductCreated       = "product.created"
+	EventTypeProductUpdated       = "product.updated"
+	EventTypeProductDeleted       = "product.deleted"
+	EventTypeProductPriceChanged  = "product.price_changed"
+	EventTypeProductStatusChanged = "product.status_changed"
+	EventTypeVariantCreated       = "variant.created"
+	EventTypeVariantDeleted       = "variant.deleted"
+)
+
+// EventPublisherService defines the interface for publishing product events
+type EventPublisherService interface {
+	// Publish publishes outbox events in order, keyed by the product they are about
+	Publish(ctx context.Context, events []entity.OutboxEvent) error
+
+	// Close closes the publisher connections
+	Close() error
+}
@@ -5,31 +5,61 @@ import (
 	"strings"
 )
 
+// ProductStatus is a stage of the product lifecycle: draft → review → published → archived
 type ProductStatus string
 
 const (
-	Active   ProductStatus = "active"
-	Inactive ProductStatus = "inactive"
-	Draft    ProductStatus = "draft"
-	Deleted  ProductStatus = "deleted"
+	Draft     ProductStatus = "draft"
+	Review    ProductStatus = "review"
+	Published ProductStatus = "published"
+	Archived  ProductStatus = "archived"
 )
 
+// LegacyProductStatuses maps the statuses used before the lifecycle was introduced to their lifecycle stage
+var LegacyProductStatuses = map[string]ProductStatus{
+	"active":   Published,
+	"inactive": Archived,
+	"deleted":  Archived,
+}
+
+// transitions lists the statuses each status may move to
+var transitions = map[ProductStatus][]ProductStatus{
+	Draft:     {Review, Archived},
+	Review:    {Draft, Published, Archived},
+	Published: {Archived},
+	Archived:  {Draft},
+}
+
 func (s ProductStatus) String() string {
 	return string(s)
 }
 
 func (s ProductStatus) IsValid() bool {
-	statuses := [...]ProductStatus{Active, Inactive, Draft, Deleted}
-	for _, status := range statuses {
-		if s == status {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a product may move from s to next
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
 			return true
 		}
 	}
 	return false
 }
 
+// IsInitial reports whether a new product may be created with the status
+func (s ProductStatus) IsInitial() bool {
+	return s == Draft || s == Review || s == Published
+}
+
+// ParseProductStatus parses a lifecycle status, accepting the legacy statuses as aliases
 func ParseProductStatus(status string) (ProductStatus, error) {
-	status = strings.ToLower(status)
+	status = strings.ToLower(strings.TrimSpace(status))
+	if legacy, ok := LegacyProductStatuses[status]; ok {
+		return legacy, nil
+	}
 	if !ProductStatus(status).IsValid() {
 		return "", errors.New("invalid product status")
 	}
@@ -0,0 +1,431 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// exportPageSize is the number of products loaded per query during an export
+const exportPageSize = 500
+
+// BulkOptions configures bulk product imports
+type BulkOptions struct {
+	BatchSize int // rows upserted per transaction
+	MaxRows   int // largest accepted import file; 0 means unlimited
+
+	// Instance names this instance on the jobs it runs. Each instance only fails its own
+	// interrupted jobs, so it must be stable across restarts and differ between replicas.
+	Instance string
+}
+
+// BulkProductUsecase imports and exports products in bulk
+type BulkProductUsecase interface {
+	// StartImport validates the parsed rows of an import file in the background and upserts them by SKU.
+	// It returns the job immediately; progress is reported through GetImportJob.
+	StartImport(ctx context.Context, format entity.FileFormat, rows []entity.ProductImportRow) (*entity.ImportJob, error)
+
+	// GetImportJob retrieves the progress of an import job
+	GetImportJob(ctx context.Context, id string) (*entity.ImportJob, error)
+
+	// ListImportErrors retrieves the rows an import job rejected
+	ListImportErrors(ctx context.Context, id string) ([]entity.ImportRowError, error)
+
+	// FailInterruptedImports marks imports this instance was still running when it stopped as failed
+	FailInterruptedImports(ctx context.Context) error
+
+	// ExportProducts writes all products matching the filters and returns how many were written
+	ExportProducts(ctx context.Context, filters map[string]interface{}, w interfaces.ProductWriter) (int, error)
+}
+
+type bulkProductUsecase struct {
+	importJobRepo repository.ImportJobRepository
+	productRepo   repository.ProductRepository
+	categoryRepo  repository.CategoryRepository
+	variantRepo   repository.VariantRepository
+	outboxRepo    repository.OutboxRepository
+	priceRepo     repository.PriceRepository
+	transactor    repository.Transactor
+	searchIndex   interfaces.SearchIndex
+	options       BulkOptions
+	errBuilder    *utils.ErrorBuilder
+}
+
+// NewBulkProductUsecase creates a new instance of BulkProductUsecase
+func NewBulkProductUsecase(
+	jr repository.ImportJobRepository,
+	pr repository.ProductRepository,
+	cr repository.CategoryRepository,
+	vr repository.VariantRepository,
+	or repository.OutboxRepository,
+	prr repository.PriceRepository,
+	tx repository.Transactor,
+	si interfaces.SearchIndex,
+	options BulkOptions,
+) BulkProductUsecase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+	return &bulkProductUsecase{
+		importJobRepo: jr,
+		productRepo:   pr,
+		categoryRepo:  cr,
+		variantRepo:   vr,
+		outboxRepo:    or,
+		priceRepo:     prr,
+		transactor:    tx,
+		searchIndex:   si,
+		options:       options,
+		errBuilder:    utils.NewErrorBuilder("BulkProductUsecase"),
+	}
+}
+
+// StartImport validates the parsed rows of an import file in the background and upserts them by SKU.
+// It returns the job immediately; progress is reported through GetImportJob.
+func (bu *bulkProductUsecase) StartImport(ctx context.Context, format entity.FileFormat, rows []entity.ProductImportRow) (*entity.ImportJob, error) {
+	if !format.IsValid() {
+		return nil, bu.errBuilder.Err(entity.ErrUnsupportedFormat)
+	}
+	if bu.options.MaxRows > 0 && len(rows) > bu.options.MaxRows {
+		return nil, bu.errBuilder.Err(entity.ErrImportFileTooLarge)
+	}
+
+	job, err := bu.importJobRepo.Create(ctx, entity.ImportJob{
+		ID:        uuid.New().String(),
+		Format:    format,
+		Owner:     bu.options.Instance,
+		Status:    entity.ImportPending,
+		TotalRows: len(rows),
+	})
+	if err != nil {
+		return nil, bu.errBuilder.Err(err)
+	}
+
+	// The import outlives the request, so it must not use the request context
+	go bu.runImport(context.Background(), *job, rows)
+
+	return job, nil
+}
+
+// GetImportJob retrieves the progress of an import job
+func (bu *bulkProductUsecase) GetImportJob(ctx context.Context, id string) (*entity.ImportJob, error) {
+	job, err := bu.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, bu.errBuilder.Err(err)
+	}
+	return job, nil
+}
+
+// ListImportErrors retrieves the rows an import job rejected
+func (bu *bulkProductUsecase) ListImportErrors(ctx context.Context, id string) ([]entity.ImportRowError, error) {
+	if _, err := bu.importJobRepo.GetByID(ctx, id); err != nil {
+		return nil, bu.errBuilder.Err(err)
+	}
+	rowErrors, err := bu.importJobRepo.ListErrors(ctx, id)
+	if err != nil {
+		return nil, bu.errBuilder.Err(err)
+	}
+	return rowErrors, nil
+}
+
+// FailInterruptedImports marks imports this instance was still running when it stopped as failed.
+// Import rows are only held in memory, so such jobs cannot be resumed. Jobs of other instances
+// are left alone, since they may still be running.
+func (bu *bulkProductUsecase) FailInterruptedImports(ctx context.Context) error {
+	if _, err := bu.importJobRepo.FailUnfinished(ctx, bu.options.Instance, "import was interrupted by a service restart"); err != nil {
+		return bu.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// ExportProducts writes all products matching the filters and returns how many were written
+func (bu *bulkProductUsecase) ExportProducts(ctx context.Context, filters map[string]interface{}, w interfaces.ProductWriter) (int, error) {
+	written := 0
+	for offset := 0; ; offset += exportPageSize {
+		products, _, err := bu.productRepo.List(ctx, offset, exportPageSize, filters)
+		if err != nil {
+			return written, bu.errBuilder.Err(err)
+		}
+		for _, product := range products {
+			if err := w.Write(product); err != nil {
+				return written, bu.errBuilder.Err(err)
+			}
+			written++
+		}
+		if len(products) < exportPageSize {
+			break
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return written, bu.errBuilder.Err(err)
+	}
+	return written, nil
+}
+
+// runImport processes an import job batch by batch, storing progress after each batch
+func (bu *bulkProductUsecase) runImport(ctx context.Context, job entity.ImportJob, rows []entity.ProductImportRow) {
+	defer func() {
+		if r := recover(); r != nil {
+			bu.failImport(ctx, job, fmt.Errorf("%v", r))
+		}
+	}()
+
+	job.Status = entity.ImportRunning
+	job.StartedAt = utils.NowPtr()
+	if _, err := bu.importJobRepo.Update(ctx, job); err != nil {
+		return
+	}
+
+	state := &importState{
+		firstLine:  make(map[string]int),
+		categories: make(map[string]bool),
+	}
+	for start := 0; start < len(rows); start += bu.options.BatchSize {
+		batch := rows[start:min(start+bu.options.BatchSize, len(rows))]
+
+		created, updated, rowErrors := bu.importBatch(ctx, job.ID, batch, state)
+		job.ProcessedRows += len(batch)
+		job.CreatedCount += created
+		job.UpdatedCount += updated
+		job.FailedCount += len(rowErrors)
+
+		if err := bu.importJobRepo.AddErrors(ctx, rowErrors); err != nil {
+			bu.failImport(ctx, job, err)
+			return
+		}
+		if _, err := bu.importJobRepo.Update(ctx, job); err != nil {
+			return
+		}
+	}
+
+	job.Status = entity.ImportCompleted
+	job.FinishedAt = utils.NowPtr()
+	_, _ = bu.importJobRepo.Update(ctx, job)
+}
+
+// importState carries what earlier batches of an import learned to later ones
+type importState struct {
+	firstLine  map[string]int  // SKU -> line it first appeared on
+	categories map[string]bool // category ID -> exists
+}
+
+// importBatch validates a batch of rows and upserts the valid ones in one transaction.
+// When the transaction fails, every valid row of the batch is reported as failed.
+func (bu *bulkProductUsecase) importBatch(ctx context.Context, jobID string, batch []entity.ProductImportRow, state *importState) (int, int, []entity.ImportRowError) {
+	var rowErrors []entity.ImportRowError
+	reject := func(row *entity.ProductImportRow, message string) {
+		rowErrors = append(rowErrors, entity.ImportRowError{JobID: jobID, Line: row.Line, SKU: row.Product.SKU, Message: message})
+	}
+
+	if err := bu.loadCategories(ctx, batch, state.categories); err != nil {
+		for i := range batch {
+			reject(&batch[i], fmt.Sprintf("could not check category: %v", err))
+		}
+		return 0, 0, rowErrors
+	}
+
+	existing, err := bu.loadExisting(ctx, batch)
+	if err != nil {
+		for i := range batch {
+			reject(&batch[i], fmt.Sprintf("could not look up existing products: %v", err))
+		}
+		return 0, 0, rowErrors
+	}
+
+	products := make([]entity.Product, 0, len(batch))
+	rowsBySKU := make(map[string]*entity.ProductImportRow, len(batch))
+	for i := range batch {
+		row := &batch[i]
+		if message := bu.validateRow(ctx, row, existing[row.Product.SKU], state); message != "" {
+			reject(row, message)
+			continue
+		}
+
+		product := row.Product
+		product.ID = uuid.New().String()
+		products = append(products, product)
+		rowsBySKU[product.SKU] = row
+	}
+	if len(products) == 0 {
+		return 0, 0, rowErrors
+	}
+
+	// Products, their price history and events are saved together
+	var created, updated []*entity.Product
+	err = bu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, updated, err = bu.productRepo.UpsertBySKU(ctx, products)
+		if err != nil {
+			return err
+		}
+
+		var events []entity.OutboxEvent
+		for _, product := range created {
+			if err := bu.priceRepo.AddChange(ctx, *newPriceChange(nil, product, entity.PriceSourceImport)); err != nil {
+				return err
+			}
+			productEvents, err := productChangeEvents(nil, product)
+			if err != nil {
+				return err
+			}
+			events = append(events, productEvents...)
+		}
+		for _, product := range updated {
+			if change := newPriceChange(existing[product.SKU], product, entity.PriceSourceImport); change != nil {
+				if err := bu.priceRepo.AddChange(ctx, *change); err != nil {
+					return err
+				}
+			}
+			// Restored soft-deleted products have no previous state and are announced as created
+			productEvents, err := productChangeEvents(existing[product.SKU], product)
+			if err != nil {
+				return err
+			}
+			events = append(events, productEvents...)
+		}
+		return bu.outboxRepo.Add(ctx, events)
+	})
+	if err != nil {
+		for i := range products {
+			reject(rowsBySKU[products[i].SKU], fmt.Sprintf("batch was not saved: %v", err))
+		}
+		return 0, 0, rowErrors
+	}
+
+	for _, product := range created {
+		_ = bu.searchIndex.Index(ctx, product)
+	}
+	for _, product := range updated {
+		_ = bu.searchIndex.Index(ctx, product)
+	}
+	return len(created), len(updated), rowErrors
+}
+
+// validateRow checks a row and normalises its status against the product it updates, if any.
+// It returns a message describing the first problem, or an empty string when the row is valid.
+func (bu *bulkProductUsecase) validateRow(ctx context.Context, row *entity.ProductImportRow, existing *entity.Product, state *importState) string {
+	product := &row.Product
+	switch {
+	case row.Err != nil:
+		return row.Err.Error()
+	case product.SKU == "":
+		return "sku is required"
+	case product.Name == "":
+		return "name is required"
+	case product.Price <= 0:
+		return "price must be greater than zero"
+	case product.CategoryID == "":
+		return "category_id is required"
+	case !state.categories[product.CategoryID]:
+		return fmt.Sprintf("category %q not found", product.CategoryID)
+	}
+
+	if line, ok := state.firstLine[product.SKU]; ok {
+		return fmt.Sprintf("duplicate sku, first used on line %d", line)
+	}
+	state.firstLine[product.SKU] = row.Line
+
+	if message := normaliseImportStatus(product, existing); message != "" {
+		return message
+	}
+
+	if _, err := bu.variantRepo.GetBySKU(ctx, product.SKU); err == nil {
+		return "sku is already used by a product variant"
+	}
+	return ""
+}
+
+// normaliseImportStatus applies the lifecycle rules to an imported row.
+// A blank status keeps the status of the product being updated, or makes a new product a draft.
+// Updates keep the product's schedule as far as it still applies to the new status.
+func normaliseImportStatus(product *entity.Product, existing *entity.Product) string {
+	if existing == nil {
+		if product.Status == "" {
+			product.Status = valueobject.Draft.String()
+		}
+		status, err := valueobject.ParseProductStatus(product.Status)
+		if err != nil || !status.IsInitial() {
+			return fmt.Sprintf("invalid status %q for a new product", product.Status)
+		}
+		product.Status = status.String()
+		return ""
+	}
+
+	if product.Status == "" {
+		product.Status = existing.Status
+	}
+	status, err := nextStatus(existing.Status, product.Status)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return fmt.Sprintf("cannot change status from %q to %q", existing.Status, product.Status)
+		}
+		return fmt.Sprintf("invalid status %q", product.Status)
+	}
+	product.Status = status.String()
+	product.PublishAt = existing.PublishAt
+	product.UnpublishAt = existing.UnpublishAt
+	dropStaleSchedule(product)
+	return ""
+}
+
+// loadExisting retrieves the live products the batch rows update, keyed by SKU
+func (bu *bulkProductUsecase) loadExisting(ctx context.Context, batch []entity.ProductImportRow) (map[string]*entity.Product, error) {
+	skus := make([]string, 0, len(batch))
+	for i := range batch {
+		if sku := batch[i].Product.SKU; sku != "" {
+			skus = append(skus, sku)
+		}
+	}
+	products, err := bu.productRepo.GetBySKUs(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*entity.Product, len(products))
+	for _, product := range products {
+		existing[product.SKU] = product
+	}
+	return existing, nil
+}
+
+// loadCategories records which category IDs referenced by the batch exist
+func (bu *bulkProductUsecase) loadCategories(ctx context.Context, batch []entity.ProductImportRow, known map[string]bool) error {
+	var ids []string
+	for i := range batch {
+		id := batch[i].Product.CategoryID
+		if _, ok := known[id]; !ok && id != "" {
+			known[id] = false
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	categories, err := bu.categoryRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		for _, id := range ids {
+			delete(known, id)
+		}
+		return err
+	}
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+	return nil
+}
+
+// failImport marks a job as failed with the error that stopped it
+func (bu *bulkProductUsecase) failImport(ctx context.Context, job entity.ImportJob, err error) {
+	job.Status = entity.ImportFailed
+	job.Error = fmt.Sprintf("import aborted: %v", err)
+	job.FinishedAt = utils.NowPtr()
+	_, _ = bu.importJobRepo.Update(ctx, job)
+}
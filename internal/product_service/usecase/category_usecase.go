@@ -23,6 +23,15 @@ type CategoryUsecase interface {
 	// GetChildCategories retrieves child categories for a parent category
 	GetChildCategories(ctx context.Context, parentID string) ([]*entity.Category, error)
 
+	// GetCategorySubtree retrieves a category and all of its descendants ordered by level
+	GetCategorySubtree(ctx context.Context, id string) ([]*entity.Category, error)
+
+	// GetBreadcrumbs retrieves the categories from the root down to the given category
+	GetBreadcrumbs(ctx context.Context, id string) ([]*entity.Category, error)
+
+	// MoveCategory moves a category and its subtree below a new parent, or to the top level when parentID is nil
+	MoveCategory(ctx context.Context, id string, parentID *string) (*entity.Category, error)
+
 	// UpdateCategory updates an existing category
 	UpdateCategory(ctx context.Context, id string, category entity.Category) (*entity.Category, error)
 	UpdateCategoryPartial(ctx context.Context, id string, patch map[string]interface{}) (*entity.Category, error)
@@ -34,6 +43,7 @@ type CategoryUsecase interface {
 type categoryUsecase struct {
 	categoryRepo repository.CategoryRepository
 	productRepo  repository.ProductRepository
+	transactor   repository.Transactor
 	errBuilder   *utils.ErrorBuilder
 }
 
@@ -41,10 +51,12 @@ type categoryUsecase struct {
 func NewCategoryUsecase(
 	cr repository.CategoryRepository,
 	pr repository.ProductRepository,
+	tx repository.Transactor,
 ) CategoryUsecase {
 	return &categoryUsecase{
 		categoryRepo: cr,
 		productRepo:  pr,
+		transactor:   tx,
 		errBuilder:   utils.NewErrorBuilder("CategoryUsecase"),
 	}
 }
@@ -57,27 +69,23 @@ func (cu *categoryUsecase) CreateCategory(ctx context.Context, category *entity.
 		return nil, cu.errBuilder.Err(entity.ErrCategoryAlreadyExists)
 	}
 
-	// If parent ID is provided, validate parent exists
-	if category.ParentID != nil && *category.ParentID != "" {
-		parent, err := cu.categoryRepo.GetByID(ctx, *category.ParentID)
-		if err != nil {
-			return nil, cu.errBuilder.Err(entity.ErrCategoryNotFound)
-		}
-		// Set level based on parent
-		category.Level = parent.Level + 1
-	} else {
-		// Top-level category
-		category.Level = 1
-		category.ParentID = nil
-	}
-
 	// Generate ID if not provided
 	if category.ID == "" {
 		category.ID = uuid.New().String()
 	}
 
-	// Create category
-	createdCategory, err := cu.categoryRepo.Create(ctx, *category)
+	var createdCategory *entity.Category
+	err = cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Validate the parent, if any, and set level and path
+		category.Path = ""
+		if err := cu.place(ctx, category, category.ParentID); err != nil {
+			return err
+		}
+
+		// Create category
+		createdCategory, err = cu.categoryRepo.Create(ctx, *category)
+		return err
+	})
 	if err != nil {
 		return nil, cu.errBuilder.Err(err)
 	}
@@ -121,46 +129,40 @@ func (cu *categoryUsecase) GetChildCategories(ctx context.Context, parentID stri
 
 // UpdateCategory updates an existing category
 func (cu *categoryUsecase) UpdateCategory(ctx context.Context, id string, category entity.Category) (*entity.Category, error) {
-	// Ensure the category exists
-	existingCategory, err := cu.categoryRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, cu.errBuilder.Err(entity.ErrCategoryNotFound)
-	}
-
-	// If name is changing, check if new name is unique
-	if category.Name != existingCategory.Name {
-		c, err := cu.categoryRepo.GetByName(ctx, category.Name)
-		if err == nil && c != nil && c.ID != id {
-			return nil, cu.errBuilder.Err(entity.ErrCategoryAlreadyExists)
+	var updatedCategory *entity.Category
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Ensure the category exists, locking it so its path cannot change underneath us
+		existingCategory, err := cu.categoryRepo.LockByID(ctx, id)
+		if err != nil {
+			return entity.ErrCategoryNotFound
 		}
-	}
 
-	// If parent ID is changing, validate new parent exists and prevent circular references
-	if category.ParentID != nil && *category.ParentID != "" &&
-		(existingCategory.ParentID == nil || *existingCategory.ParentID != *category.ParentID) {
-
-		// Prevent setting itself as parent
-		if *category.ParentID == id {
-			return nil, cu.errBuilder.Err(errors.New("category cannot be its own parent"))
+		// If name is changing, check if new name is unique
+		if category.Name != existingCategory.Name {
+			c, err := cu.categoryRepo.GetByName(ctx, category.Name)
+			if err == nil && c != nil && c.ID != id {
+				return entity.ErrCategoryAlreadyExists
+			}
 		}
 
-		parent, err := cu.categoryRepo.GetByID(ctx, *category.ParentID)
-		if err != nil {
-			return nil, cu.errBuilder.Err(entity.ErrCategoryNotFound)
+		// Set ID to ensure we're updating the correct record
+		category.ID = id
+
+		// Keep the current position unless the parent is changing
+		parentID := category.ParentID
+		category.ParentID = existingCategory.ParentID
+		category.Level = existingCategory.Level
+		category.Path = existingCategory.Path
+		if !sameParent(existingCategory.ParentID, parentID) {
+			if err := cu.place(ctx, &category, parentID); err != nil {
+				return err
+			}
 		}
-		// Update level based on new parent
-		category.Level = parent.Level + 1
-	} else if category.ParentID == nil || *category.ParentID == "" {
-		// Changing to top-level category
-		category.Level = 1
-		category.ParentID = nil
-	}
 
-	// Set ID to ensure we're updating the correct record
-	category.ID = id
-
-	// Update the category
-	updatedCategory, err := cu.categoryRepo.Update(ctx, category)
+		// Update the category
+		updatedCategory, err = cu.save(ctx, existingCategory, category)
+		return err
+	})
 	if err != nil {
 		return nil, cu.errBuilder.Err(err)
 	}
@@ -203,63 +205,167 @@ func (cu *categoryUsecase) DeleteCategory(ctx context.Context, id string) error
 	return nil
 }
 func (cu *categoryUsecase) UpdateCategoryPartial(ctx context.Context, id string, patch map[string]interface{}) (*entity.Category, error) {
-	// Ensure the category exists
-	existingCategory, err := cu.categoryRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, cu.errBuilder.Err(entity.ErrCategoryNotFound)
-	}
+	var result *entity.Category
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Ensure the category exists, locking it so its path cannot change underneath us
+		existingCategory, err := cu.categoryRepo.LockByID(ctx, id)
+		if err != nil {
+			return entity.ErrCategoryNotFound
+		}
 
-	// Create a modifiable copy of the existing category
-	updatedCategory := *existingCategory
-
-	// Apply updates from the patch map
-	for key, value := range patch {
-		switch key {
-		case "name":
-			if name, ok := value.(string); ok && name != "" {
-				// Check if name is unique
-				if name != existingCategory.Name {
-					c, err := cu.categoryRepo.GetByName(ctx, name)
-					if err == nil && c != nil && c.ID != id {
-						return nil, cu.errBuilder.Err(entity.ErrCategoryAlreadyExists)
+		// Create a modifiable copy of the existing category
+		updatedCategory := *existingCategory
+
+		// Apply updates from the patch map
+		for key, value := range patch {
+			switch key {
+			case "name":
+				if name, ok := value.(string); ok && name != "" {
+					// Check if name is unique
+					if name != existingCategory.Name {
+						c, err := cu.categoryRepo.GetByName(ctx, name)
+						if err == nil && c != nil && c.ID != id {
+							return entity.ErrCategoryAlreadyExists
+						}
 					}
+					updatedCategory.Name = name
 				}
-				updatedCategory.Name = name
-			}
-		case "description":
-			if description, ok := value.(string); ok {
-				updatedCategory.Description = description
-			}
-		case "parent_id":
-			if parentID, ok := value.(string); ok && parentID != "" {
-				// Prevent setting itself as parent
-				if parentID == id {
-					return nil, cu.errBuilder.Err(errors.New("category cannot be its own parent"))
+			case "description":
+				if description, ok := value.(string); ok {
+					updatedCategory.Description = description
 				}
-
-				// Validate parent exists
-				parent, err := cu.categoryRepo.GetByID(ctx, parentID)
-				if err != nil {
-					return nil, cu.errBuilder.Err(entity.ErrCategoryNotFound)
+			case "parent_id":
+				if parentID, ok := value.(string); ok && parentID != "" {
+					// Validate the new parent and move the subtree below it
+					if !sameParent(existingCategory.ParentID, &parentID) {
+						if err := cu.place(ctx, &updatedCategory, &parentID); err != nil {
+							return err
+						}
+					}
+				} else if value == nil && existingCategory.ParentID != nil {
+					// Remove parent (make it a top-level category)
+					if err := cu.place(ctx, &updatedCategory, nil); err != nil {
+						return err
+					}
 				}
-
-				// Set parentID and update level
-				parentIDCopy := parentID // Create a copy to use as pointer
-				updatedCategory.ParentID = &parentIDCopy
-				updatedCategory.Level = parent.Level + 1
-			} else if value == nil {
-				// Remove parent (make it a top-level category)
-				updatedCategory.ParentID = nil
-				updatedCategory.Level = 1
 			}
 		}
-	}
 
-	// Update the category
-	result, err := cu.categoryRepo.Update(ctx, updatedCategory)
+		// Update the category
+		result, err = cu.save(ctx, existingCategory, updatedCategory)
+		return err
+	})
 	if err != nil {
 		return nil, cu.errBuilder.Err(err)
 	}
 
 	return result, nil
 }
+
+// GetCategorySubtree retrieves a category and all of its descendants ordered by level
+func (cu *categoryUsecase) GetCategorySubtree(ctx context.Context, id string) ([]*entity.Category, error) {
+	categories, err := cu.categoryRepo.GetSubtree(ctx, id)
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return categories, nil
+}
+
+// GetBreadcrumbs retrieves the categories from the root down to the given category
+func (cu *categoryUsecase) GetBreadcrumbs(ctx context.Context, id string) ([]*entity.Category, error) {
+	category, err := cu.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+
+	breadcrumbs, err := cu.categoryRepo.GetByIDs(ctx, category.AncestorIDs())
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return breadcrumbs, nil
+}
+
+// MoveCategory moves a category and its subtree below a new parent, or to the top level when parentID is nil
+func (cu *categoryUsecase) MoveCategory(ctx context.Context, id string, parentID *string) (*entity.Category, error) {
+	var movedCategory *entity.Category
+	err := cu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existingCategory, err := cu.categoryRepo.LockByID(ctx, id)
+		if err != nil {
+			return entity.ErrCategoryNotFound
+		}
+		if sameParent(existingCategory.ParentID, parentID) {
+			movedCategory = existingCategory
+			return nil
+		}
+
+		category := *existingCategory
+		if err := cu.place(ctx, &category, parentID); err != nil {
+			return err
+		}
+
+		movedCategory, err = cu.save(ctx, existingCategory, category)
+		return err
+	})
+	if err != nil {
+		return nil, cu.errBuilder.Err(err)
+	}
+	return movedCategory, nil
+}
+
+// place positions a category below the parent with the given ID, or at the top level when parentID is empty.
+// It rejects moves below the category's own subtree and moves that would nest the subtree too deeply.
+// It runs in the caller's transaction and locks the parent and the subtree, so neither path can change
+// before the category is saved.
+func (cu *categoryUsecase) place(ctx context.Context, category *entity.Category, parentID *string) error {
+	var parent *entity.Category
+	if parentID != nil && *parentID != "" {
+		p, err := cu.categoryRepo.LockByID(ctx, *parentID)
+		if err != nil {
+			return entity.ErrCategoryNotFound
+		}
+		if p.ID == category.ID || (category.Path != "" && category.IsAncestorOf(p)) {
+			return entity.ErrCategoryCycle
+		}
+		parent = p
+	}
+
+	// Height of the subtree being placed; new categories have no descendants
+	height := 1
+	if category.Path != "" {
+		subtree, err := cu.categoryRepo.LockSubtree(ctx, category.ID)
+		if err != nil {
+			return err
+		}
+		for _, c := range subtree {
+			height = max(height, c.Level-category.Level+1)
+		}
+	}
+
+	category.SetParent(parent)
+	if category.Level+height-1 > entity.MaxCategoryDepth {
+		return entity.ErrCategoryTooDeep
+	}
+	return nil
+}
+
+// save stores a category, moving its descendants along when its path changed
+func (cu *categoryUsecase) save(ctx context.Context, existingCategory *entity.Category, category entity.Category) (*entity.Category, error) {
+	if category.Path == existingCategory.Path {
+		return cu.categoryRepo.Update(ctx, category)
+	}
+	return cu.categoryRepo.MoveSubtree(ctx, category, existingCategory.Path, existingCategory.Level)
+}
+
+// sameParent reports whether two parent IDs refer to the same parent, treating empty as top-level
+func sameParent(a, b *string) bool {
+	if a != nil && *a == "" {
+		a = nil
+	}
+	if b != nil && *b == "" {
+		b = nil
+	}
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+// ImageInfo describes a decoded image
+type ImageInfo struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Thumbnail is an encoded thumbnail and its description
+type Thumbnail struct {
+	Data []byte
+	Info ImageInfo
+}
+
+// ImageProcessor validates uploaded images and generates thumbnails
+type ImageProcessor interface {
+	// Inspect decodes an image and returns its MIME type and dimensions
+	Inspect(data []byte) (ImageInfo, error)
+
+	// Thumbnails decodes an image once and scales it down to each of the given widths, keeping its aspect ratio.
+	// The thumbnails are returned in the order of widths.
+	Thumbnails(data []byte, widths []int) ([]Thumbnail, error)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// InventoryService holds the stock of products and variants, keyed by stock ID (see entity.StockID)
+type InventoryService interface {
+	// GetStock retrieves the quantity and reserved quantity held under a stock ID.
+	// It fails with ErrInventoryNotFound if the inventory service holds no stock under it.
+	GetStock(ctx context.Context, stockID string) (*entity.Inventory, error)
+
+	// ReserveStock reserves quantities by stock ID for an order, all of them or none.
+	// It fails with ErrInsufficientStock if any of them is not available.
+	ReserveStock(ctx context.Context, orderID string, quantities map[string]int) error
+
+	// CompleteReservation deducts the stock reserved for an order
+	CompleteReservation(ctx context.Context, orderID string) error
+
+	// CancelReservation releases the stock reserved for an order
+	CancelReservation(ctx context.Context, orderID string) error
+
+	// ImportStock gives a stock ID that has no stock yet the quantity of the record referenceID.
+	// It fails with ErrStockAlreadyExists if the stock ID has stock.
+	ImportStock(ctx context.Context, stockID string, quantity int, referenceID string) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "context"
+
+// MediaStorage stores uploaded product media files and serves them under a public URL
+type MediaStorage interface {
+	// Save stores data under key and returns the URL it is served from
+	Save(ctx context.Context, key, contentType string, data []byte) (string, error)
+
+	// Delete removes the file stored under key; deleting a missing file is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+
+// ProductWriter encodes products into a bulk export file
+type ProductWriter interface {
+	// Write appends a product to the file
+	Write(product *entity.Product) error
+
+	// Flush writes any buffered data to the underlying writer
+	Flush() error
+}
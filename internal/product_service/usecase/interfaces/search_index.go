@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// SearchIndex is a full-text product search backend
+type SearchIndex interface {
+	// Index adds a product to the index or replaces its previous version
+	Index(ctx context.Context, product *entity.Product) error
+
+	// Remove deletes a product from the index
+	Remove(ctx context.Context, productID string) error
+
+	// Rebuild replaces the whole index with the given products
+	Rebuild(ctx context.Context, products []*entity.Product) error
+
+	// Search returns products ranked by relevance along with facet counts
+	Search(ctx context.Context, query entity.SearchQuery) (*entity.SearchResult, error)
+}
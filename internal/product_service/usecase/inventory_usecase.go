@@ -3,247 +3,285 @@ package usecase
 import (
 	"context"
 	"errors"
-	"strconv"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
 )
 
+// defaultMigrationBatchSize is the number of legacy records migrated per batch when none is given
+const defaultMigrationBatchSize = 100
+
+// InventoryUsecase reads and reserves the stock of products and variants. Stock is held by the
+// inventory service; this service only resolves products, variants and SKUs to it.
 type InventoryUsecase interface {
 	// GetInventory retrieves inventory for a product
 	GetInventory(ctx context.Context, productID string) (*entity.Inventory, error)
 
-	// UpdateInventory updates the inventory for a product
-	UpdateInventory(ctx context.Context, productID string, quantity int) error
-	UpdateInventoryPartial(ctx context.Context, productID string, patch map[string]interface{}) (*entity.Inventory, error)
-	// ReserveStock reserves stock for a product (e.g., when added to cart)
-	ReserveStock(ctx context.Context, productID string, quantity int) error
+	// ReserveStock reserves stock of a product for an order
+	ReserveStock(ctx context.Context, orderID, productID string, quantity int) error
 
-	// ConfirmReservation confirms a reservation (e.g., after successful payment)
-	ConfirmReservation(ctx context.Context, productID string, quantity int) error
+	// ConfirmReservation confirms the reservations of an order (e.g., after successful payment)
+	ConfirmReservation(ctx context.Context, orderID string) error
 
-	// CancelReservation cancels a reservation (e.g., when removing from cart)
-	CancelReservation(ctx context.Context, productID string, quantity int) error
+	// CancelReservation cancels the reservations of an order and releases their stock
+	CancelReservation(ctx context.Context, orderID string) error
 
 	// IsInStock checks if a product is in stock
 	IsInStock(ctx context.Context, productID string, quantity int) (bool, error)
+
+	// ListProductInventory retrieves the inventory of a product and all of its variants
+	ListProductInventory(ctx context.Context, productID string) ([]*entity.Inventory, error)
+
+	// GetInventoryBySKU retrieves inventory for a product or variant SKU
+	GetInventoryBySKU(ctx context.Context, sku string) (*entity.Inventory, error)
+
+	// ReserveStockBySKU reserves stock of a product or variant SKU for an order
+	ReserveStockBySKU(ctx context.Context, orderID, sku string, quantity int) error
+
+	// IsInStockBySKU checks if a product or variant SKU is in stock
+	IsInStockBySKU(ctx context.Context, sku string, quantity int) (bool, error)
+
+	// MigrateLegacyInventory moves the stock records this service kept to the inventory service
+	MigrateLegacyInventory(ctx context.Context, batchSize int) (*entity.InventoryMigration, error)
 }
 
 // inventoryUsecase implements the InventoryUsecase interface
 type inventoryUsecase struct {
+	inventory     interfaces.InventoryService
 	inventoryRepo repository.InventoryRepository
 	productRepo   repository.ProductRepository
+	variantRepo   repository.VariantRepository
 	errBuilder    *utils.ErrorBuilder
 }
 
 // NewInventoryUsecase creates a new instance of InventoryUsecase
 func NewInventoryUsecase(
+	is interfaces.InventoryService,
 	ir repository.InventoryRepository,
 	pr repository.ProductRepository,
+	vr repository.VariantRepository,
 ) InventoryUsecase {
 	return &inventoryUsecase{
+		inventory:     is,
 		inventoryRepo: ir,
 		productRepo:   pr,
+		variantRepo:   vr,
 		errBuilder:    utils.NewErrorBuilder("InventoryUsecase"),
 	}
 }
 
 // GetInventory retrieves inventory for a product
 func (iu *inventoryUsecase) GetInventory(ctx context.Context, productID string) (*entity.Inventory, error) {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
-	if err != nil {
-		return nil, iu.errBuilder.Err(entity.ErrProductNotFound)
-	}
-
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
+	inventory, err := iu.productInventory(ctx, productID)
 	if err != nil {
 		return nil, iu.errBuilder.Err(err)
 	}
 	return inventory, nil
 }
 
-// UpdateInventory updates the inventory for a product
-func (iu *inventoryUsecase) UpdateInventory(ctx context.Context, productID string, quantity int) error {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
+// ReserveStock reserves stock of a product for an order
+func (iu *inventoryUsecase) ReserveStock(ctx context.Context, orderID, productID string, quantity int) error {
+	inventory, err := iu.productInventory(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(entity.ErrProductNotFound)
+		return iu.errBuilder.Err(err)
 	}
+	return iu.reserve(ctx, orderID, inventory, quantity)
+}
 
-	// Get current inventory to check if it exists
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
-	if err != nil {
+// ConfirmReservation confirms the reservations of an order and deducts their stock
+func (iu *inventoryUsecase) ConfirmReservation(ctx context.Context, orderID string) error {
+	if err := iu.inventory.CompleteReservation(ctx, orderID); err != nil {
 		return iu.errBuilder.Err(err)
 	}
+	return nil
+}
 
-	// Ensure quantity is not negative
-	if quantity < 0 {
-		return iu.errBuilder.Err(errors.New("quantity cannot be negative"))
+// CancelReservation cancels the reservations of an order and releases their stock
+func (iu *inventoryUsecase) CancelReservation(ctx context.Context, orderID string) error {
+	if err := iu.inventory.CancelReservation(ctx, orderID); err != nil {
+		return iu.errBuilder.Err(err)
 	}
+	return nil
+}
 
-	// Update inventory with new quantity
-	inventory.Quantity = quantity
-	_, err = iu.inventoryRepo.Update(ctx, *inventory)
+// IsInStock checks if a product is in stock
+func (iu *inventoryUsecase) IsInStock(ctx context.Context, productID string, quantity int) (bool, error) {
+	inventory, err := iu.productInventory(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(err)
+		return false, iu.errBuilder.Err(err)
 	}
 
-	return nil
+	// Check if available stock is sufficient
+	return inventory.Available() >= quantity, nil
 }
 
-// ReserveStock reserves stock for a product
-func (iu *inventoryUsecase) ReserveStock(ctx context.Context, productID string, quantity int) error {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
+// ListProductInventory retrieves the inventory of a product and all of its variants.
+// Products with variants only report the stock of their live variants.
+func (iu *inventoryUsecase) ListProductInventory(ctx context.Context, productID string) ([]*entity.Inventory, error) {
+	product, err := iu.productRepo.GetByID(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(entity.ErrProductNotFound)
+		return nil, iu.errBuilder.Err(entity.ErrProductNotFound)
 	}
 
-	// Check if there's enough stock
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
+	variants, err := iu.variantRepo.ListByProductID(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(err)
+		return nil, iu.errBuilder.Err(err)
 	}
 
-	// Check if we have enough available stock (total - reserved)
-	availableStock := inventory.Quantity - inventory.Reserved
-	if availableStock < quantity {
-		return iu.errBuilder.Err(entity.ErrInsufficientStock)
+	if len(variants) == 0 {
+		inventory, err := iu.stock(ctx, product.ID, nil, product.SKU)
+		if err != nil {
+			return nil, iu.errBuilder.Err(err)
+		}
+		return []*entity.Inventory{inventory}, nil
 	}
 
-	// Reserve the stock
-	err = iu.inventoryRepo.ReserveStock(ctx, productID, quantity)
-	if err != nil {
-		return iu.errBuilder.Err(err)
+	result := make([]*entity.Inventory, 0, len(variants))
+	for i := range variants {
+		variantID := variants[i].ID
+		inventory, err := iu.stock(ctx, product.ID, &variantID, variants[i].SKU)
+		if err != nil {
+			return nil, iu.errBuilder.Err(err)
+		}
+		result = append(result, inventory)
 	}
-
-	return nil
+	return result, nil
 }
 
-// ConfirmReservation confirms a reservation and decreases the actual stock
-func (iu *inventoryUsecase) ConfirmReservation(ctx context.Context, productID string, quantity int) error {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
+// GetInventoryBySKU retrieves inventory for a product or variant SKU
+func (iu *inventoryUsecase) GetInventoryBySKU(ctx context.Context, sku string) (*entity.Inventory, error) {
+	inventory, err := iu.skuInventory(ctx, sku)
 	if err != nil {
-		return iu.errBuilder.Err(entity.ErrProductNotFound)
+		return nil, iu.errBuilder.Err(err)
 	}
+	return inventory, nil
+}
 
-	// Get current inventory
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
+// ReserveStockBySKU reserves stock of a product or variant SKU for an order
+func (iu *inventoryUsecase) ReserveStockBySKU(ctx context.Context, orderID, sku string, quantity int) error {
+	inventory, err := iu.skuInventory(ctx, sku)
 	if err != nil {
 		return iu.errBuilder.Err(err)
 	}
+	return iu.reserve(ctx, orderID, inventory, quantity)
+}
 
-	// Check if there's enough reserved stock
-	if inventory.Reserved < quantity {
-		return iu.errBuilder.Err(errors.New("not enough reserved stock to confirm"))
+// IsInStockBySKU checks if a product or variant SKU is in stock
+func (iu *inventoryUsecase) IsInStockBySKU(ctx context.Context, sku string, quantity int) (bool, error) {
+	inventory, err := iu.skuInventory(ctx, sku)
+	if err != nil {
+		return false, iu.errBuilder.Err(err)
 	}
+	return inventory.Available() >= quantity, nil
+}
 
-	// Update inventory: decrease both quantity and reserved amount
-	inventory.Quantity -= quantity
-	inventory.Reserved -= quantity
-	_, err = iu.inventoryRepo.Update(ctx, *inventory)
-	if err != nil {
-		return iu.errBuilder.Err(err)
+// MigrateLegacyInventory imports each legacy stock record into the inventory service and deletes it.
+// Records whose stock the inventory service already has are skipped and kept, so the migration can be
+// run again after a failure. Reserved units carry no order to reserve them for, so they are imported
+// as available stock.
+func (iu *inventoryUsecase) MigrateLegacyInventory(ctx context.Context, batchSize int) (*entity.InventoryMigration, error) {
+	if batchSize < 1 {
+		batchSize = defaultMigrationBatchSize
 	}
 
-	return nil
+	migration := &entity.InventoryMigration{Skipped: []string{}}
+	afterID := ""
+	for {
+		records, err := iu.inventoryRepo.ListLegacy(ctx, afterID, batchSize)
+		if err != nil {
+			return migration, iu.errBuilder.Err(err)
+		}
+
+		for _, record := range records {
+			afterID = record.ID
+			stockID := entity.StockID(record.ProductID, record.VariantID)
+			err := iu.inventory.ImportStock(ctx, stockID, record.Quantity, record.ID)
+			if errors.Is(err, entity.ErrStockAlreadyExists) {
+				migration.Skipped = append(migration.Skipped, record.ID)
+				continue
+			}
+			if err != nil {
+				return migration, iu.errBuilder.Err(err)
+			}
+
+			if err := iu.inventoryRepo.Delete(ctx, record.ID); err != nil {
+				return migration, iu.errBuilder.Err(err)
+			}
+			migration.Migrated++
+			migration.ReleasedReserved += record.Reserved
+		}
+
+		if len(records) < batchSize {
+			return migration, nil
+		}
+	}
 }
 
-// CancelReservation cancels a reservation and releases the stock
-func (iu *inventoryUsecase) CancelReservation(ctx context.Context, productID string, quantity int) error {
+// productInventory retrieves the product-level inventory of a product without variants
+func (iu *inventoryUsecase) productInventory(ctx context.Context, productID string) (*entity.Inventory, error) {
 	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
+	product, err := iu.productRepo.GetByID(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(entity.ErrProductNotFound)
+		return nil, entity.ErrProductNotFound
 	}
 
-	// Release the reserved stock
-	err = iu.inventoryRepo.ReleaseReservedStock(ctx, productID, quantity)
+	// Stock of products with variants is tracked per variant SKU
+	variants, err := iu.variantRepo.ListByProductID(ctx, productID)
 	if err != nil {
-		return iu.errBuilder.Err(err)
+		return nil, err
+	}
+	if len(variants) > 0 {
+		return nil, entity.ErrVariantRequired
 	}
 
-	return nil
+	return iu.stock(ctx, product.ID, nil, product.SKU)
 }
 
-// IsInStock checks if a product is in stock
-func (iu *inventoryUsecase) IsInStock(ctx context.Context, productID string, quantity int) (bool, error) {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
-	if err != nil {
-		return false, iu.errBuilder.Err(entity.ErrProductNotFound)
+// skuInventory resolves a variant SKU or a product SKU to its inventory
+func (iu *inventoryUsecase) skuInventory(ctx context.Context, sku string) (*entity.Inventory, error) {
+	variant, err := iu.variantRepo.GetBySKU(ctx, sku)
+	if err == nil {
+		variantID := variant.ID
+		return iu.stock(ctx, variant.ProductID, &variantID, variant.SKU)
 	}
-
-	// Get inventory
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
-	if err != nil {
-		return false, iu.errBuilder.Err(err)
+	if !errors.Is(err, entity.ErrVariantNotFound) {
+		return nil, err
 	}
 
-	// Check if available stock is sufficient
-	availableStock := inventory.Quantity - inventory.Reserved
-	return availableStock >= quantity, nil
-}
-func (iu *inventoryUsecase) UpdateInventoryPartial(ctx context.Context, productID string, patch map[string]interface{}) (*entity.Inventory, error) {
-	// Ensure the product exists
-	_, err := iu.productRepo.GetByID(ctx, productID)
+	product, err := iu.productRepo.GetBySKU(ctx, sku)
 	if err != nil {
-		return nil, iu.errBuilder.Err(entity.ErrProductNotFound)
+		return nil, err
 	}
+	return iu.productInventory(ctx, product.ID)
+}
 
-	// Get current inventory
-	inventory, err := iu.inventoryRepo.GetByProductID(ctx, productID)
-	if err != nil {
-		return nil, iu.errBuilder.Err(err)
+// stock retrieves the stock of a product or variant from the inventory service. Products and variants
+// the inventory service has not provisioned yet have no stock.
+func (iu *inventoryUsecase) stock(ctx context.Context, productID string, variantID *string, sku string) (*entity.Inventory, error) {
+	inventory, err := iu.inventory.GetStock(ctx, entity.StockID(productID, variantID))
+	if errors.Is(err, entity.ErrInventoryNotFound) {
+		inventory = &entity.Inventory{}
+	} else if err != nil {
+		return nil, err
 	}
 
-	// Create a modifiable copy
-	updatedInventory := *inventory
-
-	// Apply updates from patch
-	for key, value := range patch {
-		switch key {
-		case "quantity":
-			switch v := value.(type) {
-			case float64:
-				if v >= 0 {
-					updatedInventory.Quantity = int(v)
-				}
-			case int:
-				if v >= 0 {
-					updatedInventory.Quantity = v
-				}
-			case string:
-				if quantity, err := strconv.Atoi(v); err == nil && quantity >= 0 {
-					updatedInventory.Quantity = quantity
-				}
-			}
-		case "reserved":
-			switch v := value.(type) {
-			case float64:
-				if v >= 0 && int(v) <= updatedInventory.Quantity {
-					updatedInventory.Reserved = int(v)
-				}
-			case int:
-				if v >= 0 && v <= updatedInventory.Quantity {
-					updatedInventory.Reserved = v
-				}
-			case string:
-				if reserved, err := strconv.Atoi(v); err == nil && reserved >= 0 && reserved <= updatedInventory.Quantity {
-					updatedInventory.Reserved = reserved
-				}
-			}
-		}
+	inventory.ProductID = productID
+	inventory.VariantID = variantID
+	inventory.SKU = sku
+	return inventory, nil
+}
+
+// reserve reserves stock of a product or variant for an order
+func (iu *inventoryUsecase) reserve(ctx context.Context, orderID string, inventory *entity.Inventory, quantity int) error {
+	if orderID == "" || quantity < 1 {
+		return iu.errBuilder.Err(errors.New("order ID and a positive quantity are required"))
 	}
 
-	// Update inventory
-	result, err := iu.inventoryRepo.Update(ctx, updatedInventory)
+	err := iu.inventory.ReserveStock(ctx, orderID, map[string]int{inventory.StockID(): quantity})
 	if err != nil {
-		return nil, iu.errBuilder.Err(err)
+		return iu.errBuilder.Err(err)
 	}
-
-	return result, nil
+	return nil
 }
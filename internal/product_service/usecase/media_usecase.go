@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// fileExtensions maps image MIME types to the extension used in storage keys
+var fileExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// MediaOptions configures media uploads
+type MediaOptions struct {
+	ThumbnailWidths []int // widths of the generated thumbnails; wider than the image are skipped
+	MaxUploadSize   int64 // bytes
+}
+
+// MediaUsecase manages the ordered images of a product
+type MediaUsecase interface {
+	// UploadMedia stores an image, generates its thumbnails and appends it to the product's images
+	UploadMedia(ctx context.Context, productID string, data []byte, altText string) (*entity.ProductMedia, error)
+
+	// ListMedia retrieves the images of a product in display order
+	ListMedia(ctx context.Context, productID string) ([]entity.ProductMedia, error)
+
+	// UpdateMedia changes the alt text of an image
+	UpdateMedia(ctx context.Context, productID, mediaID, altText string) (*entity.ProductMedia, error)
+
+	// ReorderMedia sets the display order of all images of a product
+	ReorderMedia(ctx context.Context, productID string, mediaIDs []string) ([]entity.ProductMedia, error)
+
+	// DeleteMedia removes an image and its stored files
+	DeleteMedia(ctx context.Context, productID, mediaID string) error
+
+	// DeleteProductMedia removes all images of a product and their stored files
+	DeleteProductMedia(ctx context.Context, productID string) error
+}
+
+type mediaUsecase struct {
+	mediaRepo   repository.MediaRepository
+	productRepo repository.ProductRepository
+	outboxRepo  repository.OutboxRepository
+	transactor  repository.Transactor
+	searchIndex interfaces.SearchIndex
+	storage     interfaces.MediaStorage
+	processor   interfaces.ImageProcessor
+	options     MediaOptions
+	errBuilder  *utils.ErrorBuilder
+}
+
+// NewMediaUsecase creates a new instance of MediaUsecase
+func NewMediaUsecase(
+	mr repository.MediaRepository,
+	pr repository.ProductRepository,
+	or repository.OutboxRepository,
+	tx repository.Transactor,
+	si interfaces.SearchIndex,
+	storage interfaces.MediaStorage,
+	processor interfaces.ImageProcessor,
+	options MediaOptions,
+) MediaUsecase {
+	return &mediaUsecase{
+		mediaRepo:   mr,
+		productRepo: pr,
+		outboxRepo:  or,
+		transactor:  tx,
+		searchIndex: si,
+		storage:     storage,
+		processor:   processor,
+		options:     options,
+		errBuilder:  utils.NewErrorBuilder("MediaUsecase"),
+	}
+}
+
+// UploadMedia stores an image, generates its thumbnails and appends it to the product's images.
+// Files already stored are removed again if a later step fails.
+func (mu *mediaUsecase) UploadMedia(ctx context.Context, productID string, data []byte, altText string) (*entity.ProductMedia, error) {
+	if _, err := mu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, mu.errBuilder.Err(entity.ErrProductNotFound)
+	}
+	if mu.options.MaxUploadSize > 0 && int64(len(data)) > mu.options.MaxUploadSize {
+		return nil, mu.errBuilder.Err(entity.ErrMediaTooLarge)
+	}
+	info, err := mu.processor.Inspect(data)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	existing, err := mu.mediaRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	media := entity.ProductMedia{
+		ID:          uuid.New().String(),
+		ProductID:   productID,
+		AltText:     altText,
+		ContentType: info.ContentType,
+		Width:       info.Width,
+		Height:      info.Height,
+		Size:        int64(len(data)),
+	}
+	if len(existing) > 0 {
+		media.Position = existing[len(existing)-1].Position + 1
+	}
+
+	media.StorageKey = mu.storageKey(&media, "original", info.ContentType)
+	media.URL, err = mu.storage.Save(ctx, media.StorageKey, info.ContentType, data)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	if err := mu.generateThumbnails(ctx, &media, data); err != nil {
+		mu.deleteFiles(ctx, &media)
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	created, err := mu.mediaRepo.Create(ctx, media)
+	if err != nil {
+		mu.deleteFiles(ctx, &media)
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	if err := mu.syncPrimaryImage(ctx, productID, ""); err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+	return created, nil
+}
+
+// ListMedia retrieves the images of a product in display order
+func (mu *mediaUsecase) ListMedia(ctx context.Context, productID string) ([]entity.ProductMedia, error) {
+	media, err := mu.mediaRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+	return media, nil
+}
+
+// UpdateMedia changes the alt text of an image
+func (mu *mediaUsecase) UpdateMedia(ctx context.Context, productID, mediaID, altText string) (*entity.ProductMedia, error) {
+	media, err := mu.getProductMedia(ctx, productID, mediaID)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	media.AltText = altText
+	updated, err := mu.mediaRepo.Update(ctx, *media)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// ReorderMedia sets the display order of all images of a product.
+// mediaIDs must list every image of the product exactly once.
+func (mu *mediaUsecase) ReorderMedia(ctx context.Context, productID string, mediaIDs []string) ([]entity.ProductMedia, error) {
+	existing, err := mu.mediaRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+	if len(mediaIDs) != len(existing) {
+		return nil, mu.errBuilder.Err(entity.ErrInvalidMediaOrder)
+	}
+
+	known := make(map[string]bool, len(existing))
+	for _, media := range existing {
+		known[media.ID] = true
+	}
+	positions := make(map[string]int, len(mediaIDs))
+	for i, id := range mediaIDs {
+		if _, dup := positions[id]; dup || !known[id] {
+			return nil, mu.errBuilder.Err(entity.ErrInvalidMediaOrder)
+		}
+		positions[id] = i
+	}
+
+	if err := mu.mediaRepo.SetPositions(ctx, productID, positions); err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+	if err := mu.syncPrimaryImage(ctx, productID, ""); err != nil {
+		return nil, mu.errBuilder.Err(err)
+	}
+
+	for i := range existing {
+		existing[i].Position = positions[existing[i].ID]
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].Position < existing[j].Position })
+	return existing, nil
+}
+
+// DeleteMedia removes an image and its stored files
+func (mu *mediaUsecase) DeleteMedia(ctx context.Context, productID, mediaID string) error {
+	media, err := mu.getProductMedia(ctx, productID, mediaID)
+	if err != nil {
+		return mu.errBuilder.Err(err)
+	}
+
+	if err := mu.mediaRepo.Delete(ctx, media.ID); err != nil {
+		return mu.errBuilder.Err(err)
+	}
+	mu.deleteFiles(ctx, media)
+
+	if err := mu.syncPrimaryImage(ctx, productID, media.URL); err != nil {
+		return mu.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// DeleteProductMedia removes all images of a product and their stored files.
+// It does not touch the product itself, which may already be deleted.
+func (mu *mediaUsecase) DeleteProductMedia(ctx context.Context, productID string) error {
+	media, err := mu.mediaRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return mu.errBuilder.Err(err)
+	}
+	if len(media) == 0 {
+		return nil
+	}
+
+	if err := mu.mediaRepo.DeleteByProductID(ctx, productID); err != nil {
+		return mu.errBuilder.Err(err)
+	}
+	for i := range media {
+		mu.deleteFiles(ctx, &media[i])
+	}
+	return nil
+}
+
+// generateThumbnails stores a thumbnail for every configured width smaller than the image
+func (mu *mediaUsecase) generateThumbnails(ctx context.Context, media *entity.ProductMedia, data []byte) error {
+	var widths []int
+	for _, width := range mu.options.ThumbnailWidths {
+		if width > 0 && width < media.Width {
+			widths = append(widths, width)
+		}
+	}
+	if len(widths) == 0 {
+		return nil
+	}
+
+	thumbnails, err := mu.processor.Thumbnails(data, widths)
+	if err != nil {
+		return err
+	}
+	for i, rendered := range thumbnails {
+		info := rendered.Info
+		thumbnail := entity.MediaThumbnail{
+			Width:      info.Width,
+			Height:     info.Height,
+			StorageKey: mu.storageKey(media, fmt.Sprintf("w%d", widths[i]), info.ContentType),
+		}
+		thumbnail.URL, err = mu.storage.Save(ctx, thumbnail.StorageKey, info.ContentType, rendered.Data)
+		if err != nil {
+			return err
+		}
+		media.Thumbnails = append(media.Thumbnails, thumbnail)
+	}
+	return nil
+}
+
+// getProductMedia retrieves a media record and checks that it belongs to the product
+func (mu *mediaUsecase) getProductMedia(ctx context.Context, productID, mediaID string) (*entity.ProductMedia, error) {
+	media, err := mu.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return nil, entity.ErrMediaNotFound
+	}
+	if media.ProductID != productID {
+		return nil, entity.ErrMediaNotFound
+	}
+	return media, nil
+}
+
+// deleteFiles removes the stored files of an image.
+// Failures only leave orphaned files behind, so they don't fail the operation.
+func (mu *mediaUsecase) deleteFiles(ctx context.Context, media *entity.ProductMedia) {
+	for _, key := range media.StorageKeys() {
+		if key != "" {
+			_ = mu.storage.Delete(ctx, key)
+		}
+	}
+}
+
+// syncPrimaryImage keeps Product.ImageURL pointing at the first image for clients that only read a single image.
+// An image URL set directly on the product is kept until images are uploaded; removedURL is cleared when no image is left.
+// Like any other product change, product.updated is added to the outbox with the update and the search index is refreshed.
+func (mu *mediaUsecase) syncPrimaryImage(ctx context.Context, productID, removedURL string) error {
+	var updated *entity.Product
+	err := mu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := mu.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		media, err := mu.mediaRepo.ListByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		primary := product.ImageURL
+		if len(media) > 0 {
+			primary = media[0].URL
+		} else if removedURL != "" && product.ImageURL == removedURL {
+			primary = ""
+		}
+		if primary == product.ImageURL {
+			return nil
+		}
+
+		previous := *product
+		product.ImageURL = primary
+		updated, err = mu.productRepo.Update(ctx, *product)
+		if err != nil {
+			return err
+		}
+		events, err := productChangeEvents(&previous, updated)
+		if err != nil {
+			return err
+		}
+		return mu.outboxRepo.Add(ctx, events)
+	})
+	if err != nil || updated == nil {
+		return err
+	}
+
+	// Failures don't fail the change itself; the index is rebuilt from the database on startup
+	_ = mu.searchIndex.Index(ctx, updated)
+	return nil
+}
+
+// storageKey builds the storage key of one rendition of an image
+func (mu *mediaUsecase) storageKey(media *entity.ProductMedia, name, contentType string) string {
+	return fmt.Sprintf("products/%s/%s/%s.%s", media.ProductID, media.ID, name, fileExtensions[contentType])
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// OutboxOptions configures the outbox relay
+type OutboxOptions struct {
+	BatchSize int           // events published per Kafka write
+	Retention time.Duration // how long published events are kept
+}
+
+// OutboxUsecase relays events stored in the outbox to the event publisher
+type OutboxUsecase interface {
+	// RelayPending publishes pending events in order until none are left or publishing fails.
+	// It returns the number of events published.
+	RelayPending(ctx context.Context) (int, error)
+
+	// PurgePublished removes events published longer ago than the retention period
+	PurgePublished(ctx context.Context) (int, error)
+}
+
+type outboxUsecase struct {
+	outboxRepo repository.OutboxRepository
+	transactor repository.Transactor
+	eventPub   service.EventPublisherService
+	options    OutboxOptions
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewOutboxUsecase creates a new instance of OutboxUsecase
+func NewOutboxUsecase(
+	or repository.OutboxRepository,
+	tx repository.Transactor,
+	ep service.EventPublisherService,
+	options OutboxOptions,
+) OutboxUsecase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+	return &outboxUsecase{
+		outboxRepo: or,
+		transactor: tx,
+		eventPub:   ep,
+		options:    options,
+		errBuilder: utils.NewErrorBuilder("OutboxUsecase"),
+	}
+}
+
+// RelayPending publishes pending events in order until none are left or publishing fails.
+// Each batch stays locked from listing until it is marked published, so relays running in
+// other replicas never publish it as well. A failed batch is retried from its first event on
+// the next call, so events are delivered at least once and consumers must tolerate duplicates.
+func (ou *outboxUsecase) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		var ids []string
+		var publishErr error
+		err := ou.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			events, err := ou.outboxRepo.ListPending(ctx, ou.options.BatchSize)
+			if err != nil || len(events) == 0 {
+				return err
+			}
+
+			ids = make([]string, len(events))
+			for i := range events {
+				ids[i] = events[i].ID
+			}
+
+			if publishErr = ou.eventPub.Publish(ctx, events); publishErr != nil {
+				return publishErr
+			}
+			return ou.outboxRepo.MarkPublished(ctx, ids, time.Now())
+		})
+		if publishErr != nil {
+			// Recorded outside the rolled back transaction
+			_ = ou.outboxRepo.MarkFailed(ctx, ids, publishErr.Error())
+		}
+		if err != nil {
+			return published, ou.errBuilder.Err(err)
+		}
+		published += len(ids)
+
+		if len(ids) < ou.options.BatchSize {
+			return published, nil
+		}
+	}
+}
+
+// PurgePublished removes events published longer ago than the retention period
+func (ou *outboxUsecase) PurgePublished(ctx context.Context) (int, error) {
+	if ou.options.Retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := ou.outboxRepo.DeletePublishedBefore(ctx, time.Now().Add(-ou.options.Retention))
+	if err != nil {
+		return 0, ou.errBuilder.Err(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,366 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// PriceUsecase manages the price book of products: price history, scheduled changes and sales
+type PriceUsecase interface {
+	// GetPriceHistory retrieves the price changes of a product, newest first
+	GetPriceHistory(ctx context.Context, productID string, page, pageSize int) ([]entity.PriceChange, int, error)
+
+	// SchedulePriceChange schedules a change of the base price of a product
+	SchedulePriceChange(ctx context.Context, productID string, price float64, effectiveAt time.Time) (*entity.ScheduledPrice, error)
+
+	// ListScheduledPrices retrieves the scheduled price changes of a product, applied ones included
+	ListScheduledPrices(ctx context.Context, productID string) ([]entity.ScheduledPrice, error)
+
+	// CancelScheduledPrice removes a scheduled price change that has not been applied yet
+	CancelScheduledPrice(ctx context.Context, productID, id string) error
+
+	// ApplyScheduledPrices applies the scheduled price changes due at now.
+	// It returns the number of changes applied.
+	ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error)
+
+	// CreateSale puts a product on sale for a period
+	CreateSale(ctx context.Context, sale *entity.Sale) (*entity.Sale, error)
+
+	// ListSales retrieves the sales of a product
+	ListSales(ctx context.Context, productID string) ([]entity.Sale, error)
+
+	// DeleteSale removes a sale
+	DeleteSale(ctx context.Context, productID, id string) error
+
+	// GetEffectivePrice resolves the price of a product, or of one of its variants, at a point in time
+	GetEffectivePrice(ctx context.Context, productID, variantID string, at time.Time) (*entity.EffectivePrice, error)
+}
+
+type priceUsecase struct {
+	priceRepo      repository.PriceRepository
+	productRepo    repository.ProductRepository
+	variantRepo    repository.VariantRepository
+	productUsecase ProductUsecase
+	transactor     repository.Transactor
+	errBuilder     *utils.ErrorBuilder
+}
+
+// NewPriceUsecase creates a new instance of PriceUsecase
+func NewPriceUsecase(
+	prr repository.PriceRepository,
+	pr repository.ProductRepository,
+	vr repository.VariantRepository,
+	pu ProductUsecase,
+	tx repository.Transactor,
+) PriceUsecase {
+	return &priceUsecase{
+		priceRepo:      prr,
+		productRepo:    pr,
+		variantRepo:    vr,
+		productUsecase: pu,
+		transactor:     tx,
+		errBuilder:     utils.NewErrorBuilder("PriceUsecase"),
+	}
+}
+
+// GetPriceHistory retrieves the price changes of a product, newest first
+func (pu *priceUsecase) GetPriceHistory(ctx context.Context, productID string, page, pageSize int) ([]entity.PriceChange, int, error) {
+	if _, err := pu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, 0, pu.errBuilder.Err(err)
+	}
+
+	offset := (page - 1) * pageSize
+	changes, total, err := pu.priceRepo.ListChanges(ctx, productID, offset, pageSize)
+	if err != nil {
+		return nil, 0, pu.errBuilder.Err(err)
+	}
+	return changes, total, nil
+}
+
+// SchedulePriceChange schedules a change of the base price of a product.
+// The change must take effect in the future; use the product itself to change the price now.
+func (pu *priceUsecase) SchedulePriceChange(ctx context.Context, productID string, price float64, effectiveAt time.Time) (*entity.ScheduledPrice, error) {
+	if price <= 0 {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPrice)
+	}
+	if !effectiveAt.After(time.Now()) {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidSchedule)
+	}
+	if _, err := pu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	scheduled, err := pu.priceRepo.CreateScheduled(ctx, entity.ScheduledPrice{
+		ID:          uuid.New().String(),
+		ProductID:   productID,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return scheduled, nil
+}
+
+// ListScheduledPrices retrieves the scheduled price changes of a product, applied ones included
+func (pu *priceUsecase) ListScheduledPrices(ctx context.Context, productID string) ([]entity.ScheduledPrice, error) {
+	if _, err := pu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	scheduled, err := pu.priceRepo.ListScheduled(ctx, productID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return scheduled, nil
+}
+
+// CancelScheduledPrice removes a scheduled price change that has not been applied yet.
+// Applied changes are kept as part of the price book.
+func (pu *priceUsecase) CancelScheduledPrice(ctx context.Context, productID, id string) error {
+	scheduled, err := pu.priceRepo.GetScheduled(ctx, id)
+	if err != nil {
+		return pu.errBuilder.Err(err)
+	}
+	if scheduled.ProductID != productID {
+		return pu.errBuilder.Err(entity.ErrScheduledPriceNotFound)
+	}
+	if !scheduled.IsPending() {
+		return pu.errBuilder.Err(entity.ErrScheduledPriceApplied)
+	}
+
+	if err := pu.priceRepo.DeleteScheduled(ctx, id); err != nil {
+		return pu.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// ApplyScheduledPrices applies the scheduled price changes due at now in effective order,
+// each in its own transaction. Every replica runs it, so a change is claimed by marking it applied
+// before the price is set; a change another replica claimed is skipped. Changes of deleted products
+// are marked applied without effect. When a change fails, the later changes of its product are left
+// for the next run and the failures are returned together.
+func (pu *priceUsecase) ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error) {
+	applied := 0
+	var errs []error
+	seen := make(map[string]bool)
+	failedProducts := make(map[string]bool)
+	for {
+		due, err := pu.priceRepo.ListDueScheduled(ctx, now, scheduledBatchSize)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		progressed := false
+		for _, scheduled := range due {
+			if seen[scheduled.ID] {
+				continue
+			}
+			seen[scheduled.ID] = true
+			progressed = true
+			if failedProducts[scheduled.ProductID] {
+				continue
+			}
+
+			claimed := false
+			err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				claimed, err = pu.priceRepo.MarkScheduledApplied(ctx, scheduled.ID, now)
+				if err != nil || !claimed {
+					return err
+				}
+				_, err = pu.productUsecase.SetPrice(ctx, scheduled.ProductID, scheduled.Price, entity.PriceSourceScheduled)
+				if err != nil && !errors.Is(err, entity.ErrProductNotFound) {
+					return err
+				}
+				return nil
+			})
+			if err != nil {
+				failedProducts[scheduled.ProductID] = true
+				errs = append(errs, fmt.Errorf("scheduled price %s: %w", scheduled.ID, err))
+				continue
+			}
+			if claimed {
+				applied++
+			}
+		}
+		// Applied changes no longer match, so the next call returns the following batch.
+		// Skipped changes still do; stop once a batch holds nothing else.
+		if len(due) < scheduledBatchSize || !progressed {
+			break
+		}
+	}
+	if len(errs) > 0 {
+		return applied, pu.errBuilder.Err(errors.Join(errs...))
+	}
+	return applied, nil
+}
+
+// CreateSale puts a product on sale for a period. Sales of a product may not overlap,
+// so at most one sale price applies at any time. The product stays locked from the overlap
+// check until the sale is stored, so concurrent sales of the product are checked one at a time.
+func (pu *priceUsecase) CreateSale(ctx context.Context, sale *entity.Sale) (*entity.Sale, error) {
+	if sale.Price <= 0 {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPrice)
+	}
+	if sale.StartsAt.IsZero() {
+		sale.StartsAt = time.Now()
+	}
+	if sale.EndsAt != nil && !sale.EndsAt.After(sale.StartsAt) {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidSalePeriod)
+	}
+
+	var createdSale *entity.Sale
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := pu.productRepo.LockByID(ctx, sale.ProductID); err != nil {
+			return err
+		}
+
+		sales, err := pu.priceRepo.ListSales(ctx, sale.ProductID)
+		if err != nil {
+			return err
+		}
+		for i := range sales {
+			if sale.Overlaps(&sales[i]) {
+				return entity.ErrSaleOverlap
+			}
+		}
+
+		sale.ID = uuid.New().String()
+		createdSale, err = pu.priceRepo.CreateSale(ctx, *sale)
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return createdSale, nil
+}
+
+// ListSales retrieves the sales of a product
+func (pu *priceUsecase) ListSales(ctx context.Context, productID string) ([]entity.Sale, error) {
+	if _, err := pu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	sales, err := pu.priceRepo.ListSales(ctx, productID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return sales, nil
+}
+
+// DeleteSale removes a sale
+func (pu *priceUsecase) DeleteSale(ctx context.Context, productID, id string) error {
+	sale, err := pu.priceRepo.GetSale(ctx, id)
+	if err != nil {
+		return pu.errBuilder.Err(err)
+	}
+	if sale.ProductID != productID {
+		return pu.errBuilder.Err(entity.ErrSaleNotFound)
+	}
+
+	if err := pu.priceRepo.DeleteSale(ctx, id); err != nil {
+		return pu.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// GetEffectivePrice resolves the price of a product, or of one of its variants, at a point in time.
+// The base price comes from the price history for past times and from pending scheduled changes
+// for future ones. A variant price override replaces the base price, and a sale running at that
+// time applies when it is lower, with the base price reported as the compare-at price.
+func (pu *priceUsecase) GetEffectivePrice(ctx context.Context, productID, variantID string, at time.Time) (*entity.EffectivePrice, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	product, err := pu.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	basePrice, err := pu.basePriceAt(ctx, product, at)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	price := &entity.EffectivePrice{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		At:        at,
+	}
+	if variantID != "" {
+		variant, err := pu.variantRepo.GetByID(ctx, variantID)
+		if err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+		if variant.ProductID != product.ID {
+			return nil, pu.errBuilder.Err(entity.ErrVariantNotFound)
+		}
+		price.VariantID = variant.ID
+		price.SKU = variant.SKU
+		basePrice = variant.EffectivePrice(basePrice)
+	}
+	price.BasePrice = basePrice
+	price.Price = basePrice
+
+	sale, err := pu.priceRepo.GetSaleAt(ctx, product.ID, at)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if sale != nil && sale.Price < basePrice {
+		compareAt := basePrice
+		price.Price = sale.Price
+		price.CompareAtPrice = &compareAt
+		price.SaleID = sale.ID
+	}
+	return price, nil
+}
+
+// basePriceAt resolves the base price of a product at a point in time, ignoring sales
+func (pu *priceUsecase) basePriceAt(ctx context.Context, product *entity.Product, at time.Time) (float64, error) {
+	if at.After(time.Now()) {
+		scheduled, err := pu.priceRepo.GetPendingAt(ctx, product.ID, at)
+		if err != nil || scheduled == nil {
+			return product.Price, err
+		}
+		return scheduled.Price, nil
+	}
+
+	change, err := pu.priceRepo.GetChangeAt(ctx, product.ID, at)
+	if err != nil || change == nil {
+		return product.Price, err
+	}
+	// The history starts after at: the price before its first change, or the initial
+	// price of a product that did not exist yet
+	if change.ChangedAt.After(at) && change.OldPrice > 0 {
+		return change.OldPrice, nil
+	}
+	return change.NewPrice, nil
+}
+
+// newPriceChange describes the price change from previous to current, or returns nil when the
+// price did not change. A product without a previous state records its initial price.
+func newPriceChange(previous, current *entity.Product, source entity.PriceSource) *entity.PriceChange {
+	change := &entity.PriceChange{
+		ID:        uuid.New().String(),
+		ProductID: current.ID,
+		NewPrice:  current.Price,
+		Source:    source,
+		ChangedAt: time.Now(),
+	}
+	if previous != nil {
+		if previous.Price == current.Price {
+			return nil
+		}
+		change.OldPrice = previous.Price
+	}
+	return change
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+)
+
+// newProductEvent builds an outbox event about product.
+// previous is the product before the change, if there was one.
+func newProductEvent(eventType string, product, previous *entity.Product) (entity.OutboxEvent, error) {
+	id := uuid.New().String()
+	now := time.Now()
+
+	payload := entity.ProductEvent{
+		EventID:     id,
+		EventType:   eventType,
+		Timestamp:   now,
+		ProductID:   product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+		Status:      product.Status,
+		PublishAt:   product.PublishAt,
+		UnpublishAt: product.UnpublishAt,
+	}
+	if previous != nil {
+		previousPrice := previous.Price
+		payload.PreviousPrice = &previousPrice
+		payload.PreviousStatus = previous.Status
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+	return entity.OutboxEvent{
+		ID:          id,
+		AggregateID: product.ID,
+		EventType:   eventType,
+		Payload:     data,
+		CreatedAt:   now,
+	}, nil
+}
+
+// newVariantEvent builds an outbox event about a variant of a product.
+// It is keyed by the product so consumers see it in order with the product's own events.
+func newVariantEvent(eventType string, variant *entity.ProductVariant) (entity.OutboxEvent, error) {
+	id := uuid.New().String()
+	now := time.Now()
+
+	data, err := json.Marshal(entity.ProductEvent{
+		EventID:   id,
+		EventType: eventType,
+		Timestamp: now,
+		ProductID: variant.ProductID,
+		VariantID: variant.ID,
+		SKU:       variant.SKU,
+	})
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+	return entity.OutboxEvent{
+		ID:          id,
+		AggregateID: variant.ProductID,
+		EventType:   eventType,
+		Payload:     data,
+		CreatedAt:   now,
+	}, nil
+}
+
+// productChangeEvents describes the change from previous to current: product.updated,
+// followed by product.price_changed and product.status_changed when those changed.
+// A product without a previous state is announced as product.created.
+func productChangeEvents(previous, current *entity.Product) ([]entity.OutboxEvent, error) {
+	if previous == nil {
+		event, err := newProductEvent(service.EventTypeProductCreated, current, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []entity.OutboxEvent{event}, nil
+	}
+
+	eventTypes := []string{service.EventTypeProductUpdated}
+	if current.Price != previous.Price {
+		eventTypes = append(eventTypes, service.EventTypeProductPriceChanged)
+	}
+	if current.Status != previous.Status {
+		eventTypes = append(eventTypes, service.EventTypeProductStatusChanged)
+	}
+
+	events := make([]entity.OutboxEvent, len(eventTypes))
+	for i, eventType := range eventTypes {
+		event, err := newProductEvent(eventType, current, previous)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	return events, nil
+}
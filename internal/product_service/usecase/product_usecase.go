@@ -2,11 +2,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
 )
 
@@ -30,76 +35,101 @@ type ProductUsecase interface {
 	// DeleteProduct deletes a product by ID
 	DeleteProduct(ctx context.Context, id string) error
 
-	// GetProductsByCategory retrieves products by category ID
-	GetProductsByCategory(ctx context.Context, categoryID string, page, pageSize int) ([]*entity.Product, int, error)
+	// GetProductsByCategory retrieves products by category ID, optionally including descendant categories
+	GetProductsByCategory(ctx context.Context, categoryID string, includeDescendants bool, page, pageSize int) ([]*entity.Product, int, error)
+
+	// ChangeStatus moves a product to another lifecycle status
+	ChangeStatus(ctx context.Context, id, status string) (*entity.Product, error)
+
+	// ScheduleProduct sets or clears the times at which a product is published and unpublished
+	ScheduleProduct(ctx context.Context, id string, publishAt, unpublishAt *time.Time) (*entity.Product, error)
+
+	// RunScheduledTransitions publishes and unpublishes products whose scheduled time has passed.
+	// It returns the number of products that changed status.
+	RunScheduledTransitions(ctx context.Context, now time.Time) (int, error)
+
+	// SetPrice changes the base price of a product and records the change in its price history
+	SetPrice(ctx context.Context, id string, price float64, source entity.PriceSource) (*entity.Product, error)
 }
 
+// scheduledBatchSize is the number of due products loaded at a time by RunScheduledTransitions
+const scheduledBatchSize = 100
+
 // productUsecase implements the ProductUsecase interface
 type productUsecase struct {
-	productRepo   repository.ProductRepository
-	categoryRepo  repository.CategoryRepository
-	inventoryRepo repository.InventoryRepository
-	errBuilder    *utils.ErrorBuilder
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	variantRepo  repository.VariantRepository
+	searchIndex  interfaces.SearchIndex
+	mediaUsecase MediaUsecase
+	outboxRepo   repository.OutboxRepository
+	priceRepo    repository.PriceRepository
+	transactor   repository.Transactor
+	errBuilder   *utils.ErrorBuilder
 }
 
 // NewProductUsecase creates a new instance of ProductUsecase
 func NewProductUsecase(
 	pr repository.ProductRepository,
 	cr repository.CategoryRepository,
-	ir repository.InventoryRepository,
+	vr repository.VariantRepository,
+	si interfaces.SearchIndex,
+	mu MediaUsecase,
+	or repository.OutboxRepository,
+	prr repository.PriceRepository,
+	tx repository.Transactor,
 ) ProductUsecase {
 	return &productUsecase{
-		productRepo:   pr,
-		categoryRepo:  cr,
-		inventoryRepo: ir,
-		errBuilder:    utils.NewErrorBuilder("ProductUsecase"),
+		productRepo:  pr,
+		categoryRepo: cr,
+		variantRepo:  vr,
+		searchIndex:  si,
+		mediaUsecase: mu,
+		outboxRepo:   or,
+		priceRepo:    prr,
+		transactor:   tx,
+		errBuilder:   utils.NewErrorBuilder("ProductUsecase"),
 	}
 }
 
 // CreateProduct creates a new product
 func (pu *productUsecase) CreateProduct(ctx context.Context, product *entity.Product) (*entity.Product, error) {
-	// Check if product with same SKU already exists
-	existingProduct, err := pu.productRepo.GetBySKU(ctx, product.SKU)
-	if err == nil && existingProduct != nil {
+	// Check if a product or variant with same SKU already exists
+	if pu.skuTaken(ctx, product.SKU, "") {
 		return nil, pu.errBuilder.Err(entity.ErrProductSKUExists)
 	}
 
 	// Validate category exists
-	_, err = pu.categoryRepo.GetByID(ctx, product.CategoryID)
+	_, err := pu.categoryRepo.GetByID(ctx, product.CategoryID)
 	if err != nil {
 		return nil, pu.errBuilder.Err(entity.ErrCategoryNotFound)
 	}
 
-	// Set default status if not provided
+	// New products start as drafts unless they skip straight to review or publication
 	if product.Status == "" {
-		product.Status = valueobject.Active.String()
+		product.Status = valueobject.Draft.String()
 	}
+	status, err := valueobject.ParseProductStatus(product.Status)
+	if err != nil || !status.IsInitial() {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidProductStatus)
+	}
+	product.Status = status.String()
+	dropStaleSchedule(product)
 
 	// Generate ID if not provided
 	if product.ID == "" {
 		product.ID = uuid.New().String()
 	}
 
-	// Create product
-	createdProduct, err := pu.productRepo.Create(ctx, *product)
+	// Create the product; the inventory service provisions its stock from the product.created event
+	createdProduct, err := pu.save(ctx, nil, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+		return pu.productRepo.Create(ctx, *product)
+	})
 	if err != nil {
 		return nil, pu.errBuilder.Err(err)
 	}
 
-	// Create inventory record with initial quantity of 0
-	inventory := entity.Inventory{
-		ID:        uuid.New().String(),
-		ProductID: createdProduct.ID,
-		Quantity:  0,
-		Reserved:  0,
-	}
-
-	_, err = pu.inventoryRepo.Create(ctx, inventory)
-	if err != nil {
-		// Log the error but don't fail the product creation
-		// In a real implementation, you might want to handle this better
-		// Perhaps delete the product or use a transaction
-	}
+	pu.syncSearchIndex(ctx, createdProduct)
 
 	return createdProduct, nil
 }
@@ -110,6 +140,9 @@ func (pu *productUsecase) GetProductByID(ctx context.Context, id string) (*entit
 	if err != nil {
 		return nil, pu.errBuilder.Err(err)
 	}
+	if err := pu.loadDetails(ctx, product); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
 	return product, nil
 }
 
@@ -119,6 +152,9 @@ func (pu *productUsecase) GetProductBySKU(ctx context.Context, sku string) (*ent
 	if err != nil {
 		return nil, pu.errBuilder.Err(err)
 	}
+	if err := pu.loadDetails(ctx, product); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
 	return product, nil
 }
 
@@ -141,11 +177,8 @@ func (pu *productUsecase) UpdateProduct(ctx context.Context, id string, product
 	}
 
 	// If SKU is changing, check if new SKU is unique
-	if product.SKU != existingProduct.SKU {
-		p, err := pu.productRepo.GetBySKU(ctx, product.SKU)
-		if err == nil && p != nil && p.ID != id {
-			return nil, pu.errBuilder.Err(entity.ErrProductSKUExists)
-		}
+	if product.SKU != existingProduct.SKU && pu.skuTaken(ctx, product.SKU, id) {
+		return nil, pu.errBuilder.Err(entity.ErrProductSKUExists)
 	}
 
 	// If category is changing, validate new category exists
@@ -156,37 +189,74 @@ func (pu *productUsecase) UpdateProduct(ctx context.Context, id string, product
 		}
 	}
 
+	// A blank status keeps the current one; any other must be reachable from it
+	if product.Status == "" {
+		product.Status = existingProduct.Status
+	} else {
+		status, err := nextStatus(existingProduct.Status, product.Status)
+		if err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+		product.Status = status.String()
+	}
+
+	// The schedule is managed through ScheduleProduct
+	product.PublishAt = existingProduct.PublishAt
+	product.UnpublishAt = existingProduct.UnpublishAt
+	dropStaleSchedule(&product)
+
 	// Set ID to ensure we're updating the correct record
 	product.ID = id
 
 	// Update the product
-	updatedProduct, err := pu.productRepo.Update(ctx, product)
+	updatedProduct, err := pu.save(ctx, existingProduct, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+		return pu.productRepo.Update(ctx, product)
+	})
 	if err != nil {
 		return nil, pu.errBuilder.Err(err)
 	}
 
+	pu.syncSearchIndex(ctx, updatedProduct)
+
 	return updatedProduct, nil
 }
 
 // DeleteProduct deletes a product by ID
 func (pu *productUsecase) DeleteProduct(ctx context.Context, id string) error {
 	// Ensure the product exists
-	_, err := pu.productRepo.GetByID(ctx, id)
+	product, err := pu.productRepo.GetByID(ctx, id)
 	if err != nil {
 		return pu.errBuilder.Err(entity.ErrProductNotFound)
 	}
 
 	// Delete the product
-	err = pu.productRepo.Delete(ctx, id)
+	err = pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := pu.productRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		event, err := newProductEvent(service.EventTypeProductDeleted, product, nil)
+		if err != nil {
+			return err
+		}
+		return pu.outboxRepo.Add(ctx, []entity.OutboxEvent{event})
+	})
 	if err != nil {
 		return pu.errBuilder.Err(err)
 	}
 
+	// The product is already deleted; a stale entry is dropped on the next reindex
+	_ = pu.searchIndex.Remove(ctx, id)
+
+	// Remove the product images and their stored files
+	if err := pu.mediaUsecase.DeleteProductMedia(ctx, id); err != nil {
+		return pu.errBuilder.Err(err)
+	}
+
 	return nil
 }
 
-// GetProductsByCategory retrieves products by category ID
-func (pu *productUsecase) GetProductsByCategory(ctx context.Context, categoryID string, page, pageSize int) ([]*entity.Product, int, error) {
+// GetProductsByCategory retrieves products by category ID, optionally including descendant categories
+func (pu *productUsecase) GetProductsByCategory(ctx context.Context, categoryID string, includeDescendants bool, page, pageSize int) ([]*entity.Product, int, error) {
 	// Ensure the category exists
 	_, err := pu.categoryRepo.GetByID(ctx, categoryID)
 	if err != nil {
@@ -194,7 +264,24 @@ func (pu *productUsecase) GetProductsByCategory(ctx context.Context, categoryID
 	}
 
 	offset := (page - 1) * pageSize
-	products, total, err := pu.productRepo.GetByCategory(ctx, categoryID, offset, pageSize)
+	if !includeDescendants {
+		products, total, err := pu.productRepo.GetByCategory(ctx, categoryID, offset, pageSize)
+		if err != nil {
+			return nil, 0, pu.errBuilder.Err(err)
+		}
+		return products, total, nil
+	}
+
+	subtree, err := pu.categoryRepo.GetSubtree(ctx, categoryID)
+	if err != nil {
+		return nil, 0, pu.errBuilder.Err(err)
+	}
+	categoryIDs := make([]string, len(subtree))
+	for i, category := range subtree {
+		categoryIDs[i] = category.ID
+	}
+
+	products, total, err := pu.productRepo.GetByCategories(ctx, categoryIDs, offset, pageSize)
 	if err != nil {
 		return nil, 0, pu.errBuilder.Err(err)
 	}
@@ -209,6 +296,7 @@ func (pu *productUsecase) UpdateProductPartial(ctx context.Context, id string, p
 	}
 
 	// Create a modifiable copy of the existing product
+	previous := *existingProduct
 	updatedProduct := existingProduct
 
 	// Apply updates from the patch map to the product entity
@@ -242,28 +330,310 @@ func (pu *productUsecase) UpdateProductPartial(ctx context.Context, id string, p
 		case "sku":
 			if sku, ok := value.(string); ok && sku != existingProduct.SKU {
 				// Check if SKU is unique
-				p, err := pu.productRepo.GetBySKU(ctx, sku)
-				if err == nil && p != nil && p.ID != id {
+				if pu.skuTaken(ctx, sku, id) {
 					return nil, pu.errBuilder.Err(entity.ErrProductSKUExists)
 				}
 				updatedProduct.SKU = sku
 			}
 		case "status":
 			if status, ok := value.(string); ok {
-				// Validate status
-				if _, err := valueobject.ParseProductStatus(status); err != nil {
+				next, err := nextStatus(previous.Status, status)
+				if err != nil {
 					return nil, pu.errBuilder.Err(err)
 				}
-				updatedProduct.Status = status
+				updatedProduct.Status = next.String()
+				dropStaleSchedule(updatedProduct)
 			}
 		}
 	}
 
 	// Update the product
-	updatedProduct, err = pu.productRepo.Update(ctx, *updatedProduct)
+	product := *updatedProduct
+	updatedProduct, err = pu.save(ctx, &previous, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+		return pu.productRepo.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	pu.syncSearchIndex(ctx, updatedProduct)
+
+	return updatedProduct, nil
+}
+
+// ChangeStatus moves a product to another lifecycle status.
+// Scheduled transitions that no longer apply to the new status are cleared.
+func (pu *productUsecase) ChangeStatus(ctx context.Context, id, status string) (*entity.Product, error) {
+	product, err := pu.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	next, err := nextStatus(product.Status, status)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if next.String() == product.Status {
+		return product, nil
+	}
+
+	updatedProduct, err := pu.transition(ctx, *product, next)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return updatedProduct, nil
+}
+
+// ScheduleProduct sets or clears the times at which a product is published and unpublished.
+// Only drafts and products in review can be scheduled for publication; the scheduler
+// publishes them once they are in review. Archived products can't be scheduled at all.
+func (pu *productUsecase) ScheduleProduct(ctx context.Context, id string, publishAt, unpublishAt *time.Time) (*entity.Product, error) {
+	product, err := pu.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	status := valueobject.ProductStatus(product.Status)
+	if publishAt != nil && status != valueobject.Draft && status != valueobject.Review {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidSchedule)
+	}
+	if unpublishAt != nil && status == valueobject.Archived {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidSchedule)
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidSchedule)
+	}
+
+	previous := *product
+	product.PublishAt = publishAt
+	product.UnpublishAt = unpublishAt
+	updatedProduct, err := pu.save(ctx, &previous, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+		return pu.productRepo.Update(ctx, *product)
+	})
 	if err != nil {
 		return nil, pu.errBuilder.Err(err)
 	}
+	return updatedProduct, nil
+}
+
+// RunScheduledTransitions publishes products in review whose publish time has passed,
+// then archives published products whose unpublish time has passed. Every replica runs it,
+// so each product is locked and checked again before it moves. A product that fails is skipped
+// for the rest of the run and the failures are returned together.
+func (pu *productUsecase) RunScheduledTransitions(ctx context.Context, now time.Time) (int, error) {
+	changed := 0
+	var errs []error
+	for _, step := range []struct {
+		list        func(context.Context, time.Time, int) ([]*entity.Product, error)
+		from, to    valueobject.ProductStatus
+		scheduledAt func(*entity.Product) *time.Time
+	}{
+		{pu.productRepo.ListDueForPublish, valueobject.Review, valueobject.Published, func(p *entity.Product) *time.Time { return p.PublishAt }},
+		{pu.productRepo.ListDueForUnpublish, valueobject.Published, valueobject.Archived, func(p *entity.Product) *time.Time { return p.UnpublishAt }},
+	} {
+		seen := make(map[string]bool)
+		for {
+			products, err := step.list(ctx, now, scheduledBatchSize)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			progressed := false
+			for _, product := range products {
+				if seen[product.ID] {
+					continue
+				}
+				seen[product.ID] = true
+				progressed = true
 
+				moved, err := pu.transitionIfDue(ctx, product.ID, step.from, step.to, step.scheduledAt, now)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("product %s: %w", product.ID, err))
+					continue
+				}
+				if moved {
+					changed++
+				}
+			}
+			// Moved products no longer match, so the next call returns the following batch.
+			// Failed products still match; stop once a batch holds nothing else.
+			if len(products) < scheduledBatchSize || !progressed {
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return changed, pu.errBuilder.Err(errors.Join(errs...))
+	}
+	return changed, nil
+}
+
+// transitionIfDue locks a product and moves it from one status to another when its scheduled
+// time is at or before now. It reports whether the product moved.
+func (pu *productUsecase) transitionIfDue(ctx context.Context, id string, from, to valueobject.ProductStatus, scheduledAt func(*entity.Product) *time.Time, now time.Time) (bool, error) {
+	var updatedProduct *entity.Product
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := pu.productRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Another replica may have moved it, or it may have been rescheduled, since it was listed
+		if at := scheduledAt(product); product.Status != from.String() || at == nil || at.After(now) {
+			return nil
+		}
+
+		previous := *product
+		product.Status = to.String()
+		dropStaleSchedule(product)
+		updatedProduct, err = pu.save(ctx, &previous, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+			return pu.productRepo.Update(ctx, *product)
+		})
+		return err
+	})
+	if err != nil || updatedProduct == nil {
+		return false, err
+	}
+
+	pu.syncSearchIndex(ctx, updatedProduct)
+	return true, nil
+}
+
+// transition saves a product in a new status
+func (pu *productUsecase) transition(ctx context.Context, product entity.Product, status valueobject.ProductStatus) (*entity.Product, error) {
+	previous := product
+	product.Status = status.String()
+	dropStaleSchedule(&product)
+
+	updatedProduct, err := pu.save(ctx, &previous, entity.PriceSourceManual, func(ctx context.Context) (*entity.Product, error) {
+		return pu.productRepo.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pu.syncSearchIndex(ctx, updatedProduct)
 	return updatedProduct, nil
 }
+
+// SetPrice changes the base price of a product and records the change in its price history.
+// The product is locked, so concurrent changes are recorded one after the other.
+func (pu *productUsecase) SetPrice(ctx context.Context, id string, price float64, source entity.PriceSource) (*entity.Product, error) {
+	if price <= 0 {
+		return nil, pu.errBuilder.Err(entity.ErrInvalidPrice)
+	}
+
+	var product, updatedProduct *entity.Product
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = pu.productRepo.LockByID(ctx, id)
+		if err != nil || product.Price == price {
+			return err
+		}
+
+		previous := *product
+		product.Price = price
+		updatedProduct, err = pu.save(ctx, &previous, source, func(ctx context.Context) (*entity.Product, error) {
+			return pu.productRepo.Update(ctx, *product)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if updatedProduct == nil {
+		return product, nil
+	}
+
+	pu.syncSearchIndex(ctx, updatedProduct)
+	return updatedProduct, nil
+}
+
+// save runs write in a transaction and stores the events describing the change from previous
+// to the written product in the outbox, so the events are published if and only if the change
+// is committed. Changed prices are recorded in the price history with source.
+func (pu *productUsecase) save(ctx context.Context, previous *entity.Product, source entity.PriceSource, write func(ctx context.Context) (*entity.Product, error)) (*entity.Product, error) {
+	var saved *entity.Product
+	err := pu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		if change := newPriceChange(previous, product, source); change != nil {
+			if err := pu.priceRepo.AddChange(ctx, *change); err != nil {
+				return err
+			}
+		}
+		events, err := productChangeEvents(previous, product)
+		if err != nil {
+			return err
+		}
+		if err := pu.outboxRepo.Add(ctx, events); err != nil {
+			return err
+		}
+		saved = product
+		return nil
+	})
+	return saved, err
+}
+
+// nextStatus parses a requested status and checks that a product in current may move to it.
+// Keeping the current status is always allowed.
+func nextStatus(current, requested string) (valueobject.ProductStatus, error) {
+	next, err := valueobject.ParseProductStatus(requested)
+	if err != nil {
+		return "", entity.ErrInvalidProductStatus
+	}
+	from, err := valueobject.ParseProductStatus(current)
+	if err == nil && from != next && !from.CanTransitionTo(next) {
+		return "", entity.ErrInvalidStatusTransition
+	}
+	return next, nil
+}
+
+// dropStaleSchedule clears scheduled transitions that can no longer happen from the product's status
+func dropStaleSchedule(product *entity.Product) {
+	status := valueobject.ProductStatus(product.Status)
+	if status != valueobject.Draft && status != valueobject.Review {
+		product.PublishAt = nil
+	}
+	if status == valueobject.Archived {
+		product.UnpublishAt = nil
+	}
+}
+
+// syncSearchIndex updates the search index after a product change.
+// Failures don't fail the change itself; the index is rebuilt from the database on startup.
+func (pu *productUsecase) syncSearchIndex(ctx context.Context, product *entity.Product) {
+	_ = pu.searchIndex.Index(ctx, product)
+}
+
+// loadDetails attaches the option axes, variants and images to a product
+func (pu *productUsecase) loadDetails(ctx context.Context, product *entity.Product) error {
+	options, err := pu.variantRepo.ListOptions(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+	variants, err := pu.variantRepo.ListByProductID(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+	media, err := pu.mediaUsecase.ListMedia(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+	product.Options = options
+	product.Variants = variants
+	product.Media = media
+	return nil
+}
+
+// skuTaken reports whether a SKU is used by a variant or by a product other than productID
+func (pu *productUsecase) skuTaken(ctx context.Context, sku, productID string) bool {
+	if p, err := pu.productRepo.GetBySKU(ctx, sku); err == nil && p != nil && p.ID != productID {
+		return true
+	}
+	if v, err := pu.variantRepo.GetBySKU(ctx, sku); err == nil && v != nil {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// reindexBatchSize is the number of products loaded per query when rebuilding the index
+const reindexBatchSize = 500
+
+type SearchUsecase interface {
+	// SearchProducts runs a full-text search with facet counts
+	SearchProducts(ctx context.Context, query entity.SearchQuery, page, pageSize int) (*entity.SearchResult, error)
+
+	// Reindex rebuilds the search index from the product database
+	Reindex(ctx context.Context) error
+}
+
+// searchUsecase implements the SearchUsecase interface
+type searchUsecase struct {
+	searchIndex  interfaces.SearchIndex
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	errBuilder   *utils.ErrorBuilder
+}
+
+// NewSearchUsecase creates a new instance of SearchUsecase
+func NewSearchUsecase(
+	si interfaces.SearchIndex,
+	pr repository.ProductRepository,
+	cr repository.CategoryRepository,
+) SearchUsecase {
+	return &searchUsecase{
+		searchIndex:  si,
+		productRepo:  pr,
+		categoryRepo: cr,
+		errBuilder:   utils.NewErrorBuilder("SearchUsecase"),
+	}
+}
+
+// SearchProducts runs a full-text search with facet counts
+func (su *searchUsecase) SearchProducts(ctx context.Context, query entity.SearchQuery, page, pageSize int) (*entity.SearchResult, error) {
+	query.Offset = (page - 1) * pageSize
+	query.Limit = pageSize
+
+	result, err := su.searchIndex.Search(ctx, query)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+
+	// Label category facets with the category names
+	for i := range result.Facets.Categories {
+		category, err := su.categoryRepo.GetByID(ctx, result.Facets.Categories[i].Value)
+		if err == nil {
+			result.Facets.Categories[i].Label = category.Name
+		}
+	}
+
+	return result, nil
+}
+
+// Reindex rebuilds the search index from the product database
+func (su *searchUsecase) Reindex(ctx context.Context) error {
+	var products []*entity.Product
+	for offset := 0; ; offset += reindexBatchSize {
+		batch, _, err := su.productRepo.List(ctx, offset, reindexBatchSize, nil)
+		if err != nil {
+			return su.errBuilder.Err(err)
+		}
+		products = append(products, batch...)
+		if len(batch) < reindexBatchSize {
+			break
+		}
+	}
+
+	if err := su.searchIndex.Rebuild(ctx, products); err != nil {
+		return su.errBuilder.Err(err)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+type VariantUsecase interface {
+	// SetOptions replaces the option axes of a product
+	SetOptions(ctx context.Context, productID string, options []entity.ProductOption) ([]entity.ProductOption, error)
+
+	// CreateVariant creates a new variant of a product and announces it so its stock is provisioned
+	CreateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) (*entity.ProductVariant, error)
+
+	// GetVariant retrieves a variant of a product
+	GetVariant(ctx context.Context, productID, variantID string) (*entity.ProductVariant, error)
+
+	// GetVariantBySKU retrieves a variant by SKU
+	GetVariantBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error)
+
+	// ListVariants retrieves all variants of a product
+	ListVariants(ctx context.Context, productID string) ([]entity.ProductVariant, error)
+
+	// UpdateVariant updates an existing variant
+	UpdateVariant(ctx context.Context, productID, variantID string, variant entity.ProductVariant) (*entity.ProductVariant, error)
+
+	// DeleteVariant deletes a variant and announces it so its stock is retired
+	DeleteVariant(ctx context.Context, productID, variantID string) error
+}
+
+// variantUsecase implements the VariantUsecase interface
+type variantUsecase struct {
+	variantRepo repository.VariantRepository
+	productRepo repository.ProductRepository
+	outboxRepo  repository.OutboxRepository
+	transactor  repository.Transactor
+	errBuilder  *utils.ErrorBuilder
+}
+
+// NewVariantUsecase creates a new instance of VariantUsecase
+func NewVariantUsecase(
+	vr repository.VariantRepository,
+	pr repository.ProductRepository,
+	or repository.OutboxRepository,
+	tx repository.Transactor,
+) VariantUsecase {
+	return &variantUsecase{
+		variantRepo: vr,
+		productRepo: pr,
+		outboxRepo:  or,
+		transactor:  tx,
+		errBuilder:  utils.NewErrorBuilder("VariantUsecase"),
+	}
+}
+
+// SetOptions replaces the option axes of a product.
+// Existing variants must still be valid against the new options.
+func (vu *variantUsecase) SetOptions(ctx context.Context, productID string, options []entity.ProductOption) ([]entity.ProductOption, error) {
+	if _, err := vu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, vu.errBuilder.Err(entity.ErrProductNotFound)
+	}
+	if err := entity.ValidateOptions(options); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+
+	variants, err := vu.variantRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	for i := range variants {
+		if err := variants[i].Validate(options); err != nil {
+			return nil, vu.errBuilder.Err(entity.ErrInvalidVariantOptions)
+		}
+	}
+
+	for i := range options {
+		options[i].ID = uuid.New().String()
+		options[i].Position = i
+	}
+
+	result, err := vu.variantRepo.ReplaceOptions(ctx, productID, options)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	return result, nil
+}
+
+// CreateVariant creates a new variant of a product. The variant.created event is stored in the same
+// transaction, so the inventory service provisions stock for every variant that exists.
+func (vu *variantUsecase) CreateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) (*entity.ProductVariant, error) {
+	if _, err := vu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, vu.errBuilder.Err(entity.ErrProductNotFound)
+	}
+
+	options, err := vu.variantRepo.ListOptions(ctx, productID)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if err := variant.Validate(options); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if err := vu.checkSKU(ctx, variant.SKU, ""); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if err := vu.checkOptionsUnique(ctx, productID, variant, ""); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+
+	variant.ID = uuid.New().String()
+	variant.ProductID = productID
+
+	var createdVariant *entity.ProductVariant
+	err = vu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		createdVariant, err = vu.variantRepo.Create(ctx, *variant)
+		if err != nil {
+			return err
+		}
+		event, err := newVariantEvent(service.EventTypeVariantCreated, createdVariant)
+		if err != nil {
+			return err
+		}
+		return vu.outboxRepo.Add(ctx, []entity.OutboxEvent{event})
+	})
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+
+	return createdVariant, nil
+}
+
+// GetVariant retrieves a variant of a product
+func (vu *variantUsecase) GetVariant(ctx context.Context, productID, variantID string) (*entity.ProductVariant, error) {
+	variant, err := vu.variantRepo.GetByID(ctx, variantID)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if variant.ProductID != productID {
+		return nil, vu.errBuilder.Err(entity.ErrVariantNotFound)
+	}
+	return variant, nil
+}
+
+// GetVariantBySKU retrieves a variant by SKU
+func (vu *variantUsecase) GetVariantBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	variant, err := vu.variantRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	return variant, nil
+}
+
+// ListVariants retrieves all variants of a product
+func (vu *variantUsecase) ListVariants(ctx context.Context, productID string) ([]entity.ProductVariant, error) {
+	if _, err := vu.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, vu.errBuilder.Err(entity.ErrProductNotFound)
+	}
+
+	variants, err := vu.variantRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	return variants, nil
+}
+
+// UpdateVariant updates an existing variant
+func (vu *variantUsecase) UpdateVariant(ctx context.Context, productID, variantID string, variant entity.ProductVariant) (*entity.ProductVariant, error) {
+	existingVariant, err := vu.GetVariant(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := vu.variantRepo.ListOptions(ctx, productID)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if err := variant.Validate(options); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+	if variant.SKU != existingVariant.SKU {
+		if err := vu.checkSKU(ctx, variant.SKU, variantID); err != nil {
+			return nil, vu.errBuilder.Err(err)
+		}
+	}
+	if err := vu.checkOptionsUnique(ctx, productID, &variant, variantID); err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+
+	variant.ID = variantID
+	variant.ProductID = productID
+
+	updatedVariant, err := vu.variantRepo.Update(ctx, variant)
+	if err != nil {
+		return nil, vu.errBuilder.Err(err)
+	}
+
+	return updatedVariant, nil
+}
+
+// DeleteVariant deletes a variant. The variant.deleted event is stored in the same transaction,
+// so the inventory service retires its stock.
+func (vu *variantUsecase) DeleteVariant(ctx context.Context, productID, variantID string) error {
+	variant, err := vu.GetVariant(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	err = vu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := vu.variantRepo.Delete(ctx, variantID); err != nil {
+			return err
+		}
+		event, err := newVariantEvent(service.EventTypeVariantDeleted, variant)
+		if err != nil {
+			return err
+		}
+		return vu.outboxRepo.Add(ctx, []entity.OutboxEvent{event})
+	})
+	if err != nil {
+		return vu.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// checkSKU ensures a SKU is not used by any product or by another variant
+func (vu *variantUsecase) checkSKU(ctx context.Context, sku, variantID string) error {
+	if p, err := vu.productRepo.GetBySKU(ctx, sku); err == nil && p != nil {
+		return entity.ErrProductSKUExists
+	}
+	if v, err := vu.variantRepo.GetBySKU(ctx, sku); err == nil && v != nil && v.ID != variantID {
+		return entity.ErrProductSKUExists
+	}
+	return nil
+}
+
+// checkOptionsUnique ensures no other variant of the product has the same option values
+func (vu *variantUsecase) checkOptionsUnique(ctx context.Context, productID string, variant *entity.ProductVariant, variantID string) error {
+	variants, err := vu.variantRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	key := variant.OptionsKey()
+	for i := range variants {
+		if variants[i].ID != variantID && variants[i].OptionsKey() == key {
+			return entity.ErrVariantExists
+		}
+	}
+	return nil
+}
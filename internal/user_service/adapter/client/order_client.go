@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	orderpb "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/adapter/controller/grpc/proto"
+)
+
+const exportPageSize = 100
+
+// OrderServiceClient exports a user's orders from the order service over gRPC
+type OrderServiceClient struct {
+	conn   *grpc.ClientConn
+	client orderpb.OrderServiceClient
+}
+
+// NewOrderServiceClient creates a client for the order service at the given address
+func NewOrderServiceClient(address string) (*OrderServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order service client: %w", err)
+	}
+	return &OrderServiceClient{
+		conn:   conn,
+		client: orderpb.NewOrderServiceClient(conn),
+	}, nil
+}
+
+// Name identifies the service in data exports
+func (c *OrderServiceClient) Name() string {
+	return "order_service"
+}
+
+// ExportUserData returns all orders of a user as a JSON array
+func (c *OrderServiceClient) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	marshaller := protojson.MarshalOptions{UseProtoNames: true}
+	orders := make([]json.RawMessage, 0)
+
+	for page := int32(1); ; page++ {
+		resp, err := c.client.GetOrdersByUser(ctx, &orderpb.GetOrdersByUserRequest{
+			UserId:   userID,
+			Page:     page,
+			PageSize: exportPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+		for _, order := range resp.Orders {
+			b, err := marshaller.Marshal(order)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, b)
+		}
+		if len(resp.Orders) < exportPageSize || page >= resp.TotalPages {
+			break
+		}
+	}
+
+	return json.Marshal(orders)
+}
+
+// Close closes the underlying connection
+func (c *OrderServiceClient) Close() error {
+	return c.conn.Close()
+}
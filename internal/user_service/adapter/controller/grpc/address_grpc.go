@@ -0,0 +1,199 @@
+package grpcctl
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/grpc/proto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// UpdateProfile applies a partial update to a user's profile
+func (s *UserServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserResponse, error) {
+	s.logger.Info("gRPC UpdateProfile request received", "id", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	update := entity.ProfileUpdate{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Phone:     req.Phone,
+	}
+	if req.Preferences != nil {
+		update.Preferences = convertProtoToPreferences(req.Preferences)
+	}
+
+	user, err := s.userUsecase.UpdateProfile(ctx, req.Id, update)
+	if err != nil {
+		s.logger.Error("Failed to update profile", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertUserToProto(user), nil
+}
+
+// ListAddresses lists a user's saved addresses
+func (s *UserServer) ListAddresses(ctx context.Context, req *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	s.logger.Info("gRPC ListAddresses request received", "user_id", req.UserId)
+
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	addresses, err := s.addressUsecase.ListAddresses(ctx, req.UserId)
+	if err != nil {
+		s.logger.Error("Failed to list addresses", "error", err)
+		return nil, handleError(err)
+	}
+
+	resp := &pb.ListAddressesResponse{Addresses: make([]*pb.Address, len(addresses))}
+	for i, address := range addresses {
+		resp.Addresses[i] = convertAddressToProto(address)
+	}
+	return resp, nil
+}
+
+// CreateAddress saves a new address for a user
+func (s *UserServer) CreateAddress(ctx context.Context, req *pb.CreateAddressRequest) (*pb.Address, error) {
+	s.logger.Info("gRPC CreateAddress request received", "user_id", req.UserId)
+
+	if req.UserId == "" || req.Address == nil {
+		return nil, status.Error(codes.InvalidArgument, "user ID and address are required")
+	}
+
+	address, err := s.addressUsecase.CreateAddress(ctx, req.UserId, convertProtoToAddress(req.Address))
+	if err != nil {
+		s.logger.Error("Failed to create address", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertAddressToProto(address), nil
+}
+
+// UpdateAddress updates a saved address
+func (s *UserServer) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.Address, error) {
+	s.logger.Info("gRPC UpdateAddress request received", "user_id", req.UserId, "address_id", req.AddressId)
+
+	if req.UserId == "" || req.AddressId == "" || req.Address == nil {
+		return nil, status.Error(codes.InvalidArgument, "user ID, address ID and address are required")
+	}
+
+	address, err := s.addressUsecase.UpdateAddress(ctx, req.UserId, req.AddressId, convertProtoToAddress(req.Address))
+	if err != nil {
+		s.logger.Error("Failed to update address", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertAddressToProto(address), nil
+}
+
+// DeleteAddress removes a saved address
+func (s *UserServer) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	s.logger.Info("gRPC DeleteAddress request received", "user_id", req.UserId, "address_id", req.AddressId)
+
+	if req.UserId == "" || req.AddressId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID and address ID are required")
+	}
+
+	if err := s.addressUsecase.DeleteAddress(ctx, req.UserId, req.AddressId); err != nil {
+		s.logger.Error("Failed to delete address", "error", err)
+		return nil, handleError(err)
+	}
+
+	return &pb.DeleteAddressResponse{Success: true}, nil
+}
+
+// SetDefaultAddress makes an address the user's default shipping or billing address
+func (s *UserServer) SetDefaultAddress(ctx context.Context, req *pb.SetDefaultAddressRequest) (*pb.Address, error) {
+	s.logger.Info("gRPC SetDefaultAddress request received", "user_id", req.UserId, "address_id", req.AddressId)
+
+	addressType := entity.AddressType(req.Type)
+	if req.UserId == "" || req.AddressId == "" || !addressType.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "user ID, address ID and a type of shipping or billing are required")
+	}
+
+	address, err := s.addressUsecase.SetDefaultAddress(ctx, req.UserId, req.AddressId, addressType)
+	if err != nil {
+		s.logger.Error("Failed to set default address", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertAddressToProto(address), nil
+}
+
+// GetDefaultAddresses returns a user's default shipping and billing addresses
+func (s *UserServer) GetDefaultAddresses(ctx context.Context, req *pb.GetDefaultAddressesRequest) (*pb.DefaultAddressesResponse, error) {
+	s.logger.Info("gRPC GetDefaultAddresses request received", "user_id", req.UserId)
+
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	shipping, billing, err := s.addressUsecase.GetDefaultAddresses(ctx, req.UserId)
+	if err != nil {
+		s.logger.Error("Failed to get default addresses", "error", err)
+		return nil, handleError(err)
+	}
+
+	resp := &pb.DefaultAddressesResponse{}
+	if shipping != nil {
+		resp.Shipping = convertAddressToProto(shipping)
+	}
+	if billing != nil {
+		resp.Billing = convertAddressToProto(billing)
+	}
+	return resp, nil
+}
+
+// Helper function to convert a domain address to a protobuf address
+func convertAddressToProto(address *entity.Address) *pb.Address {
+	return &pb.Address{
+		Id:                address.ID,
+		UserId:            address.UserID,
+		Label:             address.Label,
+		RecipientName:     address.RecipientName,
+		Phone:             address.Phone,
+		Street:            address.Street,
+		City:              address.City,
+		State:             address.State,
+		Country:           address.Country,
+		PostalCode:        address.PostalCode,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+		CreatedAt:         timestamppb.New(address.CreatedAt),
+		UpdatedAt:         timestamppb.New(address.UpdatedAt),
+	}
+}
+
+// Helper function to convert a protobuf address to a domain address
+func convertProtoToAddress(address *pb.Address) entity.Address {
+	return entity.Address{
+		Label:             address.Label,
+		RecipientName:     address.RecipientName,
+		Phone:             address.Phone,
+		Street:            address.Street,
+		City:              address.City,
+		State:             address.State,
+		Country:           address.Country,
+		PostalCode:        address.PostalCode,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+	}
+}
+
+// Helper function to convert protobuf preferences to domain preferences
+func convertProtoToPreferences(preferences *pb.UserPreferences) *entity.UserPreferences {
+	return &entity.UserPreferences{
+		Language:          preferences.Language,
+		Currency:          preferences.Currency,
+		Timezone:          preferences.Timezone,
+		MarketingEmails:   preferences.MarketingEmails,
+		SMSNotifications:  preferences.SmsNotifications,
+		OrderUpdatesEmail: preferences.OrderUpdatesEmail,
+	}
+}
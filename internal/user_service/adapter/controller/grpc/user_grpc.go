@@ -3,8 +3,10 @@ package grpcctl
 import (
 	"context"
 	"errors"
+	"net"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -17,17 +19,24 @@ import (
 // UserServer implements the gRPC UserService interface
 type UserServer struct {
 	pb.UnimplementedUserServiceServer
-	authUsecase usecase.AuthUsecase
-	userUsecase usecase.UserUsecase
-	logger      logger.Logger
+	authUsecase    usecase.AuthUsecase
+	userUsecase    usecase.UserUsecase
+	addressUsecase usecase.AddressUsecase
+	logger         logger.Logger
 }
 
 // NewUserServer creates a new UserServer instance
-func NewUserServer(authUsecase usecase.AuthUsecase, userUsecase usecase.UserUsecase, logger logger.Logger) *UserServer {
+func NewUserServer(
+	authUsecase usecase.AuthUsecase,
+	userUsecase usecase.UserUsecase,
+	addressUsecase usecase.AddressUsecase,
+	logger logger.Logger,
+) *UserServer {
 	return &UserServer{
-		authUsecase: authUsecase,
-		userUsecase: userUsecase,
-		logger:      logger,
+		authUsecase:    authUsecase,
+		userUsecase:    userUsecase,
+		addressUsecase: addressUsecase,
+		logger:         logger,
 	}
 }
 
@@ -112,18 +121,30 @@ func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 func (s *UserServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	s.logger.Info("gRPC Login request received", "email", req.Email)
 
-	tokenPair, err := s.authUsecase.Login(ctx, req.Email, req.Password)
+	result, err := s.authUsecase.Login(ctx, req.Email, req.Password, peerAddress(ctx))
 	if err != nil {
 		s.logger.Error("Failed to login", "error", err)
 		return nil, handleError(err)
 	}
 
-	return &pb.LoginResponse{
-		TokenPair: &pb.TokenPairResponse{
-			AccessToken:  tokenPair.AccessToken,
-			RefreshToken: tokenPair.RefreshToken,
-		},
-	}, nil
+	return convertLoginResultToProto(result), nil
+}
+
+// VerifyTwoFactor completes a login with a TOTP or recovery code
+func (s *UserServer) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (*pb.LoginResponse, error) {
+	s.logger.Info("gRPC VerifyTwoFactor request received")
+
+	if req.ChallengeToken == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "challenge token and code are required")
+	}
+
+	result, err := s.authUsecase.VerifyTwoFactor(ctx, req.ChallengeToken, req.Code, peerAddress(ctx))
+	if err != nil {
+		s.logger.Error("Failed to verify two-factor code", "error", err)
+		return nil, handleError(err)
+	}
+
+	return convertLoginResultToProto(result), nil
 }
 
 // RefreshToken refreshes an access token using a refresh token
@@ -146,6 +167,19 @@ func (s *UserServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenReque
 	}, nil
 }
 
+// peerAddress returns the client IP of the gRPC caller, if known
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
 // Helper function to convert domain user entity to protobuf user response
 func convertUserToProto(user *entity.User) *pb.UserResponse {
 	return &pb.UserResponse{
@@ -155,7 +189,33 @@ func convertUserToProto(user *entity.User) *pb.UserResponse {
 		LastName:  user.LastName,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Phone:     user.Phone,
+		Preferences: &pb.UserPreferences{
+			Language:          user.Preferences.Language,
+			Currency:          user.Preferences.Currency,
+			Timezone:          user.Preferences.Timezone,
+			MarketingEmails:   user.Preferences.MarketingEmails,
+			SmsNotifications:  user.Preferences.SMSNotifications,
+			OrderUpdatesEmail: user.Preferences.OrderUpdatesEmail,
+		},
+	}
+}
+
+// Helper function to convert a login result to protobuf login response
+func convertLoginResultToProto(result *entity.LoginResult) *pb.LoginResponse {
+	resp := &pb.LoginResponse{
+		TwoFactorRequired:  result.TwoFactorRequired,
+		EnrollmentRequired: result.EnrollmentRequired,
+		ChallengeToken:     result.ChallengeToken,
+		RecoveryCodes:      result.RecoveryCodes,
+	}
+	if result.TokenPair != nil {
+		resp.TokenPair = &pb.TokenPairResponse{
+			AccessToken:  result.TokenPair.AccessToken,
+			RefreshToken: result.TokenPair.RefreshToken,
+		}
 	}
+	return resp
 }
 
 // handleError maps domain errors to appropriate gRPC status errors
@@ -176,6 +236,69 @@ func handleError(err error) error {
 	case errors.Is(err, entity.ErrInvalidToken) || errors.Is(err, entity.ErrTokenHasBeenRevoked) || errors.Is(err, entity.ErrInvalidToken):
 		statusCode = codes.Unauthenticated
 		message = "Invalid or revoked token"
+	case errors.Is(err, entity.ErrEmailNotVerified):
+		statusCode = codes.PermissionDenied
+		message = "Email not verified"
+	case errors.Is(err, entity.ErrEmailAlreadyVerified):
+		statusCode = codes.FailedPrecondition
+		message = "Email already verified"
+	case errors.Is(err, entity.ErrAccountLocked):
+		statusCode = codes.PermissionDenied
+		message = "Account is temporarily locked"
+	case errors.Is(err, entity.ErrTooManyLoginAttempts):
+		statusCode = codes.ResourceExhausted
+		message = "Too many login attempts"
+	case errors.Is(err, entity.ErrForbidden):
+		statusCode = codes.PermissionDenied
+		message = "Forbidden"
+	case errors.Is(err, entity.ErrInvalidTwoFactorCode):
+		statusCode = codes.Unauthenticated
+		message = "Invalid two-factor code"
+	case errors.Is(err, entity.ErrTwoFactorNotEnrolled):
+		statusCode = codes.FailedPrecondition
+		message = "Two-factor authentication not enrolled"
+	case errors.Is(err, entity.ErrTwoFactorEnabled):
+		statusCode = codes.AlreadyExists
+		message = "Two-factor authentication already enabled"
+	case errors.Is(err, entity.ErrTwoFactorRequired):
+		statusCode = codes.PermissionDenied
+		message = "Two-factor authentication is required"
+	case errors.Is(err, entity.ErrUnknownProvider):
+		statusCode = codes.NotFound
+		message = "Unknown identity provider"
+	case errors.Is(err, entity.ErrInvalidLoginState):
+		statusCode = codes.InvalidArgument
+		message = "Invalid or expired login state"
+	case errors.Is(err, entity.ErrIdentityConflict):
+		statusCode = codes.AlreadyExists
+		message = "Email is already registered to another account"
+	case errors.Is(err, entity.ErrExternalAuthFailed):
+		statusCode = codes.Unavailable
+		message = "External authentication failed"
+	case errors.Is(err, entity.ErrAddressNotFound):
+		statusCode = codes.NotFound
+		message = "Address not found"
+	case errors.Is(err, entity.ErrInvalidAddress):
+		statusCode = codes.InvalidArgument
+		message = "Invalid address"
+	case errors.Is(err, entity.ErrInvalidPhone):
+		statusCode = codes.InvalidArgument
+		message = "Invalid phone number"
+	case errors.Is(err, entity.ErrErasureNotFound):
+		statusCode = codes.NotFound
+		message = "Erasure request not found"
+	case errors.Is(err, entity.ErrDataExportFailed):
+		statusCode = codes.Unavailable
+		message = "Personal data could not be collected from all services"
+	case errors.Is(err, entity.ErrAccountSuspended):
+		statusCode = codes.PermissionDenied
+		message = "Account is suspended"
+	case errors.Is(err, entity.ErrInvalidRole):
+		statusCode = codes.InvalidArgument
+		message = "Invalid role"
+	case errors.Is(err, entity.ErrSelfModification):
+		statusCode = codes.FailedPrecondition
+		message = "Admins cannot change their own role or suspension"
 	case errors.Is(err, entity.ErrInternalServerError):
 		statusCode = codes.Internal
 		message = "Internal server error"
@@ -0,0 +1,144 @@
+package httpctl
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/dto"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+)
+
+// ListUsers handles listing and searching users (admin only)
+func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filters := make(map[string]interface{})
+	if query := c.Query("q"); query != "" {
+		filters["query"] = query
+	}
+	if role := c.Query("role"); role != "" {
+		parsed, err := vo.ParseRole(role)
+		if err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+		filters["role"] = parsed.String()
+	}
+	for param, key := range map[string]string{"createdFrom": "created_from", "createdTo": "created_to"} {
+		if value := c.Query(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return HandleError(c, ErrBadRequest)
+			}
+			filters[key] = t
+		}
+	}
+	if suspended := c.Query("suspended"); suspended != "" {
+		b, err := strconv.ParseBool(suspended)
+		if err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+		filters["suspended"] = b
+	}
+	switch deleted := c.Query("deleted"); deleted {
+	case "":
+	case "include", "only":
+		filters["deleted"] = deleted
+	default:
+		return HandleError(c, ErrBadRequest)
+	}
+
+	users, total, err := h.userAdmin.ListUsers(c.Context(), page, pageSize, filters)
+	if err != nil {
+		h.logger.Error("Failed to list users", "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Users retrieved", dto.NewPaginatedResponse(total, page, pageSize, users))
+}
+
+// ChangeUserRole handles assigning a new role to a user (admin only)
+func (h *UserHandler) ChangeUserRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.RoleRequest
+	if err := c.BodyParser(&req); err != nil || req.Role == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+	role, err := vo.ParseRole(req.Role)
+	if err != nil {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	actorID, _ := c.Locals(LocalUserID).(string)
+	user, err := h.userAdmin.ChangeRole(c.Context(), actorID, id, role)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Role updated", user)
+}
+
+// SuspendUser handles suspending a user account (admin only)
+func (h *UserHandler) SuspendUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	var req dto.SuspendRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return HandleError(c, ErrBadRequest)
+		}
+	}
+
+	actorID, _ := c.Locals(LocalUserID).(string)
+	user, err := h.userAdmin.SuspendUser(c.Context(), actorID, id, req.Reason)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "User suspended", user)
+}
+
+// ReactivateUser handles lifting a user suspension (admin only)
+func (h *UserHandler) ReactivateUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	user, err := h.userAdmin.ReactivateUser(c.Context(), id)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "User reactivated", user)
+}
+
+// ListUserSessions handles listing a user's active sessions (admin only)
+func (h *UserHandler) ListUserSessions(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	sessions, err := h.userAdmin.ListSessions(c.Context(), id)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Sessions retrieved", sessions)
+}
@@ -0,0 +1,65 @@
+package httpctl
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
+)
+
+// Keys used to store the authenticated caller in fiber locals
+const (
+	LocalUserID = "user_id"
+	LocalRole   = "role"
+)
+
+// AuthMiddleware validates the bearer access token and stores the caller in fiber locals
+func AuthMiddleware(tokenUsecase uc.TokenUsecase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		tokenStr, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenStr == "" {
+			return HandleError(c, entity.ErrInvalidToken)
+		}
+
+		claims, err := tokenUsecase.ValidateToken(c.Context(), tokenStr)
+		if err != nil {
+			return HandleError(c, err)
+		}
+		if claims.TokenType != jwt_service.AccessToken {
+			return HandleError(c, entity.ErrInvalidToken)
+		}
+
+		c.Locals(LocalUserID, claims.UserID)
+		c.Locals(LocalRole, claims.Role)
+		return c.Next()
+	}
+}
+
+// RequireRole only lets callers with one of the given roles through. It must run after AuthMiddleware.
+func RequireRole(roles ...vo.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals(LocalRole).(string)
+		for _, r := range roles {
+			if role == r.String() {
+				return c.Next()
+			}
+		}
+		return HandleError(c, entity.ErrForbidden)
+	}
+}
+
+// RequireSelfOrRole only lets through callers whose ID matches the given route parameter
+// or who have one of the given roles. It must run after AuthMiddleware.
+func RequireSelfOrRole(param string, roles ...vo.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _ := c.Locals(LocalUserID).(string)
+		if userID != "" && userID == c.Params(param) {
+			return c.Next()
+		}
+		return RequireRole(roles...)(c)
+	}
+}
@@ -0,0 +1,71 @@
+package httpctl
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// oauthStateCookie binds an in-flight social login to the browser that started it
+const oauthStateCookie = "oauth_state"
+
+// ListOAuthProviders returns the configured social login providers
+func (h *UserHandler) ListOAuthProviders(c *fiber.Ctx) error {
+	return SuccessResp(c, fiber.StatusOK, "Providers retrieved", h.socialLogin.Providers())
+}
+
+// StartOAuthLogin redirects the user agent to the identity provider
+func (h *UserHandler) StartOAuthLogin(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	if provider == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	authURL, state, err := h.socialLogin.StartLogin(c.Context(), provider)
+	if err != nil {
+		h.logger.Error("Failed to start social login", "provider", provider, "error", err)
+		return HandleError(c, err)
+	}
+
+	// Lax lets the cookie ride along on the provider's top-level redirect back to the callback
+	c.Cookie(&fiber.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// OAuthCallback completes the social login after the provider redirects back.
+// The state must match the cookie set when the login started, so a login cannot be completed in another browser.
+func (h *UserHandler) OAuthCallback(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	if providerErr := c.Query("error"); providerErr != "" {
+		h.logger.Warn("Identity provider returned an error", "provider", provider, "error", providerErr)
+		return HandleError(c, ErrBadRequest)
+	}
+	state, code := c.Query("state"), c.Query("code")
+	if provider == "" || state == "" || code == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	cookieState := c.Cookies(oauthStateCookie)
+	c.ClearCookie(oauthStateCookie)
+	if subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		h.logger.Warn("Social login state does not match the browser", "provider", provider)
+		return HandleError(c, ErrBadRequest)
+	}
+
+	result, err := h.socialLogin.CompleteLogin(c.Context(), provider, state, code)
+	if err != nil {
+		h.logger.Error("Failed to complete social login", "provider", provider, "error", err)
+		return HandleError(c, err)
+	}
+	if result.TwoFactorRequired {
+		return SuccessResp(c, fiber.StatusAccepted, "Two-factor authentication required", result)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Login successful", result.TokenPair)
+}
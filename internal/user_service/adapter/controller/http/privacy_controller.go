@@ -0,0 +1,73 @@
+package httpctl
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportMyData downloads everything stored about the caller as a JSON archive
+func (h *UserHandler) ExportMyData(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	return h.exportUserData(c, userID)
+}
+
+// RequestMyErasure erases the caller's account and personal data across services
+func (h *UserHandler) RequestMyErasure(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	return h.requestErasure(c, userID)
+}
+
+// ExportUserData downloads everything stored about a user (admin only)
+func (h *UserHandler) ExportUserData(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+	return h.exportUserData(c, id)
+}
+
+// RequestErasure erases a user's account and personal data across services (admin only)
+func (h *UserHandler) RequestErasure(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+	return h.requestErasure(c, id)
+}
+
+// GetErasureStatus returns the progress of a user's latest erasure request (admin only)
+func (h *UserHandler) GetErasureStatus(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	request, err := h.privacyUsecase.GetErasureStatus(c.Context(), id)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Erasure request retrieved", request)
+}
+
+func (h *UserHandler) exportUserData(c *fiber.Ctx, userID string) error {
+	export, err := h.privacyUsecase.ExportUserData(c.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to export user data", "user_id", userID, "error", err)
+		return HandleError(c, err)
+	}
+
+	c.Attachment(fmt.Sprintf("user-data-%s.json", userID))
+	return c.Status(fiber.StatusOK).JSON(export)
+}
+
+func (h *UserHandler) requestErasure(c *fiber.Ctx, userID string) error {
+	request, err := h.privacyUsecase.RequestErasure(c.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to request erasure", "user_id", userID, "error", err)
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusAccepted, "Erasure requested", request)
+}
@@ -0,0 +1,135 @@
+package httpctl
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/dto"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// GetProfile returns the caller's profile
+func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+
+	user, err := h.userUsecase.GetUserByID(c.Context(), userID)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Profile retrieved", user)
+}
+
+// UpdateProfile updates the caller's name, phone number and preferences
+func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	var req dto.ProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	user, err := h.userUsecase.UpdateProfile(c.Context(), userID, req.ToEntity())
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Profile updated", user)
+}
+
+// ListAddresses returns the caller's address book
+func (h *UserHandler) ListAddresses(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+
+	addresses, err := h.addressUsecase.ListAddresses(c.Context(), userID)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Addresses retrieved", addresses)
+}
+
+// GetAddress returns one of the caller's addresses
+func (h *UserHandler) GetAddress(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	addressID := c.Params("addressId")
+	if addressID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	address, err := h.addressUsecase.GetAddress(c.Context(), userID, addressID)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Address retrieved", address)
+}
+
+// CreateAddress adds an address to the caller's address book
+func (h *UserHandler) CreateAddress(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	var req dto.AddressRequest
+	if err := c.BodyParser(&req); err != nil {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	address, err := h.addressUsecase.CreateAddress(c.Context(), userID, req.ToEntity())
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusCreated, "Address created", address)
+}
+
+// UpdateAddress updates one of the caller's addresses
+func (h *UserHandler) UpdateAddress(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	addressID := c.Params("addressId")
+	if addressID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+	var req dto.AddressRequest
+	if err := c.BodyParser(&req); err != nil {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	address, err := h.addressUsecase.UpdateAddress(c.Context(), userID, addressID, req.ToEntity())
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Address updated", address)
+}
+
+// DeleteAddress removes one of the caller's addresses
+func (h *UserHandler) DeleteAddress(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	addressID := c.Params("addressId")
+	if addressID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.addressUsecase.DeleteAddress(c.Context(), userID, addressID); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Address deleted", nil)
+}
+
+// SetDefaultAddress makes one of the caller's addresses the default shipping or billing address
+func (h *UserHandler) SetDefaultAddress(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	addressID := c.Params("addressId")
+	var req dto.DefaultAddressRequest
+	if err := c.BodyParser(&req); err != nil || addressID == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+	addressType := entity.AddressType(req.Type)
+	if !addressType.IsValid() {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	address, err := h.addressUsecase.SetDefaultAddress(c.Context(), userID, addressID, addressType)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Default address updated", address)
+}
@@ -58,6 +58,69 @@ func HandleError(c *fiber.Ctx, err error) error {
 	case errors.Is(err, entity.ErrInvalidToken) || errors.Is(err, entity.ErrTokenHasBeenRevoked):
 		statusCode = http.StatusUnauthorized
 		message = "Invalid or revoked token"
+	case errors.Is(err, entity.ErrEmailNotVerified):
+		statusCode = http.StatusForbidden
+		message = "Email not verified"
+	case errors.Is(err, entity.ErrEmailAlreadyVerified):
+		statusCode = http.StatusConflict
+		message = "Email already verified"
+	case errors.Is(err, entity.ErrAccountLocked):
+		statusCode = http.StatusLocked
+		message = "Account is temporarily locked"
+	case errors.Is(err, entity.ErrTooManyLoginAttempts):
+		statusCode = http.StatusTooManyRequests
+		message = "Too many login attempts"
+	case errors.Is(err, entity.ErrForbidden):
+		statusCode = http.StatusForbidden
+		message = "Forbidden"
+	case errors.Is(err, entity.ErrInvalidTwoFactorCode):
+		statusCode = http.StatusUnauthorized
+		message = "Invalid two-factor code"
+	case errors.Is(err, entity.ErrTwoFactorNotEnrolled):
+		statusCode = http.StatusBadRequest
+		message = "Two-factor authentication not enrolled"
+	case errors.Is(err, entity.ErrTwoFactorEnabled):
+		statusCode = http.StatusConflict
+		message = "Two-factor authentication already enabled"
+	case errors.Is(err, entity.ErrTwoFactorRequired):
+		statusCode = http.StatusForbidden
+		message = "Two-factor authentication is required"
+	case errors.Is(err, entity.ErrUnknownProvider):
+		statusCode = http.StatusNotFound
+		message = "Unknown identity provider"
+	case errors.Is(err, entity.ErrInvalidLoginState):
+		statusCode = http.StatusBadRequest
+		message = "Invalid or expired login state"
+	case errors.Is(err, entity.ErrIdentityConflict):
+		statusCode = http.StatusConflict
+		message = "Email is already registered to another account"
+	case errors.Is(err, entity.ErrExternalAuthFailed):
+		statusCode = http.StatusBadGateway
+		message = "External authentication failed"
+	case errors.Is(err, entity.ErrAddressNotFound):
+		statusCode = http.StatusNotFound
+		message = "Address not found"
+	case errors.Is(err, entity.ErrInvalidAddress):
+		statusCode = http.StatusBadRequest
+		message = "Invalid address"
+	case errors.Is(err, entity.ErrInvalidPhone):
+		statusCode = http.StatusBadRequest
+		message = "Invalid phone number"
+	case errors.Is(err, entity.ErrErasureNotFound):
+		statusCode = http.StatusNotFound
+		message = "Erasure request not found"
+	case errors.Is(err, entity.ErrDataExportFailed):
+		statusCode = http.StatusServiceUnavailable
+		message = "Personal data could not be collected from all services"
+	case errors.Is(err, entity.ErrAccountSuspended):
+		statusCode = http.StatusForbidden
+		message = "Account is suspended"
+	case errors.Is(err, entity.ErrInvalidRole):
+		statusCode = http.StatusBadRequest
+		message = "Invalid role"
+	case errors.Is(err, entity.ErrSelfModification):
+		statusCode = http.StatusConflict
+		message = "Admins cannot change their own role or suspension"
 	case errors.Is(err, entity.ErrInternalServerError):
 		statusCode = http.StatusInternalServerError
 		message = "Internal server error"
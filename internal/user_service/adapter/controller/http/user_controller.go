@@ -3,40 +3,105 @@ package httpctl
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/dto"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
 	uc "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
 )
 
 // UserHandler handles HTTP requests for the user service
 type UserHandler struct {
-	authUsecase uc.AuthUsecase
-	userUsecase uc.UserUsecase
-	logger      logger.Logger
+	authUsecase    uc.AuthUsecase
+	userUsecase    uc.UserUsecase
+	accountUsecase uc.AccountUsecase
+	addressUsecase uc.AddressUsecase
+	tokenUsecase   uc.TokenUsecase
+	twoFactor      uc.TwoFactorUsecase
+	socialLogin    uc.SocialLoginUsecase
+	privacyUsecase uc.PrivacyUsecase
+	userAdmin      uc.UserAdminUsecase
+	logger         logger.Logger
 }
 
 // NewUserHandler creates a new instance of UserHandler
-func NewUserHandler(authUsecase uc.AuthUsecase, userUsecase uc.UserUsecase, logger logger.Logger) *UserHandler {
+func NewUserHandler(
+	authUsecase uc.AuthUsecase,
+	userUsecase uc.UserUsecase,
+	accountUsecase uc.AccountUsecase,
+	addressUsecase uc.AddressUsecase,
+	tokenUsecase uc.TokenUsecase,
+	twoFactor uc.TwoFactorUsecase,
+	socialLogin uc.SocialLoginUsecase,
+	privacyUsecase uc.PrivacyUsecase,
+	userAdmin uc.UserAdminUsecase,
+	logger logger.Logger,
+) *UserHandler {
 	return &UserHandler{
-		authUsecase: authUsecase,
-		userUsecase: userUsecase,
-		logger:      logger,
+		authUsecase:    authUsecase,
+		userUsecase:    userUsecase,
+		accountUsecase: accountUsecase,
+		addressUsecase: addressUsecase,
+		tokenUsecase:   tokenUsecase,
+		twoFactor:      twoFactor,
+		socialLogin:    socialLogin,
+		privacyUsecase: privacyUsecase,
+		userAdmin:      userAdmin,
+		logger:         logger,
 	}
 }
 
 // RegisterRoutes registers the routes for the user service
 func (h *UserHandler) RegisterRoutes(r fiber.Router) {
 	userGroup := r.Group("/users")
+	adminOnly := []fiber.Handler{AuthMiddleware(h.tokenUsecase), RequireRole(vo.Admin)}
+	selfOrAdmin := []fiber.Handler{AuthMiddleware(h.tokenUsecase), RequireSelfOrRole("id", vo.Admin)}
 
-	// userGroup.Post("/", h.CreateUser)
-	userGroup.Get("/:id", h.GetUser)
-	userGroup.Put("/:id", h.UpdateUser)
-	userGroup.Delete("/:id", h.DeleteUser)
+	userGroup.Get("/", append(adminOnly, h.ListUsers)...)
+	userGroup.Post("/", append(adminOnly, h.CreateUser)...)
+	userGroup.Get("/:id", append(selfOrAdmin, h.GetUser)...)
+	userGroup.Put("/:id", append(selfOrAdmin, h.UpdateUser)...)
+	userGroup.Delete("/:id", append(selfOrAdmin, h.DeleteUser)...)
 
 	userGroup.Post("/login", h.Login)
+	userGroup.Post("/login/2fa", h.VerifyTwoFactor)
+	userGroup.Post("/login/2fa/enroll", h.BeginLoginTwoFactorEnrollment)
+
+	userGroup.Get("/oauth/providers", h.ListOAuthProviders)
+	userGroup.Get("/oauth/:provider/login", h.StartOAuthLogin)
+	userGroup.Get("/oauth/:provider/callback", h.OAuthCallback)
 	userGroup.Post("/register", h.Register)
+
+	userGroup.Post("/verify-email/request", h.RequestEmailVerification)
+	userGroup.Post("/verify-email/confirm", h.ConfirmEmailVerification)
+	userGroup.Post("/password-reset/request", h.RequestPasswordReset)
+	userGroup.Post("/password-reset/confirm", h.ConfirmPasswordReset)
+
+	me := userGroup.Group("/me", AuthMiddleware(h.tokenUsecase))
+	me.Get("/profile", h.GetProfile)
+	me.Patch("/profile", h.UpdateProfile)
+	me.Get("/addresses", h.ListAddresses)
+	me.Post("/addresses", h.CreateAddress)
+	me.Get("/addresses/:addressId", h.GetAddress)
+	me.Put("/addresses/:addressId", h.UpdateAddress)
+	me.Delete("/addresses/:addressId", h.DeleteAddress)
+	me.Post("/addresses/:addressId/default", h.SetDefaultAddress)
+	me.Get("/data-export", h.ExportMyData)
+	me.Post("/erasure", h.RequestMyErasure)
+	me.Post("/2fa/enroll", h.BeginTwoFactorEnrollment)
+	me.Post("/2fa/confirm", h.ConfirmTwoFactorEnrollment)
+	me.Post("/2fa/disable", h.DisableTwoFactor)
+	me.Post("/2fa/recovery-codes", h.RegenerateRecoveryCodes)
+
+	userGroup.Put("/:id/role", append(adminOnly, h.ChangeUserRole)...)
+	userGroup.Post("/:id/suspend", append(adminOnly, h.SuspendUser)...)
+	userGroup.Post("/:id/reactivate", append(adminOnly, h.ReactivateUser)...)
+	userGroup.Get("/:id/sessions", append(adminOnly, h.ListUserSessions)...)
+	userGroup.Post("/:id/unlock", append(adminOnly, h.UnlockAccount)...)
+	userGroup.Get("/:id/data-export", append(adminOnly, h.ExportUserData)...)
+	userGroup.Post("/:id/erasure", append(adminOnly, h.RequestErasure)...)
+	userGroup.Get("/:id/erasure", append(adminOnly, h.GetErasureStatus)...)
 }
 
-// CreateUser handles the creation of a new user
+// CreateUser handles the creation of a new customer account (admin only)
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	var req dto.UserRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -46,6 +111,7 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 	ctx := c.Context()
 	userEntity := req.ToEntity()
+	userEntity.Role = vo.User
 	user, err := h.userUsecase.CreateUser(ctx, &userEntity, req.Password)
 	if err != nil {
 		return HandleError(c, err)
@@ -114,12 +180,15 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	tokenPair, err := h.authUsecase.Login(ctx, req.Email, req.Password)
+	result, err := h.authUsecase.Login(ctx, req.Email, req.Password, c.IP())
 	if err != nil {
 		return HandleError(c, err)
 	}
+	if result.TwoFactorRequired {
+		return SuccessResp(c, fiber.StatusAccepted, "Two-factor authentication required", result)
+	}
 
-	return SuccessResp(c, fiber.StatusOK, "Login successful", tokenPair)
+	return SuccessResp(c, fiber.StatusOK, "Login successful", result.TokenPair)
 }
 func (h *UserHandler) Register(c *fiber.Ctx) error {
 	var req dto.UserRequest
@@ -132,6 +201,11 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 	if err != nil {
 		return HandleError(c, err)
 	}
+	if tokenPair == nil {
+		return SuccessResp(c, fiber.StatusCreated, "Registration successful, please verify your email", fiber.Map{
+			"user": user,
+		})
+	}
 
 	return SuccessResp(c, fiber.StatusOK, "Registration successful", fiber.Map{
 		"user":      user,
@@ -139,3 +213,166 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 	},
 	)
 }
+
+// RequestEmailVerification re-sends the email verification link
+func (h *UserHandler) RequestEmailVerification(c *fiber.Ctx) error {
+	var req dto.EmailRequest
+	if err := c.BodyParser(&req); err != nil || req.Email == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.accountUsecase.RequestEmailVerification(c.Context(), req.Email); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusAccepted, "If the account exists, a verification email has been sent", nil)
+}
+
+// ConfirmEmailVerification verifies an email address using the emailed token
+func (h *UserHandler) ConfirmEmailVerification(c *fiber.Ctx) error {
+	var req dto.TokenRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.accountUsecase.ConfirmEmailVerification(c.Context(), req.Token); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Email verified", nil)
+}
+
+// RequestPasswordReset sends a password reset link
+func (h *UserHandler) RequestPasswordReset(c *fiber.Ctx) error {
+	var req dto.EmailRequest
+	if err := c.BodyParser(&req); err != nil || req.Email == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.accountUsecase.RequestPasswordReset(c.Context(), req.Email); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusAccepted, "If the account exists, a password reset email has been sent", nil)
+}
+
+// ConfirmPasswordReset sets a new password using the emailed token
+func (h *UserHandler) ConfirmPasswordReset(c *fiber.Ctx) error {
+	var req dto.PasswordResetConfirmRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" || len(req.NewPassword) < 8 {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.accountUsecase.ConfirmPasswordReset(c.Context(), req.Token, req.NewPassword); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Password has been reset", nil)
+}
+
+// UnlockAccount clears a failed-login lockout (admin only)
+func (h *UserHandler) UnlockAccount(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.authUsecase.UnlockAccount(c.Context(), id); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Account unlocked", nil)
+}
+
+// VerifyTwoFactor completes a login with a TOTP or recovery code
+func (h *UserHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var req dto.TwoFactorChallengeRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" || req.Code == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	result, err := h.authUsecase.VerifyTwoFactor(c.Context(), req.ChallengeToken, req.Code, c.IP())
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Login successful", result)
+}
+
+// BeginLoginTwoFactorEnrollment starts mandatory enrolment for a user holding a login challenge
+func (h *UserHandler) BeginLoginTwoFactorEnrollment(c *fiber.Ctx) error {
+	var req dto.TwoFactorChallengeRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	enrollment, err := h.authUsecase.BeginTwoFactorEnrollment(c.Context(), req.ChallengeToken)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Two-factor enrollment started", enrollment)
+}
+
+// BeginTwoFactorEnrollment generates a new TOTP secret for the caller
+func (h *UserHandler) BeginTwoFactorEnrollment(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+
+	enrollment, err := h.twoFactor.BeginEnrollment(c.Context(), userID)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Two-factor enrollment started", enrollment)
+}
+
+// ConfirmTwoFactorEnrollment enables two-factor authentication for the caller
+func (h *UserHandler) ConfirmTwoFactorEnrollment(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	codes, err := h.twoFactor.ConfirmEnrollment(c.Context(), userID, req.Code)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Two-factor authentication enabled", fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor turns off two-factor authentication for the caller
+func (h *UserHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	if err := h.twoFactor.Disable(c.Context(), userID, req.Code); err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the caller's recovery codes
+func (h *UserHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID, _ := c.Locals(LocalUserID).(string)
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return HandleError(c, ErrBadRequest)
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(c.Context(), userID, req.Code)
+	if err != nil {
+		return HandleError(c, err)
+	}
+
+	return SuccessResp(c, fiber.StatusOK, "Recovery codes regenerated", fiber.Map{
+		"recovery_codes": codes,
+	})
+}
@@ -21,6 +21,80 @@ type UserLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
+type RoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type SuspendRequest struct {
+	Reason string `json:"reason"`
+}
+
 type TokenRequest struct {
 	Token string `json:"token" validate:"required"`
 }
+
+type EmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code"`
+}
+
+type AddressRequest struct {
+	Label             string `json:"label"`
+	RecipientName     string `json:"recipient_name"`
+	Phone             string `json:"phone"`
+	Street            string `json:"street" validate:"required"`
+	City              string `json:"city" validate:"required"`
+	State             string `json:"state"`
+	Country           string `json:"country" validate:"required"`
+	PostalCode        string `json:"postal_code" validate:"required"`
+	IsDefaultShipping bool   `json:"is_default_shipping"`
+	IsDefaultBilling  bool   `json:"is_default_billing"`
+}
+
+func (a AddressRequest) ToEntity() entity.Address {
+	return entity.Address{
+		Label:             a.Label,
+		RecipientName:     a.RecipientName,
+		Phone:             a.Phone,
+		Street:            a.Street,
+		City:              a.City,
+		State:             a.State,
+		Country:           a.Country,
+		PostalCode:        a.PostalCode,
+		IsDefaultShipping: a.IsDefaultShipping,
+		IsDefaultBilling:  a.IsDefaultBilling,
+	}
+}
+
+type DefaultAddressRequest struct {
+	Type string `json:"type" validate:"required,oneof=shipping billing"`
+}
+
+type ProfileRequest struct {
+	FirstName   *string                 `json:"first_name"`
+	LastName    *string                 `json:"last_name"`
+	Phone       *string                 `json:"phone"`
+	Preferences *entity.UserPreferences `json:"preferences"`
+}
+
+func (p ProfileRequest) ToEntity() entity.ProfileUpdate {
+	return entity.ProfileUpdate{
+		FirstName:   p.FirstName,
+		LastName:    p.LastName,
+		Phone:       p.Phone,
+		Preferences: p.Preferences,
+	}
+}
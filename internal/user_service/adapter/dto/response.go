@@ -11,6 +11,32 @@ type UserResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// PaginatedResponse represents a paginated response
+type PaginatedResponse struct {
+	Total      int         `json:"total"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	TotalPages int         `json:"total_pages"`
+	Data       interface{} `json:"data"`
+}
+
+// NewPaginatedResponse creates a new paginated response
+func NewPaginatedResponse(total, page, pageSize int, data interface{}) PaginatedResponse {
+	totalPages := total / pageSize
+	if total%pageSize != 0 {
+		totalPages++
+	}
+
+	return PaginatedResponse{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		Data:       data,
+	}
+}
+
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/service"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConfig holds the configuration for Kafka connection
+type KafkaConfig struct {
+	Brokers         []string `yaml:"brokers"`
+	UserTopic       string   `yaml:"user_topic"`
+	ConsumerGroupID string   `yaml:"consumer_group_id"`
+}
+
+// KafkaEventPublisher implements the EventPublisherService interface using Kafka
+type KafkaEventPublisher struct {
+	writer       *kafka.Writer
+	kafkaConfig  *KafkaConfig
+	serviceState string // Can be used for health checks
+}
+
+// UserEventPayload represents the common payload structure for user events
+type UserEventPayload struct {
+	EventID   string                 `json:"event_id"`
+	EventType string                 `json:"event_type"`
+	Timestamp time.Time              `json:"timestamp"`
+	UserID    string                 `json:"user_id,omitempty"`
+	Email     string                 `json:"email,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	Reason    string                 `json:"reason,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Service   string                 `json:"service,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// NewKafkaEventPublisher creates a new Kafka event publisher
+func NewKafkaEventPublisher(config *KafkaConfig) (*KafkaEventPublisher, error) {
+	w := &kafka.Writer{
+		Addr:                   kafka.TCP(config.Brokers...),
+		Topic:                  config.UserTopic,
+		Balancer:               &kafka.LeastBytes{},
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           50 * time.Millisecond,
+	}
+
+	return &KafkaEventPublisher{
+		writer:       w,
+		kafkaConfig:  config,
+		serviceState: "ready",
+	}, nil
+}
+
+// serializeAndPublish serializes an event payload and publishes it to Kafka
+func (k *KafkaEventPublisher) serializeAndPublish(ctx context.Context, key string, payload UserEventPayload) error {
+	payload.EventID = uuid.New().String()
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event payload: %w", err)
+	}
+
+	message := kafka.Message{
+		Key:   []byte(key),
+		Value: payloadBytes,
+		Time:  time.Now(),
+	}
+
+	if err := k.writer.WriteMessages(ctx, message); err != nil {
+		return fmt.Errorf("failed to write message to Kafka: %w", err)
+	}
+
+	return nil
+}
+
+// PublishLoginFailed publishes an event that a login attempt has failed
+func (k *KafkaEventPublisher) PublishLoginFailed(ctx context.Context, email, ipAddress, reason string) error {
+	payload := UserEventPayload{
+		EventType: service.EventTypeUserLoginFailed,
+		Timestamp: time.Now(),
+		Email:     email,
+		IPAddress: ipAddress,
+		Reason:    reason,
+	}
+
+	return k.serializeAndPublish(ctx, email, payload)
+}
+
+// PublishUserLocked publishes an event that an account or client IP has been locked out
+func (k *KafkaEventPublisher) PublishUserLocked(ctx context.Context, attempt *entity.LoginAttempt, ipAddress string) error {
+	payload := UserEventPayload{
+		EventType: service.EventTypeUserLocked,
+		Timestamp: time.Now(),
+		IPAddress: ipAddress,
+		Reason:    "too many failed login attempts",
+		Data: map[string]interface{}{
+			"scope":        attempt.Scope,
+			"key":          attempt.Key,
+			"failed_count": attempt.FailedCount,
+			"lock_count":   attempt.LockCount,
+			"locked_until": attempt.LockedUntil,
+		},
+	}
+	if attempt.Scope == entity.LoginAttemptScopeAccount {
+		payload.Email = attempt.Key
+	}
+
+	return k.serializeAndPublish(ctx, attempt.Key, payload)
+}
+
+// PublishErasureRequested asks all services to erase the personal data of a user
+func (k *KafkaEventPublisher) PublishErasureRequested(ctx context.Context, request *entity.ErasureRequest) error {
+	payload := UserEventPayload{
+		EventType: service.EventTypeUserErasureRequested,
+		Timestamp: time.Now(),
+		UserID:    request.UserID,
+		RequestID: request.ID,
+		Data: map[string]interface{}{
+			"services": request.PendingServices,
+		},
+	}
+
+	return k.serializeAndPublish(ctx, request.UserID, payload)
+}
+
+// Close closes the Kafka writer connection
+func (k *KafkaEventPublisher) Close() error {
+	if err := k.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close user topic writer: %w", err)
+	}
+	k.serviceState = "closed"
+	return nil
+}
@@ -0,0 +1,139 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry of a failed event
+	retryBaseDelay = time.Second
+	// retryMaxDelay caps the delay between retries of a failed event
+	retryMaxDelay = time.Minute
+)
+
+// KafkaEventSubscriber consumes acknowledgements from other services on the user topic
+type KafkaEventSubscriber struct {
+	reader         *kafka.Reader
+	privacyUsecase usecase.PrivacyUsecase
+	logger         logger.Logger
+	wg             sync.WaitGroup
+	stopChan       chan struct{}
+}
+
+// NewKafkaEventSubscriber creates a new Kafka event subscriber
+func NewKafkaEventSubscriber(
+	config *KafkaConfig,
+	privacyUsecase usecase.PrivacyUsecase,
+	logger logger.Logger,
+) (*KafkaEventSubscriber, error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        config.Brokers,
+		Topic:          config.UserTopic,
+		GroupID:        config.ConsumerGroupID,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+		StartOffset:    kafka.FirstOffset,
+	})
+
+	return &KafkaEventSubscriber{
+		reader:         reader,
+		privacyUsecase: privacyUsecase,
+		logger:         logger,
+		stopChan:       make(chan struct{}),
+	}, nil
+}
+
+// Start begins consuming user events in the background
+func (s *KafkaEventSubscriber) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.consume(ctx)
+	}()
+}
+
+// Close stops the consumer and closes the reader
+func (s *KafkaEventSubscriber) Close() error {
+	close(s.stopChan)
+	s.wg.Wait()
+	return s.reader.Close()
+}
+
+func (s *KafkaEventSubscriber) consume(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		default:
+		}
+
+		readCtx, cancel := context.WithTimeout(ctx, time.Second)
+		msg, err := s.reader.FetchMessage(readCtx)
+		cancel()
+		if err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+				s.logger.Error("Failed to read user event", "error", err)
+			}
+			continue
+		}
+
+		// Committing past a failed completion would leave its erasure request pending forever
+		if !s.retry(ctx, msg) {
+			return
+		}
+		if err := s.reader.CommitMessages(ctx, msg); err != nil {
+			s.logger.Error("Failed to commit user event", "error", err)
+		}
+	}
+}
+
+// retry handles a user event until it succeeds, doubling the delay after each failure.
+// It reports false if the subscriber is stopped before the event is handled.
+func (s *KafkaEventSubscriber) retry(ctx context.Context, msg kafka.Message) bool {
+	delay := retryBaseDelay
+	for {
+		err := s.handleMessage(ctx, msg.Value)
+		if err == nil {
+			return true
+		}
+
+		s.logger.Error("Failed to handle user event", "error", err, "offset", msg.Offset, "retry_in", delay)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-s.stopChan:
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, retryMaxDelay)
+	}
+}
+
+// handleMessage dispatches a user event. Events published by this service itself are ignored.
+func (s *KafkaEventSubscriber) handleMessage(ctx context.Context, value []byte) error {
+	var payload UserEventPayload
+	if err := json.Unmarshal(value, &payload); err != nil {
+		// Retrying cannot fix a malformed message
+		s.logger.Error("Failed to unmarshal user event", "error", err)
+		return nil
+	}
+
+	switch payload.EventType {
+	case service.EventTypeUserErasureCompleted:
+		s.logger.Info("Erasure completed by service", "request_id", payload.RequestID, "service", payload.Service)
+		return s.privacyUsecase.RecordErasureCompletion(ctx, payload.RequestID, payload.Service)
+	}
+	return nil
+}
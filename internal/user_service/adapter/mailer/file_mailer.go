@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/logger"
+)
+
+// FileMailer implements interfaces.Mailer for local testing.
+// Messages are logged and, when a directory is configured, written to it as .eml files.
+type FileMailer struct {
+	dir    string
+	from   string
+	logger logger.Logger
+}
+
+// NewFileMailer creates a new file/log mailer
+func NewFileMailer(dir, from string, logger logger.Logger) *FileMailer {
+	return &FileMailer{dir: dir, from: from, logger: logger}
+}
+
+// Send logs the message and writes it to the mail directory
+func (m *FileMailer) Send(ctx context.Context, msg interfaces.MailMessage) error {
+	m.logger.Info("Mail sent", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg), 0o644); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/oidc"
+)
+
+// Provider adapts pkg/oidc to the interfaces.IdentityProvider interface
+type Provider struct {
+	provider *oidc.Provider
+}
+
+// NewProvider creates a new identity provider from its client configuration
+func NewProvider(config oidc.Config) *Provider {
+	return &Provider{provider: oidc.NewProvider(config, nil)}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return p.provider.Name()
+}
+
+// AuthCodeURL returns the provider authorization URL
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	return p.provider.AuthCodeURL(ctx, state, nonce, codeChallenge)
+}
+
+// Exchange redeems an authorization code and returns the verified identity
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*entity.ExternalIdentity, error) {
+	claims, err := p.provider.Exchange(ctx, code, codeVerifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" && claims.Name != "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+
+	return &entity.ExternalIdentity{
+		Provider:      p.Name(),
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		FirstName:     firstName,
+		LastName:      lastName,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// GormAddressRepository implements AddressRepository interface using GORM
+type GormAddressRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAddressRepository creates a new instance of GormAddressRepository
+func NewGormAddressRepository(db *gorm.DB) *GormAddressRepository {
+	return &GormAddressRepository{db: db}
+}
+
+// Create stores a new address
+func (r *GormAddressRepository) Create(ctx context.Context, address *entity.Address) error {
+	addressModel := model.NewAddressModel(address)
+	if err := r.db.WithContext(ctx).Create(addressModel).Error; err != nil {
+		return err
+	}
+	*address = *addressModel.ToEntity()
+	return nil
+}
+
+// GetByID retrieves an address owned by a user, or nil if none exists
+func (r *GormAddressRepository) GetByID(ctx context.Context, userID, id string) (*entity.Address, error) {
+	var address model.Address
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return address.ToEntity(), nil
+}
+
+// ListByUserID retrieves all addresses of a user, defaults first
+func (r *GormAddressRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Address, error) {
+	var addresses []*model.Address
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("is_default_shipping DESC, is_default_billing DESC, created_at ASC").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.Address, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.ToEntity()
+	}
+	return result, nil
+}
+
+// Update updates an existing address
+func (r *GormAddressRepository) Update(ctx context.Context, address *entity.Address) error {
+	addressModel := model.NewAddressModel(address)
+	if err := r.db.WithContext(ctx).Save(addressModel).Error; err != nil {
+		return err
+	}
+	*address = *addressModel.ToEntity()
+	return nil
+}
+
+// Delete removes an address owned by a user
+func (r *GormAddressRepository) Delete(ctx context.Context, userID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.Address{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrAddressNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID removes all addresses of a user
+func (r *GormAddressRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.Address{}).Error
+}
+
+// SetDefault marks an address as the user's default of the given type and clears the previous default
+func (r *GormAddressRepository) SetDefault(ctx context.Context, userID, id string, addressType entity.AddressType) error {
+	column := "is_default_shipping"
+	if addressType == entity.BillingAddress {
+		column = "is_default_billing"
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Address{}).
+			Where("user_id = ? AND id <> ?", userID, id).
+			Update(column, false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&model.Address{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Update(column, true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Either the address does not exist or it already was the default
+			var count int64
+			if err := tx.Model(&model.Address{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return entity.ErrAddressNotFound
+			}
+		}
+		return nil
+	})
+}
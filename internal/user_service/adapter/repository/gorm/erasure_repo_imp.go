@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// GormErasureRequestRepository implements ErasureRequestRepository interface using GORM
+type GormErasureRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewGormErasureRequestRepository creates a new instance of GormErasureRequestRepository
+func NewGormErasureRequestRepository(db *gorm.DB) *GormErasureRequestRepository {
+	return &GormErasureRequestRepository{db: db}
+}
+
+// Create stores a new erasure request
+func (r *GormErasureRequestRepository) Create(ctx context.Context, request *entity.ErasureRequest) error {
+	return r.db.WithContext(ctx).Create(model.NewErasureRequestModel(request)).Error
+}
+
+// GetByID retrieves an erasure request, or nil if none exists
+func (r *GormErasureRequestRepository) GetByID(ctx context.Context, id string) (*entity.ErasureRequest, error) {
+	var request model.ErasureRequest
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return request.ToEntity(), nil
+}
+
+// GetLatestByUserID retrieves the most recent erasure request of a user, or nil if none exists
+func (r *GormErasureRequestRepository) GetLatestByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	var request model.ErasureRequest
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("requested_at DESC").
+		First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return request.ToEntity(), nil
+}
+
+// Update saves the progress of an erasure request
+func (r *GormErasureRequestRepository) Update(ctx context.Context, request *entity.ErasureRequest) error {
+	return r.db.WithContext(ctx).Save(model.NewErasureRequestModel(request)).Error
+}
+
+// UpdateByID applies fn to an erasure request under a row lock and saves it when fn reports a change
+func (r *GormErasureRequestRepository) UpdateByID(ctx context.Context, id string, fn func(request *entity.ErasureRequest) bool) (*entity.ErasureRequest, error) {
+	var updated *entity.ErasureRequest
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var request model.ErasureRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&request).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		updated = request.ToEntity()
+		if !fn(updated) {
+			return nil
+		}
+		return tx.Save(model.NewErasureRequestModel(updated)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
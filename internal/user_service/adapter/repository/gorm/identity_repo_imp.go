@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// GormIdentityRepository implements IdentityRepository interface using GORM
+type GormIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIdentityRepository creates a new instance of GormIdentityRepository
+func NewGormIdentityRepository(db *gorm.DB) *GormIdentityRepository {
+	return &GormIdentityRepository{db: db}
+}
+
+// Create links a new external identity to a user
+func (r *GormIdentityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	return r.db.WithContext(ctx).Create(model.NewIdentityModel(identity)).Error
+}
+
+// GetByProviderSubject retrieves an identity by provider and subject, or nil if none exists
+func (r *GormIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	var identity model.Identity
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return identity.ToEntity(), nil
+}
+
+// ListByUserID retrieves all identities linked to a user
+func (r *GormIdentityRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error) {
+	var identities []*model.Identity
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&identities).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*entity.Identity, len(identities))
+	for i, identity := range identities {
+		result[i] = identity.ToEntity()
+	}
+	return result, nil
+}
+
+// DeleteByUserID unlinks all identities of a user
+func (r *GormIdentityRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.Identity{}).Error
+}
+
+// CreateLoginState stores the state of an in-flight login
+func (r *GormIdentityRepository) CreateLoginState(ctx context.Context, state *entity.OIDCLoginState) error {
+	return r.db.WithContext(ctx).Create(model.NewOIDCLoginStateModel(state)).Error
+}
+
+// ConsumeLoginState deletes and returns a login state, or nil if none exists.
+// The delete is the claim, so a state can only be redeemed once.
+func (r *GormIdentityRepository) ConsumeLoginState(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	var loginState model.OIDCLoginState
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state = ?", state).First(&loginState).Error; err != nil {
+			return err
+		}
+		result := tx.Where("state = ?", state).Delete(&model.OIDCLoginState{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return loginState.ToEntity(), nil
+}
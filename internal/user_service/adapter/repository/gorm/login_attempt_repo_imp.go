@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// GormLoginAttemptRepository implements LoginAttemptRepository interface using GORM
+type GormLoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLoginAttemptRepository creates a new instance of GormLoginAttemptRepository
+func NewGormLoginAttemptRepository(db *gorm.DB) *GormLoginAttemptRepository {
+	return &GormLoginAttemptRepository{db: db}
+}
+
+// Get retrieves the counter for a scope and key, or nil if none exists
+func (r *GormLoginAttemptRepository) Get(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := r.db.WithContext(ctx).
+		Where("scope = ? AND `key` = ?", scope, key).
+		First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return attempt.ToEntity(), nil
+}
+
+// Update applies fn to the counter for a scope and key under a row lock, creating it if none exists
+func (r *GormLoginAttemptRepository) Update(ctx context.Context, scope entity.LoginAttemptScope, key string, fn func(attempt *entity.LoginAttempt)) (*entity.LoginAttempt, error) {
+	var updated *entity.LoginAttempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Insert an empty counter if there is none, so there is always a row to lock
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.LoginAttempt{Scope: scope, Key: key, LastFailedAt: time.Now()}).Error
+		if err != nil {
+			return err
+		}
+
+		var attempt model.LoginAttempt
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND `key` = ?", scope, key).
+			First(&attempt).Error
+		if err != nil {
+			return err
+		}
+
+		updated = attempt.ToEntity()
+		fn(updated)
+		return tx.Save(model.NewLoginAttemptModel(updated)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes the counter for a scope and key
+func (r *GormLoginAttemptRepository) Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error {
+	return r.db.WithContext(ctx).
+		Where("scope = ? AND `key` = ?", scope, key).
+		Delete(&model.LoginAttempt{}).Error
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type Address struct {
+	ID                string    `gorm:"primaryKey;type:char(36)" json:"id"`
+	UserID            string    `gorm:"not null;index;type:char(36)" json:"user_id"`
+	Label             string    `gorm:"size:50" json:"label"`
+	RecipientName     string    `gorm:"size:200" json:"recipient_name"`
+	Phone             string    `gorm:"size:32" json:"phone"`
+	Street            string    `gorm:"not null;size:255" json:"street"`
+	City              string    `gorm:"not null;size:100" json:"city"`
+	State             string    `gorm:"size:100" json:"state"`
+	Country           string    `gorm:"not null;size:100" json:"country"`
+	PostalCode        string    `gorm:"not null;size:20" json:"postal_code"`
+	IsDefaultShipping bool      `gorm:"not null;default:false" json:"is_default_shipping"`
+	IsDefaultBilling  bool      `gorm:"not null;default:false" json:"is_default_billing"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (a *Address) TableName() string {
+	return "user_addresses"
+}
+
+func NewAddressModel(address *entity.Address) *Address {
+	return &Address{
+		ID:                address.ID,
+		UserID:            address.UserID,
+		Label:             address.Label,
+		RecipientName:     address.RecipientName,
+		Phone:             address.Phone,
+		Street:            address.Street,
+		City:              address.City,
+		State:             address.State,
+		Country:           address.Country,
+		PostalCode:        address.PostalCode,
+		IsDefaultShipping: address.IsDefaultShipping,
+		IsDefaultBilling:  address.IsDefaultBilling,
+		CreatedAt:         address.CreatedAt,
+		UpdatedAt:         address.UpdatedAt,
+	}
+}
+
+func (a *Address) ToEntity() *entity.Address {
+	return &entity.Address{
+		ID:                a.ID,
+		UserID:            a.UserID,
+		Label:             a.Label,
+		RecipientName:     a.RecipientName,
+		Phone:             a.Phone,
+		Street:            a.Street,
+		City:              a.City,
+		State:             a.State,
+		Country:           a.Country,
+		PostalCode:        a.PostalCode,
+		IsDefaultShipping: a.IsDefaultShipping,
+		IsDefaultBilling:  a.IsDefaultBilling,
+		CreatedAt:         a.CreatedAt,
+		UpdatedAt:         a.UpdatedAt,
+	}
+}
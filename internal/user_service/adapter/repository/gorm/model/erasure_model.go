@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type ErasureRequest struct {
+	ID                string               `gorm:"primaryKey;type:char(36)" json:"id"`
+	UserID            string               `gorm:"not null;index;type:char(36)" json:"user_id"`
+	Status            entity.ErasureStatus `gorm:"not null;type:varchar(20)" json:"status"`
+	PendingServices   string               `gorm:"type:text" json:"pending_services"`
+	CompletedServices string               `gorm:"type:text" json:"completed_services"`
+	RequestedAt       time.Time            `gorm:"not null" json:"requested_at"`
+	CompletedAt       *time.Time           `json:"completed_at"`
+	UpdatedAt         time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (e *ErasureRequest) TableName() string {
+	return "erasure_requests"
+}
+
+func NewErasureRequestModel(request *entity.ErasureRequest) *ErasureRequest {
+	return &ErasureRequest{
+		ID:                request.ID,
+		UserID:            request.UserID,
+		Status:            request.Status,
+		PendingServices:   encodeStringList(request.PendingServices),
+		CompletedServices: encodeStringList(request.CompletedServices),
+		RequestedAt:       request.RequestedAt,
+		CompletedAt:       request.CompletedAt,
+		UpdatedAt:         request.UpdatedAt,
+	}
+}
+
+func (e *ErasureRequest) ToEntity() *entity.ErasureRequest {
+	return &entity.ErasureRequest{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		Status:            e.Status,
+		PendingServices:   decodeStringList(e.PendingServices),
+		CompletedServices: decodeStringList(e.CompletedServices),
+		RequestedAt:       e.RequestedAt,
+		CompletedAt:       e.CompletedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type Identity struct {
+	ID        string    `gorm:"primaryKey;type:char(36)" json:"id"`
+	UserID    string    `gorm:"not null;index;type:char(36)" json:"user_id"`
+	Provider  string    `gorm:"not null;size:50;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject   string    `gorm:"not null;size:255;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+	Email     string    `gorm:"size:100" json:"email"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (i *Identity) TableName() string {
+	return "identities"
+}
+
+func NewIdentityModel(identity *entity.Identity) *Identity {
+	return &Identity{
+		ID:        identity.ID,
+		UserID:    identity.UserID,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+		UpdatedAt: identity.UpdatedAt,
+	}
+}
+
+func (i *Identity) ToEntity() *entity.Identity {
+	return &entity.Identity{
+		ID:        i.ID,
+		UserID:    i.UserID,
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+type OIDCLoginState struct {
+	State        string    `gorm:"primaryKey;size:64" json:"-"`
+	Provider     string    `gorm:"not null;size:50" json:"provider"`
+	CodeVerifier string    `gorm:"not null;size:128" json:"-"`
+	Nonce        string    `gorm:"not null;size:128" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (s *OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+func NewOIDCLoginStateModel(state *entity.OIDCLoginState) *OIDCLoginState {
+	return &OIDCLoginState{
+		State:        state.State,
+		Provider:     state.Provider,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+		ExpiresAt:    state.ExpiresAt,
+		CreatedAt:    state.CreatedAt,
+	}
+}
+
+func (s *OIDCLoginState) ToEntity() *entity.OIDCLoginState {
+	return &entity.OIDCLoginState{
+		State:        s.State,
+		Provider:     s.Provider,
+		CodeVerifier: s.CodeVerifier,
+		Nonce:        s.Nonce,
+		ExpiresAt:    s.ExpiresAt,
+		CreatedAt:    s.CreatedAt,
+	}
+}
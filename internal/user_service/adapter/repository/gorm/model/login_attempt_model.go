@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type LoginAttempt struct {
+	Scope        entity.LoginAttemptScope `gorm:"primaryKey;type:varchar(20)" json:"scope"`
+	Key          string                   `gorm:"primaryKey;size:255" json:"key"`
+	FailedCount  int                      `gorm:"not null;default:0" json:"failed_count"`
+	LockCount    int                      `gorm:"not null;default:0" json:"lock_count"`
+	LastFailedAt time.Time                `json:"last_failed_at"`
+	LockedUntil  *time.Time               `gorm:"index" json:"locked_until"`
+	UpdatedAt    time.Time                `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (a *LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func NewLoginAttemptModel(attempt *entity.LoginAttempt) *LoginAttempt {
+	return &LoginAttempt{
+		Scope:        attempt.Scope,
+		Key:          attempt.Key,
+		FailedCount:  attempt.FailedCount,
+		LockCount:    attempt.LockCount,
+		LastFailedAt: attempt.LastFailedAt,
+		LockedUntil:  attempt.LockedUntil,
+		UpdatedAt:    attempt.UpdatedAt,
+	}
+}
+
+func (a *LoginAttempt) ToEntity() *entity.LoginAttempt {
+	return &entity.LoginAttempt{
+		Scope:        a.Scope,
+		Key:          a.Key,
+		FailedCount:  a.FailedCount,
+		LockCount:    a.LockCount,
+		LastFailedAt: a.LastFailedAt,
+		LockedUntil:  a.LockedUntil,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
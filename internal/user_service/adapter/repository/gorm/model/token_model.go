@@ -14,6 +14,7 @@ type Token struct {
 	Token     string           `gorm:"not null;type:text" json:"token"`
 	Type      entity.TokenType `gorm:"not null;type:varchar(20)" json:"type"`
 	ExpiresAt time.Time        `gorm:"not null;autoUpdateTime" json:"expires_at"`
+	UsedAt    *time.Time       `json:"used_at"`
 	CreatedAt time.Time        `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt *gorm.DeletedAt  `gorm:"index" json:"deleted_at"`
@@ -29,6 +30,7 @@ func NewTokenModel(token *entity.Token) *Token {
 		Token:     token.Token,
 		Type:      token.Type,
 		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
 		CreatedAt: token.CreatedAt,
 		UpdatedAt: token.UpdatedAt,
 		DeletedAt: &gorm.DeletedAt{Time: utils.ValueOr(token.DeletedAt)},
@@ -42,6 +44,7 @@ func (t *Token) ToEntity() *entity.Token {
 		Token:     t.Token,
 		Type:      t.Type,
 		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
 		CreatedAt: t.CreatedAt,
 		UpdatedAt: t.UpdatedAt,
 		DeletedAt: &t.DeletedAt.Time,
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
@@ -10,15 +11,25 @@ import (
 )
 
 type User struct {
-	ID             string          `gorm:"primaryKey;type:char(36)" json:"id"`
-	Email          string          `gorm:"uniqueIndex;not null;size:100" json:"email"`
-	HashedPassword string          `gorm:"not null" json:"-"`
-	FirstName      string          `gorm:"size:100" json:"first_name"`
-	LastName       string          `gorm:"size:100" json:"last_name"`
-	Role           vo.Role         `gorm:"type:varchar(20)" json:"role"`
-	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt      *gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	ID               string          `gorm:"primaryKey;type:char(36)" json:"id"`
+	Email            string          `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	HashedPassword   string          `gorm:"not null" json:"-"`
+	FirstName        string          `gorm:"size:100" json:"first_name"`
+	LastName         string          `gorm:"size:100" json:"last_name"`
+	Role             vo.Role         `gorm:"type:varchar(20)" json:"role"`
+	Phone            string          `gorm:"size:32" json:"phone"`
+	Preferences      string          `gorm:"type:text" json:"preferences"`
+	EmailVerified    bool            `gorm:"not null;default:false" json:"email_verified"`
+	VerifiedAt       *time.Time      `json:"verified_at"`
+	TwoFactorEnabled bool            `gorm:"not null;default:false" json:"two_factor_enabled"`
+	TwoFactorSecret  string          `gorm:"size:64" json:"-"`
+	RecoveryCodes    string          `gorm:"type:text" json:"-"`
+	TwoFactorCounter int64           `gorm:"not null;default:0" json:"-"`
+	SuspendedAt      *time.Time      `gorm:"index" json:"suspended_at"`
+	SuspensionReason string          `gorm:"size:255" json:"suspension_reason"`
+	CreatedAt        time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        *gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
 
 func (u *User) TableName() string {
@@ -28,27 +39,79 @@ func (u *User) TableName() string {
 // ToEntity converts the GORM User model to the domain entity User
 func (u *User) ToEntity() *entity.User {
 	return &entity.User{
-		ID:             u.ID,
-		Email:          u.Email,
-		HashedPassword: u.HashedPassword,
-		FirstName:      u.FirstName,
-		LastName:       u.LastName,
-		Role:           u.Role,
-		CreatedAt:      u.CreatedAt,
-		UpdatedAt:      u.UpdatedAt,
-		DeletedAt:      utils.DeletedAtPtrToTimePtr(u.DeletedAt),
+		ID:               u.ID,
+		Email:            u.Email,
+		HashedPassword:   u.HashedPassword,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		Role:             u.Role,
+		Phone:            u.Phone,
+		Preferences:      decodePreferences(u.Preferences),
+		EmailVerified:    u.EmailVerified,
+		VerifiedAt:       u.VerifiedAt,
+		TwoFactorEnabled: u.TwoFactorEnabled,
+		TwoFactorSecret:  u.TwoFactorSecret,
+		RecoveryCodes:    decodeStringList(u.RecoveryCodes),
+		TwoFactorCounter: u.TwoFactorCounter,
+		SuspendedAt:      u.SuspendedAt,
+		SuspensionReason: u.SuspensionReason,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+		DeletedAt:        utils.DeletedAtPtrToTimePtr(u.DeletedAt),
 	}
 }
 func NewUserModel(user *entity.User) *User {
 	return &User{
-		ID:             user.ID,
-		Email:          user.Email,
-		HashedPassword: user.HashedPassword,
-		FirstName:      user.FirstName,
-		LastName:       user.LastName,
-		Role:           user.Role,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
-		DeletedAt:      utils.TimePtrToDeletedAt(user.DeletedAt),
+		ID:               user.ID,
+		Email:            user.Email,
+		HashedPassword:   user.HashedPassword,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Role:             user.Role,
+		Phone:            user.Phone,
+		Preferences:      encodePreferences(user.Preferences),
+		EmailVerified:    user.EmailVerified,
+		VerifiedAt:       user.VerifiedAt,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		TwoFactorSecret:  user.TwoFactorSecret,
+		RecoveryCodes:    encodeStringList(user.RecoveryCodes),
+		TwoFactorCounter: user.TwoFactorCounter,
+		SuspendedAt:      user.SuspendedAt,
+		SuspensionReason: user.SuspensionReason,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		DeletedAt:        utils.TimePtrToDeletedAt(user.DeletedAt),
 	}
 }
+
+// encodeStringList stores a string slice, such as the recovery code hashes, as a JSON array
+func encodeStringList(codes []string) string {
+	if len(codes) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(codes)
+	return string(b)
+}
+
+func decodeStringList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var codes []string
+	_ = json.Unmarshal([]byte(value), &codes)
+	return codes
+}
+
+// encodePreferences stores the user preferences as a JSON object
+func encodePreferences(preferences entity.UserPreferences) string {
+	b, _ := json.Marshal(preferences)
+	return string(b)
+}
+
+func decodePreferences(value string) entity.UserPreferences {
+	var preferences entity.UserPreferences
+	if value != "" {
+		_ = json.Unmarshal([]byte(value), &preferences)
+	}
+	return preferences
+}
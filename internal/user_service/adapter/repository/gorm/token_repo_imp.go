@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -75,3 +76,38 @@ func (r *GormTokenRepository) Update(ctx context.Context, by, token entity.Token
 func (r *GormTokenRepository) Create(ctx context.Context, token *entity.Token) error {
 	return r.db.WithContext(ctx).Create(model.NewTokenModel(token)).Error
 }
+
+// FindByTokenAndType retrieves a token by value, restricted to the given type
+func (r *GormTokenRepository) FindByTokenAndType(ctx context.Context, value string, tokenType entity.TokenType) (*entity.Token, error) {
+	var token model.Token
+	err := r.db.WithContext(ctx).
+		Where("token = ? AND type = ?", value, tokenType).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return token.ToEntity(), nil
+}
+
+// MarkUsed marks a single-use token as consumed. It only succeeds once per token.
+func (r *GormTokenRepository) MarkUsed(ctx context.Context, tokenID string) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Token{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrInvalidToken
+	}
+	return nil
+}
+
+// DeleteByUserIDAndType removes all tokens of the given type for a user
+func (r *GormTokenRepository) DeleteByUserIDAndType(ctx context.Context, userID string, tokenType entity.TokenType) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND type = ?", userID, tokenType).Delete(&model.Token{}).Error
+}
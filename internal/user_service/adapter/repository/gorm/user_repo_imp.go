@@ -2,12 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
 	"gorm.io/gorm"
 )
 
+// likeEscaper escapes the LIKE wildcards in a search term so they match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // GormUserRepository implements UserRepository interface using GORM
 type GormUserRepository struct {
 	db *gorm.DB
@@ -50,6 +56,59 @@ func (r *GormUserRepository) GetByEmail(ctx context.Context, email string) (*ent
 
 // GetByUsername retrieves a user by username
 
+// List retrieves users with optional filtering
+func (r *GormUserRepository) List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*entity.User, int, error) {
+	var userModels []model.User
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.User{})
+
+	// Soft-deleted users are hidden unless explicitly requested
+	switch filters["deleted"] {
+	case "include":
+		query = query.Unscoped()
+	case "only":
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+
+	for key, value := range filters {
+		switch key {
+		case "query":
+			pattern := fmt.Sprintf("%%%s%%", likeEscaper.Replace(fmt.Sprint(value)))
+			query = query.Where("email LIKE ? OR first_name LIKE ? OR last_name LIKE ?", pattern, pattern, pattern)
+		case "role":
+			query = query.Where("role = ?", value)
+		case "created_from":
+			query = query.Where("created_at >= ?", value)
+		case "created_to":
+			query = query.Where("created_at <= ?", value)
+		case "suspended":
+			if suspended, _ := value.(bool); suspended {
+				query = query.Where("suspended_at IS NOT NULL")
+			} else {
+				query = query.Where("suspended_at IS NULL")
+			}
+		}
+	}
+
+	// Count total matching records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&userModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]*entity.User, len(userModels))
+	for i := range userModels {
+		users[i] = userModels[i].ToEntity()
+	}
+
+	return users, int(total), nil
+}
+
 // Update updates an existing user
 func (r *GormUserRepository) Update(ctx context.Context, user entity.User) (*entity.User, error) {
 	userModel := model.NewUserModel(&user)
@@ -60,6 +119,56 @@ func (r *GormUserRepository) Update(ctx context.Context, user entity.User) (*ent
 	return userModel.ToEntity(), nil
 }
 
+// ClaimTwoFactorCounter records the time step of an accepted TOTP code unless it has been used already.
+// The conditional update makes concurrent claims of the same code exclusive.
+func (r *GormUserRepository) ClaimTwoFactorCounter(ctx context.Context, id string, counter int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.User{}).
+		Where("id = ? AND two_factor_counter < ?", id, counter).
+		Update("two_factor_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SpendRecoveryCode removes a recovery code unless it has been spent already. The codes are
+// replaced with an update conditional on the value read, and read again when another request
+// changed them in between, so concurrent requests cannot spend the same code twice.
+func (r *GormUserRepository) SpendRecoveryCode(ctx context.Context, id, hash string) (bool, error) {
+	for {
+		var userModel model.User
+		if err := r.db.WithContext(ctx).Select("id", "recovery_codes").Where("id = ?", id).First(&userModel).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, entity.ErrUserNotFound
+			}
+			return false, err
+		}
+
+		codes := userModel.ToEntity().RecoveryCodes
+		remaining := make([]string, 0, len(codes))
+		for _, code := range codes {
+			if code != hash {
+				remaining = append(remaining, code)
+			}
+		}
+		if len(remaining) == len(codes) {
+			return false, nil
+		}
+
+		result := r.db.WithContext(ctx).
+			Model(&model.User{}).
+			Where("id = ? AND recovery_codes = ?", id, userModel.RecoveryCodes).
+			Update("recovery_codes", model.NewUserModel(&entity.User{RecoveryCodes: remaining}).RecoveryCodes)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return true, nil
+		}
+	}
+}
+
 // Delete removes a user by ID
 func (r *GormUserRepository) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.User{}).Error
@@ -6,15 +6,24 @@ import (
 	"time"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/oidc"
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig       `yaml:"server"`
-	Database DatabaseConfig     `yaml:"database"`
-	JWT      jwt_service.Config `yaml:"jwt"`
-	GRPC     GRPCConfig         `yaml:"grpc"`
+	Server    ServerConfig       `yaml:"server"`
+	Database  DatabaseConfig     `yaml:"database"`
+	JWT       jwt_service.Config `yaml:"jwt"`
+	GRPC      GRPCConfig         `yaml:"grpc"`
+	Mailer    MailerConfig       `yaml:"mailer"`
+	Account   AccountConfig      `yaml:"account"`
+	Security  SecurityConfig     `yaml:"security"`
+	TwoFactor TwoFactorConfig    `yaml:"twoFactor"`
+	OIDC      OIDCConfig         `yaml:"oidc"`
+	Kafka     KafkaConfig        `yaml:"kafka"`
+	Privacy   PrivacyConfig      `yaml:"privacy"`
+	Services  ServicesConfig     `yaml:"services"`
 }
 
 // ServerConfig contains HTTP server configuration
@@ -23,6 +32,9 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `yaml:"readTimeout"`
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
 	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+	// ProxyHeader carries the client IP set by the gateway; it is only trusted from TrustedProxies
+	ProxyHeader    string   `yaml:"proxyHeader"`
+	TrustedProxies []string `yaml:"trustedProxies"` // IPs or CIDR ranges of the gateway
 }
 
 // DatabaseConfig contains database configuration
@@ -42,6 +54,66 @@ type GRPCConfig struct {
 	Port string `yaml:"port"`
 }
 
+// MailerConfig contains outgoing email configuration
+type MailerConfig struct {
+	Driver   string `yaml:"driver"` // "smtp" or "file"
+	From     string `yaml:"from"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Dir      string `yaml:"dir"` // output directory for the file driver
+}
+
+// AccountConfig contains email verification and password reset configuration
+type AccountConfig struct {
+	BaseURL                  string        `yaml:"baseURL"`
+	RequireEmailVerification bool          `yaml:"requireEmailVerification"`
+	VerificationTokenTTL     time.Duration `yaml:"verificationTokenTTL"`
+	PasswordResetTokenTTL    time.Duration `yaml:"passwordResetTokenTTL"`
+}
+
+// SecurityConfig contains failed-login throttling configuration
+type SecurityConfig struct {
+	MaxAccountAttempts int           `yaml:"maxAccountAttempts"`
+	MaxIPAttempts      int           `yaml:"maxIPAttempts"`
+	AttemptWindow      time.Duration `yaml:"attemptWindow"`
+	BaseLockout        time.Duration `yaml:"baseLockout"`
+	MaxLockout         time.Duration `yaml:"maxLockout"`
+}
+
+// TwoFactorConfig contains TOTP two-factor authentication configuration
+type TwoFactorConfig struct {
+	Issuer       string        `yaml:"issuer"`
+	ChallengeTTL time.Duration `yaml:"challengeTTL"`
+}
+
+// OIDCConfig contains social login configuration
+type OIDCConfig struct {
+	StateTTL  time.Duration `yaml:"stateTTL"`
+	Providers []oidc.Config `yaml:"providers"`
+}
+
+// KafkaConfig contains event publishing configuration
+type KafkaConfig struct {
+	Brokers         []string `yaml:"brokers"`
+	UserTopic       string   `yaml:"user_topic"`
+	ConsumerGroupID string   `yaml:"consumer_group_id"`
+}
+
+// PrivacyConfig contains data-subject request configuration
+type PrivacyConfig struct {
+	// ErasureServices must each report completion before an erasure request is done.
+	// payment_service stores payments and saved payment methods per user, so requests stay
+	// pending until it reports, even though it does not consume user events yet.
+	ErasureServices []string `yaml:"erasureServices"`
+}
+
+// ServicesConfig contains the addresses of downstream gRPC services
+type ServicesConfig struct {
+	OrderService string `yaml:"orderService"`
+}
+
 // LoadConfig loads configuration from a YAML file
 func LoadConfig(configPath string) (*Config, error) {
 	// Set default configuration
@@ -51,6 +123,13 @@ func LoadConfig(configPath string) (*Config, error) {
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
 			IdleTimeout:  60 * time.Second,
+			ProxyHeader:  "X-Forwarded-For",
+			TrustedProxies: []string{
+				"127.0.0.1",
+				"10.0.0.0/8",
+				"172.16.0.0/12",
+				"192.168.0.0/16",
+			},
 		},
 		Database: DatabaseConfig{
 			User:     "root",
@@ -71,6 +150,42 @@ func LoadConfig(configPath string) (*Config, error) {
 		GRPC: GRPCConfig{
 			Port: "50051",
 		},
+		Mailer: MailerConfig{
+			Driver: "file",
+			From:   "no-reply@localhost",
+			Port:   "587",
+		},
+		Account: AccountConfig{
+			BaseURL:                  "http://localhost:3000",
+			RequireEmailVerification: true,
+			VerificationTokenTTL:     24 * time.Hour,
+			PasswordResetTokenTTL:    time.Hour,
+		},
+		Security: SecurityConfig{
+			MaxAccountAttempts: 5,
+			MaxIPAttempts:      20,
+			AttemptWindow:      15 * time.Minute,
+			BaseLockout:        time.Minute,
+			MaxLockout:         24 * time.Hour,
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:       "Ecom",
+			ChallengeTTL: 5 * time.Minute,
+		},
+		OIDC: OIDCConfig{
+			StateTTL: 10 * time.Minute,
+		},
+		Kafka: KafkaConfig{
+			Brokers:         []string{"localhost:9092"},
+			UserTopic:       "user_events",
+			ConsumerGroupID: "user-service",
+		},
+		Privacy: PrivacyConfig{
+			ErasureServices: []string{"order_service", "payment_service"},
+		},
+		Services: ServicesConfig{
+			OrderService: "127.0.0.1:50053",
+		},
 	}
 
 	// Read config file
@@ -125,5 +240,27 @@ func overrideWithEnv(config *Config) *Config {
 		config.GRPC.Port = value
 	}
 
+	// Mailer
+	if value := os.Getenv("MAILER_DRIVER"); value != "" {
+		config.Mailer.Driver = value
+	}
+	if value := os.Getenv("SMTP_HOST"); value != "" {
+		config.Mailer.Host = value
+	}
+	if value := os.Getenv("SMTP_PORT"); value != "" {
+		config.Mailer.Port = value
+	}
+	if value := os.Getenv("SMTP_USERNAME"); value != "" {
+		config.Mailer.Username = value
+	}
+	if value := os.Getenv("SMTP_PASSWORD"); value != "" {
+		config.Mailer.Password = value
+	}
+
+	// Downstream services
+	if value := os.Getenv("ORDER_SERVICE_ADDR"); value != "" {
+		config.Services.OrderService = value
+	}
+
 	return config
 }
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// AddressType selects which default an address is used for
+type AddressType string
+
+const (
+	ShippingAddress AddressType = "shipping"
+	BillingAddress  AddressType = "billing"
+)
+
+// IsValid reports whether the address type is known
+func (t AddressType) IsValid() bool {
+	return t == ShippingAddress || t == BillingAddress
+}
+
+// Address is an entry in a user's address book
+type Address struct {
+	ID                string    `json:"id"`
+	UserID            string    `json:"user_id"`
+	Label             string    `json:"label"`
+	RecipientName     string    `json:"recipient_name"`
+	Phone             string    `json:"phone"`
+	Street            string    `json:"street"`
+	City              string    `json:"city"`
+	State             string    `json:"state"`
+	Country           string    `json:"country"`
+	PostalCode        string    `json:"postal_code"`
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Validate checks that the fields needed to deliver to the address are present
+func (a *Address) Validate() error {
+	if strings.TrimSpace(a.Street) == "" ||
+		strings.TrimSpace(a.City) == "" ||
+		strings.TrimSpace(a.Country) == "" ||
+		strings.TrimSpace(a.PostalCode) == "" {
+		return ErrInvalidAddress
+	}
+	return nil
+}
@@ -3,11 +3,32 @@ package entity
 import "errors"
 
 var (
-	ErrUserNotFound        = errors.New("user not found")
-	ErrUserAlreadyExists   = errors.New("user already exists")
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrInternalServerError = errors.New("internal server error")
-	ErrUserExists          = errors.New("user already exists")
-	ErrTokenHasBeenRevoked = errors.New("token has been revoked")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrInternalServerError  = errors.New("internal server error")
+	ErrUserExists           = errors.New("user already exists")
+	ErrTokenHasBeenRevoked  = errors.New("token has been revoked")
+	ErrEmailNotVerified     = errors.New("email not verified")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrAccountLocked        = errors.New("account is temporarily locked")
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+	ErrForbidden            = errors.New("forbidden")
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication not enrolled")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorRequired    = errors.New("two-factor authentication is required")
+	ErrUnknownProvider      = errors.New("unknown identity provider")
+	ErrInvalidLoginState    = errors.New("invalid or expired login state")
+	ErrIdentityConflict     = errors.New("email is already registered to another account")
+	ErrExternalAuthFailed   = errors.New("external authentication failed")
+	ErrAddressNotFound      = errors.New("address not found")
+	ErrInvalidAddress       = errors.New("invalid address")
+	ErrInvalidPhone         = errors.New("invalid phone number")
+	ErrErasureNotFound      = errors.New("erasure request not found")
+	ErrDataExportFailed     = errors.New("failed to collect personal data")
+	ErrAccountSuspended     = errors.New("account is suspended")
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrSelfModification     = errors.New("admins cannot change their own role or suspension")
 )
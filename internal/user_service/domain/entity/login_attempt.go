@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// LoginAttemptScope identifies what a failed-login counter is keyed on
+type LoginAttemptScope string
+
+const (
+	LoginAttemptScopeAccount LoginAttemptScope = "account"
+	LoginAttemptScopeIP      LoginAttemptScope = "ip"
+)
+
+// LoginAttempt tracks consecutive failed logins for an account or a client IP
+type LoginAttempt struct {
+	Scope        LoginAttemptScope `json:"scope"`
+	Key          string            `json:"key"`
+	FailedCount  int               `json:"failed_count"`
+	LockCount    int               `json:"lock_count"`
+	LastFailedAt time.Time         `json:"last_failed_at"`
+	LockedUntil  *time.Time        `json:"locked_until"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// IsLocked reports whether the counter is currently locked out
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && a.LockedUntil.After(now)
+}
+
+// RetryAfter returns how long until the lock expires
+func (a *LoginAttempt) RetryAfter(now time.Time) time.Duration {
+	if !a.IsLocked(now) {
+		return 0
+	}
+	return a.LockedUntil.Sub(now)
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// ErasureStatus is the state of a data-subject erasure request
+type ErasureStatus string
+
+const (
+	ErasurePending   ErasureStatus = "pending"
+	ErasureCompleted ErasureStatus = "completed"
+)
+
+// ErasureRequest tracks the erasure of a user's personal data across services
+type ErasureRequest struct {
+	ID                string        `json:"id"`
+	UserID            string        `json:"user_id"`
+	Status            ErasureStatus `json:"status"`
+	PendingServices   []string      `json:"pending_services"`
+	CompletedServices []string      `json:"completed_services"`
+	RequestedAt       time.Time     `json:"requested_at"`
+	CompletedAt       *time.Time    `json:"completed_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+// MarkServiceCompleted records that a service has erased its data.
+// It reports false when the service was not pending, so duplicate reports are ignored.
+func (r *ErasureRequest) MarkServiceCompleted(service string, now time.Time) bool {
+	i := slices.Index(r.PendingServices, service)
+	if i < 0 {
+		return false
+	}
+	r.PendingServices = slices.Delete(r.PendingServices, i, i+1)
+	r.CompletedServices = append(r.CompletedServices, service)
+	if len(r.PendingServices) == 0 {
+		r.Status = ErasureCompleted
+		r.CompletedAt = &now
+	}
+	return true
+}
+
+// Session describes an issued token without its secret value
+type Session struct {
+	ID        string     `json:"id"`
+	Type      TokenType  `json:"type"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// NewSession builds a session view of a stored token
+func NewSession(token *Token) Session {
+	return Session{
+		ID:        token.ID,
+		Type:      token.Type,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+	}
+}
+
+// DataExport is the archive of everything the platform stores about a user
+type DataExport struct {
+	GeneratedAt time.Time                  `json:"generated_at"`
+	UserID      string                     `json:"user_id"`
+	Profile     *User                      `json:"profile"`
+	Addresses   []*Address                 `json:"addresses"`
+	Identities  []*Identity                `json:"identities"`
+	Sessions    []Session                  `json:"sessions"`
+	Services    map[string]json.RawMessage `json:"services"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "regexp"
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ()-]{6,20}$`)
+
+// UserPreferences holds the user's locale and notification settings
+type UserPreferences struct {
+	Language          string `json:"language"`
+	Currency          string `json:"currency"`
+	Timezone          string `json:"timezone"`
+	MarketingEmails   bool   `json:"marketing_emails"`
+	SMSNotifications  bool   `json:"sms_notifications"`
+	OrderUpdatesEmail bool   `json:"order_updates_email"`
+}
+
+// ProfileUpdate is a partial update of the user profile. Nil fields are left unchanged.
+type ProfileUpdate struct {
+	FirstName   *string
+	LastName    *string
+	Phone       *string
+	Preferences *UserPreferences
+}
+
+// ValidatePhone checks the phone number format. An empty number clears the field.
+func ValidatePhone(phone string) error {
+	if phone != "" && !phonePattern.MatchString(phone) {
+		return ErrInvalidPhone
+	}
+	return nil
+}
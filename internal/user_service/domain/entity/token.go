@@ -7,8 +7,11 @@ import (
 type TokenType string
 
 const (
-	AccessToken  TokenType = "access"
-	RefreshToken TokenType = "refresh"
+	AccessToken            TokenType = "access"
+	RefreshToken           TokenType = "refresh"
+	EmailVerificationToken TokenType = "email_verification"
+	PasswordResetToken     TokenType = "password_reset"
+	TwoFactorChallenge     TokenType = "two_factor_challenge"
 )
 
 type Token struct {
@@ -17,10 +20,17 @@ type Token struct {
 	Token     string     `json:"token"`
 	Type      TokenType  `json:"type"`
 	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
 }
+
+// IsUsable reports whether a single-use token is still unused and not expired
+func (t *Token) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.ExpiresAt.After(now)
+}
+
 type TokenPair struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
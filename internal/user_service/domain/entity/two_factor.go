@@ -0,0 +1,17 @@
+package entity
+
+// TwoFactorEnrollment holds the data an authenticator app needs to be set up
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// LoginResult is the outcome of the first login step.
+// Either TokenPair is set, or a second factor must be presented with ChallengeToken.
+type LoginResult struct {
+	TokenPair          *TokenPair `json:"token_pair,omitempty"`
+	TwoFactorRequired  bool       `json:"two_factor_required"`
+	EnrollmentRequired bool       `json:"enrollment_required"`
+	ChallengeToken     string     `json:"challenge_token,omitempty"`
+	RecoveryCodes      []string   `json:"recovery_codes,omitempty"`
+}
@@ -7,13 +7,56 @@ import (
 )
 
 type User struct {
-	ID             string     `json:"id"`
-	Email          string     `json:"email"`
-	HashedPassword string     `json:"-"`
-	FirstName      string     `json:"first_name"`
-	LastName       string     `json:"last_name"`
-	Role           vo.Role    `json:"role"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	DeletedAt      *time.Time `json:"deleted_at"`
+	ID             string          `json:"id"`
+	Email          string          `json:"email"`
+	HashedPassword string          `json:"-"`
+	FirstName      string          `json:"first_name"`
+	LastName       string          `json:"last_name"`
+	Role           vo.Role         `json:"role"`
+	Phone          string          `json:"phone"`
+	Preferences    UserPreferences `json:"preferences"`
+	EmailVerified  bool            `json:"email_verified"`
+	VerifiedAt     *time.Time      `json:"verified_at"`
+	// TwoFactorSecret is set on enrolment and only active once TwoFactorEnabled is true
+	TwoFactorEnabled bool     `json:"two_factor_enabled"`
+	TwoFactorSecret  string   `json:"-"`
+	RecoveryCodes    []string `json:"-"` // SHA-256 hashes of unused recovery codes
+	// TwoFactorCounter is the time step of the last accepted TOTP code; codes of earlier steps are rejected
+	TwoFactorCounter int64      `json:"-"`
+	SuspendedAt      *time.Time `json:"suspended_at"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+}
+
+// RequiresTwoFactor reports whether logins must be completed with a second factor.
+// Two-factor authentication is mandatory for admins.
+func (u *User) RequiresTwoFactor() bool {
+	return u.TwoFactorEnabled || u.Role == vo.Admin
+}
+
+// IsSuspended reports whether an admin has suspended the account
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// ApplyProfile applies the non-nil fields of a profile update
+func (u *User) ApplyProfile(update ProfileUpdate) error {
+	if update.Phone != nil {
+		if err := ValidatePhone(*update.Phone); err != nil {
+			return err
+		}
+		u.Phone = *update.Phone
+	}
+	if update.FirstName != nil {
+		u.FirstName = *update.FirstName
+	}
+	if update.LastName != nil {
+		u.LastName = *update.LastName
+	}
+	if update.Preferences != nil {
+		u.Preferences = *update.Preferences
+	}
+	return nil
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type AddressRepository interface {
+	// Create stores a new address
+	Create(ctx context.Context, address *entity.Address) error
+
+	// GetByID retrieves an address owned by a user, or nil if none exists
+	GetByID(ctx context.Context, userID, id string) (*entity.Address, error)
+
+	// ListByUserID retrieves all addresses of a user, defaults first
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Address, error)
+
+	// Update updates an existing address
+	Update(ctx context.Context, address *entity.Address) error
+
+	// Delete removes an address owned by a user
+	Delete(ctx context.Context, userID, id string) error
+
+	// DeleteByUserID removes all addresses of a user
+	DeleteByUserID(ctx context.Context, userID string) error
+
+	// SetDefault marks an address as the user's default of the given type and clears the previous default
+	SetDefault(ctx context.Context, userID, id string, addressType entity.AddressType) error
+}
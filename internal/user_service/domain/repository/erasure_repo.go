@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type ErasureRequestRepository interface {
+	// Create stores a new erasure request
+	Create(ctx context.Context, request *entity.ErasureRequest) error
+
+	// GetByID retrieves an erasure request, or nil if none exists
+	GetByID(ctx context.Context, id string) (*entity.ErasureRequest, error)
+
+	// GetLatestByUserID retrieves the most recent erasure request of a user, or nil if none exists
+	GetLatestByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+
+	// Update saves the progress of an erasure request
+	Update(ctx context.Context, request *entity.ErasureRequest) error
+
+	// UpdateByID applies fn to an erasure request while holding its row lock and saves the result when
+	// fn reports a change. Concurrent updates of a request are serialised. It returns nil if none exists.
+	UpdateByID(ctx context.Context, id string, fn func(request *entity.ErasureRequest) bool) (*entity.ErasureRequest, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type IdentityRepository interface {
+	// Create links a new external identity to a user
+	Create(ctx context.Context, identity *entity.Identity) error
+
+	// GetByProviderSubject retrieves an identity by provider and subject, or nil if none exists
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error)
+
+	// ListByUserID retrieves all identities linked to a user
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error)
+
+	// DeleteByUserID unlinks all identities of a user
+	DeleteByUserID(ctx context.Context, userID string) error
+
+	// CreateLoginState stores the state of an in-flight login
+	CreateLoginState(ctx context.Context, state *entity.OIDCLoginState) error
+
+	// ConsumeLoginState deletes and returns a login state, or nil if none exists
+	ConsumeLoginState(ctx context.Context, state string) (*entity.OIDCLoginState, error)
+}
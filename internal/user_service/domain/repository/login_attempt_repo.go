@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type LoginAttemptRepository interface {
+	// Get retrieves the counter for a scope and key, or nil if none exists
+	Get(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempt, error)
+
+	// Update applies fn to the counter for a scope and key while holding its row lock, creating the
+	// counter first if none exists, and saves the result. Concurrent updates of a counter are serialised.
+	Update(ctx context.Context, scope entity.LoginAttemptScope, key string, fn func(attempt *entity.LoginAttempt)) (*entity.LoginAttempt, error)
+
+	// Delete removes the counter for a scope and key
+	Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error
+}
@@ -9,8 +9,16 @@ import (
 type TokenRepository interface {
 	Create(ctx context.Context, token *entity.Token) error
 	FindByToken(ctx context.Context, tokenStr string) (*entity.Token, error)
+	// FindByUserID retrieves all tokens issued to a user
+	FindByUserID(ctx context.Context, userID string) ([]*entity.Token, error)
 	// GetByUserID(ctx context.Context, userID string, tokenType entity.TokenType) (*entity.Token, error)
 	Delete(ctx context.Context, tokenID string) error
 	DeleteByUserID(ctx context.Context, userID string) error
 	Update(ctx context.Context, by entity.Token, token entity.Token) error
+	// FindByTokenAndType retrieves a token by value, restricted to the given type
+	FindByTokenAndType(ctx context.Context, tokenStr string, tokenType entity.TokenType) (*entity.Token, error)
+	// MarkUsed marks a single-use token as consumed
+	MarkUsed(ctx context.Context, tokenID string) error
+	// DeleteByUserIDAndType removes all tokens of the given type for a user
+	DeleteByUserIDAndType(ctx context.Context, userID string, tokenType entity.TokenType) error
 }
@@ -18,9 +18,21 @@ type UserRepository interface {
 
 	// GetByentity.Username retrieves a user by username
 
+	// List retrieves users with optional filtering.
+	// Supported filters: query, role, created_from, created_to, suspended and deleted ("include" or "only").
+	List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*entity.User, int, error)
+
 	// Update updates an existing user
 	Update(ctx context.Context, user entity.User) (*entity.User, error)
 
+	// ClaimTwoFactorCounter records the time step of an accepted TOTP code. It reports false, leaving the
+	// user unchanged, if a code of the same or a later step has already been accepted.
+	ClaimTwoFactorCounter(ctx context.Context, id string, counter int64) (bool, error)
+
+	// SpendRecoveryCode removes the hash of a recovery code from a user. It reports false, leaving the
+	// user unchanged, if the user has no such code, including when it has been spent already.
+	SpendRecoveryCode(ctx context.Context, id, hash string) (bool, error)
+
 	// Delete removes a user by ID
 	Delete(ctx context.Context, id string) error
 }
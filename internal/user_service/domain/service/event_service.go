@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Event types for user service
+const (
+	EventTypeUserLoginFailed = "user.login_failed"
+	EventTypeUserLocked      = "user.locked"
+
+	// Erasure is requested by the user service and acknowledged by every service holding personal data
+	EventTypeUserErasureRequested = "user.erasure_requested"
+	EventTypeUserErasureCompleted = "user.erasure_completed"
+)
+
+// EventPublisherService defines the interface for publishing user events
+type EventPublisherService interface {
+	// PublishLoginFailed publishes an event that a login attempt has failed
+	PublishLoginFailed(ctx context.Context, email, ipAddress, reason string) error
+
+	// PublishUserLocked publishes an event that an account or client IP has been locked out
+	PublishUserLocked(ctx context.Context, attempt *entity.LoginAttempt, ipAddress string) error
+
+	// PublishErasureRequested asks all services to erase the personal data of a user
+	PublishErasureRequested(ctx context.Context, request *entity.ErasureRequest) error
+
+	// Close closes the publisher connections
+	Close() error
+}
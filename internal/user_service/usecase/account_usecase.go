@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// AccountOptions configures the email verification and password reset flows
+type AccountOptions struct {
+	BaseURL               string
+	VerificationTokenTTL  time.Duration
+	PasswordResetTokenTTL time.Duration
+}
+
+// AccountUsecase defines email verification and password reset operations
+type AccountUsecase interface {
+	// SendVerificationEmail issues a new verification token for the user and mails it
+	SendVerificationEmail(ctx context.Context, user *entity.User) error
+
+	// RequestEmailVerification re-sends the verification email for an address
+	RequestEmailVerification(ctx context.Context, email string) error
+
+	// ConfirmEmailVerification consumes a verification token and marks the email verified
+	ConfirmEmailVerification(ctx context.Context, token string) error
+
+	// RequestPasswordReset issues a password reset token and mails it
+	RequestPasswordReset(ctx context.Context, email string) error
+
+	// ConfirmPasswordReset consumes a reset token and sets a new password
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+}
+
+type accountUsecase struct {
+	userRepo   repository.UserRepository
+	tokenRepo  repository.TokenRepository
+	mailer     interfaces.Mailer
+	options    AccountOptions
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewAccountUsecase creates a new instance of AccountUsecase
+func NewAccountUsecase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	mailer interfaces.Mailer,
+	options AccountOptions,
+) AccountUsecase {
+	return &accountUsecase{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		mailer:     mailer,
+		options:    options,
+		errBuilder: utils.NewErrorBuilder("AccountUsecase"),
+	}
+}
+
+// SendVerificationEmail issues a new verification token for the user and mails it
+func (au *accountUsecase) SendVerificationEmail(ctx context.Context, user *entity.User) error {
+	if user.EmailVerified {
+		return au.errBuilder.Err(entity.ErrEmailAlreadyVerified)
+	}
+
+	// Only the most recent verification link stays valid
+	if err := au.tokenRepo.DeleteByUserIDAndType(ctx, user.ID, entity.EmailVerificationToken); err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	rawToken, err := au.issueToken(ctx, user.ID, entity.EmailVerificationToken, au.options.VerificationTokenTTL)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	msg := interfaces.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s/verify-email?token=%s\n\nThis link expires in %s.\n",
+			user.FirstName, au.options.BaseURL, rawToken, au.options.VerificationTokenTTL,
+		),
+	}
+	if err := au.mailer.Send(ctx, msg); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// RequestEmailVerification re-sends the verification email for an address.
+// Unknown and already verified addresses are ignored so the endpoint cannot be used to probe for accounts.
+func (au *accountUsecase) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := au.userRepo.GetByEmail(ctx, email)
+	if err != nil || user == nil || user.EmailVerified {
+		return nil
+	}
+	return au.SendVerificationEmail(ctx, user)
+}
+
+// ConfirmEmailVerification consumes a verification token and marks the email verified
+func (au *accountUsecase) ConfirmEmailVerification(ctx context.Context, token string) error {
+	stored, err := au.consumeToken(ctx, token, entity.EmailVerificationToken)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	user, err := au.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	user.EmailVerified = true
+	user.VerifiedAt = utils.NowPtr()
+	if _, err := au.userRepo.Update(ctx, *user); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// RequestPasswordReset issues a password reset token and mails it.
+// Unknown addresses are ignored so the endpoint cannot be used to probe for accounts.
+func (au *accountUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := au.userRepo.GetByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil
+	}
+
+	if err := au.tokenRepo.DeleteByUserIDAndType(ctx, user.ID, entity.PasswordResetToken); err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	rawToken, err := au.issueToken(ctx, user.ID, entity.PasswordResetToken, au.options.PasswordResetTokenTTL)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	msg := interfaces.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s/reset-password?token=%s\n\nThis link expires in %s. If you did not request a reset you can ignore this email.\n",
+			user.FirstName, au.options.BaseURL, rawToken, au.options.PasswordResetTokenTTL,
+		),
+	}
+	if err := au.mailer.Send(ctx, msg); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// ConfirmPasswordReset consumes a reset token and sets a new password.
+// All existing sessions of the user are revoked.
+func (au *accountUsecase) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	stored, err := au.consumeToken(ctx, token, entity.PasswordResetToken)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	user, err := au.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+	user.HashedPassword = string(hashedPassword)
+	// Receiving the reset link proves ownership of the mailbox
+	if !user.EmailVerified {
+		user.EmailVerified = true
+		user.VerifiedAt = utils.NowPtr()
+	}
+	if _, err := au.userRepo.Update(ctx, *user); err != nil {
+		return au.errBuilder.Err(err)
+	}
+
+	for _, tokenType := range []entity.TokenType{entity.AccessToken, entity.RefreshToken} {
+		if err := au.tokenRepo.DeleteByUserIDAndType(ctx, user.ID, tokenType); err != nil {
+			return au.errBuilder.Err(err)
+		}
+	}
+	return nil
+}
+
+// issueToken stores the hash of a new random token and returns the raw value
+func (au *accountUsecase) issueToken(ctx context.Context, userID string, tokenType entity.TokenType, ttl time.Duration) (string, error) {
+	rawToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	token := &entity.Token{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      tokenType,
+		Token:     utils.HashToken(rawToken),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := au.tokenRepo.Create(ctx, token); err != nil {
+		return "", err
+	}
+	return rawToken, nil
+}
+
+// consumeToken validates a single-use token and marks it as used
+func (au *accountUsecase) consumeToken(ctx context.Context, rawToken string, tokenType entity.TokenType) (*entity.Token, error) {
+	stored, err := au.tokenRepo.FindByTokenAndType(ctx, utils.HashToken(rawToken), tokenType)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil || !stored.IsUsable(time.Now()) {
+		return nil, entity.ErrInvalidToken
+	}
+	if err := au.tokenRepo.MarkUsed(ctx, stored.ID); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
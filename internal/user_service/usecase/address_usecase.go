@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// AddressUsecase defines address book operations
+type AddressUsecase interface {
+	// ListAddresses retrieves all saved addresses of a user
+	ListAddresses(ctx context.Context, userID string) ([]*entity.Address, error)
+
+	// GetAddress retrieves a single address of a user
+	GetAddress(ctx context.Context, userID, addressID string) (*entity.Address, error)
+
+	// CreateAddress saves a new address. The first address becomes the default for shipping and billing.
+	CreateAddress(ctx context.Context, userID string, address entity.Address) (*entity.Address, error)
+
+	// UpdateAddress updates a saved address
+	UpdateAddress(ctx context.Context, userID, addressID string, address entity.Address) (*entity.Address, error)
+
+	// DeleteAddress removes a saved address and promotes another address to any default it held
+	DeleteAddress(ctx context.Context, userID, addressID string) error
+
+	// SetDefaultAddress makes an address the user's default of the given type
+	SetDefaultAddress(ctx context.Context, userID, addressID string, addressType entity.AddressType) (*entity.Address, error)
+
+	// GetDefaultAddresses returns the default shipping and billing addresses. Either may be nil.
+	GetDefaultAddresses(ctx context.Context, userID string) (shipping *entity.Address, billing *entity.Address, err error)
+}
+
+type addressUsecase struct {
+	addressRepo repository.AddressRepository
+	userRepo    repository.UserRepository
+	errBuilder  *utils.ErrorBuilder
+}
+
+// NewAddressUsecase creates a new instance of AddressUsecase
+func NewAddressUsecase(addressRepo repository.AddressRepository, userRepo repository.UserRepository) AddressUsecase {
+	return &addressUsecase{
+		addressRepo: addressRepo,
+		userRepo:    userRepo,
+		errBuilder:  utils.NewErrorBuilder("AddressUsecase"),
+	}
+}
+
+// ListAddresses retrieves all saved addresses of a user
+func (au *addressUsecase) ListAddresses(ctx context.Context, userID string) ([]*entity.Address, error) {
+	addresses, err := au.addressRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return addresses, nil
+}
+
+// GetAddress retrieves a single address of a user
+func (au *addressUsecase) GetAddress(ctx context.Context, userID, addressID string) (*entity.Address, error) {
+	address, err := au.addressRepo.GetByID(ctx, userID, addressID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if address == nil {
+		return nil, au.errBuilder.Err(entity.ErrAddressNotFound)
+	}
+	return address, nil
+}
+
+// CreateAddress saves a new address. The first address becomes the default for shipping and billing.
+func (au *addressUsecase) CreateAddress(ctx context.Context, userID string, address entity.Address) (*entity.Address, error) {
+	if err := address.Validate(); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if err := entity.ValidatePhone(address.Phone); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if _, err := au.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	existing, err := au.addressRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	makeShipping := address.IsDefaultShipping || len(existing) == 0
+	makeBilling := address.IsDefaultBilling || len(existing) == 0
+
+	address.ID = uuid.New().String()
+	address.UserID = userID
+	address.IsDefaultShipping = false
+	address.IsDefaultBilling = false
+	if err := au.addressRepo.Create(ctx, &address); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
+	if err := au.applyDefaults(ctx, &address, makeShipping, makeBilling); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return &address, nil
+}
+
+// UpdateAddress updates a saved address
+func (au *addressUsecase) UpdateAddress(ctx context.Context, userID, addressID string, address entity.Address) (*entity.Address, error) {
+	if err := address.Validate(); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if err := entity.ValidatePhone(address.Phone); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
+	existing, err := au.addressRepo.GetByID(ctx, userID, addressID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if existing == nil {
+		return nil, au.errBuilder.Err(entity.ErrAddressNotFound)
+	}
+
+	// Defaults only move through SetDefault, so an update can set but never clear them
+	makeShipping := address.IsDefaultShipping && !existing.IsDefaultShipping
+	makeBilling := address.IsDefaultBilling && !existing.IsDefaultBilling
+
+	address.ID = existing.ID
+	address.UserID = existing.UserID
+	address.IsDefaultShipping = existing.IsDefaultShipping
+	address.IsDefaultBilling = existing.IsDefaultBilling
+	address.CreatedAt = existing.CreatedAt
+	if err := au.addressRepo.Update(ctx, &address); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
+	if err := au.applyDefaults(ctx, &address, makeShipping, makeBilling); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return &address, nil
+}
+
+// DeleteAddress removes a saved address and promotes another address to any default it held
+func (au *addressUsecase) DeleteAddress(ctx context.Context, userID, addressID string) error {
+	existing, err := au.addressRepo.GetByID(ctx, userID, addressID)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+	if existing == nil {
+		return au.errBuilder.Err(entity.ErrAddressNotFound)
+	}
+
+	if err := au.addressRepo.Delete(ctx, userID, addressID); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	if !existing.IsDefaultShipping && !existing.IsDefaultBilling {
+		return nil
+	}
+
+	remaining, err := au.addressRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return au.errBuilder.Err(err)
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	if err := au.applyDefaults(ctx, remaining[0], existing.IsDefaultShipping, existing.IsDefaultBilling); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// SetDefaultAddress makes an address the user's default of the given type
+func (au *addressUsecase) SetDefaultAddress(ctx context.Context, userID, addressID string, addressType entity.AddressType) (*entity.Address, error) {
+	if !addressType.IsValid() {
+		return nil, au.errBuilder.Err(entity.ErrInvalidAddress)
+	}
+	if err := au.addressRepo.SetDefault(ctx, userID, addressID, addressType); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return au.GetAddress(ctx, userID, addressID)
+}
+
+// GetDefaultAddresses returns the default shipping and billing addresses. Either may be nil.
+func (au *addressUsecase) GetDefaultAddresses(ctx context.Context, userID string) (*entity.Address, *entity.Address, error) {
+	addresses, err := au.addressRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, au.errBuilder.Err(err)
+	}
+
+	var shipping, billing *entity.Address
+	for _, address := range addresses {
+		if address.IsDefaultShipping {
+			shipping = address
+		}
+		if address.IsDefaultBilling {
+			billing = address
+		}
+	}
+	return shipping, billing, nil
+}
+
+// applyDefaults marks an address as the default shipping and/or billing address
+func (au *addressUsecase) applyDefaults(ctx context.Context, address *entity.Address, shipping, billing bool) error {
+	if shipping {
+		if err := au.addressRepo.SetDefault(ctx, address.UserID, address.ID, entity.ShippingAddress); err != nil {
+			return err
+		}
+		address.IsDefaultShipping = true
+	}
+	if billing {
+		if err := au.addressRepo.SetDefault(ctx, address.UserID, address.ID, entity.BillingAddress); err != nil {
+			return err
+		}
+		address.IsDefaultBilling = true
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
 	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
@@ -11,27 +13,47 @@ import (
 
 type AuthUsecase interface {
 	Register(ctx context.Context, user entity.User, password string) (*entity.User, *entity.TokenPair, error)
-	Login(ctx context.Context, email, password string) (*entity.TokenPair, error)
+	Login(ctx context.Context, email, password, ipAddress string) (*entity.LoginResult, error)
+	BeginTwoFactorEnrollment(ctx context.Context, challengeToken string) (*entity.TwoFactorEnrollment, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code, ipAddress string) (*entity.LoginResult, error)
+	StartSession(ctx context.Context, user *entity.User) (*entity.LoginResult, error)
 	RefreshToken(ctx context.Context, tokenStr string) (*entity.TokenPair, error)
+	UnlockAccount(ctx context.Context, userID string) error
 }
 
 type authUsecase struct {
-	userUsecase  UserUsecase
-	tokenUsecase TokenUsecase
-	errBuilder   *utils.ErrorBuilder
+	userUsecase              UserUsecase
+	tokenUsecase             TokenUsecase
+	accountUsecase           AccountUsecase
+	loginGuard               LoginGuardUsecase
+	twoFactor                TwoFactorUsecase
+	requireEmailVerification bool
+	errBuilder               *utils.ErrorBuilder
 }
 
 // NewAuthUsecase creates a new instance of AuthUsecase
-func NewAuthUsecase(userUsecase UserUsecase, tokenUsecase TokenUsecase) AuthUsecase {
+func NewAuthUsecase(
+	userUsecase UserUsecase,
+	tokenUsecase TokenUsecase,
+	accountUsecase AccountUsecase,
+	loginGuard LoginGuardUsecase,
+	twoFactor TwoFactorUsecase,
+	requireEmailVerification bool,
+) AuthUsecase {
 
 	return &authUsecase{
-		userUsecase:  userUsecase,
-		tokenUsecase: tokenUsecase,
-		errBuilder:   utils.NewErrorBuilder("AuthUsecase"),
+		userUsecase:              userUsecase,
+		tokenUsecase:             tokenUsecase,
+		accountUsecase:           accountUsecase,
+		loginGuard:               loginGuard,
+		twoFactor:                twoFactor,
+		requireEmailVerification: requireEmailVerification,
+		errBuilder:               utils.NewErrorBuilder("AuthUsecase"),
 	}
 }
 
-// Register creates a new user and generates a token
+// Register creates a new user and sends a verification email.
+// Tokens are only issued immediately when email verification is not required.
 func (au *authUsecase) Register(ctx context.Context, user entity.User, password string) (*entity.User, *entity.TokenPair, error) {
 	// Check if user already exists
 	existingUser, err := au.userUsecase.GetUserByEmail(ctx, user.Email)
@@ -39,12 +61,21 @@ func (au *authUsecase) Register(ctx context.Context, user entity.User, password
 		return nil, nil, au.errBuilder.Err(entity.ErrUserExists)
 	}
 	user.Role = vo.User
+	user.EmailVerified = false
 	// Create user (password handling is done in UserUsecase)
 	createdUser, err := au.userUsecase.CreateUser(ctx, &user, password)
 	if err != nil {
 		return nil, nil, au.errBuilder.Err(err)
 	}
 
+	// The account exists either way; a failed email can be requested again
+	if err := au.accountUsecase.SendVerificationEmail(ctx, createdUser); err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", createdUser.ID, err)
+	}
+	if au.requireEmailVerification {
+		return createdUser, nil, nil
+	}
+
 	// Generate token pair
 	tokenPair, err := au.tokenUsecase.GenerateTokenPair(ctx, createdUser.ID, vo.User.String())
 	if err != nil {
@@ -53,27 +84,111 @@ func (au *authUsecase) Register(ctx context.Context, user entity.User, password
 	return createdUser, tokenPair, nil
 }
 
-// Login authenticates a user and generates a token
-func (au *authUsecase) Login(ctx context.Context, email, password string) (*entity.TokenPair, error) {
+// Login authenticates a user and generates a token.
+// Failed attempts are counted per account and per client IP and lead to a temporary lockout.
+// Users with two-factor authentication, and all admins, receive a challenge token instead of a token pair.
+func (au *authUsecase) Login(ctx context.Context, email, password, ipAddress string) (*entity.LoginResult, error) {
+	if err := au.loginGuard.Check(ctx, email, ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Get user by email
 	user, err := au.userUsecase.GetUserByEmail(ctx, email)
 	if err != nil {
+		_ = au.loginGuard.RecordFailure(ctx, email, ipAddress, "unknown account")
 		return nil, entity.ErrInvalidCredentials
 	}
 	// Verify password (assuming this is handled in the user entity or repository)
 	// This is a placeholder - in a real implementation, you would use a proper password verification method
 	if err := utils.VerifyPassword(password, user.HashedPassword); err != nil {
 		fmt.Println("error", err)
+		_ = au.loginGuard.RecordFailure(ctx, email, ipAddress, "invalid password")
 		return nil, entity.ErrInvalidCredentials
 	}
 
+	return au.StartSession(ctx, user)
+}
+
+// StartSession finishes a first-factor login for an authenticated user.
+// It rejects suspended accounts, enforces email verification and returns either a token pair or a two-factor challenge.
+func (au *authUsecase) StartSession(ctx context.Context, user *entity.User) (*entity.LoginResult, error) {
+	if user.IsSuspended() {
+		return nil, entity.ErrAccountSuspended
+	}
+	if au.requireEmailVerification && !user.EmailVerified {
+		return nil, entity.ErrEmailNotVerified
+	}
+
+	if user.RequiresTwoFactor() {
+		challenge, err := au.twoFactor.IssueChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, au.errBuilder.Err(err)
+		}
+		return &entity.LoginResult{
+			TwoFactorRequired:  true,
+			EnrollmentRequired: !user.TwoFactorEnabled,
+			ChallengeToken:     challenge,
+		}, nil
+	}
+
+	return au.completeLogin(ctx, user)
+}
+
+// BeginTwoFactorEnrollment starts the mandatory enrolment of a user holding a login challenge
+func (au *authUsecase) BeginTwoFactorEnrollment(ctx context.Context, challengeToken string) (*entity.TwoFactorEnrollment, error) {
+	user, err := au.twoFactor.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	enrollment, err := au.twoFactor.BeginEnrollment(ctx, user.ID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return enrollment, nil
+}
+
+// VerifyTwoFactor completes the second login step with a TOTP or recovery code
+func (au *authUsecase) VerifyTwoFactor(ctx context.Context, challengeToken, code, ipAddress string) (*entity.LoginResult, error) {
+	challenged, err := au.twoFactor.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if err := au.loginGuard.Check(ctx, challenged.Email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	user, recoveryCodes, err := au.twoFactor.VerifyChallenge(ctx, challengeToken, code)
+	if err != nil {
+		if user != nil && errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			_ = au.loginGuard.RecordFailure(ctx, user.Email, ipAddress, "invalid two-factor code")
+		}
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, entity.ErrAccountSuspended
+	}
+
+	result, err := au.completeLogin(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
+
+// completeLogin resets the failed-login counter and issues a token pair
+func (au *authUsecase) completeLogin(ctx context.Context, user *entity.User) (*entity.LoginResult, error) {
+	if err := au.loginGuard.RecordSuccess(ctx, user.Email); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
 	// Generate token pair
 	tokenPair, err := au.tokenUsecase.GenerateTokenPair(ctx, user.ID, user.Role.String())
 	if err != nil {
 		return nil, au.errBuilder.Err(err)
 	}
 
-	return tokenPair, nil
+	return &entity.LoginResult{TokenPair: tokenPair}, nil
 }
 
 // RefreshToken generates a new access token using a refresh token
@@ -103,3 +218,15 @@ func (au *authUsecase) RefreshToken(ctx context.Context, tokenStr string) (*enti
 	}
 	return token, nil
 }
+
+// UnlockAccount clears a failed-login lockout for a user
+func (au *authUsecase) UnlockAccount(ctx context.Context, userID string) error {
+	user, err := au.userUsecase.GetUserByID(ctx, userID)
+	if err != nil {
+		return au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if err := au.loginGuard.Unlock(ctx, user.Email); err != nil {
+		return au.errBuilder.Err(err)
+	}
+	return nil
+}
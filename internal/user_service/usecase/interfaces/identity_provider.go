@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// IdentityProvider is an external OpenID Connect provider used for social login
+type IdentityProvider interface {
+	// Name returns the provider name used in URLs and the identities table
+	Name() string
+
+	// AuthCodeURL returns the provider authorization URL for the PKCE authorization code flow
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange redeems an authorization code and returns the verified identity
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*entity.ExternalIdentity, error)
+}
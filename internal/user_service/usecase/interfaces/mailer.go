@@ -0,0 +1,15 @@
+package interfaces
+
+import "context"
+
+// MailMessage is a plain-text email sent by the user service
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails such as verification and password reset links
+type Mailer interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// PersonalDataSource is another service that stores personal data about users
+type PersonalDataSource interface {
+	// Name identifies the service in data exports
+	Name() string
+
+	// ExportUserData returns everything the service stores about a user as JSON
+	ExportUserData(ctx context.Context, userID string) (json.RawMessage, error)
+}
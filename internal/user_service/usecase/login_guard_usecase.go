@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// LoginGuardOptions configures failed-login throttling
+type LoginGuardOptions struct {
+	MaxAccountAttempts int
+	MaxIPAttempts      int
+	AttemptWindow      time.Duration
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+}
+
+// LoginGuardUsecase defines brute-force protection for logins
+type LoginGuardUsecase interface {
+	// Check returns an error if the account or the client IP is currently locked out
+	Check(ctx context.Context, email, ipAddress string) error
+
+	// RecordFailure counts a failed login against the account and the client IP
+	RecordFailure(ctx context.Context, email, ipAddress, reason string) error
+
+	// RecordSuccess clears the failed-login counter of the account
+	RecordSuccess(ctx context.Context, email string) error
+
+	// Unlock clears the lockout of an account
+	Unlock(ctx context.Context, email string) error
+}
+
+type loginGuardUsecase struct {
+	attemptRepo repository.LoginAttemptRepository
+	eventPub    service.EventPublisherService
+	options     LoginGuardOptions
+	errBuilder  *utils.ErrorBuilder
+}
+
+// NewLoginGuardUsecase creates a new instance of LoginGuardUsecase
+func NewLoginGuardUsecase(
+	attemptRepo repository.LoginAttemptRepository,
+	eventPub service.EventPublisherService,
+	options LoginGuardOptions,
+) LoginGuardUsecase {
+	return &loginGuardUsecase{
+		attemptRepo: attemptRepo,
+		eventPub:    eventPub,
+		options:     options,
+		errBuilder:  utils.NewErrorBuilder("LoginGuardUsecase"),
+	}
+}
+
+// Check returns an error if the account or the client IP is currently locked out
+func (lg *loginGuardUsecase) Check(ctx context.Context, email, ipAddress string) error {
+	now := time.Now()
+
+	if ipAddress != "" {
+		attempt, err := lg.attemptRepo.Get(ctx, entity.LoginAttemptScopeIP, ipAddress)
+		if err != nil {
+			return lg.errBuilder.Err(err)
+		}
+		if attempt != nil && attempt.IsLocked(now) {
+			return lg.errBuilder.Err(entity.ErrTooManyLoginAttempts)
+		}
+	}
+
+	attempt, err := lg.attemptRepo.Get(ctx, entity.LoginAttemptScopeAccount, normalizeEmail(email))
+	if err != nil {
+		return lg.errBuilder.Err(err)
+	}
+	if attempt != nil && attempt.IsLocked(now) {
+		return lg.errBuilder.Err(entity.ErrAccountLocked)
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login against the account and the client IP
+func (lg *loginGuardUsecase) RecordFailure(ctx context.Context, email, ipAddress, reason string) error {
+	// Publishing is best effort; a broker outage must not change login behaviour
+	_ = lg.eventPub.PublishLoginFailed(ctx, email, ipAddress, reason)
+
+	if err := lg.recordFailure(ctx, entity.LoginAttemptScopeAccount, normalizeEmail(email), lg.options.MaxAccountAttempts, ipAddress); err != nil {
+		return lg.errBuilder.Err(err)
+	}
+	if ipAddress != "" {
+		if err := lg.recordFailure(ctx, entity.LoginAttemptScopeIP, ipAddress, lg.options.MaxIPAttempts, ipAddress); err != nil {
+			return lg.errBuilder.Err(err)
+		}
+	}
+	return nil
+}
+
+// RecordSuccess clears the failed-login counter of the account.
+// The IP counter is left to expire so one valid login cannot reset a spraying client.
+func (lg *loginGuardUsecase) RecordSuccess(ctx context.Context, email string) error {
+	if err := lg.attemptRepo.Delete(ctx, entity.LoginAttemptScopeAccount, normalizeEmail(email)); err != nil {
+		return lg.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// Unlock clears the lockout of an account
+func (lg *loginGuardUsecase) Unlock(ctx context.Context, email string) error {
+	if err := lg.attemptRepo.Delete(ctx, entity.LoginAttemptScopeAccount, normalizeEmail(email)); err != nil {
+		return lg.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// recordFailure increments a counter and locks it with exponential backoff once maxAttempts is reached.
+// The counter is updated under its row lock so concurrent failures each count.
+func (lg *loginGuardUsecase) recordFailure(ctx context.Context, scope entity.LoginAttemptScope, key string, maxAttempts int, ipAddress string) error {
+	now := time.Now()
+	locked := false
+
+	attempt, err := lg.attemptRepo.Update(ctx, scope, key, func(attempt *entity.LoginAttempt) {
+		locked = lg.countFailure(attempt, maxAttempts, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		_ = lg.eventPub.PublishUserLocked(ctx, attempt, ipAddress)
+	}
+	return nil
+}
+
+// countFailure adds a failure to a counter and reports whether it locked the counter
+func (lg *loginGuardUsecase) countFailure(attempt *entity.LoginAttempt, maxAttempts int, now time.Time) bool {
+	if !attempt.IsLocked(now) {
+		quiet := now.Sub(attempt.LastFailedAt)
+		// Failures outside the window no longer count towards a lock
+		if quiet > lg.options.AttemptWindow {
+			attempt.FailedCount = 0
+		}
+		// A long quiet period also forgives previous lockouts
+		if quiet > lg.options.MaxLockout {
+			attempt.LockCount = 0
+		}
+	}
+
+	attempt.FailedCount++
+	attempt.LastFailedAt = now
+
+	if maxAttempts > 0 && attempt.FailedCount >= maxAttempts {
+		lockedUntil := now.Add(lg.lockoutDuration(attempt.LockCount))
+		attempt.LockedUntil = &lockedUntil
+		attempt.LockCount++
+		attempt.FailedCount = 0
+		return true
+	}
+	return false
+}
+
+// lockoutDuration doubles the base lockout for every previous lock, up to MaxLockout
+func (lg *loginGuardUsecase) lockoutDuration(lockCount int) time.Duration {
+	d := lg.options.BaseLockout
+	for i := 0; i < lockCount; i++ {
+		d *= 2
+		if d >= lg.options.MaxLockout {
+			return lg.options.MaxLockout
+		}
+	}
+	return d
+}
+
+// normalizeEmail makes account counters case insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
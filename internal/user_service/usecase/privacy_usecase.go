@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// PrivacyOptions configures data-subject requests
+type PrivacyOptions struct {
+	// ErasureServices are the services that must report completion before an erasure request is done
+	ErasureServices []string
+}
+
+// PrivacyUsecase defines data-subject export and erasure operations
+type PrivacyUsecase interface {
+	// ExportUserData gathers the personal data held about a user by every service
+	ExportUserData(ctx context.Context, userID string) (*entity.DataExport, error)
+
+	// RequestErasure erases the user's local data and asks the other services to do the same.
+	// Calling it again while a request is pending re-sends the request.
+	RequestErasure(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+
+	// GetErasureStatus returns the latest erasure request of a user
+	GetErasureStatus(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+
+	// RecordErasureCompletion marks a service as done with an erasure request
+	RecordErasureCompletion(ctx context.Context, requestID, serviceName string) error
+}
+
+type privacyUsecase struct {
+	userRepo         repository.UserRepository
+	addressRepo      repository.AddressRepository
+	identityRepo     repository.IdentityRepository
+	tokenRepo        repository.TokenRepository
+	loginAttemptRepo repository.LoginAttemptRepository
+	erasureRepo      repository.ErasureRequestRepository
+	sources          []interfaces.PersonalDataSource
+	eventPub         service.EventPublisherService
+	options          PrivacyOptions
+	errBuilder       *utils.ErrorBuilder
+}
+
+// NewPrivacyUsecase creates a new instance of PrivacyUsecase
+func NewPrivacyUsecase(
+	userRepo repository.UserRepository,
+	addressRepo repository.AddressRepository,
+	identityRepo repository.IdentityRepository,
+	tokenRepo repository.TokenRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	erasureRepo repository.ErasureRequestRepository,
+	sources []interfaces.PersonalDataSource,
+	eventPub service.EventPublisherService,
+	options PrivacyOptions,
+) PrivacyUsecase {
+	return &privacyUsecase{
+		userRepo:         userRepo,
+		addressRepo:      addressRepo,
+		identityRepo:     identityRepo,
+		tokenRepo:        tokenRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		erasureRepo:      erasureRepo,
+		sources:          sources,
+		eventPub:         eventPub,
+		options:          options,
+		errBuilder:       utils.NewErrorBuilder("PrivacyUsecase"),
+	}
+}
+
+// ExportUserData gathers the personal data held about a user by every service
+func (pu *privacyUsecase) ExportUserData(ctx context.Context, userID string) (*entity.DataExport, error) {
+	user, err := pu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	addresses, err := pu.addressRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	identities, err := pu.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	tokens, err := pu.tokenRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	export := &entity.DataExport{
+		GeneratedAt: time.Now(),
+		UserID:      userID,
+		Profile:     user,
+		Addresses:   addresses,
+		Identities:  identities,
+		Sessions:    make([]entity.Session, len(tokens)),
+		Services:    make(map[string]json.RawMessage, len(pu.sources)),
+	}
+	for i, token := range tokens {
+		export.Sessions[i] = entity.NewSession(token)
+	}
+
+	// A partial archive would misrepresent what is stored, so any unavailable service fails the export
+	for _, source := range pu.sources {
+		data, err := source.ExportUserData(ctx, userID)
+		if err != nil {
+			return nil, pu.errBuilder.Err(fmt.Errorf("%w: %s: %v", entity.ErrDataExportFailed, source.Name(), err))
+		}
+		export.Services[source.Name()] = data
+	}
+
+	return export, nil
+}
+
+// RequestErasure erases the user's local data and asks the other services to do the same.
+// Calling it again while a request is pending re-sends the request.
+func (pu *privacyUsecase) RequestErasure(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	latest, err := pu.erasureRepo.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if latest != nil && latest.Status == entity.ErasurePending {
+		if err := pu.eventPub.PublishErasureRequested(ctx, latest); err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+		// A previous attempt may have failed to publish before the local data was erased
+		if user, err := pu.userRepo.GetByID(ctx, userID); err == nil {
+			if err := pu.eraseLocalData(ctx, user); err != nil {
+				return nil, pu.errBuilder.Err(err)
+			}
+		}
+		return latest, nil
+	}
+
+	user, err := pu.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	now := time.Now()
+	request := &entity.ErasureRequest{
+		ID:              uuid.New().String(),
+		UserID:          userID,
+		Status:          entity.ErasurePending,
+		PendingServices: append([]string(nil), pu.options.ErasureServices...),
+		RequestedAt:     now,
+	}
+	if len(request.PendingServices) == 0 {
+		request.Status = entity.ErasureCompleted
+		request.CompletedAt = &now
+	}
+	if err := pu.erasureRepo.Create(ctx, request); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+
+	// Publish before erasing locally so a failed publish can be retried while the user still exists
+	if request.Status == entity.ErasurePending {
+		if err := pu.eventPub.PublishErasureRequested(ctx, request); err != nil {
+			return nil, pu.errBuilder.Err(err)
+		}
+	}
+
+	if err := pu.eraseLocalData(ctx, user); err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	return request, nil
+}
+
+// GetErasureStatus returns the latest erasure request of a user
+func (pu *privacyUsecase) GetErasureStatus(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	request, err := pu.erasureRepo.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, pu.errBuilder.Err(err)
+	}
+	if request == nil {
+		return nil, pu.errBuilder.Err(entity.ErrErasureNotFound)
+	}
+	return request, nil
+}
+
+// RecordErasureCompletion marks a service as done with an erasure request.
+// Services report at about the same time, so the request is updated under its row lock.
+func (pu *privacyUsecase) RecordErasureCompletion(ctx context.Context, requestID, serviceName string) error {
+	request, err := pu.erasureRepo.UpdateByID(ctx, requestID, func(request *entity.ErasureRequest) bool {
+		return request.MarkServiceCompleted(serviceName, time.Now())
+	})
+	if err != nil {
+		return pu.errBuilder.Err(err)
+	}
+	if request == nil {
+		return pu.errBuilder.Err(entity.ErrErasureNotFound)
+	}
+	return nil
+}
+
+// eraseLocalData removes the user's sessions, addresses and linked identities,
+// anonymises the user row and soft-deletes it. The ID is kept to correlate the erasure request.
+func (pu *privacyUsecase) eraseLocalData(ctx context.Context, user *entity.User) error {
+	if err := pu.tokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := pu.addressRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := pu.identityRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := pu.loginAttemptRepo.Delete(ctx, entity.LoginAttemptScopeAccount, normalizeEmail(user.Email)); err != nil {
+		return err
+	}
+
+	anonymised := entity.User{
+		ID:        user.ID,
+		Email:     fmt.Sprintf("erased-%s@erased.invalid", user.ID),
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}
+	if _, err := pu.userRepo.Update(ctx, anonymised); err != nil {
+		return err
+	}
+	return pu.userRepo.Delete(ctx, user.ID)
+}
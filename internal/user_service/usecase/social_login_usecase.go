@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/oidc"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// SocialLoginUsecase defines OpenID Connect login operations
+type SocialLoginUsecase interface {
+	// Providers returns the names of the configured identity providers
+	Providers() []string
+
+	// StartLogin creates a login state and returns the provider authorization URL and the state.
+	// The state must be bound to the user agent, and only a callback from that agent completed.
+	StartLogin(ctx context.Context, provider string) (authURL string, state string, err error)
+
+	// CompleteLogin redeems the authorization code, links or provisions the user and starts a session
+	CompleteLogin(ctx context.Context, provider, state, code string) (*entity.LoginResult, error)
+}
+
+type socialLoginUsecase struct {
+	providers      map[string]interfaces.IdentityProvider
+	identityRepo   repository.IdentityRepository
+	userRepo       repository.UserRepository
+	authUsecase    AuthUsecase
+	accountUsecase AccountUsecase
+	stateTTL       time.Duration
+	errBuilder     *utils.ErrorBuilder
+}
+
+// NewSocialLoginUsecase creates a new instance of SocialLoginUsecase
+func NewSocialLoginUsecase(
+	providers []interfaces.IdentityProvider,
+	identityRepo repository.IdentityRepository,
+	userRepo repository.UserRepository,
+	authUsecase AuthUsecase,
+	accountUsecase AccountUsecase,
+	stateTTL time.Duration,
+) SocialLoginUsecase {
+	byName := make(map[string]interfaces.IdentityProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &socialLoginUsecase{
+		providers:      byName,
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		authUsecase:    authUsecase,
+		accountUsecase: accountUsecase,
+		stateTTL:       stateTTL,
+		errBuilder:     utils.NewErrorBuilder("SocialLoginUsecase"),
+	}
+}
+
+// Providers returns the names of the configured identity providers
+func (su *socialLoginUsecase) Providers() []string {
+	names := make([]string, 0, len(su.providers))
+	for name := range su.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartLogin creates a login state and returns the provider authorization URL and the state
+func (su *socialLoginUsecase) StartLogin(ctx context.Context, providerName string) (string, string, error) {
+	provider, ok := su.providers[providerName]
+	if !ok {
+		return "", "", su.errBuilder.Err(entity.ErrUnknownProvider)
+	}
+
+	state, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", "", su.errBuilder.Err(err)
+	}
+	nonce, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", "", su.errBuilder.Err(err)
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", su.errBuilder.Err(err)
+	}
+
+	loginState := &entity.OIDCLoginState{
+		State:        utils.HashToken(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(su.stateTTL),
+	}
+	if err := su.identityRepo.CreateLoginState(ctx, loginState); err != nil {
+		return "", "", su.errBuilder.Err(err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		return "", "", su.errBuilder.Err(fmt.Errorf("%w: %v", entity.ErrExternalAuthFailed, err))
+	}
+	return authURL, state, nil
+}
+
+// CompleteLogin redeems the authorization code, links or provisions the user and starts a session
+func (su *socialLoginUsecase) CompleteLogin(ctx context.Context, providerName, state, code string) (*entity.LoginResult, error) {
+	provider, ok := su.providers[providerName]
+	if !ok {
+		return nil, su.errBuilder.Err(entity.ErrUnknownProvider)
+	}
+
+	loginState, err := su.identityRepo.ConsumeLoginState(ctx, utils.HashToken(state))
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+	if loginState == nil || loginState.Provider != providerName || loginState.ExpiresAt.Before(time.Now()) {
+		return nil, su.errBuilder.Err(entity.ErrInvalidLoginState)
+	}
+
+	external, err := provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, su.errBuilder.Err(fmt.Errorf("%w: %v", entity.ErrExternalAuthFailed, err))
+	}
+
+	user, err := su.resolveUser(ctx, external)
+	if err != nil {
+		return nil, su.errBuilder.Err(err)
+	}
+
+	return su.authUsecase.StartSession(ctx, user)
+}
+
+// resolveUser finds the user linked to an external identity.
+// Unlinked identities are merged into an existing account by verified email, or a new user is provisioned.
+// Both sides must have verified the email: an unverified local account may have been registered by someone
+// else in advance, and linking would let their password into the account.
+func (su *socialLoginUsecase) resolveUser(ctx context.Context, external *entity.ExternalIdentity) (*entity.User, error) {
+	identity, err := su.identityRepo.GetByProviderSubject(ctx, external.Provider, external.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := su.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, entity.ErrUserNotFound
+		}
+		return user, nil
+	}
+
+	if external.Email == "" {
+		return nil, fmt.Errorf("%w: provider did not return an email", entity.ErrExternalAuthFailed)
+	}
+
+	user, err := su.userRepo.GetByEmail(ctx, external.Email)
+	if err == nil && user != nil {
+		// Only a provider-verified email proves ownership of the existing account,
+		// and only a verified account is known to belong to the owner of the email
+		if !external.EmailVerified || !user.EmailVerified {
+			return nil, entity.ErrIdentityConflict
+		}
+	} else {
+		user, err = su.provisionUser(ctx, external)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := su.identityRepo.Create(ctx, &entity.Identity{
+		ID:       uuid.New().String(),
+		UserID:   user.ID,
+		Provider: external.Provider,
+		Subject:  external.Subject,
+		Email:    external.Email,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// provisionUser creates a customer account for a first-time external login.
+// The random password cannot be used until the user resets it.
+func (su *socialLoginUsecase) provisionUser(ctx context.Context, external *entity.ExternalIdentity) (*entity.User, error) {
+	password, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := entity.User{
+		ID:             uuid.New().String(),
+		Email:          external.Email,
+		HashedPassword: string(hashedPassword),
+		FirstName:      external.FirstName,
+		LastName:       external.LastName,
+		Role:           vo.User,
+		EmailVerified:  external.EmailVerified,
+	}
+	if external.EmailVerified {
+		user.VerifiedAt = utils.NowPtr()
+	}
+
+	created, err := su.userRepo.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created.EmailVerified {
+		if err := su.accountUsecase.SendVerificationEmail(ctx, created); err != nil && !errors.Is(err, entity.ErrEmailAlreadyVerified) {
+			return nil, err
+		}
+	}
+	return created, nil
+}
@@ -94,9 +94,9 @@ func (tu *tokenUsecase) GenerateTokenPair(ctx context.Context, userID, role stri
 
 // ValidateToken validates a token
 func (tu *tokenUsecase) ValidateToken(ctx context.Context, tokenValue string) (*jwt_service.CustomClaims, error) {
-	// First check if the token exists in the repository
+	// First check if the token exists in the repository; logged out and revoked tokens do not
 	token, err := tu.tokenRepo.FindByToken(ctx, tokenValue)
-	if err != nil {
+	if err != nil || token == nil {
 		return nil, tu.errBuilder.Err(entity.ErrInvalidToken)
 	}
 
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/totp"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 10
+	totpSkew          = 1
+)
+
+// TwoFactorOptions configures TOTP two-factor authentication
+type TwoFactorOptions struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+// TwoFactorUsecase defines TOTP enrolment and verification operations
+type TwoFactorUsecase interface {
+	// BeginEnrollment generates a new pending secret for the user
+	BeginEnrollment(ctx context.Context, userID string) (*entity.TwoFactorEnrollment, error)
+
+	// ConfirmEnrollment enables two-factor authentication once a valid code is presented and returns fresh recovery codes
+	ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error)
+
+	// Disable turns off two-factor authentication after verifying a code. Not allowed for admins.
+	Disable(ctx context.Context, userID, code string) error
+
+	// RegenerateRecoveryCodes replaces the recovery codes after verifying a code
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+
+	// IssueChallenge stores a short-lived intermediate token for the second login step
+	IssueChallenge(ctx context.Context, userID string) (string, error)
+
+	// ChallengeUser resolves the user behind an unused challenge token without consuming it
+	ChallengeUser(ctx context.Context, challengeToken string) (*entity.User, error)
+
+	// VerifyChallenge consumes a challenge token with a TOTP or recovery code.
+	// Recovery codes are returned when the challenge completed a pending enrolment.
+	VerifyChallenge(ctx context.Context, challengeToken, code string) (*entity.User, []string, error)
+}
+
+type twoFactorUsecase struct {
+	userRepo   repository.UserRepository
+	tokenRepo  repository.TokenRepository
+	options    TwoFactorOptions
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewTwoFactorUsecase creates a new instance of TwoFactorUsecase
+func NewTwoFactorUsecase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	options TwoFactorOptions,
+) TwoFactorUsecase {
+	return &twoFactorUsecase{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		options:    options,
+		errBuilder: utils.NewErrorBuilder("TwoFactorUsecase"),
+	}
+}
+
+// BeginEnrollment generates a new pending secret for the user
+func (tf *twoFactorUsecase) BeginEnrollment(ctx context.Context, userID string) (*entity.TwoFactorEnrollment, error) {
+	user, err := tf.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if user.TwoFactorEnabled {
+		return nil, tf.errBuilder.Err(entity.ErrTwoFactorEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+	user.TwoFactorSecret = secret
+	if _, err := tf.userRepo.Update(ctx, *user); err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+
+	return &entity.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, tf.options.Issuer, user.Email),
+	}, nil
+}
+
+// ConfirmEnrollment enables two-factor authentication once a valid code is presented and returns fresh recovery codes
+func (tf *twoFactorUsecase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := tf.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	codes, err := tf.confirmEnrollment(ctx, user, code)
+	if err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication after verifying a code. Not allowed for admins.
+func (tf *twoFactorUsecase) Disable(ctx context.Context, userID, code string) error {
+	user, err := tf.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if !user.TwoFactorEnabled {
+		return tf.errBuilder.Err(entity.ErrTwoFactorNotEnrolled)
+	}
+	if user.Role == vo.Admin {
+		return tf.errBuilder.Err(entity.ErrTwoFactorRequired)
+	}
+	if !tf.verifyCode(ctx, user, code) {
+		return tf.errBuilder.Err(entity.ErrInvalidTwoFactorCode)
+	}
+
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.RecoveryCodes = nil
+	if _, err := tf.userRepo.Update(ctx, *user); err != nil {
+		return tf.errBuilder.Err(err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after verifying a code
+func (tf *twoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := tf.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if !user.TwoFactorEnabled {
+		return nil, tf.errBuilder.Err(entity.ErrTwoFactorNotEnrolled)
+	}
+	if !tf.validateTOTP(ctx, user, code) {
+		return nil, tf.errBuilder.Err(entity.ErrInvalidTwoFactorCode)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+	user.RecoveryCodes = hashes
+	if _, err := tf.userRepo.Update(ctx, *user); err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+	return codes, nil
+}
+
+// IssueChallenge stores a short-lived intermediate token for the second login step
+func (tf *twoFactorUsecase) IssueChallenge(ctx context.Context, userID string) (string, error) {
+	rawToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", tf.errBuilder.Err(err)
+	}
+
+	token := &entity.Token{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      entity.TwoFactorChallenge,
+		Token:     utils.HashToken(rawToken),
+		ExpiresAt: time.Now().Add(tf.options.ChallengeTTL),
+	}
+	if err := tf.tokenRepo.Create(ctx, token); err != nil {
+		return "", tf.errBuilder.Err(err)
+	}
+	return rawToken, nil
+}
+
+// ChallengeUser resolves the user behind an unused challenge token without consuming it
+func (tf *twoFactorUsecase) ChallengeUser(ctx context.Context, challengeToken string) (*entity.User, error) {
+	stored, err := tf.findChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, tf.errBuilder.Err(err)
+	}
+	user, err := tf.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// VerifyChallenge consumes a challenge token with a TOTP or recovery code.
+// Recovery codes are returned when the challenge completed a pending enrolment.
+func (tf *twoFactorUsecase) VerifyChallenge(ctx context.Context, challengeToken, code string) (*entity.User, []string, error) {
+	stored, err := tf.findChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, tf.errBuilder.Err(err)
+	}
+	user, err := tf.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, tf.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	var recoveryCodes []string
+	if user.TwoFactorEnabled {
+		if !tf.verifyCode(ctx, user, code) {
+			return user, nil, tf.errBuilder.Err(entity.ErrInvalidTwoFactorCode)
+		}
+	} else {
+		// Mandatory enrolment during login: the code confirms the pending secret
+		recoveryCodes, err = tf.confirmEnrollment(ctx, user, code)
+		if err != nil {
+			return user, nil, tf.errBuilder.Err(err)
+		}
+	}
+
+	if err := tf.tokenRepo.MarkUsed(ctx, stored.ID); err != nil {
+		return nil, nil, tf.errBuilder.Err(err)
+	}
+	return user, recoveryCodes, nil
+}
+
+// confirmEnrollment validates a code against the pending secret and enables two-factor authentication
+func (tf *twoFactorUsecase) confirmEnrollment(ctx context.Context, user *entity.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled {
+		return nil, entity.ErrTwoFactorEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, entity.ErrTwoFactorNotEnrolled
+	}
+	if !tf.validateTOTP(ctx, user, code) {
+		return nil, entity.ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.TwoFactorEnabled = true
+	user.RecoveryCodes = hashes
+	if _, err := tf.userRepo.Update(ctx, *user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyCode accepts either a current TOTP code or an unused recovery code, which is then burned
+func (tf *twoFactorUsecase) verifyCode(ctx context.Context, user *entity.User, code string) bool {
+	if tf.validateTOTP(ctx, user, code) {
+		return true
+	}
+
+	hashed := utils.HashToken(normalizeRecoveryCode(code))
+	for i, stored := range user.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hashed)) == 1 {
+			// Only one of several requests presenting the same code can spend it
+			spent, err := tf.userRepo.SpendRecoveryCode(ctx, user.ID, stored)
+			if err != nil || !spent {
+				return false
+			}
+			user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// validateTOTP accepts a current TOTP code once. The time step of the code is claimed, so the same
+// code, or an earlier one still within the allowed skew, cannot be replayed.
+func (tf *twoFactorUsecase) validateTOTP(ctx context.Context, user *entity.User, code string) bool {
+	counter, ok := totp.ValidateCounter(user.TwoFactorSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return false
+	}
+	claimed, err := tf.userRepo.ClaimTwoFactorCounter(ctx, user.ID, counter)
+	if err != nil || !claimed {
+		return false
+	}
+	// Keep the claim when the user is saved again
+	user.TwoFactorCounter = counter
+	return true
+}
+
+// findChallenge loads an unused, unexpired challenge token
+func (tf *twoFactorUsecase) findChallenge(ctx context.Context, rawToken string) (*entity.Token, error) {
+	stored, err := tf.tokenRepo.FindByTokenAndType(ctx, utils.HashToken(rawToken), entity.TwoFactorChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil || !stored.IsUsable(time.Now()) {
+		return nil, entity.ErrInvalidToken
+	}
+	return stored, nil
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes for storage
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := utils.GenerateSecureToken(recoveryCodeBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		code := normalizeRecoveryCode(raw)
+		codes[i] = code
+		hashes[i] = utils.HashToken(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case insensitive and ignores separators
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "", "_", "").Replace(code)
+}
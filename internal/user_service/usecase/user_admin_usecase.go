@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/repository"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+)
+
+// sessionTokenTypes are the token types that let a user act on their account
+var sessionTokenTypes = []entity.TokenType{entity.AccessToken, entity.RefreshToken, entity.TwoFactorChallenge}
+
+// UserAdminUsecase defines the user management operations available to admins
+type UserAdminUsecase interface {
+	// ListUsers retrieves users with optional filtering
+	ListUsers(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.User, int, error)
+
+	// ChangeRole assigns a new role to a user and revokes their sessions so it takes effect immediately
+	ChangeRole(ctx context.Context, actorID, userID string, role vo.Role) (*entity.User, error)
+
+	// SuspendUser blocks a user from logging in and revokes their sessions
+	SuspendUser(ctx context.Context, actorID, userID, reason string) (*entity.User, error)
+
+	// ReactivateUser lifts a suspension
+	ReactivateUser(ctx context.Context, userID string) (*entity.User, error)
+
+	// ListSessions returns the user's unexpired access and refresh tokens
+	ListSessions(ctx context.Context, userID string) ([]entity.Session, error)
+}
+
+type userAdminUsecase struct {
+	userRepo   repository.UserRepository
+	tokenRepo  repository.TokenRepository
+	errBuilder *utils.ErrorBuilder
+}
+
+// NewUserAdminUsecase creates a new instance of UserAdminUsecase
+func NewUserAdminUsecase(userRepo repository.UserRepository, tokenRepo repository.TokenRepository) UserAdminUsecase {
+	return &userAdminUsecase{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		errBuilder: utils.NewErrorBuilder("UserAdminUsecase"),
+	}
+}
+
+// ListUsers retrieves users with optional filtering
+func (au *userAdminUsecase) ListUsers(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.User, int, error) {
+	offset := (page - 1) * pageSize
+	users, total, err := au.userRepo.List(ctx, offset, pageSize, filters)
+	if err != nil {
+		return nil, 0, au.errBuilder.Err(err)
+	}
+	return users, total, nil
+}
+
+// ChangeRole assigns a new role to a user and revokes their sessions so it takes effect immediately.
+// The role is embedded in issued tokens, so existing sessions would otherwise keep the old role.
+func (au *userAdminUsecase) ChangeRole(ctx context.Context, actorID, userID string, role vo.Role) (*entity.User, error) {
+	if !role.IsValid() {
+		return nil, au.errBuilder.Err(entity.ErrInvalidRole)
+	}
+	if actorID == userID {
+		return nil, au.errBuilder.Err(entity.ErrSelfModification)
+	}
+
+	user, err := au.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	user.Role = role
+	updated, err := au.userRepo.Update(ctx, *user)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	if err := au.revokeSessions(ctx, userID); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// SuspendUser blocks a user from logging in and revokes their sessions
+func (au *userAdminUsecase) SuspendUser(ctx context.Context, actorID, userID, reason string) (*entity.User, error) {
+	if actorID == userID {
+		return nil, au.errBuilder.Err(entity.ErrSelfModification)
+	}
+
+	user, err := au.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	if !user.IsSuspended() {
+		user.SuspendedAt = utils.NowPtr()
+	}
+	user.SuspensionReason = reason
+	updated, err := au.userRepo.Update(ctx, *user)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
+	// Revoke even when already suspended, in case an earlier revocation failed
+	if err := au.revokeSessions(ctx, userID); err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// ReactivateUser lifts a suspension
+func (au *userAdminUsecase) ReactivateUser(ctx context.Context, userID string) (*entity.User, error) {
+	user, err := au.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if !user.IsSuspended() {
+		return user, nil
+	}
+
+	user.SuspendedAt = nil
+	user.SuspensionReason = ""
+	updated, err := au.userRepo.Update(ctx, *user)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+	return updated, nil
+}
+
+// ListSessions returns the user's unexpired access and refresh tokens
+func (au *userAdminUsecase) ListSessions(ctx context.Context, userID string) ([]entity.Session, error) {
+	if _, err := au.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, au.errBuilder.Err(entity.ErrUserNotFound)
+	}
+
+	tokens, err := au.tokenRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, au.errBuilder.Err(err)
+	}
+
+	now := time.Now()
+	sessions := make([]entity.Session, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Type != entity.AccessToken && token.Type != entity.RefreshToken {
+			continue
+		}
+		if !token.ExpiresAt.After(now) {
+			continue
+		}
+		sessions = append(sessions, entity.NewSession(token))
+	}
+	return sessions, nil
+}
+
+// revokeSessions deletes every token that lets the user act on their account
+func (au *userAdminUsecase) revokeSessions(ctx context.Context, userID string) error {
+	for _, tokenType := range sessionTokenTypes {
+		if err := au.tokenRepo.DeleteByUserIDAndType(ctx, userID, tokenType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
@@ -19,8 +20,11 @@ type UserUsecase interface {
 	// GetUserByEmail retrieves a user by email
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 
-	// UpdateUser updates an existing user
-	UpdateUser(ctx context.Context, id string, user entity.User) (*entity.User, error)
+	// UpdateUser updates the email and name of an existing user
+	UpdateUser(ctx context.Context, id string, update entity.User) (*entity.User, error)
+
+	// UpdateProfile applies a partial update to the user's profile fields
+	UpdateProfile(ctx context.Context, id string, update entity.ProfileUpdate) (*entity.User, error)
 
 	// DeleteUser deletes a user by ID
 	DeleteUser(ctx context.Context, id string) error
@@ -75,9 +79,41 @@ func (uu *userUsecase) GetUserByEmail(ctx context.Context, email string) (*entit
 	return user, nil
 }
 
-// UpdateUser updates an existing user
-func (uu *userUsecase) UpdateUser(ctx context.Context, id string, user entity.User) (*entity.User, error) {
-	updatedUser, err := uu.userRepo.Update(ctx, user)
+// UpdateUser updates the email and name of an existing user; empty fields are left unchanged.
+// Everything else, such as the role, suspension and two-factor settings, has its own flow.
+// A new email address has to be verified again.
+func (uu *userUsecase) UpdateUser(ctx context.Context, id string, update entity.User) (*entity.User, error) {
+	user, err := uu.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, uu.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if update.Email != "" && !strings.EqualFold(update.Email, user.Email) {
+		user.Email = update.Email
+		user.EmailVerified = false
+	}
+	if update.FirstName != "" {
+		user.FirstName = update.FirstName
+	}
+	if update.LastName != "" {
+		user.LastName = update.LastName
+	}
+	updatedUser, err := uu.userRepo.Update(ctx, *user)
+	if err != nil {
+		return nil, uu.errBuilder.Err(err)
+	}
+	return updatedUser, nil
+}
+
+// UpdateProfile applies a partial update to the user's profile fields
+func (uu *userUsecase) UpdateProfile(ctx context.Context, id string, update entity.ProfileUpdate) (*entity.User, error) {
+	user, err := uu.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, uu.errBuilder.Err(entity.ErrUserNotFound)
+	}
+	if err := user.ApplyProfile(update); err != nil {
+		return nil, uu.errBuilder.Err(err)
+	}
+	updatedUser, err := uu.userRepo.Update(ctx, *user)
 	if err != nil {
 		return nil, uu.errBuilder.Err(err)
 	}
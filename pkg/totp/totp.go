@@ -0,0 +1,116 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Common errors
+var (
+	ErrInvalidSecret = errors.New("invalid totp secret")
+)
+
+// Defaults used by common authenticator apps (RFC 6238)
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30 * time.Second
+	secretSize    = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI builds the otpauth:// URI rendered as a QR code by authenticator apps
+func ProvisioningURI(secret, issuer, account string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(DefaultDigits))
+	v.Set("period", fmt.Sprint(int(DefaultPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// GenerateCode returns the code for the given secret at time t
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix()/int64(DefaultPeriod.Seconds()))), nil
+}
+
+// Validate checks a code against the secret, allowing skew periods of clock drift either way
+func Validate(secret, code string, t time.Time, skew int) bool {
+	_, ok := ValidateCounter(secret, code, t, skew)
+	return ok
+}
+
+// ValidateCounter checks a code like Validate and also returns the time step the code belongs to.
+// Callers record the step to reject a code that is presented again within its period.
+func ValidateCounter(secret, code string, t time.Time, skew int) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+	code = strings.TrimSpace(code)
+	if len(code) != DefaultDigits {
+		return 0, false
+	}
+
+	counter := t.Unix() / int64(DefaultPeriod.Seconds())
+	for i := -skew; i <= skew; i++ {
+		expected := hotp(key, uint64(counter+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// hotp computes an RFC 4226 HMAC-based one-time password
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < DefaultDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", DefaultDigits, value%mod)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,631 @@
+// Package inventorystore provides an in-memory inventory service store and a no-op event publisher for tests.
+package inventorystore
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+)
+
+// Store is an in-memory InventoryRepository, WarehouseRepository, CycleCountRepository,
+// SupplierRepository, PurchaseOrderRepository and Transactor.
+// Transactions run one at a time and restore the previous state when they fail.
+type Store struct {
+	txMu         sync.Mutex
+	mu           sync.Mutex
+	items        map[string]entity.InventoryItem
+	reservations map[string]entity.InventoryReservation
+	transactions []entity.StockTransaction
+	warehouses   map[string]entity.Warehouse
+	levels       map[[2]string]entity.StockLevel
+	transfers    []entity.StockTransfer
+	counts       map[string]entity.CycleCount
+	suppliers    map[string]entity.Supplier
+	orders       map[string]entity.PurchaseOrder
+}
+
+type inTxKey struct{}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		items:        map[string]entity.InventoryItem{},
+		reservations: map[string]entity.InventoryReservation{},
+		warehouses:   map[string]entity.Warehouse{},
+		levels:       map[[2]string]entity.StockLevel{},
+		counts:       map[string]entity.CycleCount{},
+		suppliers:    map[string]entity.Supplier{},
+		orders:       map[string]entity.PurchaseOrder{},
+	}
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	clone := make(map[K]V, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+func (s *Store) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(inTxKey{}) != nil {
+		return fn(ctx)
+	}
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	items, reservations, levels, counts := cloneMap(s.items), cloneMap(s.reservations), cloneMap(s.levels), cloneMap(s.counts)
+	suppliers, orders := cloneMap(s.suppliers), cloneMap(s.orders)
+	transactions, transfers := len(s.transactions), len(s.transfers)
+	s.mu.Unlock()
+
+	err := fn(context.WithValue(ctx, inTxKey{}, true))
+	if err != nil {
+		s.mu.Lock()
+		s.items, s.reservations, s.levels, s.counts = items, reservations, levels, counts
+		s.suppliers, s.orders = suppliers, orders
+		s.transactions, s.transfers = s.transactions[:transactions], s.transfers[:transfers]
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *Store) GetInventoryItem(_ context.Context, productID string) (*entity.InventoryItem, error) {
+	// Let other goroutines run between a read and the write based on it, as a database round trip would
+	defer runtime.Gosched()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[productID]
+	if !ok {
+		return nil, entity.ErrInventoryNotFound
+	}
+	return &item, nil
+}
+
+func (s *Store) LockInventoryItems(ctx context.Context, productIDs []string) ([]*entity.InventoryItem, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockInventoryItems called outside a transaction")
+	}
+	result := make([]*entity.InventoryItem, len(productIDs))
+	for i, productID := range productIDs {
+		item, err := s.GetInventoryItem(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+func (s *Store) CreateInventoryItem(_ context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[item.ProductID]; ok {
+		return nil, entity.ErrSKUAlreadyExists
+	}
+	s.items[item.ProductID] = *item
+	created := *item
+	return &created, nil
+}
+
+func (s *Store) UpdateInventoryItem(_ context.Context, item *entity.InventoryItem) (*entity.InventoryItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[item.ProductID] = *item
+	updated := *item
+	return &updated, nil
+}
+
+func (s *Store) CreateReservation(_ context.Context, reservation *entity.InventoryReservation) (*entity.InventoryReservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reservations[reservation.ReservationID] = *reservation
+	created := *reservation
+	return &created, nil
+}
+
+func (s *Store) GetReservationByID(_ context.Context, reservationID string) (*entity.InventoryReservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reservation, ok := s.reservations[reservationID]
+	if !ok {
+		return nil, entity.ErrInventoryNotFound
+	}
+	return &reservation, nil
+}
+
+func (s *Store) GetReservationsByOrderID(_ context.Context, orderID string) ([]*entity.InventoryReservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.InventoryReservation
+	for _, reservation := range s.reservations {
+		if reservation.OrderID == orderID {
+			reservation := reservation
+			result = append(result, &reservation)
+		}
+	}
+	return result, nil
+}
+
+func (s *Store) LockReservationsByOrderID(ctx context.Context, orderID string) ([]*entity.InventoryReservation, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockReservationsByOrderID called outside a transaction")
+	}
+	return s.GetReservationsByOrderID(ctx, orderID)
+}
+
+func (s *Store) LockBackorderedReservations(ctx context.Context, productID string) ([]*entity.InventoryReservation, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockBackorderedReservations called outside a transaction")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.InventoryReservation
+	for _, reservation := range s.reservations {
+		if reservation.ProductID == productID && reservation.Status == valueobject.ReserveStatusBackordered.String() {
+			reservation := reservation
+			result = append(result, &reservation)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].ReservedAt.Equal(result[j].ReservedAt) {
+			return result[i].ReservedAt.Before(result[j].ReservedAt)
+		}
+		return result[i].ReservationID < result[j].ReservationID
+	})
+	return result, nil
+}
+
+func (s *Store) GetExpiredReservations(_ context.Context, now time.Time, limit int) ([]*entity.InventoryReservation, error) {
+	defer runtime.Gosched()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.InventoryReservation
+	for _, reservation := range s.reservations {
+		if reservation.Status == valueobject.ReserveStatusReserved.String() && reservation.ExpiresAt.Before(now) && len(result) < limit {
+			reservation := reservation
+			result = append(result, &reservation)
+		}
+	}
+	return result, nil
+}
+
+func (s *Store) UpdateReservation(ctx context.Context, reservation *entity.InventoryReservation) (*entity.InventoryReservation, error) {
+	return s.CreateReservation(ctx, reservation)
+}
+
+func (s *Store) DeleteReservation(_ context.Context, reservationID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reservations, reservationID)
+	return nil
+}
+
+func (s *Store) RecordStockTransaction(_ context.Context, transaction *entity.StockTransaction) (*entity.StockTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions = append(s.transactions, *transaction)
+	recorded := *transaction
+	return &recorded, nil
+}
+
+func (s *Store) GetStockTransactions(_ context.Context, productID string, limit, offset int) ([]*entity.StockTransaction, int, error) {
+	return nil, 0, nil
+}
+
+func (s *Store) GetStockLedgerTotals(_ context.Context, productID string, until *time.Time) ([]*entity.StockLedgerTotal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byKey := map[[2]string]*entity.StockLedgerTotal{}
+	var result []*entity.StockLedgerTotal
+	for _, transaction := range s.transactions {
+		if transaction.ProductID != productID || (until != nil && transaction.OccurredAt.After(*until)) {
+			continue
+		}
+		key := [2]string{transaction.WarehouseID, transaction.Type}
+		total, ok := byKey[key]
+		if !ok {
+			total = &entity.StockLedgerTotal{WarehouseID: transaction.WarehouseID, Type: transaction.Type}
+			byKey[key] = total
+			result = append(result, total)
+		}
+		total.Qty += transaction.Qty
+	}
+	return result, nil
+}
+
+func (s *Store) ListProductIDs(_ context.Context, afterProductID string, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []string
+	for productID := range s.items {
+		if productID > afterProductID {
+			result = append(result, productID)
+		}
+	}
+	sort.Strings(result)
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *Store) GetLowStockItems(_ context.Context, limit, offset int) ([]*entity.InventoryItem, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.InventoryItem
+	for _, item := range s.items {
+		if item.AvailableQty <= item.ReorderLevel {
+			item := item
+			result = append(result, &item)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ProductID < result[j].ProductID })
+	total := len(result)
+	if offset >= total {
+		return nil, total, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, nil
+}
+
+func (s *Store) CreateWarehouse(_ context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.warehouses {
+		if existing.Code == warehouse.Code {
+			return nil, entity.ErrWarehouseCodeExists
+		}
+	}
+	s.warehouses[warehouse.ID] = *warehouse
+	created := *warehouse
+	return &created, nil
+}
+
+func (s *Store) GetWarehouse(_ context.Context, id string) (*entity.Warehouse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	warehouse, ok := s.warehouses[id]
+	if !ok {
+		return nil, entity.ErrWarehouseNotFound
+	}
+	return &warehouse, nil
+}
+
+func (s *Store) GetWarehouseByCode(_ context.Context, code string) (*entity.Warehouse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, warehouse := range s.warehouses {
+		if warehouse.Code == code {
+			return &warehouse, nil
+		}
+	}
+	return nil, entity.ErrWarehouseNotFound
+}
+
+func (s *Store) ListWarehouses(_ context.Context, activeOnly bool) ([]*entity.Warehouse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.Warehouse
+	for _, warehouse := range s.warehouses {
+		if warehouse.Active || !activeOnly {
+			warehouse := warehouse
+			result = append(result, &warehouse)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Priority < result[j].Priority })
+	return result, nil
+}
+
+func (s *Store) UpdateWarehouse(_ context.Context, warehouse *entity.Warehouse) (*entity.Warehouse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.warehouses[warehouse.ID] = *warehouse
+	updated := *warehouse
+	return &updated, nil
+}
+
+func (s *Store) GetStockLevels(_ context.Context, productID string) ([]*entity.StockLevel, error) {
+	defer runtime.Gosched()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.StockLevel
+	for _, level := range s.levels {
+		if level.ProductID == productID {
+			level := level
+			result = append(result, &level)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].WarehouseID < result[j].WarehouseID })
+	return result, nil
+}
+
+func (s *Store) LockStockLevels(ctx context.Context, productIDs []string) ([]*entity.StockLevel, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockStockLevels called outside a transaction")
+	}
+	var result []*entity.StockLevel
+	for _, productID := range productIDs {
+		levels, err := s.GetStockLevels(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, levels...)
+	}
+	return result, nil
+}
+
+func (s *Store) GetWarehouseStockLevels(_ context.Context, warehouseID string) ([]*entity.StockLevel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.StockLevel
+	for _, level := range s.levels {
+		if level.WarehouseID == warehouseID {
+			level := level
+			result = append(result, &level)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ProductID < result[j].ProductID })
+	return result, nil
+}
+
+func (s *Store) SaveStockLevel(_ context.Context, level *entity.StockLevel) (*entity.StockLevel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.levels[[2]string{level.ProductID, level.WarehouseID}] = *level
+	saved := *level
+	return &saved, nil
+}
+
+func (s *Store) CreateStockTransfer(_ context.Context, transfer *entity.StockTransfer) (*entity.StockTransfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transfers = append(s.transfers, *transfer)
+	created := *transfer
+	return &created, nil
+}
+
+func (s *Store) GetStockTransfers(_ context.Context, productID string, limit, offset int) ([]*entity.StockTransfer, int, error) {
+	return nil, 0, nil
+}
+
+func (s *Store) AssignToWarehouse(_ context.Context, warehouseID string) (int, error) {
+	return 0, nil
+}
+
+// cloneCycleCount copies a cycle count with its lines, so stored counts are never shared with callers
+func cloneCycleCount(count *entity.CycleCount) *entity.CycleCount {
+	clone := *count
+	clone.Lines = make([]*entity.CycleCountLine, len(count.Lines))
+	for i, line := range count.Lines {
+		line := *line
+		clone.Lines[i] = &line
+	}
+	return &clone
+}
+
+func (s *Store) CreateCycleCount(_ context.Context, count *entity.CycleCount) (*entity.CycleCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[count.CountID] = *cloneCycleCount(count)
+	return cloneCycleCount(count), nil
+}
+
+func (s *Store) GetCycleCount(_ context.Context, countID string) (*entity.CycleCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count, ok := s.counts[countID]
+	if !ok {
+		return nil, entity.ErrCycleCountNotFound
+	}
+	return cloneCycleCount(&count), nil
+}
+
+func (s *Store) LockCycleCount(ctx context.Context, countID string) (*entity.CycleCount, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockCycleCount called outside a transaction")
+	}
+	return s.GetCycleCount(ctx, countID)
+}
+
+func (s *Store) UpdateCycleCount(ctx context.Context, count *entity.CycleCount) (*entity.CycleCount, error) {
+	return s.CreateCycleCount(ctx, count)
+}
+
+func (s *Store) CreateSupplier(_ context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suppliers[supplier.ID] = *supplier
+	created := *supplier
+	return &created, nil
+}
+
+func (s *Store) GetSupplier(_ context.Context, id string) (*entity.Supplier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	supplier, ok := s.suppliers[id]
+	if !ok {
+		return nil, entity.ErrSupplierNotFound
+	}
+	return &supplier, nil
+}
+
+func (s *Store) LockSupplier(ctx context.Context, id string) (*entity.Supplier, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockSupplier called outside a transaction")
+	}
+	return s.GetSupplier(ctx, id)
+}
+
+func (s *Store) ListSuppliers(_ context.Context, activeOnly bool) ([]*entity.Supplier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.Supplier
+	for _, supplier := range s.suppliers {
+		if activeOnly && !supplier.Active {
+			continue
+		}
+		supplier := supplier
+		result = append(result, &supplier)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (s *Store) UpdateSupplier(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	if _, err := s.GetSupplier(ctx, supplier.ID); err != nil {
+		return nil, err
+	}
+	return s.CreateSupplier(ctx, supplier)
+}
+
+// clonePurchaseOrder copies a purchase order with its lines, so stored orders are never shared with callers
+func clonePurchaseOrder(po *entity.PurchaseOrder) *entity.PurchaseOrder {
+	clone := *po
+	clone.Lines = make([]*entity.PurchaseOrderLine, len(po.Lines))
+	for i, line := range po.Lines {
+		line := *line
+		clone.Lines[i] = &line
+	}
+	return &clone
+}
+
+func (s *Store) CreatePurchaseOrder(_ context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[po.ID] = *clonePurchaseOrder(po)
+	return clonePurchaseOrder(po), nil
+}
+
+func (s *Store) GetPurchaseOrder(_ context.Context, id string) (*entity.PurchaseOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	po, ok := s.orders[id]
+	if !ok {
+		return nil, entity.ErrPurchaseOrderNotFound
+	}
+	return clonePurchaseOrder(&po), nil
+}
+
+func (s *Store) LockPurchaseOrder(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockPurchaseOrder called outside a transaction")
+	}
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+func (s *Store) LockDraftPurchaseOrder(ctx context.Context, supplierID, warehouseID string) (*entity.PurchaseOrder, error) {
+	if ctx.Value(inTxKey{}) == nil {
+		return nil, errors.New("LockDraftPurchaseOrder called outside a transaction")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var oldest *entity.PurchaseOrder
+	for _, po := range s.orders {
+		if po.SupplierID != supplierID || po.WarehouseID != warehouseID ||
+			po.Status != valueobject.PurchaseOrderStatusDraft.String() {
+			continue
+		}
+		if oldest == nil || po.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = clonePurchaseOrder(&po)
+		}
+	}
+	if oldest == nil {
+		return nil, entity.ErrPurchaseOrderNotFound
+	}
+	return oldest, nil
+}
+
+func (s *Store) HasOutstandingPurchaseOrderLine(_ context.Context, productID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, po := range s.orders {
+		if !valueobject.PurchaseOrderStatus(po.Status).IsOpen() {
+			continue
+		}
+		for _, line := range po.Lines {
+			if line.ProductID == productID && line.OutstandingQty() > 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) ListPurchaseOrders(_ context.Context, status string, limit, offset int) ([]*entity.PurchaseOrder, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entity.PurchaseOrder
+	for _, po := range s.orders {
+		if status == "" || po.Status == status {
+			result = append(result, clonePurchaseOrder(&po))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	total := len(result)
+	if offset >= total {
+		return nil, total, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, nil
+}
+
+func (s *Store) UpdatePurchaseOrder(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	return s.CreatePurchaseOrder(ctx, po)
+}
+
+// NoopPublisher discards inventory events
+// Transactions returns the stock transactions recorded so far
+func (s *Store) Transactions() []entity.StockTransaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.StockTransaction(nil), s.transactions...)
+}
+
+// Transfers returns the stock transfers recorded so far
+func (s *Store) Transfers() []entity.StockTransfer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.StockTransfer(nil), s.transfers...)
+}
+
+// NoopPublisher is an EventPublisherService that discards every event
+type NoopPublisher struct{}
+
+func (NoopPublisher) PublishStockUpdated(context.Context, *entity.InventoryItem) error { return nil }
+func (NoopPublisher) PublishStockReserved(context.Context, *entity.InventoryReservation) error {
+	return nil
+}
+func (NoopPublisher) PublishStockReservationFailed(context.Context, string, string, string) error {
+	return nil
+}
+func (NoopPublisher) PublishStockReleased(context.Context, *entity.InventoryReservation) error {
+	return nil
+}
+func (NoopPublisher) PublishStockDeducted(context.Context, *entity.StockTransaction) error {
+	return nil
+}
+func (NoopPublisher) PublishStockLow(context.Context, *entity.InventoryItem) error { return nil }
+func (NoopPublisher) PublishReservationExpired(context.Context, string, []*entity.InventoryReservation) error {
+	return nil
+}
+func (NoopPublisher) PublishStockBackordered(context.Context, *entity.InventoryReservation, string) error {
+	return nil
+}
+func (NoopPublisher) PublishBackorderAllocated(context.Context, *entity.InventoryReservation, int) error {
+	return nil
+}
+func (NoopPublisher) Close() error { return nil }
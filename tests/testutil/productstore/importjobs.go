@@ -0,0 +1,87 @@
+package productstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// ImportJobs is an in-memory ImportJobRepository
+type ImportJobs struct {
+	mu        sync.Mutex
+	jobs      map[string]entity.ImportJob
+	rowErrors []entity.ImportRowError
+}
+
+// NewImportJobs creates an empty ImportJobs
+func NewImportJobs() *ImportJobs {
+	return &ImportJobs{jobs: map[string]entity.ImportJob{}}
+}
+
+func (r *ImportJobs) Create(_ context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.CreatedAt = time.Now()
+	r.jobs[job.ID] = job
+	return &job, nil
+}
+
+func (r *ImportJobs) GetByID(_ context.Context, id string) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, entity.ErrImportJobNotFound
+	}
+	return &job, nil
+}
+
+func (r *ImportJobs) Update(_ context.Context, job entity.ImportJob) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.jobs[job.ID]
+	if !ok {
+		return nil, entity.ErrImportJobNotFound
+	}
+	job.Format, job.Owner, job.CreatedAt = existing.Format, existing.Owner, existing.CreatedAt
+	r.jobs[job.ID] = job
+	return &job, nil
+}
+
+func (r *ImportJobs) AddErrors(_ context.Context, rowErrors []entity.ImportRowError) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rowErrors = append(r.rowErrors, rowErrors...)
+	return nil
+}
+
+func (r *ImportJobs) ListErrors(_ context.Context, jobID string) ([]entity.ImportRowError, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rowErrors []entity.ImportRowError
+	for _, rowError := range r.rowErrors {
+		if rowError.JobID == jobID {
+			rowErrors = append(rowErrors, rowError)
+		}
+	}
+	sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Line < rowErrors[j].Line })
+	return rowErrors, nil
+}
+
+func (r *ImportJobs) FailUnfinished(_ context.Context, owner, message string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := 0
+	for id, job := range r.jobs {
+		if job.Owner == owner && !job.IsFinished() {
+			now := time.Now()
+			job.Status, job.Error, job.FinishedAt = entity.ImportFailed, message, &now
+			r.jobs[id] = job
+			failed++
+		}
+	}
+	return failed, nil
+}
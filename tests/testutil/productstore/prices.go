@@ -0,0 +1,90 @@
+package productstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+)
+
+// Prices returns a PriceRepository backed by the store; price changes roll back with its transactions.
+// Methods it does not implement panic through the embedded nil PriceRepository.
+func (s *Store) Prices() *Prices {
+	return &Prices{s: s}
+}
+
+// Prices is the PriceRepository view of a Store
+type Prices struct {
+	repository.PriceRepository
+	s *Store
+}
+
+func (p *Prices) AddChange(_ context.Context, change entity.PriceChange) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	p.s.prices = append(p.s.prices, change)
+	return nil
+}
+
+// Changes returns the recorded price history of a product, oldest first
+func (p *Prices) Changes(productID string) []entity.PriceChange {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	var changes []entity.PriceChange
+	for _, change := range p.s.prices {
+		if change.ProductID == productID {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (p *Prices) CreateScheduled(_ context.Context, scheduled entity.ScheduledPrice) (*entity.ScheduledPrice, error) {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	scheduled.CreatedAt = time.Now()
+	p.s.scheduled[scheduled.ID] = scheduled
+	return &scheduled, nil
+}
+
+func (p *Prices) GetScheduled(_ context.Context, id string) (*entity.ScheduledPrice, error) {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	scheduled, ok := p.s.scheduled[id]
+	if !ok {
+		return nil, entity.ErrScheduledPriceNotFound
+	}
+	return &scheduled, nil
+}
+
+func (p *Prices) ListDueScheduled(_ context.Context, now time.Time, limit int) ([]entity.ScheduledPrice, error) {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	var due []entity.ScheduledPrice
+	for _, scheduled := range p.s.scheduled {
+		if scheduled.IsPending() && !scheduled.EffectiveAt.After(now) {
+			due = append(due, scheduled)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].EffectiveAt.Equal(due[j].EffectiveAt) {
+			return due[i].EffectiveAt.Before(due[j].EffectiveAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due[:min(limit, len(due))], nil
+}
+
+func (p *Prices) MarkScheduledApplied(_ context.Context, id string, appliedAt time.Time) (bool, error) {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	scheduled, ok := p.s.scheduled[id]
+	if !ok || !scheduled.IsPending() {
+		return false, nil
+	}
+	scheduled.AppliedAt = &appliedAt
+	p.s.scheduled[id] = scheduled
+	return true, nil
+}
@@ -0,0 +1,614 @@
+// Package productstore provides in-memory product service repositories for tests.
+package productstore
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/repository"
+)
+
+// Store is an in-memory ProductRepository, VariantRepository, CategoryRepository, MediaRepository,
+// PriceRepository, OutboxRepository and Transactor.
+// Transactions run one at a time and restore the previous state when they fail.
+// Product methods it does not implement panic through the embedded nil ProductRepository.
+type Store struct {
+	repository.ProductRepository
+
+	txMu       sync.Mutex
+	mu         sync.Mutex
+	products   map[string]entity.Product
+	variants   map[string]entity.ProductVariant
+	options    map[string][]entity.ProductOption
+	categories map[string]entity.Category
+	media      map[string]entity.ProductMedia
+	outbox     []entity.OutboxEvent
+	prices     []entity.PriceChange
+	scheduled  map[string]entity.ScheduledPrice
+
+	// OutboxErr, when set, is returned by Add
+	OutboxErr error
+	// UpdateErrs holds errors returned by Update, keyed by product ID
+	UpdateErrs map[string]error
+}
+
+type inTxKey struct{}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		products:   map[string]entity.Product{},
+		variants:   map[string]entity.ProductVariant{},
+		options:    map[string][]entity.ProductOption{},
+		categories: map[string]entity.Category{},
+		media:      map[string]entity.ProductMedia{},
+		scheduled:  map[string]entity.ScheduledPrice{},
+	}
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	clone := make(map[K]V, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+func (s *Store) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(inTxKey{}) != nil {
+		return fn(ctx)
+	}
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	products, variants, options, outbox := cloneMap(s.products), cloneMap(s.variants), cloneMap(s.options), len(s.outbox)
+	categories, media, prices, scheduled := cloneMap(s.categories), cloneMap(s.media), len(s.prices), cloneMap(s.scheduled)
+	s.mu.Unlock()
+
+	err := fn(context.WithValue(ctx, inTxKey{}, true))
+	if err != nil {
+		s.mu.Lock()
+		s.products, s.variants, s.options, s.outbox = products, variants, options, s.outbox[:outbox]
+		s.categories, s.media, s.prices, s.scheduled = categories, media, s.prices[:prices], scheduled
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// Outbox returns the events added so far, published or not
+func (s *Store) Outbox() []entity.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.OutboxEvent(nil), s.outbox...)
+}
+
+func (s *Store) Create(_ context.Context, product entity.Product) (*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.products {
+		if existing.SKU == product.SKU {
+			return nil, entity.ErrProductSKUExists
+		}
+	}
+	now := time.Now()
+	product.CreatedAt, product.UpdatedAt = now, now
+	s.products[product.ID] = product
+	return &product, nil
+}
+
+func (s *Store) GetByID(_ context.Context, id string) (*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	product, ok := s.products[id]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	return &product, nil
+}
+
+// LockByID is GetByID; transactions already run one at a time
+func (s *Store) LockByID(ctx context.Context, id string) (*entity.Product, error) {
+	return s.GetByID(ctx, id)
+}
+
+func (s *Store) GetBySKU(_ context.Context, sku string) (*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, product := range s.products {
+		if product.SKU == sku {
+			return &product, nil
+		}
+	}
+	return nil, entity.ErrProductNotFound
+}
+
+func (s *Store) GetBySKUs(_ context.Context, skus []string) ([]*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*entity.Product
+	for _, product := range s.products {
+		if slices.Contains(skus, product.SKU) {
+			found = append(found, &product)
+		}
+	}
+	return found, nil
+}
+
+func (s *Store) UpsertBySKU(_ context.Context, products []entity.Product) ([]*entity.Product, []*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var created, updated []*entity.Product
+	for _, product := range products {
+		now := time.Now()
+		product.CreatedAt, product.UpdatedAt = now, now
+		for _, existing := range s.products {
+			if existing.SKU == product.SKU {
+				product.ID, product.CreatedAt = existing.ID, existing.CreatedAt
+			}
+		}
+		if _, ok := s.products[product.ID]; ok {
+			updated = append(updated, &product)
+		} else {
+			created = append(created, &product)
+		}
+		s.products[product.ID] = product
+	}
+	return created, updated, nil
+}
+
+func (s *Store) ListDueForPublish(_ context.Context, now time.Time, limit int) ([]*entity.Product, error) {
+	return s.listDue(func(p *entity.Product) *time.Time { return p.PublishAt }, "review", now, limit), nil
+}
+
+func (s *Store) ListDueForUnpublish(_ context.Context, now time.Time, limit int) ([]*entity.Product, error) {
+	return s.listDue(func(p *entity.Product) *time.Time { return p.UnpublishAt }, "published", now, limit), nil
+}
+
+func (s *Store) listDue(scheduledAt func(*entity.Product) *time.Time, status string, now time.Time, limit int) []*entity.Product {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []*entity.Product
+	for _, product := range s.products {
+		if at := scheduledAt(&product); product.Status == status && at != nil && !at.After(now) {
+			due = append(due, &product)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if a, b := scheduledAt(due[i]), scheduledAt(due[j]); !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due[:min(limit, len(due))]
+}
+
+func (s *Store) Update(_ context.Context, product entity.Product) (*entity.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.products[product.ID]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	if err := s.UpdateErrs[product.ID]; err != nil {
+		return nil, err
+	}
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = time.Now()
+	s.products[product.ID] = product
+	return &product, nil
+}
+
+func (s *Store) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.products[id]; !ok {
+		return entity.ErrProductNotFound
+	}
+	delete(s.products, id)
+	return nil
+}
+
+// Variants returns a VariantRepository backed by the store; its methods share names with
+// ProductRepository, so it is a separate view
+func (s *Store) Variants() *Variants {
+	return &Variants{s: s}
+}
+
+// Variants is the VariantRepository view of a Store
+type Variants struct {
+	s *Store
+}
+
+func (v *Variants) ListOptions(_ context.Context, productID string) ([]entity.ProductOption, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	return append([]entity.ProductOption(nil), v.s.options[productID]...), nil
+}
+
+func (v *Variants) ReplaceOptions(_ context.Context, productID string, options []entity.ProductOption) ([]entity.ProductOption, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	for i := range options {
+		options[i].ProductID = productID
+	}
+	v.s.options[productID] = append([]entity.ProductOption(nil), options...)
+	return options, nil
+}
+
+func (v *Variants) Create(_ context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	for _, existing := range v.s.variants {
+		if existing.SKU == variant.SKU {
+			return nil, entity.ErrProductSKUExists
+		}
+	}
+	now := time.Now()
+	variant.CreatedAt, variant.UpdatedAt = now, now
+	v.s.variants[variant.ID] = variant
+	return &variant, nil
+}
+
+func (v *Variants) GetByID(_ context.Context, id string) (*entity.ProductVariant, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	variant, ok := v.s.variants[id]
+	if !ok {
+		return nil, entity.ErrVariantNotFound
+	}
+	return &variant, nil
+}
+
+func (v *Variants) GetBySKU(_ context.Context, sku string) (*entity.ProductVariant, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	for _, variant := range v.s.variants {
+		if variant.SKU == sku {
+			return &variant, nil
+		}
+	}
+	return nil, entity.ErrVariantNotFound
+}
+
+func (v *Variants) ListByProductID(_ context.Context, productID string) ([]entity.ProductVariant, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	var variants []entity.ProductVariant
+	for _, variant := range v.s.variants {
+		if variant.ProductID == productID {
+			variants = append(variants, variant)
+		}
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].CreatedAt.Before(variants[j].CreatedAt) })
+	return variants, nil
+}
+
+func (v *Variants) Update(_ context.Context, variant entity.ProductVariant) (*entity.ProductVariant, error) {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	existing, ok := v.s.variants[variant.ID]
+	if !ok {
+		return nil, entity.ErrVariantNotFound
+	}
+	variant.CreatedAt = existing.CreatedAt
+	variant.UpdatedAt = time.Now()
+	v.s.variants[variant.ID] = variant
+	return &variant, nil
+}
+
+func (v *Variants) Delete(_ context.Context, id string) error {
+	v.s.mu.Lock()
+	defer v.s.mu.Unlock()
+	if _, ok := v.s.variants[id]; !ok {
+		return entity.ErrVariantNotFound
+	}
+	delete(v.s.variants, id)
+	return nil
+}
+
+// Categories returns a CategoryRepository backed by the store. Transactions already run one at a time,
+// so the lock methods only read.
+func (s *Store) Categories() *Categories {
+	return &Categories{s: s}
+}
+
+// Categories is the CategoryRepository view of a Store
+type Categories struct {
+	s *Store
+}
+
+// sortByLevel orders categories by level, then name, like the GORM repository
+func sortByLevel(categories []*entity.Category) []*entity.Category {
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Level != categories[j].Level {
+			return categories[i].Level < categories[j].Level
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	return categories
+}
+
+func (c *Categories) Create(_ context.Context, category entity.Category) (*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	for _, existing := range c.s.categories {
+		if existing.Name == category.Name {
+			return nil, entity.ErrCategoryAlreadyExists
+		}
+	}
+	now := time.Now()
+	category.CreatedAt, category.UpdatedAt = now, now
+	c.s.categories[category.ID] = category
+	return &category, nil
+}
+
+func (c *Categories) GetByID(_ context.Context, id string) (*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	category, ok := c.s.categories[id]
+	if !ok {
+		return nil, entity.ErrCategoryNotFound
+	}
+	return &category, nil
+}
+
+func (c *Categories) GetByName(_ context.Context, name string) (*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	for _, category := range c.s.categories {
+		if category.Name == name {
+			return &category, nil
+		}
+	}
+	return nil, entity.ErrCategoryNotFound
+}
+
+func (c *Categories) List(_ context.Context, offset, limit int) ([]*entity.Category, int, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var categories []*entity.Category
+	for _, category := range c.s.categories {
+		categories = append(categories, &category)
+	}
+	sortByLevel(categories)
+	total := len(categories)
+	categories = categories[min(offset, total):min(offset+limit, total)]
+	return categories, total, nil
+}
+
+func (c *Categories) GetChildren(_ context.Context, parentID string) ([]*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var children []*entity.Category
+	for _, category := range c.s.categories {
+		if category.ParentID != nil && *category.ParentID == parentID {
+			children = append(children, &category)
+		}
+	}
+	return sortByLevel(children), nil
+}
+
+func (c *Categories) GetSubtree(_ context.Context, id string) ([]*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	root, ok := c.s.categories[id]
+	if !ok {
+		return nil, entity.ErrCategoryNotFound
+	}
+	var subtree []*entity.Category
+	for _, category := range c.s.categories {
+		if strings.HasPrefix(category.Path, root.Path) {
+			subtree = append(subtree, &category)
+		}
+	}
+	return sortByLevel(subtree), nil
+}
+
+func (c *Categories) LockByID(ctx context.Context, id string) (*entity.Category, error) {
+	return c.GetByID(ctx, id)
+}
+
+func (c *Categories) LockSubtree(ctx context.Context, id string) ([]*entity.Category, error) {
+	return c.GetSubtree(ctx, id)
+}
+
+func (c *Categories) GetByIDs(_ context.Context, ids []string) ([]*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var categories []*entity.Category
+	for _, id := range ids {
+		if category, ok := c.s.categories[id]; ok {
+			categories = append(categories, &category)
+		}
+	}
+	return sortByLevel(categories), nil
+}
+
+func (c *Categories) Update(_ context.Context, category entity.Category) (*entity.Category, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	existing, ok := c.s.categories[category.ID]
+	if !ok {
+		return nil, entity.ErrCategoryNotFound
+	}
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+	c.s.categories[category.ID] = category
+	return &category, nil
+}
+
+func (c *Categories) MoveSubtree(ctx context.Context, category entity.Category, oldPath string, oldLevel int) (*entity.Category, error) {
+	moved, err := c.Update(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	for id, descendant := range c.s.categories {
+		if id != category.ID && strings.HasPrefix(descendant.Path, oldPath) {
+			descendant.Path = category.Path + strings.TrimPrefix(descendant.Path, oldPath)
+			descendant.Level += category.Level - oldLevel
+			c.s.categories[id] = descendant
+		}
+	}
+	return moved, nil
+}
+
+func (c *Categories) RebuildPaths(_ context.Context) error {
+	return nil
+}
+
+func (c *Categories) Delete(_ context.Context, id string) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	if _, ok := c.s.categories[id]; !ok {
+		return entity.ErrCategoryNotFound
+	}
+	delete(c.s.categories, id)
+	return nil
+}
+
+// Media returns a MediaRepository backed by the store
+func (s *Store) Media() *Media {
+	return &Media{s: s}
+}
+
+// Media is the MediaRepository view of a Store
+type Media struct {
+	s *Store
+}
+
+func (m *Media) Create(_ context.Context, media entity.ProductMedia) (*entity.ProductMedia, error) {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	now := time.Now()
+	media.CreatedAt, media.UpdatedAt = now, now
+	m.s.media[media.ID] = media
+	return &media, nil
+}
+
+func (m *Media) GetByID(_ context.Context, id string) (*entity.ProductMedia, error) {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	media, ok := m.s.media[id]
+	if !ok {
+		return nil, entity.ErrMediaNotFound
+	}
+	return &media, nil
+}
+
+func (m *Media) ListByProductID(_ context.Context, productID string) ([]entity.ProductMedia, error) {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	var list []entity.ProductMedia
+	for _, media := range m.s.media {
+		if media.ProductID == productID {
+			list = append(list, media)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Position < list[j].Position })
+	return list, nil
+}
+
+func (m *Media) Update(_ context.Context, media entity.ProductMedia) (*entity.ProductMedia, error) {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	existing, ok := m.s.media[media.ID]
+	if !ok {
+		return nil, entity.ErrMediaNotFound
+	}
+	media.CreatedAt = existing.CreatedAt
+	media.UpdatedAt = time.Now()
+	m.s.media[media.ID] = media
+	return &media, nil
+}
+
+func (m *Media) SetPositions(_ context.Context, productID string, positions map[string]int) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	for id, position := range positions {
+		if media, ok := m.s.media[id]; ok && media.ProductID == productID {
+			media.Position = position
+			m.s.media[id] = media
+		}
+	}
+	return nil
+}
+
+func (m *Media) Delete(_ context.Context, id string) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	if _, ok := m.s.media[id]; !ok {
+		return entity.ErrMediaNotFound
+	}
+	delete(m.s.media, id)
+	return nil
+}
+
+func (m *Media) DeleteByProductID(_ context.Context, productID string) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+	for id, media := range m.s.media {
+		if media.ProductID == productID {
+			delete(m.s.media, id)
+		}
+	}
+	return nil
+}
+
+func (s *Store) Add(_ context.Context, events []entity.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.OutboxErr != nil {
+		return s.OutboxErr
+	}
+	s.outbox = append(s.outbox, events...)
+	return nil
+}
+
+func (s *Store) ListPending(_ context.Context, limit int) ([]entity.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []entity.OutboxEvent
+	for _, event := range s.outbox {
+		if event.PublishedAt == nil && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (s *Store) MarkPublished(_ context.Context, ids []string, publishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.outbox {
+		if slices.Contains(ids, s.outbox[i].ID) {
+			s.outbox[i].PublishedAt = &publishedAt
+		}
+	}
+	return nil
+}
+
+func (s *Store) MarkFailed(_ context.Context, ids []string, reason string) error {
+	return nil
+}
+
+func (s *Store) DeletePublishedBefore(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.outbox[:0]
+	for _, event := range s.outbox {
+		if event.PublishedAt == nil || !event.PublishedAt.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+	deleted := len(s.outbox) - len(kept)
+	s.outbox = kept
+	return deleted, nil
+}
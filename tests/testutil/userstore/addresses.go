@@ -0,0 +1,132 @@
+package userstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Addresses is an in-memory AddressRepository
+type Addresses struct {
+	mu        sync.Mutex
+	addresses []entity.Address // in creation order
+}
+
+// NewAddresses creates an empty Addresses
+func NewAddresses() *Addresses {
+	return &Addresses{}
+}
+
+// Create stores a new address
+func (r *Addresses) Create(ctx context.Context, address *entity.Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addresses = append(r.addresses, *address)
+	return nil
+}
+
+// GetByID retrieves an address owned by a user, or nil if none exists
+func (r *Addresses) GetByID(ctx context.Context, userID, id string) (*entity.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.index(userID, id); i >= 0 {
+		address := r.addresses[i]
+		return &address, nil
+	}
+	return nil, nil
+}
+
+// ListByUserID retrieves all addresses of a user, defaults first
+func (r *Addresses) ListByUserID(ctx context.Context, userID string) ([]*entity.Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var addresses []*entity.Address
+	for _, address := range r.addresses {
+		if address.UserID == userID {
+			address := address
+			addresses = append(addresses, &address)
+		}
+	}
+	sort.SliceStable(addresses, func(i, j int) bool {
+		if addresses[i].IsDefaultShipping != addresses[j].IsDefaultShipping {
+			return addresses[i].IsDefaultShipping
+		}
+		return addresses[i].IsDefaultBilling && !addresses[j].IsDefaultBilling
+	})
+	return addresses, nil
+}
+
+// Update updates an existing address
+func (r *Addresses) Update(ctx context.Context, address *entity.Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(address.UserID, address.ID)
+	if i < 0 {
+		return entity.ErrAddressNotFound
+	}
+	r.addresses[i] = *address
+	return nil
+}
+
+// Delete removes an address owned by a user
+func (r *Addresses) Delete(ctx context.Context, userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.index(userID, id); i >= 0 {
+		r.addresses = append(r.addresses[:i], r.addresses[i+1:]...)
+	}
+	return nil
+}
+
+// DeleteByUserID removes all addresses of a user
+func (r *Addresses) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.addresses[:0]
+	for _, address := range r.addresses {
+		if address.UserID != userID {
+			kept = append(kept, address)
+		}
+	}
+	r.addresses = kept
+	return nil
+}
+
+// SetDefault marks an address as the user's default of the given type and clears the previous default
+func (r *Addresses) SetDefault(ctx context.Context, userID, id string, addressType entity.AddressType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index(userID, id) < 0 {
+		return entity.ErrAddressNotFound
+	}
+	for i := range r.addresses {
+		address := &r.addresses[i]
+		if address.UserID != userID {
+			continue
+		}
+		if addressType == entity.BillingAddress {
+			address.IsDefaultBilling = address.ID == id
+		} else {
+			address.IsDefaultShipping = address.ID == id
+		}
+	}
+	return nil
+}
+
+func (r *Addresses) index(userID, id string) int {
+	for i, address := range r.addresses {
+		if address.UserID == userID && address.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,94 @@
+package userstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Erasures is an in-memory ErasureRequestRepository
+type Erasures struct {
+	mu       sync.Mutex
+	requests []entity.ErasureRequest
+}
+
+// NewErasures creates an empty Erasures
+func NewErasures() *Erasures {
+	return &Erasures{}
+}
+
+// Create stores a new erasure request
+func (r *Erasures) Create(ctx context.Context, request *entity.ErasureRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, clone(*request))
+	return nil
+}
+
+// GetByID retrieves an erasure request, or nil if none exists
+func (r *Erasures) GetByID(ctx context.Context, id string) (*entity.ErasureRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, request := range r.requests {
+		if request.ID == id {
+			request = clone(request)
+			return &request, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetLatestByUserID retrieves the most recent erasure request of a user, or nil if none exists
+func (r *Erasures) GetLatestByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.requests) - 1; i >= 0; i-- {
+		if r.requests[i].UserID == userID {
+			request := clone(r.requests[i])
+			return &request, nil
+		}
+	}
+	return nil, nil
+}
+
+// Update saves the progress of an erasure request
+func (r *Erasures) Update(ctx context.Context, request *entity.ErasureRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.requests {
+		if r.requests[i].ID == request.ID {
+			r.requests[i] = clone(*request)
+			return nil
+		}
+	}
+	return nil
+}
+
+// UpdateByID applies fn to an erasure request and saves it when fn reports a change
+func (r *Erasures) UpdateByID(ctx context.Context, id string, fn func(request *entity.ErasureRequest) bool) (*entity.ErasureRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.requests {
+		if r.requests[i].ID == id {
+			request := clone(r.requests[i])
+			if fn(&request) {
+				r.requests[i] = clone(request)
+			}
+			return &request, nil
+		}
+	}
+	return nil, nil
+}
+
+// clone copies the service lists so callers cannot modify stored requests
+func clone(request entity.ErasureRequest) entity.ErasureRequest {
+	request.PendingServices = append([]string(nil), request.PendingServices...)
+	request.CompletedServices = append([]string(nil), request.CompletedServices...)
+	return request
+}
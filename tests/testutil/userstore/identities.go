@@ -0,0 +1,94 @@
+package userstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Identities is an in-memory IdentityRepository
+type Identities struct {
+	mu         sync.Mutex
+	identities []entity.Identity
+	states     map[string]entity.OIDCLoginState
+}
+
+// NewIdentities creates an empty Identities
+func NewIdentities() *Identities {
+	return &Identities{states: make(map[string]entity.OIDCLoginState)}
+}
+
+// Create links a new external identity to a user
+func (r *Identities) Create(ctx context.Context, identity *entity.Identity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+// GetByProviderSubject retrieves an identity by provider and subject, or nil if none exists
+func (r *Identities) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListByUserID retrieves all identities linked to a user
+func (r *Identities) ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var identities []*entity.Identity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identity := identity
+			identities = append(identities, &identity)
+		}
+	}
+	return identities, nil
+}
+
+// DeleteByUserID unlinks all identities of a user
+func (r *Identities) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.identities[:0]
+	for _, identity := range r.identities {
+		if identity.UserID != userID {
+			kept = append(kept, identity)
+		}
+	}
+	r.identities = kept
+	return nil
+}
+
+// CreateLoginState stores the state of an in-flight login
+func (r *Identities) CreateLoginState(ctx context.Context, state *entity.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state.State] = *state
+	return nil
+}
+
+// ConsumeLoginState deletes and returns a login state, or nil if none exists
+func (r *Identities) ConsumeLoginState(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loginState, ok := r.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.states, state)
+	return &loginState, nil
+}
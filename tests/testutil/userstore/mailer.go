@@ -0,0 +1,27 @@
+package userstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+)
+
+// Mailer records the messages sent through it
+type Mailer struct {
+	mu   sync.Mutex
+	Sent []interfaces.MailMessage
+	Err  error // returned by Send instead of sending when set
+}
+
+// Send records a message
+func (m *Mailer) Send(ctx context.Context, msg interfaces.MailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Sent = append(m.Sent, msg)
+	return nil
+}
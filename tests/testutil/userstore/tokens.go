@@ -0,0 +1,122 @@
+package userstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Tokens is an in-memory TokenRepository
+type Tokens struct {
+	mu     sync.Mutex
+	tokens map[string]entity.Token
+}
+
+// NewTokens creates an empty Tokens
+func NewTokens() *Tokens {
+	return &Tokens{tokens: make(map[string]entity.Token)}
+}
+
+// Create stores a new token
+func (r *Tokens) Create(ctx context.Context, token *entity.Token) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+// FindByToken retrieves a token by value, or nil if none exists
+func (r *Tokens) FindByToken(ctx context.Context, value string) (*entity.Token, error) {
+	return r.find(func(token entity.Token) bool { return token.Token == value }), nil
+}
+
+// FindByTokenAndType retrieves a token by value and type, or nil if none exists
+func (r *Tokens) FindByTokenAndType(ctx context.Context, value string, tokenType entity.TokenType) (*entity.Token, error) {
+	return r.find(func(token entity.Token) bool { return token.Token == value && token.Type == tokenType }), nil
+}
+
+// FindByUserID retrieves all tokens issued to a user
+func (r *Tokens) FindByUserID(ctx context.Context, userID string) ([]*entity.Token, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tokens []*entity.Token
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			token := token
+			tokens = append(tokens, &token)
+		}
+	}
+	return tokens, nil
+}
+
+// Delete removes a token by ID
+func (r *Tokens) Delete(ctx context.Context, tokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tokens, tokenID)
+	return nil
+}
+
+// DeleteByUserID removes all tokens of a user
+func (r *Tokens) DeleteByUserID(ctx context.Context, userID string) error {
+	return r.deleteWhere(func(token entity.Token) bool { return token.UserID == userID })
+}
+
+// DeleteByUserIDAndType removes all tokens of the given type for a user
+func (r *Tokens) DeleteByUserIDAndType(ctx context.Context, userID string, tokenType entity.TokenType) error {
+	return r.deleteWhere(func(token entity.Token) bool { return token.UserID == userID && token.Type == tokenType })
+}
+
+// Update replaces the token with the ID of by
+func (r *Tokens) Update(ctx context.Context, by entity.Token, token entity.Token) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.ID = by.ID
+	r.tokens[by.ID] = token
+	return nil
+}
+
+// MarkUsed marks a single-use token as consumed
+func (r *Tokens) MarkUsed(ctx context.Context, tokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenID]
+	if !ok {
+		return entity.ErrInvalidToken
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	r.tokens[tokenID] = token
+	return nil
+}
+
+func (r *Tokens) find(match func(entity.Token) bool) *entity.Token {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if match(token) {
+			return &token
+		}
+	}
+	return nil
+}
+
+func (r *Tokens) deleteWhere(match func(entity.Token) bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if match(token) {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package userstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+// Users is an in-memory UserRepository. Deleted users are removed rather than soft-deleted.
+type Users struct {
+	mu    sync.Mutex
+	users map[string]entity.User
+}
+
+// NewUsers creates an empty Users
+func NewUsers() *Users {
+	return &Users{users: make(map[string]entity.User)}
+}
+
+// Create stores a new user
+func (r *Users) Create(ctx context.Context, user entity.User) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return nil, entity.ErrUserAlreadyExists
+		}
+	}
+	r.users[user.ID] = user
+	return &user, nil
+}
+
+// GetByID retrieves a user by ID
+func (r *Users) GetByID(ctx context.Context, id string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, entity.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+// GetByEmail retrieves a user by email
+func (r *Users) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return &user, nil
+		}
+	}
+	return nil, entity.ErrUserNotFound
+}
+
+// List retrieves users ordered by ID; filters are ignored
+func (r *Users) List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*entity.User, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := make([]*entity.User, 0, len(r.users))
+	for _, user := range r.users {
+		user := user
+		users = append(users, &user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	total := len(users)
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return users[offset:end], total, nil
+}
+
+// Update replaces a stored user
+func (r *Users) Update(ctx context.Context, user entity.User) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return nil, entity.ErrUserNotFound
+	}
+	r.users[user.ID] = user
+	return &user, nil
+}
+
+// SpendRecoveryCode removes a recovery code unless it has been spent already
+func (r *Users) SpendRecoveryCode(ctx context.Context, id, hash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return false, entity.ErrUserNotFound
+	}
+	for i, code := range user.RecoveryCodes {
+		if code == hash {
+			user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+			r.users[id] = user
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Delete removes a user by ID
+func (r *Users) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, id)
+	return nil
+}
+
+// ClaimTwoFactorCounter records the time step of an accepted TOTP code unless it has been used already
+func (r *Users) ClaimTwoFactorCounter(ctx context.Context, id string, counter int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return false, entity.ErrUserNotFound
+	}
+	if user.TwoFactorCounter >= counter {
+		return false, nil
+	}
+	user.TwoFactorCounter = counter
+	r.users[id] = user
+	return true, nil
+}
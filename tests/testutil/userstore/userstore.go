@@ -0,0 +1,104 @@
+// Package userstore provides in-memory user service repositories and an event recorder for tests.
+package userstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+)
+
+type attemptKey struct {
+	scope entity.LoginAttemptScope
+	key   string
+}
+
+// LoginAttempts is an in-memory LoginAttemptRepository
+type LoginAttempts struct {
+	mu       sync.Mutex
+	attempts map[attemptKey]entity.LoginAttempt
+}
+
+// NewLoginAttempts creates an empty LoginAttempts
+func NewLoginAttempts() *LoginAttempts {
+	return &LoginAttempts{attempts: make(map[attemptKey]entity.LoginAttempt)}
+}
+
+// Get retrieves the counter for a scope and key, or nil if none exists
+func (r *LoginAttempts) Get(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[attemptKey{scope, key}]
+	if !ok {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// Update applies fn to the counter for a scope and key, creating it if none exists
+func (r *LoginAttempts) Update(ctx context.Context, scope entity.LoginAttemptScope, key string, fn func(attempt *entity.LoginAttempt)) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[attemptKey{scope, key}]
+	if !ok {
+		attempt = entity.LoginAttempt{Scope: scope, Key: key}
+	}
+	fn(&attempt)
+	r.attempts[attemptKey{scope, key}] = attempt
+	return &attempt, nil
+}
+
+// Delete removes the counter for a scope and key
+func (r *LoginAttempts) Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, attemptKey{scope, key})
+	return nil
+}
+
+// Events records the user events published through it
+type Events struct {
+	mu                sync.Mutex
+	LoginFailures     int
+	Locks             []entity.LoginAttempt
+	ErasureRequests   []entity.ErasureRequest
+	PublishErasureErr error
+}
+
+// PublishLoginFailed records a failed login
+func (e *Events) PublishLoginFailed(ctx context.Context, email, ipAddress, reason string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.LoginFailures++
+	return nil
+}
+
+// PublishUserLocked records a lockout
+func (e *Events) PublishUserLocked(ctx context.Context, attempt *entity.LoginAttempt, ipAddress string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.Locks = append(e.Locks, *attempt)
+	return nil
+}
+
+// PublishErasureRequested records an erasure request, failing with PublishErasureErr when set
+func (e *Events) PublishErasureRequested(ctx context.Context, request *entity.ErasureRequest) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PublishErasureErr != nil {
+		return e.PublishErasureErr
+	}
+	e.ErasureRequests = append(e.ErasureRequests, *request)
+	return nil
+}
+
+// Close does nothing
+func (e *Events) Close() error {
+	return nil
+}
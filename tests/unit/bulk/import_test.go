@@ -0,0 +1,140 @@
+package bulk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/search"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+type importFixture struct {
+	store *productstore.Store
+	jobs  *productstore.ImportJobs
+	bulk  usecase.BulkProductUsecase
+}
+
+// newImportFixture returns a bulk usecase over a store holding category c-1 and product TEE priced 10
+func newImportFixture(t *testing.T, options usecase.BulkOptions) *importFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := productstore.NewStore()
+	if _, err := store.Categories().Create(ctx, entity.Category{ID: "c-1", Name: "Shirts"}); err != nil {
+		t.Fatalf("Create category: %v", err)
+	}
+	if _, err := store.Create(ctx, entity.Product{ID: "p-1", SKU: "TEE", Name: "Tee", Price: 10, CategoryID: "c-1", Status: "draft"}); err != nil {
+		t.Fatalf("Create product: %v", err)
+	}
+
+	jobs := productstore.NewImportJobs()
+	bulk := usecase.NewBulkProductUsecase(jobs, store, store.Categories(), store.Variants(), store, store.Prices(), store, search.NewMemoryIndex(nil), options)
+	return &importFixture{store: store, jobs: jobs, bulk: bulk}
+}
+
+// run imports the rows and waits for the job to finish
+func (f *importFixture) run(t *testing.T, rows ...entity.ProductImportRow) *entity.ImportJob {
+	t.Helper()
+	ctx := context.Background()
+	job, err := f.bulk.StartImport(ctx, entity.FormatCSV, rows)
+	if err != nil {
+		t.Fatalf("StartImport: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if job, err = f.bulk.GetImportJob(ctx, job.ID); err == nil && job.IsFinished() {
+			return job
+		}
+	}
+	t.Fatalf("import did not finish: %+v", job)
+	return nil
+}
+
+func row(line int, sku string, price float64) entity.ProductImportRow {
+	return entity.ProductImportRow{Line: line, Product: entity.Product{SKU: sku, Name: sku, Price: price, CategoryID: "c-1"}}
+}
+
+func TestImportUpsertsBySKUInBatches(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t, usecase.BulkOptions{BatchSize: 2})
+
+	job := f.run(t, row(2, "TEE", 12), row(3, "CAP", 5), row(4, "SOCK", 3), row(5, "HAT", 7))
+	if job.Status != entity.ImportCompleted || job.ProcessedRows != 4 || job.CreatedCount != 3 || job.UpdatedCount != 1 || job.FailedCount != 0 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	tee, err := f.store.GetBySKU(ctx, "TEE")
+	if err != nil || tee.ID != "p-1" || tee.Price != 12 {
+		t.Fatalf("TEE = %+v, %v; want p-1 updated to 12", tee, err)
+	}
+	if changes := f.store.Prices().Changes("p-1"); len(changes) != 1 || changes[0].OldPrice != 10 || changes[0].NewPrice != 12 {
+		t.Fatalf("price history of TEE = %+v", changes)
+	}
+	for _, sku := range []string{"CAP", "SOCK", "HAT"} {
+		product, err := f.store.GetBySKU(ctx, sku)
+		if err != nil || product.Status != "draft" {
+			t.Fatalf("%s = %+v, %v; want a new draft", sku, product, err)
+		}
+	}
+	if events := f.store.Outbox(); len(events) < 4 {
+		t.Fatalf("got %d events for 4 saved products", len(events))
+	}
+}
+
+func TestImportReportsRejectedRows(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t, usecase.BulkOptions{BatchSize: 2})
+
+	// The repeated SKU lands in a later batch than its first use
+	missingCategory := row(5, "BELT", 9)
+	missingCategory.Product.CategoryID = "c-404"
+	job := f.run(t, row(2, "CAP", 5), row(3, "SOCK", 0), row(4, "CAP", 6), missingCategory)
+	if job.Status != entity.ImportCompleted || job.CreatedCount != 1 || job.FailedCount != 3 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	rowErrors, err := f.bulk.ListImportErrors(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("ListImportErrors: %v", err)
+	}
+	want := []entity.ImportRowError{
+		{JobID: job.ID, Line: 3, SKU: "SOCK", Message: "price must be greater than zero"},
+		{JobID: job.ID, Line: 4, SKU: "CAP", Message: "duplicate sku, first used on line 2"},
+		{JobID: job.ID, Line: 5, SKU: "BELT", Message: `category "c-404" not found`},
+	}
+	if len(rowErrors) != len(want) {
+		t.Fatalf("got errors %+v, want %+v", rowErrors, want)
+	}
+	for i := range want {
+		if rowErrors[i] != want[i] {
+			t.Fatalf("error %d = %+v, want %+v", i, rowErrors[i], want[i])
+		}
+	}
+
+	// The first CAP row wins
+	if product, err := f.store.GetBySKU(ctx, "CAP"); err != nil || product.Price != 5 {
+		t.Fatalf("CAP = %+v, %v; want price 5", product, err)
+	}
+}
+
+func TestFailInterruptedImportsLeavesOtherInstancesAlone(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t, usecase.BulkOptions{Instance: "replica-a"})
+
+	for id, owner := range map[string]string{"own": "replica-a", "other": "replica-b"} {
+		if _, err := f.jobs.Create(ctx, entity.ImportJob{ID: id, Owner: owner, Status: entity.ImportRunning}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if err := f.bulk.FailInterruptedImports(ctx); err != nil {
+		t.Fatalf("FailInterruptedImports: %v", err)
+	}
+
+	if own, _ := f.bulk.GetImportJob(ctx, "own"); own.Status != entity.ImportFailed || own.Error == "" {
+		t.Fatalf("own job = %+v, want failed", own)
+	}
+	if other, _ := f.bulk.GetImportJob(ctx, "other"); other.Status != entity.ImportRunning {
+		t.Fatalf("job of another replica = %+v, want still running", other)
+	}
+}
@@ -0,0 +1,95 @@
+package catalogfile_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/catalogfile"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+func TestReadCSVMapsColumnsByHeader(t *testing.T) {
+	file := "price,SKU,name,category_id,extra\n19.5,TS-100,T-Shirt,cat-1,ignored\nabc,TS-101,Shirt,cat-1,\n"
+	rows, err := catalogfile.ReadCSV(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("ReadCSV returned an error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	first := rows[0]
+	if first.Err != nil || first.Line != 2 || first.Product.SKU != "TS-100" || first.Product.Price != 19.5 || first.Product.CategoryID != "cat-1" {
+		t.Fatalf("first row = %+v", first)
+	}
+	if rows[1].Err == nil || rows[1].Line != 3 {
+		t.Fatalf("second row should carry a price error on line 3, got %+v", rows[1])
+	}
+}
+
+func TestReadCSVRequiresColumns(t *testing.T) {
+	_, err := catalogfile.ReadCSV(strings.NewReader("sku,name\nA,B\n"))
+	if !errors.Is(err, entity.ErrInvalidImportFile) {
+		t.Fatalf("error = %v, want ErrInvalidImportFile", err)
+	}
+}
+
+func TestReadJSONLReportsBadLines(t *testing.T) {
+	file := `{"sku":"A-1","name":"A","price":10,"category_id":"c"}
+
+not json
+{"sku":"B-1","name":"B","price":20,"category_id":"c"}
+`
+	rows, err := catalogfile.ReadJSONL(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("ReadJSONL returned an error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	if rows[1].Err == nil || rows[1].Line != 3 {
+		t.Fatalf("line 3 should be rejected, got %+v", rows[1])
+	}
+	if rows[2].Line != 4 || rows[2].Product.SKU != "B-1" {
+		t.Fatalf("last row = %+v", rows[2])
+	}
+}
+
+func TestExportRoundTrips(t *testing.T) {
+	products := []*entity.Product{
+		{SKU: "A-1", Name: "Mug, large", Description: "Says \"hello\"", Price: 12.25, CategoryID: "c", Status: "active"},
+		{SKU: "B-1", Name: "Plate", Price: 8, CategoryID: "c", Status: "draft", ImageURL: "/media/b.png"},
+	}
+
+	for _, format := range []entity.FileFormat{entity.FormatCSV, entity.FormatJSONL} {
+		var buf bytes.Buffer
+		w, err := catalogfile.NewWriter(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: NewWriter returned an error: %v", format, err)
+		}
+		for _, product := range products {
+			if err := w.Write(product); err != nil {
+				t.Fatalf("%s: Write returned an error: %v", format, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("%s: Flush returned an error: %v", format, err)
+		}
+
+		rows, err := catalogfile.Read(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: Read returned an error: %v", format, err)
+		}
+		if len(rows) != len(products) {
+			t.Fatalf("%s: got %d rows, want %d", format, len(rows), len(products))
+		}
+		for i, row := range rows {
+			if row.Err != nil || !reflect.DeepEqual(row.Product, *products[i]) {
+				t.Fatalf("%s: row %d = %+v, want %+v", format, i, row, *products[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package category_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+// newCategories returns a category usecase over an empty store
+func newCategories() usecase.CategoryUsecase {
+	store := productstore.NewStore()
+	return usecase.NewCategoryUsecase(store.Categories(), store, store)
+}
+
+// createChain creates n categories c-1 … c-n, each nested below the previous one
+func createChain(t *testing.T, categories usecase.CategoryUsecase, n int) {
+	t.Helper()
+	var parentID *string
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("c-%d", i)
+		if _, err := categories.CreateCategory(context.Background(), &entity.Category{ID: id, Name: id, ParentID: parentID}); err != nil {
+			t.Fatalf("CreateCategory %s: %v", id, err)
+		}
+		parentID = &id
+	}
+}
+
+func TestMoveCategoryRewritesSubtreePaths(t *testing.T) {
+	ctx := context.Background()
+	categories := newCategories()
+	createChain(t, categories, 3)
+	if _, err := categories.CreateCategory(ctx, &entity.Category{ID: "other", Name: "other"}); err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+
+	other := "other"
+	if _, err := categories.MoveCategory(ctx, "c-2", &other); err != nil {
+		t.Fatalf("MoveCategory: %v", err)
+	}
+
+	subtree, err := categories.GetCategorySubtree(ctx, "other")
+	if err != nil {
+		t.Fatalf("GetCategorySubtree: %v", err)
+	}
+	want := map[string]string{"other": "/other/", "c-2": "/other/c-2/", "c-3": "/other/c-2/c-3/"}
+	if len(subtree) != len(want) {
+		t.Fatalf("got %d categories below other, want %d", len(subtree), len(want))
+	}
+	for i, category := range subtree {
+		if category.Path != want[category.ID] || category.Level != i+1 {
+			t.Fatalf("%s: got path %s at level %d, want %s at level %d", category.ID, category.Path, category.Level, want[category.ID], i+1)
+		}
+	}
+}
+
+func TestMoveCategoryRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	categories := newCategories()
+	createChain(t, categories, 3)
+
+	for _, parentID := range []string{"c-1", "c-3"} {
+		if _, err := categories.MoveCategory(ctx, "c-1", &parentID); !errors.Is(err, entity.ErrCategoryCycle) {
+			t.Fatalf("moving c-1 below %s: got %v, want %v", parentID, err, entity.ErrCategoryCycle)
+		}
+	}
+	// The patch endpoint takes the same path
+	if _, err := categories.UpdateCategoryPartial(ctx, "c-2", map[string]interface{}{"parent_id": "c-3"}); !errors.Is(err, entity.ErrCategoryCycle) {
+		t.Fatalf("patching c-2 below c-3: got %v, want %v", err, entity.ErrCategoryCycle)
+	}
+
+	category, err := categories.GetCategoryByID(ctx, "c-1")
+	if err != nil {
+		t.Fatalf("GetCategoryByID: %v", err)
+	}
+	if category.Path != "/c-1/" {
+		t.Fatalf("rejected move changed the path to %s", category.Path)
+	}
+}
+
+func TestMoveCategoryRejectsTooDeepSubtrees(t *testing.T) {
+	ctx := context.Background()
+	categories := newCategories()
+	createChain(t, categories, entity.MaxCategoryDepth)
+
+	// A top-level category with one child cannot go below the deepest category, nor can a leaf
+	if _, err := categories.CreateCategory(ctx, &entity.Category{ID: "top", Name: "top"}); err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	top := "top"
+	if _, err := categories.CreateCategory(ctx, &entity.Category{ID: "leaf", Name: "leaf", ParentID: &top}); err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	deepest := fmt.Sprintf("c-%d", entity.MaxCategoryDepth)
+	if _, err := categories.MoveCategory(ctx, "top", &deepest); !errors.Is(err, entity.ErrCategoryTooDeep) {
+		t.Fatalf("moving top below %s: got %v, want %v", deepest, err, entity.ErrCategoryTooDeep)
+	}
+	if _, err := categories.CreateCategory(ctx, &entity.Category{ID: "new", Name: "new", ParentID: &deepest}); !errors.Is(err, entity.ErrCategoryTooDeep) {
+		t.Fatalf("creating below %s: got %v, want %v", deepest, err, entity.ErrCategoryTooDeep)
+	}
+
+	// Two levels up, the leaf lands exactly on the deepest level
+	parentID := fmt.Sprintf("c-%d", entity.MaxCategoryDepth-2)
+	moved, err := categories.MoveCategory(ctx, "top", &parentID)
+	if err != nil {
+		t.Fatalf("MoveCategory: %v", err)
+	}
+	if moved.Level != entity.MaxCategoryDepth-1 {
+		t.Fatalf("got level %d, want %d", moved.Level, entity.MaxCategoryDepth-1)
+	}
+}
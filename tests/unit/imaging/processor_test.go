@@ -0,0 +1,88 @@
+package imaging_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/imaging"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+// encodePNG returns a width x height PNG whose left half is red and right half is blue
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode returned an error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestInspectReportsTypeAndSize(t *testing.T) {
+	info, err := imaging.NewProcessor().Inspect(encodePNG(t, 40, 20))
+	if err != nil {
+		t.Fatalf("Inspect returned an error: %v", err)
+	}
+	if info.ContentType != "image/png" || info.Width != 40 || info.Height != 20 {
+		t.Fatalf("Inspect = %+v, want image/png 40x20", info)
+	}
+}
+
+func TestInspectRejectsNonImages(t *testing.T) {
+	_, err := imaging.NewProcessor().Inspect([]byte("%PDF-1.7 not an image"))
+	if !errors.Is(err, entity.ErrInvalidMedia) {
+		t.Fatalf("Inspect error = %v, want ErrInvalidMedia", err)
+	}
+}
+
+func TestThumbnailKeepsAspectRatioAndContent(t *testing.T) {
+	p := imaging.NewProcessor()
+	thumbnails, err := p.Thumbnails(encodePNG(t, 400, 300), []int{100, 200})
+	if err != nil {
+		t.Fatalf("Thumbnails returned an error: %v", err)
+	}
+	if len(thumbnails) != 2 {
+		t.Fatalf("got %d thumbnails, want 2", len(thumbnails))
+	}
+	if info := thumbnails[1].Info; info.Width != 200 || info.Height != 150 {
+		t.Fatalf("second thumbnail size = %dx%d, want 200x150", info.Width, info.Height)
+	}
+	info := thumbnails[0].Info
+	if info.Width != 100 || info.Height != 75 {
+		t.Fatalf("thumbnail size = %dx%d, want 100x75", info.Width, info.Height)
+	}
+
+	img, err := png.Decode(bytes.NewReader(thumbnails[0].Data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a PNG: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(100, 75) {
+		t.Fatalf("decoded thumbnail size = %v, want 100x75", got)
+	}
+	if r, _, b, _ := img.At(10, 10).RGBA(); r>>8 != 255 || b != 0 {
+		t.Fatalf("left pixel = %v, want red", img.At(10, 10))
+	}
+	if r, _, b, _ := img.At(90, 10).RGBA(); r != 0 || b>>8 != 255 {
+		t.Fatalf("right pixel = %v, want blue", img.At(90, 10))
+	}
+}
+
+func TestThumbnailRejectsUpscaling(t *testing.T) {
+	if _, err := imaging.NewProcessor().Thumbnails(encodePNG(t, 50, 50), []int{25, 100}); err == nil {
+		t.Fatal("Thumbnails wider than the image returned no error")
+	}
+}
@@ -0,0 +1,145 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/allocation"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func coords(lat, lon float64) (*float64, *float64) {
+	return &lat, &lon
+}
+
+// allocationFixture has three warehouses: EAST ships first, WEST is in another country, and
+// NORTH is the only one holding both products.
+func allocationFixture() ([]*entity.StockLevel, map[string]*entity.Warehouse) {
+	eastLat, eastLon := coords(40.7, -74.0)   // New York
+	westLat, westLon := coords(49.3, -123.1)  // Vancouver
+	northLat, northLon := coords(41.9, -87.6) // Chicago
+	warehouses := map[string]*entity.Warehouse{
+		"east":  {ID: "east", Code: "EAST", Country: "US", PostalCode: "10001", Latitude: eastLat, Longitude: eastLon, Priority: 0},
+		"west":  {ID: "west", Code: "WEST", Country: "CA", PostalCode: "V6B", Latitude: westLat, Longitude: westLon, Priority: 1},
+		"north": {ID: "north", Code: "NORTH", Country: "US", PostalCode: "60601", Latitude: northLat, Longitude: northLon, Priority: 2},
+	}
+	levels := []*entity.StockLevel{
+		{ProductID: "a", WarehouseID: "east", AvailableQty: 3},
+		{ProductID: "b", WarehouseID: "west", AvailableQty: 5},
+		{ProductID: "a", WarehouseID: "north", AvailableQty: 5},
+		{ProductID: "b", WarehouseID: "north", AvailableQty: 5},
+	}
+	return levels, warehouses
+}
+
+func TestAllocationStrategies(t *testing.T) {
+	seattleLat, seattleLon := coords(47.6, -122.3)
+	tests := []struct {
+		name        string
+		strategy    string
+		items       map[string]int
+		destination *entity.Address
+		want        []entity.Allocation
+	}{
+		{
+			name:     "priority splits across warehouses in priority order",
+			strategy: service.AllocationPriority,
+			items:    map[string]int{"a": 4, "b": 2},
+			want: []entity.Allocation{
+				{ProductID: "a", WarehouseID: "east", Qty: 3},
+				{ProductID: "a", WarehouseID: "north", Qty: 1},
+				{ProductID: "b", WarehouseID: "west", Qty: 2},
+			},
+		},
+		{
+			name:     "fewest splits ships from the one warehouse holding everything",
+			strategy: service.AllocationFewestSplits,
+			items:    map[string]int{"a": 4, "b": 2},
+			want: []entity.Allocation{
+				{ProductID: "a", WarehouseID: "north", Qty: 4},
+				{ProductID: "b", WarehouseID: "north", Qty: 2},
+			},
+		},
+		{
+			name:        "closest uses distance when coordinates are known",
+			strategy:    service.AllocationClosest,
+			items:       map[string]int{"b": 2},
+			destination: &entity.Address{Country: "US", Latitude: seattleLat, Longitude: seattleLon},
+			want:        []entity.Allocation{{ProductID: "b", WarehouseID: "west", Qty: 2}},
+		},
+		{
+			name:        "closest prefers the destination country and postal area without coordinates",
+			strategy:    service.AllocationClosest,
+			items:       map[string]int{"a": 2, "b": 2},
+			destination: &entity.Address{Country: "us", PostalCode: "60614"},
+			want: []entity.Allocation{
+				{ProductID: "a", WarehouseID: "north", Qty: 2},
+				{ProductID: "b", WarehouseID: "north", Qty: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := allocation.NewStrategy(tt.strategy)
+			if err != nil {
+				t.Fatalf("NewStrategy: %v", err)
+			}
+			levels, warehouses := allocationFixture()
+			got, err := strategy.Allocate(tt.items, levels, warehouses, tt.destination)
+			if err != nil {
+				t.Fatalf("Allocate: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocationInsufficientStock(t *testing.T) {
+	for _, name := range []string{service.AllocationPriority, service.AllocationClosest, service.AllocationFewestSplits} {
+		strategy, err := allocation.NewStrategy(name)
+		if err != nil {
+			t.Fatalf("NewStrategy(%q): %v", name, err)
+		}
+		levels, warehouses := allocationFixture()
+		delete(warehouses, "north") // inactive warehouses are not passed in
+		if _, err := strategy.Allocate(map[string]int{"a": 4}, levels, warehouses, nil); !errors.Is(err, entity.ErrInsufficientStock) {
+			t.Fatalf("%s: got %v, want ErrInsufficientStock", name, err)
+		}
+	}
+}
+
+func TestTransferStock(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	west := createWarehouse(t, store, "west", 1)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 5})
+
+	// Reserved stock stays put; only available stock can move
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+	if _, err := inventory.ReserveStock(ctx, "order-1", map[string]int{"p-1": 2}, nil); err != nil {
+		t.Fatalf("failed to reserve: %v", err)
+	}
+
+	warehouses := usecase.NewWarehouseUsecase(store, store, store)
+	if _, err := warehouses.TransferStock(ctx, "p-1", east.ID, west.ID, 4, ""); !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Fatalf("transferring reserved stock: got %v, want ErrInsufficientStock", err)
+	}
+	if _, err := warehouses.TransferStock(ctx, "p-1", east.ID, west.ID, 3, "rebalance"); err != nil {
+		t.Fatalf("TransferStock: %v", err)
+	}
+
+	checkLevel(t, store, "p-1", east.ID, 0, 2)
+	checkLevel(t, store, "p-1", west.ID, 3, 0)
+	checkItem(t, store, "p-1", 3, 2)
+	if len(store.Transfers()) != 1 || len(store.Transactions()) != 3 {
+		t.Fatalf("recorded %d transfers and %d stock transactions, want 1 and 3", len(store.Transfers()), len(store.Transactions()))
+	}
+}
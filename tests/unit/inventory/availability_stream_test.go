@@ -0,0 +1,136 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestAvailabilityStreamCoalescesChanges(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 5})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 1})
+
+	stream := usecase.NewAvailabilityStreamUsecase(store, usecase.AvailabilityStreamOptions{MaxProducts: 2})
+	if _, err := stream.Subscribe(ctx, []string{"p-1", "p-2", "p-3"}); err == nil {
+		t.Fatal("expected watching more than MaxProducts products to fail")
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub, err := stream.Subscribe(subCtx, []string{"p-2", "p-1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// The subscription starts with the current availability
+	<-sub.Ready()
+	initial := sub.Next()
+	if len(initial) != 2 || initial[0].ProductID != "p-1" || initial[0].AvailableQty != 5 || !initial[0].InStock {
+		t.Fatalf("unexpected initial availability: %+v", initial)
+	}
+
+	// Several changes between flushes reach the subscriber once, as of the flush
+	for _, qty := range []int{4, 3, 0} {
+		item, _ := store.GetInventoryItem(ctx, "p-1")
+		item.AvailableQty = qty
+		if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+			t.Fatalf("failed to update stock: %v", err)
+		}
+		stream.NotifyStockChanged("p-1")
+	}
+	stream.NotifyStockChanged("p-9")
+	if err := stream.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	<-sub.Ready()
+	updates := sub.Next()
+	if len(updates) != 1 || updates[0].ProductID != "p-1" || updates[0].AvailableQty != 0 || updates[0].InStock {
+		t.Fatalf("unexpected coalesced updates: %+v", updates)
+	}
+
+	// Nothing changed since the last flush
+	if err := stream.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if updates := sub.Next(); len(updates) != 0 {
+		t.Fatalf("unexpected updates without changes: %+v", updates)
+	}
+
+	// A cancelled subscription stops receiving updates
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	stream.NotifyStockChanged("p-2")
+	if err := stream.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if updates := sub.Next(); len(updates) != 0 {
+		t.Fatalf("unexpected updates after cancelling: %+v", updates)
+	}
+}
+
+func TestAvailabilityFollowsStockEvents(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 5})
+
+	stream := usecase.NewAvailabilityStreamUsecase(store, usecase.AvailabilityStreamOptions{MaxProducts: 10})
+
+	// Readers connect lazily, so no broker is needed to handle events directly
+	config := &messaging.KafkaConfig{
+		Brokers:         []string{"localhost:9092"},
+		InventoryTopic:  "inventory_events",
+		ConsumerGroupID: "inventory-test",
+	}
+	if _, err := messaging.NewAvailabilityEventConsumer(config, "", stream); err == nil {
+		t.Fatal("expected a consumer without a replica ID to fail")
+	}
+	consumer, err := messaging.NewAvailabilityEventConsumer(config, "replica-1", stream)
+	if err != nil {
+		t.Fatalf("NewAvailabilityEventConsumer: %v", err)
+	}
+	defer consumer.Close()
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub, err := stream.Subscribe(subCtx, []string{"p-1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	<-sub.Ready()
+	sub.Next()
+
+	// A reservation made by another replica reaches this replica's subscribers
+	item, _ := store.GetInventoryItem(ctx, "p-1")
+	item.AvailableQty, item.ReservedQty = 3, 2
+	if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+		t.Fatalf("failed to update stock: %v", err)
+	}
+	for _, payload := range []messaging.StockEventPayload{
+		{EventType: service.EventTypeStockLow, SKU: "p-1"},
+		{EventType: service.EventTypeStockReserved, SKU: "p-1", OrderID: "order-1", Quantity: 2},
+	} {
+		data, _ := json.Marshal(payload)
+		consumer.HandleStockEvent(data)
+	}
+	consumer.HandleStockEvent([]byte("{not json"))
+	if err := stream.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	<-sub.Ready()
+	updates := sub.Next()
+	if len(updates) != 1 || updates[0].ProductID != "p-1" || updates[0].AvailableQty != 3 {
+		t.Fatalf("unexpected updates: %+v", updates)
+	}
+}
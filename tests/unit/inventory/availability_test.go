@@ -0,0 +1,47 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 5})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 1})
+
+	item, _ := store.GetInventoryItem(ctx, "p-2")
+	item.BackorderPolicy = valueobject.BackorderPolicyBackorder.String()
+	item.BackorderLimit = 3
+	if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+		t.Fatalf("failed to set backorder policy: %v", err)
+	}
+
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+	availabilities, err := inventory.CheckAvailability(ctx, map[string]int{"p-3": 1, "p-2": 4, "p-1": 6})
+	if err != nil {
+		t.Fatalf("CheckAvailability: %v", err)
+	}
+	if len(availabilities) != 3 {
+		t.Fatalf("got %d results, want 3", len(availabilities))
+	}
+
+	p1, p2, p3 := availabilities[0], availabilities[1], availabilities[2]
+	if p1.ProductID != "p-1" || !p1.Found || p1.InStock || p1.Backorderable || p1.AvailableQty != 5 {
+		t.Fatalf("p-1 without a backorder policy: %+v", p1)
+	}
+	if p2.ProductID != "p-2" || p2.InStock || !p2.Backorderable {
+		t.Fatalf("p-2 within its backorder limit: %+v", p2)
+	}
+	if p3.ProductID != "p-3" || p3.Found || p3.InStock || p3.Backorderable {
+		t.Fatalf("unknown p-3: %+v", p3)
+	}
+
+	// Checking holds no stock
+	checkItem(t, store, "p-1", 5, 0)
+}
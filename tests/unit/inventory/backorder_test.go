@@ -0,0 +1,112 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestBackordersAreFilledInOrder(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 2})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 0})
+
+	item, _ := store.GetInventoryItem(ctx, "p-1")
+	item.BackorderPolicy = valueobject.BackorderPolicyBackorder.String()
+	item.BackorderLimit = 5
+	if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+		t.Fatalf("failed to set backorder policy: %v", err)
+	}
+
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+
+	// Items without a backorder policy still fail the whole order
+	if _, err := inventory.ReserveStock(ctx, "order-0", map[string]int{"p-1": 1, "p-2": 1}, nil); !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Fatalf("reserving p-2: got %v, want ErrInsufficientStock", err)
+	}
+
+	if _, err := inventory.ReserveStock(ctx, "order-1", map[string]int{"p-1": 4}, nil); err != nil {
+		t.Fatalf("order-1: %v", err)
+	}
+	if _, err := inventory.ReserveStock(ctx, "order-2", map[string]int{"p-1": 3}, nil); err != nil {
+		t.Fatalf("order-2: %v", err)
+	}
+	placed, _ := store.GetReservationsByOrderID(ctx, "order-2")
+	if _, err := inventory.ReserveStock(ctx, "order-3", map[string]int{"p-1": 1}, nil); !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Fatalf("order-3 beyond the backorder limit: got %v, want ErrInsufficientStock", err)
+	}
+	checkReserved(t, store, "order-1", 2, 2)
+	checkReserved(t, store, "order-2", 0, 3)
+
+	// Received stock goes to the oldest backorder first; order-2 is split
+	receipt := entity.StockAdjustment{
+		ProductID:   "p-1",
+		Type:        valueobject.StockTypeReceived.String(),
+		Qty:         3,
+		ReasonCode:  "PO_RECEIPT",
+		ActorID:     "tester",
+		ReferenceID: "po-1",
+	}
+	if _, err := inventory.AdjustStock(ctx, receipt); err != nil {
+		t.Fatalf("AdjustStock: %v", err)
+	}
+	checkReserved(t, store, "order-1", 4, 0)
+	checkReserved(t, store, "order-2", 1, 2)
+	checkLevel(t, store, "p-1", east.ID, 0, 5)
+
+	// Both parts of the split order keep the time it was placed
+	split, _ := store.GetReservationsByOrderID(ctx, "order-2")
+	for _, reservation := range split {
+		if !reservation.ReservedAt.Equal(placed[0].ReservedAt) {
+			t.Fatalf("%s reservation of order-2 reserved at %s, want %s", reservation.Status, reservation.ReservedAt, placed[0].ReservedAt)
+		}
+	}
+	if item, _ := store.GetInventoryItem(ctx, "p-1"); item.BackorderedQty != 2 {
+		t.Fatalf("backordered %d, want 2", item.BackorderedQty)
+	}
+
+	// Filled backorders are held until the order settles them, however long that takes
+	if expired, err := inventory.ExpireReservations(ctx, "order-2", time.Now().Add(24*time.Hour)); err != nil || len(expired) != 0 {
+		t.Fatalf("ExpireReservations = %d reservations, %v; want none", len(expired), err)
+	}
+	checkReserved(t, store, "order-2", 1, 2)
+
+	// Cancelling drops the rest of the backorder along with the reserved stock
+	if err := inventory.CancelReservation(ctx, "order-2"); err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	checkReserved(t, store, "order-2", 0, 0)
+	checkLevel(t, store, "p-1", east.ID, 1, 4)
+	if item, _ := store.GetInventoryItem(ctx, "p-1"); item.BackorderedQty != 0 {
+		t.Fatalf("backordered %d after cancelling, want 0", item.BackorderedQty)
+	}
+}
+
+// checkReserved checks the reserved and backordered quantities of an order
+func checkReserved(t *testing.T, store *inventorystore.Store, orderID string, reserved, backordered int) {
+	t.Helper()
+	reservations, err := store.GetReservationsByOrderID(context.Background(), orderID)
+	if err != nil {
+		t.Fatalf("failed to list reservations of %s: %v", orderID, err)
+	}
+	gotReserved, gotBackordered := 0, 0
+	for _, reservation := range reservations {
+		switch reservation.Status {
+		case valueobject.ReserveStatusReserved.String():
+			gotReserved += reservation.Qty
+		case valueobject.ReserveStatusBackordered.String():
+			gotBackordered += reservation.Qty
+		}
+	}
+	if gotReserved != reserved || gotBackordered != backordered {
+		t.Fatalf("%s: reserved=%d backordered=%d, want reserved=%d backordered=%d",
+			orderID, gotReserved, gotBackordered, reserved, backordered)
+	}
+}
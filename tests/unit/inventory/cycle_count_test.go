@@ -0,0 +1,77 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestCycleCountPostsVariances(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 5})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 4})
+
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+	if _, err := inventory.ReserveStock(ctx, "order-1", map[string]int{"p-1": 2}, nil); err != nil {
+		t.Fatalf("failed to reserve: %v", err)
+	}
+
+	counts := usecase.NewCycleCountUsecase(store, store, store, store, inventorystore.NoopPublisher{})
+	count, err := counts.StartCycleCount(ctx, east.ID, nil, "counter")
+	if err != nil {
+		t.Fatalf("StartCycleCount: %v", err)
+	}
+	if len(count.Lines) != 2 || count.Lines[0].ExpectedQty != 5 || count.Lines[1].ExpectedQty != 4 {
+		t.Fatalf("unexpected lines %+v", count.Lines)
+	}
+
+	// Every product has to be counted before the count can be posted
+	if _, err := counts.RecordCounts(ctx, count.CountID, map[string]int{"p-1": 4}, "counter"); err != nil {
+		t.Fatalf("RecordCounts: %v", err)
+	}
+	if _, err := counts.PostCycleCount(ctx, count.CountID, "COUNT_VARIANCE", "supervisor"); !errors.Is(err, entity.ErrCycleCountIncomplete) {
+		t.Fatalf("posting an incomplete count: got %v, want ErrCycleCountIncomplete", err)
+	}
+
+	if _, err := counts.RecordCounts(ctx, count.CountID, map[string]int{"p-2": 6}, "counter"); err != nil {
+		t.Fatalf("RecordCounts: %v", err)
+	}
+	posted, err := counts.PostCycleCount(ctx, count.CountID, "COUNT_VARIANCE", "supervisor")
+	if err != nil {
+		t.Fatalf("PostCycleCount: %v", err)
+	}
+	if posted.Status != valueobject.CycleCountStatusPosted.String() || posted.ClosedBy != "supervisor" {
+		t.Fatalf("posted count has status %s closed by %q", posted.Status, posted.ClosedBy)
+	}
+
+	// Variances adjust the available stock; reserved stock is untouched
+	checkLevel(t, store, "p-1", east.ID, 2, 2)
+	checkLevel(t, store, "p-2", east.ID, 6, 0)
+	checkItem(t, store, "p-1", 2, 2)
+
+	variances := map[string]int{}
+	for _, transaction := range store.Transactions() {
+		if transaction.Type != valueobject.StockTypeCycleCount.String() {
+			continue
+		}
+		if transaction.ReasonCode != "COUNT_VARIANCE" || transaction.ActorID != "supervisor" ||
+			transaction.ReferenceID == nil || *transaction.ReferenceID != count.CountID {
+			t.Fatalf("cycle count transaction not attributed to the count: %+v", transaction)
+		}
+		variances[transaction.ProductID] = transaction.Qty
+	}
+	if len(variances) != 2 || variances["p-1"] != -1 || variances["p-2"] != 2 {
+		t.Fatalf("recorded variances %v, want p-1=-1 p-2=2", variances)
+	}
+
+	if _, err := counts.CancelCycleCount(ctx, count.CountID, "supervisor"); !errors.Is(err, entity.ErrCycleCountClosed) {
+		t.Fatalf("cancelling a posted count: got %v, want ErrCycleCountClosed", err)
+	}
+}
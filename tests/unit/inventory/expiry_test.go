@@ -0,0 +1,62 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+// failingExpiry fails to expire the reservations of one order
+type failingExpiry struct {
+	usecase.InventoryUsecase
+	orderID string
+	err     error
+}
+
+func (f failingExpiry) ExpireReservations(ctx context.Context, orderID string, now time.Time) ([]*entity.InventoryReservation, error) {
+	if orderID == f.orderID {
+		return nil, f.err
+	}
+	return f.InventoryUsecase.ExpireReservations(ctx, orderID, now)
+}
+
+func TestExpirySweepSkipsFailingOrders(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	warehouse := createWarehouse(t, store, "main", 0)
+	seedStock(t, store, store, "p-1", map[string]int{warehouse.ID: 10})
+
+	inventory := newInventoryUsecase(store, store, store, warehouse.ID)
+	for i := 0; i < 6; i++ {
+		if _, err := inventory.ReserveStock(ctx, fmt.Sprintf("order-%d", i), map[string]int{"p-1": 1}, nil); err != nil {
+			t.Fatalf("failed to reserve: %v", err)
+		}
+	}
+
+	broken := errors.New("connection reset")
+	failing := failingExpiry{InventoryUsecase: inventory, orderID: "order-0", err: broken}
+	processor := usecase.NewReservationProcessorUsecase(store, inventorystore.NoopPublisher{}, failing, usecase.ReservationOptions{ExpiryBatchSize: 2})
+	later := time.Now().Add(time.Hour)
+
+	expired, err := processor.ProcessReservationExpiry(ctx, later)
+	if expired != 5 {
+		t.Fatalf("expired %d reservations, want 5", expired)
+	}
+	if !errors.Is(err, broken) {
+		t.Fatalf("got %v, want the failure of order-0", err)
+	}
+	checkItem(t, store, "p-1", 9, 1)
+
+	// The failed order is picked up again by the next sweep
+	processor = usecase.NewReservationProcessorUsecase(store, inventorystore.NoopPublisher{}, inventory, usecase.ReservationOptions{ExpiryBatchSize: 2})
+	if expired, err := processor.ProcessReservationExpiry(ctx, later); err != nil || expired != 1 {
+		t.Fatalf("second sweep expired %d reservations with %v, want 1", expired, err)
+	}
+	checkItem(t, store, "p-1", 10, 0)
+}
@@ -0,0 +1,81 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/allocation"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func newInventoryUsecase(repo repository.InventoryRepository, warehouses repository.WarehouseRepository, tx repository.Transactor, defaultWarehouseID string) usecase.InventoryUsecase {
+	return usecase.NewInventoryUsecase(repo, warehouses, tx, inventorystore.NoopPublisher{}, allocation.PriorityStrategy{},
+		usecase.InventoryOptions{DefaultWarehouseID: defaultWarehouseID})
+}
+
+func createWarehouse(t *testing.T, warehouses repository.WarehouseRepository, code string, priority int) *entity.Warehouse {
+	t.Helper()
+	warehouse, err := warehouses.CreateWarehouse(context.Background(), &entity.Warehouse{
+		ID:       uuid.New().String(),
+		Code:     code,
+		Name:     code,
+		Priority: priority,
+		Active:   true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create warehouse %s: %v", code, err)
+	}
+	return warehouse
+}
+
+// seedStock creates an inventory item holding the given quantities by warehouse ID
+func seedStock(t *testing.T, repo repository.InventoryRepository, warehouses repository.WarehouseRepository, productID string, byWarehouse map[string]int) {
+	t.Helper()
+	ctx := context.Background()
+	total := 0
+	for warehouseID, qty := range byWarehouse {
+		if _, err := warehouses.SaveStockLevel(ctx, &entity.StockLevel{ProductID: productID, WarehouseID: warehouseID, AvailableQty: qty}); err != nil {
+			t.Fatalf("failed to seed %s: %v", productID, err)
+		}
+		total += qty
+	}
+	if _, err := repo.CreateInventoryItem(ctx, &entity.InventoryItem{ProductID: productID, AvailableQty: total}); err != nil {
+		t.Fatalf("failed to create %s: %v", productID, err)
+	}
+}
+
+func checkLevel(t *testing.T, warehouses repository.WarehouseRepository, productID, warehouseID string, available, reserved int) {
+	t.Helper()
+	levels, err := warehouses.GetStockLevels(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("failed to get stock levels of %s: %v", productID, err)
+	}
+	for _, level := range levels {
+		if level.WarehouseID != warehouseID {
+			continue
+		}
+		if level.AvailableQty != available || level.ReservedQty != reserved {
+			t.Fatalf("%s at %s: available=%d reserved=%d, want available=%d reserved=%d",
+				productID, warehouseID, level.AvailableQty, level.ReservedQty, available, reserved)
+		}
+		return
+	}
+	t.Fatalf("%s has no stock level at %s", productID, warehouseID)
+}
+
+func checkItem(t *testing.T, repo repository.InventoryRepository, productID string, available, reserved int) {
+	t.Helper()
+	item, err := repo.GetInventoryItem(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("failed to get %s: %v", productID, err)
+	}
+	if item.AvailableQty != available || item.ReservedQty != reserved {
+		t.Fatalf("%s: available=%d reserved=%d, want available=%d reserved=%d",
+			productID, item.AvailableQty, item.ReservedQty, available, reserved)
+	}
+}
@@ -0,0 +1,43 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestImportStockOnlyOnce(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+
+	if _, err := inventory.ImportStock(ctx, "v-1", 7, "legacy-1"); err != nil {
+		t.Fatalf("ImportStock: %v", err)
+	}
+	checkLevel(t, store, "v-1", east.ID, 7, 0)
+	checkItem(t, store, "v-1", 7, 0)
+
+	// A second import, e.g. a re-run migration, must not add the stock again
+	if _, err := inventory.ImportStock(ctx, "v-1", 7, "legacy-1"); !errors.Is(err, entity.ErrStockAlreadyExists) {
+		t.Fatalf("importing twice: got %v, want ErrStockAlreadyExists", err)
+	}
+	checkItem(t, store, "v-1", 7, 0)
+
+	imported := 0
+	for _, transaction := range store.Transactions() {
+		if transaction.ReasonCode != entity.ReasonStockImport {
+			continue
+		}
+		if transaction.ReferenceID == nil || *transaction.ReferenceID != "legacy-1" {
+			t.Fatalf("import not attributed to the legacy record: %+v", transaction)
+		}
+		imported += transaction.Qty
+	}
+	if imported != 7 {
+		t.Fatalf("imported %d, want 7", imported)
+	}
+}
@@ -0,0 +1,87 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestLedgerReconciliation(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+
+	if _, err := inventory.CreateInventoryItem(ctx, &entity.InventoryItem{ProductID: "p-1", AvailableQty: 10, SoldQty: 2}); err != nil {
+		t.Fatalf("CreateInventoryItem: %v", err)
+	}
+	created := time.Now()
+
+	if _, err := inventory.ReserveStock(ctx, "order-1", map[string]int{"p-1": 3}, nil); err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+	if err := inventory.CompleteReservation(ctx, "order-1"); err != nil {
+		t.Fatalf("CompleteReservation: %v", err)
+	}
+	damage := entity.StockAdjustment{
+		ProductID:  "p-1",
+		Type:       valueobject.StockTypeDamaged.String(),
+		Qty:        1,
+		ReasonCode: "BROKEN",
+		ActorID:    "tester",
+	}
+	if _, err := inventory.AdjustStock(ctx, damage); err != nil {
+		t.Fatalf("AdjustStock: %v", err)
+	}
+
+	reconciler := usecase.NewReconciliationUsecase(store, store, store, inventorystore.NoopPublisher{}, usecase.ReconciliationOptions{BatchSize: 1})
+	reconciliation, err := reconciler.ReconcileProduct(ctx, "p-1", false)
+	if err != nil {
+		t.Fatalf("ReconcileProduct: %v", err)
+	}
+	if len(reconciliation.Drifts) != 0 {
+		t.Fatalf("stock kept by the usecases drifted: %+v", reconciliation.Drifts[0])
+	}
+
+	// The ledger answers for any point in time
+	snapshot, err := reconciler.GetStockAt(ctx, "p-1", created)
+	if err != nil {
+		t.Fatalf("GetStockAt: %v", err)
+	}
+	if want := (entity.StockBalance{AvailableQty: 10, SoldQty: 2}); snapshot.Total != want {
+		t.Fatalf("stock after creation %+v, want %+v", snapshot.Total, want)
+	}
+
+	// Drift is reported, and corrected to the ledger only when asked to
+	item, _ := store.GetInventoryItem(ctx, "p-1")
+	item.AvailableQty += 5
+	if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+		t.Fatalf("failed to corrupt the item: %v", err)
+	}
+	if _, err := store.SaveStockLevel(ctx, &entity.StockLevel{ProductID: "p-1", WarehouseID: east.ID, AvailableQty: 1}); err != nil {
+		t.Fatalf("failed to corrupt the stock level: %v", err)
+	}
+
+	report, err := reconciler.ReconcileAll(ctx, false)
+	if err != nil {
+		t.Fatalf("ReconcileAll: %v", err)
+	}
+	if report.Checked != 1 || len(report.Drifted) != 1 || len(report.Drifted[0].Drifts) != 2 || report.Drifted[0].Fixed {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	checkItem(t, store, "p-1", 11, 0)
+
+	if _, err := reconciler.ReconcileProduct(ctx, "p-1", true); err != nil {
+		t.Fatalf("ReconcileProduct with fix: %v", err)
+	}
+	checkItem(t, store, "p-1", 6, 0)
+	checkLevel(t, store, "p-1", east.ID, 6, 0)
+	if item, _ := store.GetInventoryItem(ctx, "p-1"); item.SoldQty != 5 {
+		t.Fatalf("sold %d after fixing, want 5", item.SoldQty)
+	}
+}
@@ -0,0 +1,102 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	orderentity "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/domain/service"
+	orderusecase "github.com/hydr0g3nz/ecom_back_microservice/internal/order_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+// stockChecker answers the order service's checks from the inventory usecase, as the gRPC client does
+type stockChecker struct {
+	inventory usecase.InventoryUsecase
+}
+
+func (c stockChecker) Unavailable(ctx context.Context, quantities map[string]int) ([]string, error) {
+	availabilities, err := c.inventory.CheckAvailability(ctx, quantities)
+	if err != nil {
+		return nil, err
+	}
+	var unavailable []string
+	for _, availability := range availabilities {
+		if !availability.InStock && !availability.Backorderable {
+			unavailable = append(unavailable, availability.ProductID)
+		}
+	}
+	return unavailable, nil
+}
+
+type orderRepo struct {
+	repository.OrderRepository
+}
+
+func (orderRepo) Create(_ context.Context, order orderentity.Order) (*orderentity.Order, error) {
+	return &order, nil
+}
+
+// orderPublisher hands created orders to the test
+type orderPublisher struct {
+	service.EventPublisherService
+	created chan *orderentity.Order
+}
+
+func (p orderPublisher) PublishOrderCreated(_ context.Context, order *orderentity.Order) error {
+	p.created <- order
+	return nil
+}
+
+// TestOrdersAreCheckedAndReservedUnderTheSameStock places an order through the order service's stock check
+// and reserves the order it publishes, so both must look at the same stock
+func TestOrdersAreCheckedAndReservedUnderTheSameStock(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "v-1", map[string]int{east.ID: 4})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 1})
+
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+	processor := usecase.NewReservationProcessorUsecase(store, inventorystore.NoopPublisher{}, inventory, usecase.ReservationOptions{})
+	publisher := orderPublisher{created: make(chan *orderentity.Order, 1)}
+	orders := orderusecase.NewOrderUsecase(orderRepo{}, publisher, nil, nil, stockChecker{inventory})
+
+	address := orderentity.Address{Street: "1 Main St", City: "Springfield", Country: "US", PostalCode: "12345"}
+	newOrder := func(items ...orderentity.OrderItem) *orderentity.Order {
+		return &orderentity.Order{UserID: "user-1", Items: items, ShippingInfo: address, BillingInfo: address}
+	}
+
+	// A variant ordered on two lines and a product without variants
+	if _, err := orders.CreateOrder(ctx, newOrder(
+		orderentity.OrderItem{ProductID: "p-1", VariantID: "v-1", Quantity: 2, Price: 10},
+		orderentity.OrderItem{ProductID: "p-1", VariantID: "v-1", Quantity: 2, Price: 10},
+		orderentity.OrderItem{ProductID: "p-2", Quantity: 1, Price: 5},
+	)); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	// Reserve the order as published, line by line
+	created := <-publisher.created
+	payload := usecase.OrderReservationPayload{OrderID: created.ID}
+	for _, item := range created.Items {
+		payload.Items = append(payload.Items, usecase.OrderItemData{ProductID: item.ProductID, VariantID: item.VariantID, Quantity: item.Quantity})
+	}
+	data, _ := json.Marshal(payload)
+	if err := processor.ProcessReservation(ctx, data); err != nil {
+		t.Fatalf("ProcessReservation: %v", err)
+	}
+	checkItem(t, store, "v-1", 0, 4)
+	checkItem(t, store, "p-2", 0, 1)
+
+	// The variant is now sold out, and the check names it by the ID its stock is kept under
+	_, err := orders.CreateOrder(ctx, newOrder(orderentity.OrderItem{ProductID: "p-1", VariantID: "v-1", Quantity: 1, Price: 10}))
+	if !errors.Is(err, orderentity.ErrInsufficientStock) || !strings.Contains(err.Error(), "v-1") {
+		t.Fatalf("CreateOrder of a sold out variant = %v, want ErrInsufficientStock for v-1", err)
+	}
+}
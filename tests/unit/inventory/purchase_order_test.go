@@ -0,0 +1,104 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestPurchaseOrderReplenishesLowStock(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "p-1", map[string]int{east.ID: 2})
+	seedStock(t, store, store, "p-2", map[string]int{east.ID: 50})
+
+	suppliers := usecase.NewSupplierUsecase(store)
+	supplier, err := suppliers.CreateSupplier(ctx, &entity.Supplier{Name: "Acme", LeadTimeDays: 3, Active: true})
+	if err != nil {
+		t.Fatalf("CreateSupplier: %v", err)
+	}
+	for _, productID := range []string{"p-1", "p-2"} {
+		item, _ := store.GetInventoryItem(ctx, productID)
+		item.ReorderLevel, item.ReorderQty, item.SupplierID = 5, 10, supplier.ID
+		if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+			t.Fatalf("failed to update %s: %v", productID, err)
+		}
+	}
+
+	orders := usecase.NewPurchaseOrderUsecase(store, store, store, store, store, inventorystore.NoopPublisher{},
+		usecase.PurchaseOrderOptions{DefaultWarehouseID: east.ID})
+
+	// Only the product at its reorder level is drafted, and only once however often stock runs low
+	drafted, err := orders.ReplenishLowStock(ctx)
+	if err != nil {
+		t.Fatalf("ReplenishLowStock: %v", err)
+	}
+	if len(drafted) != 1 || len(drafted[0].Lines) != 1 || drafted[0].Lines[0].ProductID != "p-1" ||
+		drafted[0].Lines[0].OrderedQty != 10 || drafted[0].CreatedBy != entity.SystemActorID {
+		t.Fatalf("unexpected drafts %+v", drafted)
+	}
+	po := drafted[0]
+	if again, err := orders.DraftReplenishment(ctx, "p-1"); err != nil || again != nil {
+		t.Fatalf("drafting a product already on order: got %+v, %v", again, err)
+	}
+
+	if _, err := orders.ReceivePurchaseOrder(ctx, po.ID, map[string]int{"p-1": 4}, "receiver"); !errors.Is(err, entity.ErrPurchaseOrderStatus) {
+		t.Fatalf("receiving a draft: got %v, want ErrPurchaseOrderStatus", err)
+	}
+	approved, err := orders.ApprovePurchaseOrder(ctx, po.ID, "buyer")
+	if err != nil {
+		t.Fatalf("ApprovePurchaseOrder: %v", err)
+	}
+	if approved.ExpectedAt == nil || approved.ExpectedAt.Sub(*approved.ApprovedAt).Hours() != 72 {
+		t.Fatalf("expected delivery %v not set from the supplier's lead time", approved.ExpectedAt)
+	}
+
+	partial, err := orders.ReceivePurchaseOrder(ctx, po.ID, map[string]int{"p-1": 4}, "receiver")
+	if err != nil {
+		t.Fatalf("ReceivePurchaseOrder: %v", err)
+	}
+	if partial.Status != valueobject.PurchaseOrderStatusPartiallyReceived.String() {
+		t.Fatalf("partially received order has status %s", partial.Status)
+	}
+	checkLevel(t, store, "p-1", east.ID, 6, 0)
+	checkItem(t, store, "p-1", 6, 0)
+
+	if _, err := orders.ReceivePurchaseOrder(ctx, po.ID, map[string]int{"p-1": 7}, "receiver"); !errors.Is(err, entity.ErrReceiptExceedsOrder) {
+		t.Fatalf("receiving more than ordered: got %v, want ErrReceiptExceedsOrder", err)
+	}
+	checkItem(t, store, "p-1", 6, 0)
+
+	received, err := orders.ReceivePurchaseOrder(ctx, po.ID, map[string]int{"p-1": 6}, "receiver")
+	if err != nil {
+		t.Fatalf("ReceivePurchaseOrder: %v", err)
+	}
+	if received.Status != valueobject.PurchaseOrderStatusReceived.String() {
+		t.Fatalf("fully received order has status %s", received.Status)
+	}
+	checkItem(t, store, "p-1", 12, 0)
+
+	receipts := 0
+	for _, transaction := range store.Transactions() {
+		if transaction.ReasonCode != entity.ReasonPurchaseOrderReceipt {
+			continue
+		}
+		if transaction.Type != valueobject.StockTypeReceived.String() || transaction.ActorID != "receiver" ||
+			transaction.ReferenceID == nil || *transaction.ReferenceID != po.ID {
+			t.Fatalf("receipt not attributed to the purchase order: %+v", transaction)
+		}
+		receipts += transaction.Qty
+	}
+	if receipts != 10 {
+		t.Fatalf("received %d against the purchase order, want 10", receipts)
+	}
+
+	if _, err := orders.CancelPurchaseOrder(ctx, po.ID, "buyer"); !errors.Is(err, entity.ErrPurchaseOrderStatus) {
+		t.Fatalf("cancelling a received order: got %v, want ErrPurchaseOrderStatus", err)
+	}
+}
@@ -0,0 +1,162 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	gormrepo "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/repository"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+const (
+	stressOrders = 64
+	stressStock  = 20
+)
+
+// TestReserveStockConcurrently runs against an in-memory store whose transactions are serialised
+// and rolled back on error, like InnoDB rows locked with SELECT ... FOR UPDATE.
+func TestReserveStockConcurrently(t *testing.T) {
+	store := inventorystore.NewStore()
+	runReservationStress(t, store, store, store)
+}
+
+// TestReserveStockConcurrentlyMySQL runs the same scenario against MySQL.
+// Set INVENTORY_TEST_DSN to a disposable database to run it.
+func TestReserveStockConcurrentlyMySQL(t *testing.T) {
+	dsn := os.Getenv("INVENTORY_TEST_DSN")
+	if dsn == "" {
+		t.Skip("INVENTORY_TEST_DSN not set")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&model.InventoryItem{},
+		&model.InventoryReservation{},
+		&model.StockTransaction{},
+		&model.Warehouse{},
+		&model.StockLevel{},
+		&model.StockTransfer{},
+		&model.CycleCount{},
+		&model.CycleCountLine{},
+		&model.Supplier{},
+		&model.PurchaseOrder{},
+		&model.PurchaseOrderLine{},
+	); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	runReservationStress(t, gormrepo.NewGormInventoryRepository(db), gormrepo.NewGormWarehouseRepository(db), gormrepo.NewGormTransactor(db))
+}
+
+// TestExpireReservationsConcurrently has several sweepers expire the same reservations at once,
+// as replicas of the service would, and checks that the stock is released exactly once.
+func TestExpireReservationsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	warehouse := createWarehouse(t, store, "main", 0)
+	seedStock(t, store, store, "p-1", map[string]int{warehouse.ID: stressStock})
+
+	inventory := newInventoryUsecase(store, store, store, warehouse.ID)
+	for i := 0; i < stressStock/2; i++ {
+		if _, err := inventory.ReserveStock(ctx, fmt.Sprintf("order-%d", i), map[string]int{"p-1": 2}, nil); err != nil {
+			t.Fatalf("failed to reserve: %v", err)
+		}
+	}
+	checkItem(t, store, "p-1", 0, stressStock)
+
+	processor := usecase.NewReservationProcessorUsecase(store, inventorystore.NoopPublisher{}, inventory, usecase.ReservationOptions{ExpiryBatchSize: 3})
+	later := time.Now().Add(time.Hour)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	expired := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := processor.ProcessReservationExpiry(ctx, later)
+			if err != nil {
+				t.Errorf("ProcessReservationExpiry returned an error: %v", err)
+			}
+			mu.Lock()
+			expired += n
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if expired != stressStock/2 {
+		t.Fatalf("sweepers expired %d reservations, want %d", expired, stressStock/2)
+	}
+	checkItem(t, store, "p-1", stressStock, 0)
+	checkLevel(t, store, "p-1", warehouse.ID, stressStock, 0)
+}
+
+// runReservationStress has many orders reserve the same two products at once, listing them in
+// both orders of appearance. One product has room for every order and the other for only a few,
+// held at two warehouses, so most orders must fail without leaving any reservation behind.
+func runReservationStress(t *testing.T, repo repository.InventoryRepository, warehouses repository.WarehouseRepository, tx repository.Transactor) {
+	ctx := context.Background()
+	suffix := uuid.New().String()[:8]
+	plenty, scarce := "plenty-"+suffix, "scarce-"+suffix
+	primary := createWarehouse(t, warehouses, "primary-"+suffix, 0)
+	secondary := createWarehouse(t, warehouses, "secondary-"+suffix, 1)
+	seedStock(t, repo, warehouses, plenty, map[string]int{primary.ID: stressOrders * 2})
+	seedStock(t, repo, warehouses, scarce, map[string]int{primary.ID: stressStock - 8, secondary.ID: 8})
+
+	uc := newInventoryUsecase(repo, warehouses, tx, primary.ID)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < stressOrders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			items := map[string]int{plenty: 2, scarce: 1}
+			_, err := uc.ReserveStock(ctx, fmt.Sprintf("order-%s-%d", suffix, i), items, nil)
+			switch {
+			case err == nil:
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			case !errors.Is(err, entity.ErrInsufficientStock):
+				t.Errorf("order %d: unexpected error: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if reserved != stressStock {
+		t.Fatalf("%d orders reserved stock, want exactly %d", reserved, stressStock)
+	}
+	checkItem(t, repo, scarce, 0, stressStock)
+	checkItem(t, repo, plenty, stressOrders*2-2*stressStock, 2*stressStock)
+	checkLevel(t, warehouses, scarce, primary.ID, 0, stressStock-8)
+	checkLevel(t, warehouses, scarce, secondary.ID, 0, 8)
+
+	for i := 0; i < stressOrders; i++ {
+		reservations, err := repo.GetReservationsByOrderID(ctx, fmt.Sprintf("order-%s-%d", suffix, i))
+		if err != nil {
+			t.Fatalf("failed to list reservations: %v", err)
+		}
+		if len(reservations) != 0 && len(reservations) != 2 {
+			t.Fatalf("order %d has %d reservations; a reservation must cover all items or none", i, len(reservations))
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	messaging "github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/adapter/event"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/inventory_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/inventorystore"
+)
+
+func TestVariantEventsProvisionAndRetireStock(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+
+	// Readers connect lazily, so no broker is needed to handle events directly
+	config := &messaging.KafkaConfig{
+		Brokers:         []string{"localhost:9092"},
+		OrderTopic:      "order_events",
+		ProductTopic:    "product_events",
+		InventoryTopic:  "inventory_events",
+		ConsumerGroupID: "inventory-test",
+	}
+	subscriber, err := messaging.NewKafkaEventSubscriber(config, nil, inventory, nil)
+	if err != nil {
+		t.Fatalf("NewKafkaEventSubscriber: %v", err)
+	}
+	defer subscriber.Close()
+
+	handle := func(eventType string) {
+		t.Helper()
+		data, _ := json.Marshal(messaging.ProductEventPayload{EventType: eventType, ProductID: "p-1", VariantID: "v-1"})
+		if err := subscriber.HandleProductEvent(ctx, data); err != nil {
+			t.Fatalf("HandleProductEvent(%s): %v", eventType, err)
+		}
+	}
+
+	// Stock is held under the variant ID
+	handle("variant.created")
+	checkItem(t, store, "v-1", 0, 0)
+
+	item, _ := store.GetInventoryItem(ctx, "v-1")
+	item.AvailableQty, item.ReorderLevel, item.ReorderQty = 3, 5, 10
+	item.BackorderPolicy = valueobject.BackorderPolicyBackorder.String()
+	if _, err := store.UpdateInventoryItem(ctx, item); err != nil {
+		t.Fatalf("failed to update v-1: %v", err)
+	}
+
+	// A deleted variant keeps its stock but is no longer replenished or backordered
+	handle("variant.deleted")
+	retired, _ := store.GetInventoryItem(ctx, "v-1")
+	if retired.AvailableQty != 3 || retired.ReorderQty != 0 || retired.ReorderLevel != 0 ||
+		retired.BackorderPolicy != valueobject.BackorderPolicyNone.String() {
+		t.Fatalf("variant not retired: %+v", retired)
+	}
+
+	// Retiring is repeatable and ignores stock that was never provisioned
+	handle("variant.deleted")
+	data, _ := json.Marshal(messaging.ProductEventPayload{EventType: "variant.deleted", ProductID: "p-1", VariantID: "v-2"})
+	if err := subscriber.HandleProductEvent(ctx, data); err != nil {
+		t.Fatalf("retiring unknown stock: %v", err)
+	}
+}
+
+func TestProductEventsSkipWhatRetryingCannotFix(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+
+	config := &messaging.KafkaConfig{
+		Brokers:         []string{"localhost:9092"},
+		OrderTopic:      "order_events",
+		ProductTopic:    "product_events",
+		InventoryTopic:  "inventory_events",
+		ConsumerGroupID: "inventory-test",
+	}
+	subscriber, err := messaging.NewKafkaEventSubscriber(config, nil, inventory, nil)
+	if err != nil {
+		t.Fatalf("NewKafkaEventSubscriber: %v", err)
+	}
+	defer subscriber.Close()
+
+	// Failed events are retried until they succeed, so events that can never succeed must not fail
+	missingID, _ := json.Marshal(messaging.ProductEventPayload{EventType: "variant.created", ProductID: "p-1"})
+	for _, data := range [][]byte{[]byte("{not json"), missingID} {
+		if err := subscriber.HandleProductEvent(ctx, data); err != nil {
+			t.Fatalf("HandleProductEvent(%s): %v", data, err)
+		}
+	}
+
+	created, _ := json.Marshal(messaging.ProductEventPayload{EventType: "product.created", ProductID: "p-1"})
+	if err := subscriber.HandleProductEvent(ctx, created); err != nil {
+		t.Fatalf("HandleProductEvent(product.created): %v", err)
+	}
+	checkItem(t, store, "p-1", 0, 0)
+}
+
+func TestOrderLinesReserveVariantStock(t *testing.T) {
+	ctx := context.Background()
+	store := inventorystore.NewStore()
+	east := createWarehouse(t, store, "east", 0)
+	seedStock(t, store, store, "v-1", map[string]int{east.ID: 5})
+	seedStock(t, store, store, "v-2", map[string]int{east.ID: 5})
+
+	inventory := newInventoryUsecase(store, store, store, east.ID)
+	processor := usecase.NewReservationProcessorUsecase(store, inventorystore.NoopPublisher{}, inventory, usecase.ReservationOptions{})
+
+	// Two variants of the same product, one of them ordered on two lines
+	data, _ := json.Marshal(usecase.OrderReservationPayload{
+		OrderID: "order-1",
+		Items: []usecase.OrderItemData{
+			{ProductID: "p-1", VariantID: "v-1", Quantity: 1},
+			{ProductID: "p-1", VariantID: "v-2", Quantity: 2},
+			{ProductID: "p-1", VariantID: "v-1", Quantity: 2},
+		},
+	})
+	if err := processor.ProcessReservation(ctx, data); err != nil {
+		t.Fatalf("ProcessReservation: %v", err)
+	}
+	checkItem(t, store, "v-1", 2, 3)
+	checkItem(t, store, "v-2", 3, 2)
+}
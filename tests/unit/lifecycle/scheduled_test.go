@@ -0,0 +1,91 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/search"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+func newProducts(store *productstore.Store) usecase.ProductUsecase {
+	return usecase.NewProductUsecase(store, store.Categories(), store.Variants(), search.NewMemoryIndex(nil), nil, store, store.Prices(), store)
+}
+
+func TestScheduledTransitionsSkipFailingProducts(t *testing.T) {
+	ctx := context.Background()
+	store := productstore.NewStore()
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	for i := range 5 {
+		product := entity.Product{ID: fmt.Sprintf("p-%d", i), SKU: fmt.Sprintf("SKU-%d", i), Name: "Tee", Price: 10, Status: "review", PublishAt: &past}
+		if _, err := store.Create(ctx, product); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	broken := errors.New("disk full")
+	store.UpdateErrs = map[string]error{"p-0": broken}
+
+	changed, err := newProducts(store).RunScheduledTransitions(ctx, now)
+	if changed != 4 {
+		t.Fatalf("changed %d products, want 4", changed)
+	}
+	if !errors.Is(err, broken) {
+		t.Fatalf("got %v, want the failure of p-0", err)
+	}
+
+	for i := range 5 {
+		product, _ := store.GetByID(ctx, fmt.Sprintf("p-%d", i))
+		want := "published"
+		if i == 0 {
+			want = "review"
+		}
+		if product.Status != want {
+			t.Fatalf("%s is %s, want %s", product.ID, product.Status, want)
+		}
+	}
+
+	// The failed product is picked up again by the next run
+	store.UpdateErrs = nil
+	if changed, err := newProducts(store).RunScheduledTransitions(ctx, now); err != nil || changed != 1 {
+		t.Fatalf("second run changed %d products with %v, want 1", changed, err)
+	}
+}
+
+func TestScheduledTransitionsSkipProductsMovedSinceListed(t *testing.T) {
+	ctx := context.Background()
+	store := productstore.NewStore()
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	if _, err := store.Create(ctx, entity.Product{ID: "p-1", SKU: "TEE", Name: "Tee", Price: 10, Status: "review", PublishAt: &past}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Two replicas run the scheduler at the same time; only one announces the publication
+	products := newProducts(store)
+	done := make(chan int, 2)
+	for range 2 {
+		go func() {
+			changed, _ := products.RunScheduledTransitions(ctx, now)
+			done <- changed
+		}()
+	}
+	if total := <-done + <-done; total != 1 {
+		t.Fatalf("published %d times, want once", total)
+	}
+	announced := 0
+	for _, event := range store.Outbox() {
+		if event.EventType == service.EventTypeProductStatusChanged {
+			announced++
+		}
+	}
+	if announced != 1 {
+		t.Fatalf("got %d status change events, want 1", announced)
+	}
+}
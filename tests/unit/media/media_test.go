@@ -0,0 +1,82 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"sync"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/imaging"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/storage"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+// recordingIndex records the products passed to Index
+type recordingIndex struct {
+	interfaces.SearchIndex
+
+	mu      sync.Mutex
+	indexed []entity.Product
+}
+
+func (ix *recordingIndex) Index(_ context.Context, product *entity.Product) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.indexed = append(ix.indexed, *product)
+	return nil
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestPrimaryImageChangesAreAnnounced(t *testing.T) {
+	ctx := context.Background()
+	store := productstore.NewStore()
+	if _, err := store.Create(ctx, entity.Product{ID: "p-1", SKU: "TEE", Name: "Tee", Price: 10}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	index := &recordingIndex{}
+	media := usecase.NewMediaUsecase(
+		store.Media(), store, store, store, index,
+		storage.NewLocalStorage(t.TempDir(), "http://cdn.test"),
+		imaging.NewProcessor(),
+		usecase.MediaOptions{ThumbnailWidths: []int{16, 32, 64}},
+	)
+
+	uploaded, err := media.UploadMedia(ctx, "p-1", encodePNG(t, 48, 24), "front")
+	if err != nil {
+		t.Fatalf("UploadMedia: %v", err)
+	}
+	// 64 is wider than the image and skipped
+	if len(uploaded.Thumbnails) != 2 || uploaded.Thumbnails[1].Width != 32 || uploaded.Thumbnails[1].Height != 16 {
+		t.Fatalf("got thumbnails %+v, want 16 and 32 pixels wide", uploaded.Thumbnails)
+	}
+	if err := media.DeleteMedia(ctx, "p-1", uploaded.ID); err != nil {
+		t.Fatalf("DeleteMedia: %v", err)
+	}
+
+	events := store.Outbox()
+	if len(events) != 2 {
+		t.Fatalf("got %d outbox events, want 2", len(events))
+	}
+	for _, event := range events {
+		if event.EventType != service.EventTypeProductUpdated || event.AggregateID != "p-1" {
+			t.Fatalf("got %s about %s, want %s about p-1", event.EventType, event.AggregateID, service.EventTypeProductUpdated)
+		}
+	}
+	if len(index.indexed) != 2 || index.indexed[0].ImageURL != uploaded.URL || index.indexed[1].ImageURL != "" {
+		t.Fatalf("got indexed %+v, want the product with and then without %s", index.indexed, uploaded.URL)
+	}
+}
@@ -0,0 +1,112 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/oidc"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/oidcstub"
+)
+
+func newProvider(stub *oidcstub.Provider) *oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		Name:        "stub",
+		Issuer:      stub.Issuer(),
+		ClientID:    stub.ClientID,
+		RedirectURL: "http://localhost/callback",
+	}, nil)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	stub := oidcstub.New("client-1")
+	defer stub.Close()
+	provider := newProvider(stub)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned an error: %v", err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned an invalid URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge") != "challenge-1" || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("AuthCodeURL is missing PKCE parameters: %s", authURL)
+	}
+	if q.Get("state") != "state-1" || q.Get("nonce") != "nonce-1" || q.Get("client_id") != "client-1" {
+		t.Errorf("AuthCodeURL has unexpected parameters: %s", authURL)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	stub := oidcstub.New("client-1")
+	defer stub.Close()
+	provider := newProvider(stub)
+
+	verifier, _ := oidc.GenerateCodeVerifier()
+	user := oidcstub.User{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true, GivenName: "Jane", FamilyName: "Doe"}
+	code := stub.Authorize(user, "nonce-1", oidc.CodeChallengeS256(verifier))
+
+	claims, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange returned an error: %v", err)
+	}
+	if claims.Subject != "sub-1" || claims.Email != "jane@example.com" || !claims.EmailVerified {
+		t.Errorf("Exchange returned unexpected claims: %+v", claims)
+	}
+	if claims.GivenName != "Jane" || claims.FamilyName != "Doe" {
+		t.Errorf("Exchange returned unexpected names: %+v", claims)
+	}
+
+	// Codes are single use
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-1"); !errors.Is(err, oidc.ErrExchangeFailed) {
+		t.Errorf("Exchange accepted a reused code, err = %v", err)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	stub := oidcstub.New("client-1")
+	defer stub.Close()
+	provider := newProvider(stub)
+
+	verifier, _ := oidc.GenerateCodeVerifier()
+	other, _ := oidc.GenerateCodeVerifier()
+	code := stub.Authorize(oidcstub.User{Subject: "sub-1"}, "nonce-1", oidc.CodeChallengeS256(verifier))
+
+	if _, err := provider.Exchange(context.Background(), code, other, "nonce-1"); !errors.Is(err, oidc.ErrExchangeFailed) {
+		t.Errorf("Exchange accepted a wrong code verifier, err = %v", err)
+	}
+}
+
+func TestExchangeRejectsNonceMismatch(t *testing.T) {
+	stub := oidcstub.New("client-1")
+	defer stub.Close()
+	provider := newProvider(stub)
+
+	verifier, _ := oidc.GenerateCodeVerifier()
+	code := stub.Authorize(oidcstub.User{Subject: "sub-1"}, "nonce-1", oidc.CodeChallengeS256(verifier))
+
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-2"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Errorf("Exchange accepted a mismatched nonce, err = %v", err)
+	}
+}
+
+func TestExchangeRejectsOtherAudience(t *testing.T) {
+	stub := oidcstub.New("client-1")
+	defer stub.Close()
+	provider := oidc.NewProvider(oidc.Config{
+		Name:     "stub",
+		Issuer:   stub.Issuer(),
+		ClientID: "client-2",
+	}, nil)
+
+	verifier, _ := oidc.GenerateCodeVerifier()
+	code := stub.Authorize(oidcstub.User{Subject: "sub-1"}, "nonce-1", oidc.CodeChallengeS256(verifier))
+
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-1"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Errorf("Exchange accepted a token for another client, err = %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+// recordingPublisher records published event IDs and fails while err is set
+type recordingPublisher struct {
+	published []string
+	err       error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events []entity.OutboxEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	for _, event := range events {
+		p.published = append(p.published, event.ID)
+	}
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+// addEvents adds n events e-1 … e-n to the store's outbox
+func addEvents(t *testing.T, store *productstore.Store, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		event := entity.OutboxEvent{ID: fmt.Sprintf("e-%d", i), AggregateID: "p-1", EventType: "product.updated"}
+		if err := store.Add(context.Background(), []entity.OutboxEvent{event}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	ctx := context.Background()
+	store := productstore.NewStore()
+	addEvents(t, store, 5)
+	publisher := &recordingPublisher{}
+	relay := usecase.NewOutboxUsecase(store, store, publisher, usecase.OutboxOptions{BatchSize: 2})
+
+	published, err := relay.RelayPending(ctx)
+	if err != nil {
+		t.Fatalf("RelayPending: %v", err)
+	}
+	if published != 5 || fmt.Sprint(publisher.published) != "[e-1 e-2 e-3 e-4 e-5]" {
+		t.Fatalf("published %d events %v, want e-1 to e-5 in order", published, publisher.published)
+	}
+	if pending, _ := store.ListPending(ctx, 10); len(pending) != 0 {
+		t.Fatalf("%d events still pending", len(pending))
+	}
+}
+
+func TestRelayRetriesFailedBatch(t *testing.T) {
+	ctx := context.Background()
+	store := productstore.NewStore()
+	addEvents(t, store, 3)
+	publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+	relay := usecase.NewOutboxUsecase(store, store, publisher, usecase.OutboxOptions{BatchSize: 2})
+
+	if _, err := relay.RelayPending(ctx); err == nil {
+		t.Fatal("RelayPending returned no error while the broker is down")
+	}
+	if pending, _ := store.ListPending(ctx, 10); len(pending) != 3 {
+		t.Fatalf("got %d pending events after a failed batch, want 3", len(pending))
+	}
+
+	publisher.err = nil
+	if published, err := relay.RelayPending(ctx); err != nil || published != 3 {
+		t.Fatalf("RelayPending = %d, %v; want 3 events published", published, err)
+	}
+	if fmt.Sprint(publisher.published) != "[e-1 e-2 e-3]" {
+		t.Fatalf("published %v, want e-1 to e-3 in order", publisher.published)
+	}
+}
@@ -0,0 +1,57 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+func TestSaleActiveAtIsHalfOpen(t *testing.T) {
+	start := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+	sale := entity.Sale{StartsAt: start, EndsAt: &end}
+
+	cases := map[time.Time]bool{
+		start.Add(-time.Second): false,
+		start:                   true,
+		end.Add(-time.Second):   true,
+		end:                     false,
+	}
+	for at, want := range cases {
+		if got := sale.ActiveAt(at); got != want {
+			t.Fatalf("ActiveAt(%s) = %v, want %v", at, got, want)
+		}
+	}
+
+	open := entity.Sale{StartsAt: start}
+	if !open.ActiveAt(start.AddDate(1, 0, 0)) {
+		t.Fatal("a sale without an end should run indefinitely")
+	}
+}
+
+func TestSaleOverlaps(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+	until := func(d int) *time.Time { t := day(d); return &t }
+
+	first := entity.Sale{StartsAt: day(1), EndsAt: until(5)}
+	cases := []struct {
+		name  string
+		other entity.Sale
+		want  bool
+	}{
+		{"adjacent after", entity.Sale{StartsAt: day(5), EndsAt: until(8)}, false},
+		{"adjacent before", entity.Sale{StartsAt: day(1).AddDate(0, 0, -3), EndsAt: until(1)}, false},
+		{"inside", entity.Sale{StartsAt: day(2), EndsAt: until(3)}, true},
+		{"open ended later", entity.Sale{StartsAt: day(4)}, true},
+		{"open ended after", entity.Sale{StartsAt: day(6)}, false},
+	}
+	for _, c := range cases {
+		if got := first.Overlaps(&c.other); got != c.want {
+			t.Fatalf("%s: Overlaps = %v, want %v", c.name, got, c.want)
+		}
+		if got := c.other.Overlaps(&first); got != c.want {
+			t.Fatalf("%s: reversed Overlaps = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package pricing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/search"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+// newPrices returns a price usecase over a store holding products p-0 to p-{n-1} priced 10
+func newPrices(t *testing.T, n int) (*productstore.Store, usecase.PriceUsecase) {
+	t.Helper()
+	ctx := context.Background()
+	store := productstore.NewStore()
+	for i := range n {
+		if _, err := store.Create(ctx, entity.Product{ID: fmt.Sprintf("p-%d", i), SKU: fmt.Sprintf("SKU-%d", i), Name: "Tee", Price: 10}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	products := usecase.NewProductUsecase(store, store.Categories(), store.Variants(), search.NewMemoryIndex(nil), nil, store, store.Prices(), store)
+	return store, usecase.NewPriceUsecase(store.Prices(), store, store.Variants(), products, store)
+}
+
+func schedule(t *testing.T, store *productstore.Store, id, productID string, price float64, effectiveAt time.Time) {
+	t.Helper()
+	scheduled := entity.ScheduledPrice{ID: id, ProductID: productID, Price: price, EffectiveAt: effectiveAt}
+	if _, err := store.Prices().CreateScheduled(context.Background(), scheduled); err != nil {
+		t.Fatalf("CreateScheduled: %v", err)
+	}
+}
+
+func TestScheduledPricesSkipFailingChanges(t *testing.T) {
+	ctx := context.Background()
+	store, prices := newPrices(t, 3)
+	now := time.Now()
+	schedule(t, store, "s-1", "p-0", 12, now.Add(-2*time.Hour))
+	schedule(t, store, "s-2", "p-0", 14, now.Add(-time.Hour))
+	schedule(t, store, "s-3", "p-1", 20, now.Add(-time.Hour))
+	schedule(t, store, "s-4", "p-2", 30, now.Add(-time.Hour))
+	broken := errors.New("disk full")
+	store.UpdateErrs = map[string]error{"p-0": broken}
+
+	applied, err := prices.ApplyScheduledPrices(ctx, now)
+	if applied != 2 {
+		t.Fatalf("applied %d changes, want 2", applied)
+	}
+	if !errors.Is(err, broken) {
+		t.Fatalf("got %v, want the failure of p-0", err)
+	}
+
+	// The later change of p-0 waits, so the changes still apply in order
+	for _, id := range []string{"s-1", "s-2"} {
+		if scheduled, _ := store.Prices().GetScheduled(ctx, id); !scheduled.IsPending() {
+			t.Fatalf("%s was marked applied", id)
+		}
+	}
+
+	store.UpdateErrs = nil
+	if applied, err := prices.ApplyScheduledPrices(ctx, now); err != nil || applied != 2 {
+		t.Fatalf("second run applied %d changes with %v, want 2", applied, err)
+	}
+	changes := store.Prices().Changes("p-0")
+	if len(changes) != 2 || changes[0].NewPrice != 12 || changes[1].NewPrice != 14 {
+		t.Fatalf("price history of p-0 = %+v", changes)
+	}
+}
+
+func TestScheduledPriceIsAppliedOnceAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	store, prices := newPrices(t, 1)
+	now := time.Now()
+	schedule(t, store, "s-1", "p-0", 12, now.Add(-time.Hour))
+
+	done := make(chan int, 2)
+	for range 2 {
+		go func() {
+			applied, _ := prices.ApplyScheduledPrices(ctx, now)
+			done <- applied
+		}()
+	}
+	if total := <-done + <-done; total != 1 {
+		t.Fatalf("applied %d times, want once", total)
+	}
+	if changes := store.Prices().Changes("p-0"); len(changes) != 1 {
+		t.Fatalf("price history of p-0 = %+v, want one change", changes)
+	}
+}
@@ -0,0 +1,111 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/adapter/search"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+)
+
+func newIndex(t *testing.T) *search.MemoryIndex {
+	t.Helper()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	products := []*entity.Product{
+		{ID: "1", Name: "Cotton T-Shirt", Description: "Soft everyday shirt", SKU: "TS-100", Price: 19, CategoryID: "apparel", Status: "active", CreatedAt: created},
+		{ID: "2", Name: "Running Shoes", Description: "Lightweight shoes for running", SKU: "RS-200", Price: 89, CategoryID: "footwear", Status: "active", CreatedAt: created},
+		{ID: "3", Name: "Denim Jacket", Description: "Classic jacket, pairs with any shirt", SKU: "DJ-300", Price: 120, CategoryID: "apparel", Status: "inactive", CreatedAt: created},
+	}
+	ix := search.NewMemoryIndex(nil)
+	if err := ix.Rebuild(context.Background(), products); err != nil {
+		t.Fatalf("Rebuild returned an error: %v", err)
+	}
+	return ix
+}
+
+func hitIDs(result *entity.SearchResult) []string {
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.Product.ID
+	}
+	return ids
+}
+
+func TestSearchRanksNameAboveDescription(t *testing.T) {
+	result, err := newIndex(t).Search(context.Background(), entity.SearchQuery{Text: "shirt"})
+	if err != nil {
+		t.Fatalf("Search returned an error: %v", err)
+	}
+	ids := hitIDs(result)
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("Search(shirt) = %v, want [1 3]", ids)
+	}
+}
+
+func TestSearchToleratesTyposAndPrefixes(t *testing.T) {
+	ix := newIndex(t)
+	for _, text := range []string{"runnign shoes", "jackt", "den"} {
+		result, err := ix.Search(context.Background(), entity.SearchQuery{Text: text})
+		if err != nil {
+			t.Fatalf("Search returned an error: %v", err)
+		}
+		if result.Total != 1 {
+			t.Errorf("Search(%q) matched %d products, want 1", text, result.Total)
+		}
+	}
+}
+
+func TestSearchMatchesSKU(t *testing.T) {
+	result, err := newIndex(t).Search(context.Background(), entity.SearchQuery{Text: "rs-200"})
+	if err != nil {
+		t.Fatalf("Search returned an error: %v", err)
+	}
+	if ids := hitIDs(result); len(ids) != 1 || ids[0] != "2" {
+		t.Errorf("Search(rs-200) = %v, want [2]", ids)
+	}
+}
+
+func TestSearchFacetsIgnoreOwnFilter(t *testing.T) {
+	result, err := newIndex(t).Search(context.Background(), entity.SearchQuery{CategoryID: "apparel"})
+	if err != nil {
+		t.Fatalf("Search returned an error: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Total = %d, want 2", result.Total)
+	}
+	if len(result.Facets.Categories) != 2 {
+		t.Errorf("category facets = %v, want both categories", result.Facets.Categories)
+	}
+
+	prices := map[string]int{}
+	for _, facet := range result.Facets.PriceRanges {
+		prices[facet.Value] = facet.Count
+	}
+	if prices["0-25"] != 1 || prices["100-250"] != 1 || prices["50-100"] != 0 {
+		t.Errorf("price facets = %v", result.Facets.PriceRanges)
+	}
+}
+
+func TestIndexAndRemoveKeepIndexInSync(t *testing.T) {
+	ix := newIndex(t)
+	ctx := context.Background()
+
+	renamed := &entity.Product{ID: "2", Name: "Trail Boots", SKU: "RS-200", Price: 89, CategoryID: "footwear", Status: "active"}
+	if err := ix.Index(ctx, renamed); err != nil {
+		t.Fatalf("Index returned an error: %v", err)
+	}
+	if result, _ := ix.Search(ctx, entity.SearchQuery{Text: "shoes"}); result.Total != 0 {
+		t.Errorf("old name still matches after update")
+	}
+	if result, _ := ix.Search(ctx, entity.SearchQuery{Text: "boots"}); result.Total != 1 {
+		t.Errorf("new name does not match after update")
+	}
+
+	if err := ix.Remove(ctx, "2"); err != nil {
+		t.Fatalf("Remove returned an error: %v", err)
+	}
+	if result, _ := ix.Search(ctx, entity.SearchQuery{}); result.Total != 2 {
+		t.Errorf("Total after Remove = %d, want 2", result.Total)
+	}
+}
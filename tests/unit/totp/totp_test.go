@@ -0,0 +1,77 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/totp"
+)
+
+// RFC 6238 appendix B test secret ("12345678901234567890") with 6 digit codes
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(c.unix, 0))
+		if err != nil {
+			t.Fatalf("GenerateCode returned an error: %v", err)
+		}
+		if code != c.code {
+			t.Errorf("GenerateCode at %d = %s, want %s", c.unix, code, c.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret returned an error: %v", err)
+	}
+	now := time.Now()
+	code, _ := totp.GenerateCode(secret, now)
+
+	if !totp.Validate(secret, code, now, 1) {
+		t.Error("Validate rejected the current code")
+	}
+	if !totp.Validate(secret, code, now.Add(totp.DefaultPeriod), 1) {
+		t.Error("Validate rejected a code within the allowed skew")
+	}
+	if totp.Validate(secret, code, now.Add(3*totp.DefaultPeriod), 1) {
+		t.Error("Validate accepted a code outside the allowed skew")
+	}
+	if totp.Validate("not base32!", code, now, 1) {
+		t.Error("Validate accepted an invalid secret")
+	}
+}
+
+func TestValidateCounter(t *testing.T) {
+	// Unix time 59 is in the second 30 second step
+	counter, ok := totp.ValidateCounter(rfcSecret, "287082", time.Unix(89, 0), 1)
+	if !ok || counter != 1 {
+		t.Errorf("got step %d, %v for a code of the previous step, want 1, true", counter, ok)
+	}
+	if _, ok := totp.ValidateCounter(rfcSecret, "000000", time.Unix(59, 0), 1); ok {
+		t.Error("ValidateCounter accepted a wrong code")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := totp.ProvisioningURI("SECRET", "Ecom Shop", "admin@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/Ecom%20Shop:admin@example.com?") {
+		t.Errorf("unexpected provisioning URI: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=SECRET") || !strings.Contains(uri, "issuer=Ecom+Shop") {
+		t.Errorf("provisioning URI is missing parameters: %s", uri)
+	}
+}
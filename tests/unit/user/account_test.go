@@ -0,0 +1,176 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/utils"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+type accountFixture struct {
+	users   *userstore.Users
+	tokens  *userstore.Tokens
+	mailer  *userstore.Mailer
+	account usecase.AccountUsecase
+	auth    usecase.AuthUsecase
+}
+
+func newAccountFixture() *accountFixture {
+	users := userstore.NewUsers()
+	tokens := userstore.NewTokens()
+	mailer := &userstore.Mailer{}
+
+	account := usecase.NewAccountUsecase(users, tokens, mailer, usecase.AccountOptions{
+		BaseURL:               "http://localhost",
+		VerificationTokenTTL:  time.Hour,
+		PasswordResetTokenTTL: time.Hour,
+	})
+	auth := usecase.NewAuthUsecase(
+		usecase.NewUserUsecase(users),
+		usecase.NewTokenUsecase(tokens, jwt_service.NewJWTService(jwt_service.Config{
+			SecretKey:            "test-secret",
+			AccessTokenDuration:  time.Minute,
+			RefreshTokenDuration: time.Hour,
+			Issuer:               "test",
+		})),
+		account,
+		newLoginGuard(userstore.NewLoginAttempts(), &userstore.Events{}),
+		usecase.NewTwoFactorUsecase(users, tokens, usecase.TwoFactorOptions{Issuer: "test", ChallengeTTL: time.Minute}),
+		true,
+	)
+	return &accountFixture{users: users, tokens: tokens, mailer: mailer, account: account, auth: auth}
+}
+
+// lastToken returns the token of the link in the most recent email
+func (f *accountFixture) lastToken(t *testing.T) string {
+	t.Helper()
+	if len(f.mailer.Sent) == 0 {
+		t.Fatal("no email sent")
+	}
+	body := f.mailer.Sent[len(f.mailer.Sent)-1].Body
+	start := strings.Index(body, "token=")
+	if start < 0 {
+		t.Fatalf("no token in email: %q", body)
+	}
+	token := body[start+len("token="):]
+	return token[:strings.IndexByte(token, '\n')]
+}
+
+func TestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountFixture()
+
+	user, tokens, err := f.auth.Register(ctx, entity.User{Email: "jane@example.com", FirstName: "Jane"}, "s3cret-pass")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if tokens != nil {
+		t.Fatal("tokens issued before the email was verified")
+	}
+
+	// Only the most recent link stays valid
+	first := f.lastToken(t)
+	if err := f.account.RequestEmailVerification(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("RequestEmailVerification: %v", err)
+	}
+	if err := f.account.ConfirmEmailVerification(ctx, first); !errors.Is(err, entity.ErrInvalidToken) {
+		t.Fatalf("got %v for a replaced link, want ErrInvalidToken", err)
+	}
+
+	latest := f.lastToken(t)
+	if err := f.account.ConfirmEmailVerification(ctx, latest); err != nil {
+		t.Fatalf("ConfirmEmailVerification: %v", err)
+	}
+	if verified, _ := f.users.GetByID(ctx, user.ID); !verified.EmailVerified || verified.VerifiedAt == nil {
+		t.Fatalf("email not verified: %+v", verified)
+	}
+	if err := f.account.ConfirmEmailVerification(ctx, latest); !errors.Is(err, entity.ErrInvalidToken) {
+		t.Fatalf("got %v for a used link, want ErrInvalidToken", err)
+	}
+
+	// Verified and unknown addresses look the same to the caller, and get no email
+	sent := len(f.mailer.Sent)
+	for _, email := range []string{"jane@example.com", "nobody@example.com"} {
+		if err := f.account.RequestEmailVerification(ctx, email); err != nil {
+			t.Fatalf("RequestEmailVerification(%s): %v", email, err)
+		}
+	}
+	if len(f.mailer.Sent) != sent {
+		t.Fatalf("sent %d emails, want none", len(f.mailer.Sent)-sent)
+	}
+}
+
+func TestRegisterSucceedsWhenTheVerificationEmailFails(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountFixture()
+	f.mailer.Err = errors.New("smtp unavailable")
+
+	user, _, err := f.auth.Register(ctx, entity.User{Email: "jane@example.com", FirstName: "Jane"}, "s3cret-pass")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	// The user asks for a new link once mail works again
+	f.mailer.Err = nil
+	if err := f.account.RequestEmailVerification(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("RequestEmailVerification: %v", err)
+	}
+	if err := f.account.ConfirmEmailVerification(ctx, f.lastToken(t)); err != nil {
+		t.Fatalf("ConfirmEmailVerification: %v", err)
+	}
+	if verified, _ := f.users.GetByID(ctx, user.ID); !verified.EmailVerified {
+		t.Fatal("email not verified")
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountFixture()
+
+	hashed, _ := utils.HashPassword("old-password")
+	if _, err := f.users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", HashedPassword: string(hashed)}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := f.tokens.Create(ctx, &entity.Token{ID: "t-1", UserID: "u-1", Type: entity.RefreshToken, Token: "refresh", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Unknown addresses are ignored
+	if err := f.account.RequestPasswordReset(ctx, "nobody@example.com"); err != nil || len(f.mailer.Sent) != 0 {
+		t.Fatalf("RequestPasswordReset of an unknown address = %v with %d emails", err, len(f.mailer.Sent))
+	}
+
+	if err := f.account.RequestPasswordReset(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	token := f.lastToken(t)
+	if err := f.account.ConfirmPasswordReset(ctx, token, "new-password"); err != nil {
+		t.Fatalf("ConfirmPasswordReset: %v", err)
+	}
+
+	user, _ := f.users.GetByID(ctx, "u-1")
+	if utils.VerifyPassword("new-password", user.HashedPassword) != nil {
+		t.Fatal("password not changed")
+	}
+	// Receiving the link proves ownership of the mailbox
+	if !user.EmailVerified {
+		t.Fatal("email not verified by the reset")
+	}
+	sessions, _ := f.tokens.FindByUserID(ctx, "u-1")
+	for _, session := range sessions {
+		if session.Type == entity.RefreshToken || session.Type == entity.AccessToken {
+			t.Fatalf("session not revoked: %+v", session)
+		}
+	}
+
+	if err := f.account.ConfirmPasswordReset(ctx, token, "another-password"); !errors.Is(err, entity.ErrInvalidToken) {
+		t.Fatalf("got %v for a used link, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,114 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+func newAddress(label string) entity.Address {
+	return entity.Address{Label: label, Street: "1 Main St", City: "Springfield", Country: "US", PostalCode: "12345"}
+}
+
+func TestAddressBookDefaults(t *testing.T) {
+	ctx := context.Background()
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	addresses := usecase.NewAddressUsecase(userstore.NewAddresses(), users)
+
+	home, err := addresses.CreateAddress(ctx, "u-1", newAddress("home"))
+	if err != nil {
+		t.Fatalf("CreateAddress: %v", err)
+	}
+	// The first address becomes both defaults
+	if !home.IsDefaultShipping || !home.IsDefaultBilling {
+		t.Fatalf("first address is not the default: %+v", home)
+	}
+
+	work := newAddress("work")
+	work.IsDefaultShipping = true
+	if _, err := addresses.CreateAddress(ctx, "u-1", work); err != nil {
+		t.Fatalf("CreateAddress: %v", err)
+	}
+	shipping, billing, err := addresses.GetDefaultAddresses(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("GetDefaultAddresses: %v", err)
+	}
+	if shipping.Label != "work" || billing.Label != "home" {
+		t.Fatalf("got shipping %q and billing %q, want work and home", shipping.Label, billing.Label)
+	}
+
+	// Deleting the billing default promotes the remaining address
+	if err := addresses.DeleteAddress(ctx, "u-1", home.ID); err != nil {
+		t.Fatalf("DeleteAddress: %v", err)
+	}
+	shipping, billing, _ = addresses.GetDefaultAddresses(ctx, "u-1")
+	if shipping == nil || billing == nil || shipping.ID != billing.ID || billing.Label != "work" {
+		t.Fatalf("got shipping %+v and billing %+v, want work for both", shipping, billing)
+	}
+}
+
+func TestAddressBookValidation(t *testing.T) {
+	ctx := context.Background()
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := users.Create(ctx, entity.User{ID: "u-2", Email: "john@example.com"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	addresses := usecase.NewAddressUsecase(userstore.NewAddresses(), users)
+
+	if _, err := addresses.CreateAddress(ctx, "u-1", entity.Address{Street: "1 Main St"}); !errors.Is(err, entity.ErrInvalidAddress) {
+		t.Fatalf("got %v for an incomplete address, want ErrInvalidAddress", err)
+	}
+	withPhone := newAddress("home")
+	withPhone.Phone = "call me"
+	if _, err := addresses.CreateAddress(ctx, "u-1", withPhone); !errors.Is(err, entity.ErrInvalidPhone) {
+		t.Fatalf("got %v for an invalid phone, want ErrInvalidPhone", err)
+	}
+
+	// Addresses are only reachable by their owner
+	home, err := addresses.CreateAddress(ctx, "u-1", newAddress("home"))
+	if err != nil {
+		t.Fatalf("CreateAddress: %v", err)
+	}
+	if _, err := addresses.GetAddress(ctx, "u-2", home.ID); !errors.Is(err, entity.ErrAddressNotFound) {
+		t.Fatalf("got %v reading another user's address, want ErrAddressNotFound", err)
+	}
+	if err := addresses.DeleteAddress(ctx, "u-2", home.ID); !errors.Is(err, entity.ErrAddressNotFound) {
+		t.Fatalf("got %v deleting another user's address, want ErrAddressNotFound", err)
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	profiles := usecase.NewUserUsecase(users)
+
+	phone := "+1 (555) 123-4567"
+	updated, err := profiles.UpdateProfile(ctx, "u-1", entity.ProfileUpdate{
+		Phone:       &phone,
+		Preferences: &entity.UserPreferences{Language: "th", Currency: "THB"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if updated.Phone != phone || updated.Preferences.Currency != "THB" || updated.FirstName != "Jane" {
+		t.Fatalf("unexpected profile: %+v", updated)
+	}
+
+	invalid := "not a phone"
+	if _, err := profiles.UpdateProfile(ctx, "u-1", entity.ProfileUpdate{Phone: &invalid}); !errors.Is(err, entity.ErrInvalidPhone) {
+		t.Fatalf("got %v for an invalid phone, want ErrInvalidPhone", err)
+	}
+}
@@ -0,0 +1,114 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+func newLoginGuard(attempts *userstore.LoginAttempts, events *userstore.Events) usecase.LoginGuardUsecase {
+	return usecase.NewLoginGuardUsecase(attempts, events, usecase.LoginGuardOptions{
+		MaxAccountAttempts: 3,
+		MaxIPAttempts:      10,
+		AttemptWindow:      time.Hour,
+		BaseLockout:        time.Minute,
+		MaxLockout:         time.Hour,
+	})
+}
+
+func TestLoginGuardLocksAccountAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	events := &userstore.Events{}
+	guard := newLoginGuard(userstore.NewLoginAttempts(), events)
+
+	for i := 0; i < 3; i++ {
+		if err := guard.Check(ctx, "Jane@example.com", "203.0.113.7"); err != nil {
+			t.Fatalf("attempt %d: unexpected lockout: %v", i+1, err)
+		}
+		if err := guard.RecordFailure(ctx, "jane@example.com", "203.0.113.7", "invalid_password"); err != nil {
+			t.Fatalf("RecordFailure: %v", err)
+		}
+	}
+
+	// Counters are case insensitive, so the third failure locks the account
+	if err := guard.Check(ctx, "JANE@example.com", "198.51.100.1"); !errors.Is(err, entity.ErrAccountLocked) {
+		t.Fatalf("got %v, want ErrAccountLocked", err)
+	}
+	if len(events.Locks) != 1 || events.Locks[0].Scope != entity.LoginAttemptScopeAccount {
+		t.Fatalf("got locks %+v, want one account lock", events.Locks)
+	}
+	// The IP is still below its limit
+	if err := guard.Check(ctx, "other@example.com", "203.0.113.7"); err != nil {
+		t.Fatalf("other account from the same IP: %v", err)
+	}
+
+	if err := guard.Unlock(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if err := guard.Check(ctx, "jane@example.com", "203.0.113.7"); err != nil {
+		t.Fatalf("unlocked account: %v", err)
+	}
+}
+
+func TestLoginGuardSuccessResetsAccountCounter(t *testing.T) {
+	ctx := context.Background()
+	attempts := userstore.NewLoginAttempts()
+	guard := newLoginGuard(attempts, &userstore.Events{})
+
+	for i := 0; i < 2; i++ {
+		if err := guard.RecordFailure(ctx, "jane@example.com", "203.0.113.7", "invalid_password"); err != nil {
+			t.Fatalf("RecordFailure: %v", err)
+		}
+	}
+	if err := guard.RecordSuccess(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("RecordSuccess: %v", err)
+	}
+
+	// Two more failures start from zero and stay below the limit
+	for i := 0; i < 2; i++ {
+		if err := guard.RecordFailure(ctx, "jane@example.com", "203.0.113.7", "invalid_password"); err != nil {
+			t.Fatalf("RecordFailure: %v", err)
+		}
+	}
+	if err := guard.Check(ctx, "jane@example.com", "203.0.113.7"); err != nil {
+		t.Fatalf("account locked after a successful login reset it: %v", err)
+	}
+
+	// The IP counter is not reset by the successful login
+	ip, _ := attempts.Get(ctx, entity.LoginAttemptScopeIP, "203.0.113.7")
+	if ip == nil || ip.FailedCount != 4 {
+		t.Fatalf("got IP counter %+v, want 4 failures", ip)
+	}
+}
+
+func TestLoginGuardCountsConcurrentFailures(t *testing.T) {
+	ctx := context.Background()
+	attempts := userstore.NewLoginAttempts()
+	guard := usecase.NewLoginGuardUsecase(attempts, &userstore.Events{}, usecase.LoginGuardOptions{
+		MaxIPAttempts: 100,
+		AttemptWindow: time.Hour,
+		BaseLockout:   time.Minute,
+		MaxLockout:    time.Hour,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = guard.RecordFailure(ctx, "jane@example.com", "203.0.113.7", "invalid_password")
+		}()
+	}
+	wg.Wait()
+
+	ip, _ := attempts.Get(ctx, entity.LoginAttemptScopeIP, "203.0.113.7")
+	if ip == nil || ip.FailedCount != 50 {
+		t.Fatalf("got IP counter %+v, want 50 failures", ip)
+	}
+}
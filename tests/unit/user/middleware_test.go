@@ -0,0 +1,97 @@
+package user_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httpctl "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/adapter/controller/http"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+func TestRequireSelfOrRole(t *testing.T) {
+	app := fiber.New()
+	// Stand in for AuthMiddleware with the caller taken from test headers
+	authenticated := func(c *fiber.Ctx) error {
+		c.Locals(httpctl.LocalUserID, c.Get("X-User"))
+		c.Locals(httpctl.LocalRole, c.Get("X-Role"))
+		return c.Next()
+	}
+	app.Get("/users/:id", authenticated, httpctl.RequireSelfOrRole("id", vo.Admin), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		user   string
+		role   string
+		target string
+		want   int
+	}{
+		{"owner", "u-1", vo.User.String(), "u-1", fiber.StatusOK},
+		{"other user", "u-2", vo.User.String(), "u-1", fiber.StatusForbidden},
+		{"admin", "u-2", vo.Admin.String(), "u-1", fiber.StatusOK},
+		{"anonymous", "", "", "u-1", fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users/"+tt.target, nil)
+			req.Header.Set("X-User", tt.user)
+			req.Header.Set("X-Role", tt.role)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Fatalf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRejectsUnknownTokens(t *testing.T) {
+	ctx := context.Background()
+	tokens := userstore.NewTokens()
+	tokenUsecase := usecase.NewTokenUsecase(tokens, jwt_service.NewJWTService(jwt_service.Config{
+		SecretKey:            "test-secret",
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+		Issuer:               "test",
+	}))
+
+	app := fiber.New()
+	app.Get("/me", httpctl.AuthMiddleware(tokenUsecase), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	get := func(token string) int {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/me", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	pair, err := tokenUsecase.GenerateTokenPair(ctx, "u-1", vo.User.String())
+	if err != nil {
+		t.Fatalf("GenerateTokenPair: %v", err)
+	}
+	if status := get(pair.AccessToken); status != fiber.StatusOK {
+		t.Fatalf("got status %d for a valid token, want %d", status, fiber.StatusOK)
+	}
+
+	// A correctly signed token whose session was revoked is no longer known
+	if err := tokens.DeleteByUserID(ctx, "u-1"); err != nil {
+		t.Fatalf("DeleteByUserID: %v", err)
+	}
+	if status := get(pair.AccessToken); status != fiber.StatusUnauthorized {
+		t.Fatalf("got status %d for a revoked token, want %d", status, fiber.StatusUnauthorized)
+	}
+}
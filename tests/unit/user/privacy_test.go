@@ -0,0 +1,151 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+type privacyFixture struct {
+	users      *userstore.Users
+	addresses  *userstore.Addresses
+	identities *userstore.Identities
+	tokens     *userstore.Tokens
+	events     *userstore.Events
+	privacy    usecase.PrivacyUsecase
+}
+
+func newPrivacyFixture(t *testing.T, services ...string) *privacyFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := &privacyFixture{
+		users:      userstore.NewUsers(),
+		addresses:  userstore.NewAddresses(),
+		identities: userstore.NewIdentities(),
+		tokens:     userstore.NewTokens(),
+		events:     &userstore.Events{},
+	}
+	f.privacy = usecase.NewPrivacyUsecase(f.users, f.addresses, f.identities, f.tokens,
+		userstore.NewLoginAttempts(), userstore.NewErasures(), nil, f.events,
+		usecase.PrivacyOptions{ErasureServices: services})
+
+	if _, err := f.users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", FirstName: "Jane"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := f.addresses.Create(ctx, &entity.Address{ID: "a-1", UserID: "u-1", Street: "1 Main St"}); err != nil {
+		t.Fatalf("Create address: %v", err)
+	}
+	if err := f.identities.Create(ctx, &entity.Identity{ID: "i-1", UserID: "u-1", Provider: "google", Subject: "s-1"}); err != nil {
+		t.Fatalf("Create identity: %v", err)
+	}
+	if err := f.tokens.Create(ctx, &entity.Token{ID: "t-1", UserID: "u-1", Token: "refresh", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Create token: %v", err)
+	}
+	return f
+}
+
+// assertErased checks that no local personal data of u-1 is left
+func (f *privacyFixture) assertErased(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := f.users.GetByID(ctx, "u-1"); !errors.Is(err, entity.ErrUserNotFound) {
+		t.Fatalf("user still exists: %v", err)
+	}
+	addresses, _ := f.addresses.ListByUserID(ctx, "u-1")
+	identities, _ := f.identities.ListByUserID(ctx, "u-1")
+	tokens, _ := f.tokens.FindByUserID(ctx, "u-1")
+	if len(addresses)+len(identities)+len(tokens) != 0 {
+		t.Fatalf("left %d addresses, %d identities and %d tokens", len(addresses), len(identities), len(tokens))
+	}
+}
+
+func TestErasureCompletesWhenEveryServiceReports(t *testing.T) {
+	ctx := context.Background()
+	f := newPrivacyFixture(t, "order_service", "review_service")
+
+	request, err := f.privacy.RequestErasure(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("RequestErasure: %v", err)
+	}
+	if request.Status != entity.ErasurePending || len(f.events.ErasureRequests) != 1 {
+		t.Fatalf("got status %s with %d published requests", request.Status, len(f.events.ErasureRequests))
+	}
+	f.assertErased(t)
+
+	// Duplicate and unknown reports are ignored
+	for _, service := range []string{"order_service", "order_service", "unknown"} {
+		if err := f.privacy.RecordErasureCompletion(ctx, request.ID, service); err != nil {
+			t.Fatalf("RecordErasureCompletion(%s): %v", service, err)
+		}
+	}
+	status, _ := f.privacy.GetErasureStatus(ctx, "u-1")
+	if status.Status != entity.ErasurePending || len(status.PendingServices) != 1 {
+		t.Fatalf("got %+v, want review_service still pending", status)
+	}
+
+	if err := f.privacy.RecordErasureCompletion(ctx, request.ID, "review_service"); err != nil {
+		t.Fatalf("RecordErasureCompletion: %v", err)
+	}
+	status, _ = f.privacy.GetErasureStatus(ctx, "u-1")
+	if status.Status != entity.ErasureCompleted || status.CompletedAt == nil {
+		t.Fatalf("got %+v, want completed", status)
+	}
+}
+
+func TestErasureRetriesAfterPublishFailure(t *testing.T) {
+	ctx := context.Background()
+	f := newPrivacyFixture(t, "order_service")
+
+	f.events.PublishErasureErr = errors.New("broker unavailable")
+	if _, err := f.privacy.RequestErasure(ctx, "u-1"); err == nil {
+		t.Fatal("RequestErasure succeeded without publishing")
+	}
+	// Nothing is erased until the other services have been asked to erase too
+	if _, err := f.users.GetByID(ctx, "u-1"); err != nil {
+		t.Fatalf("user erased before the request was published: %v", err)
+	}
+
+	f.events.PublishErasureErr = nil
+	request, err := f.privacy.RequestErasure(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("RequestErasure: %v", err)
+	}
+	if len(f.events.ErasureRequests) != 1 || f.events.ErasureRequests[0].ID != request.ID {
+		t.Fatalf("got %d published requests, want the pending one re-sent", len(f.events.ErasureRequests))
+	}
+	f.assertErased(t)
+}
+
+func TestErasureCompletesWhenServicesReportTogether(t *testing.T) {
+	ctx := context.Background()
+	services := []string{"order_service", "inventory_service", "payment_service"}
+	f := newPrivacyFixture(t, services...)
+
+	request, err := f.privacy.RequestErasure(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("RequestErasure: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, service := range services {
+		wg.Add(1)
+		go func(service string) {
+			defer wg.Done()
+			if err := f.privacy.RecordErasureCompletion(ctx, request.ID, service); err != nil {
+				t.Errorf("RecordErasureCompletion(%s): %v", service, err)
+			}
+		}(service)
+	}
+	wg.Wait()
+
+	status, _ := f.privacy.GetErasureStatus(ctx, "u-1")
+	if status.Status != entity.ErasureCompleted || len(status.CompletedServices) != len(services) {
+		t.Fatalf("got %+v, want every service completed", status)
+	}
+}
@@ -0,0 +1,191 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase/interfaces"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/jwt_service"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+// stubProvider returns a fixed identity for any authorization code
+type stubProvider struct {
+	identity entity.ExternalIdentity
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (p *stubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*entity.ExternalIdentity, error) {
+	identity := p.identity
+	identity.Provider = p.Name()
+	return &identity, nil
+}
+
+type socialLoginFixture struct {
+	users      *userstore.Users
+	identities *userstore.Identities
+	provider   *stubProvider
+	login      usecase.SocialLoginUsecase
+}
+
+func newSocialLoginFixture() *socialLoginFixture {
+	users := userstore.NewUsers()
+	tokens := userstore.NewTokens()
+	identities := userstore.NewIdentities()
+	provider := &stubProvider{}
+
+	account := usecase.NewAccountUsecase(users, tokens, &userstore.Mailer{}, usecase.AccountOptions{
+		BaseURL:               "http://localhost",
+		VerificationTokenTTL:  time.Hour,
+		PasswordResetTokenTTL: time.Hour,
+	})
+	auth := usecase.NewAuthUsecase(
+		usecase.NewUserUsecase(users),
+		usecase.NewTokenUsecase(tokens, jwt_service.NewJWTService(jwt_service.Config{
+			SecretKey:            "test-secret",
+			AccessTokenDuration:  time.Minute,
+			RefreshTokenDuration: time.Hour,
+			Issuer:               "test",
+		})),
+		account,
+		newLoginGuard(userstore.NewLoginAttempts(), &userstore.Events{}),
+		usecase.NewTwoFactorUsecase(users, tokens, usecase.TwoFactorOptions{Issuer: "test", ChallengeTTL: time.Minute}),
+		true,
+	)
+	return &socialLoginFixture{
+		users:      users,
+		identities: identities,
+		provider:   provider,
+		login: usecase.NewSocialLoginUsecase(
+			[]interfaces.IdentityProvider{provider},
+			identities, users, auth, account, time.Minute,
+		),
+	}
+}
+
+// loginAs runs a complete social login for the identity the provider returns
+func (f *socialLoginFixture) loginAs(t *testing.T, identity entity.ExternalIdentity) (*entity.LoginResult, error) {
+	t.Helper()
+	f.provider.identity = identity
+	_, state, err := f.login.StartLogin(context.Background(), "stub")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	return f.login.CompleteLogin(context.Background(), "stub", state, "code")
+}
+
+func TestSocialLoginLinksVerifiedEmailToExistingAccount(t *testing.T) {
+	ctx := context.Background()
+	f := newSocialLoginFixture()
+	if _, err := f.users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", Role: vo.User, EmailVerified: true}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := f.loginAs(t, entity.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if result.TokenPair == nil {
+		t.Fatalf("expected a session, got %+v", result)
+	}
+
+	linked, _ := f.identities.ListByUserID(ctx, "u-1")
+	if len(linked) != 1 || linked[0].Subject != "sub-1" {
+		t.Fatalf("got identities %+v, want sub-1 linked to u-1", linked)
+	}
+	// The next login finds the account through the link, even after the email changed at the provider
+	if _, err := f.loginAs(t, entity.ExternalIdentity{Subject: "sub-1", Email: "jane@new.example.com", EmailVerified: true}); err != nil {
+		t.Fatalf("CompleteLogin through the link: %v", err)
+	}
+	if _, err := f.users.GetByEmail(ctx, "jane@new.example.com"); err == nil {
+		t.Fatal("a linked login provisioned a second account")
+	}
+}
+
+func TestSocialLoginRejectsUnverifiedEmailOfExistingAccount(t *testing.T) {
+	ctx := context.Background()
+	f := newSocialLoginFixture()
+	if _, err := f.users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", Role: vo.User, EmailVerified: true}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	_, err := f.loginAs(t, entity.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: false})
+	if !errors.Is(err, entity.ErrIdentityConflict) {
+		t.Fatalf("got %v, want ErrIdentityConflict", err)
+	}
+	if linked, _ := f.identities.ListByUserID(ctx, "u-1"); len(linked) != 0 {
+		t.Fatalf("unverified identity was linked: %+v", linked)
+	}
+}
+
+// TestSocialLoginRejectsUnverifiedExistingAccount covers account pre-hijacking: someone registers the
+// victim's email with their own password before the victim first signs in through the provider
+func TestSocialLoginRejectsUnverifiedExistingAccount(t *testing.T) {
+	ctx := context.Background()
+	f := newSocialLoginFixture()
+	if _, err := f.users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", Role: vo.User, HashedPassword: "attacker"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	_, err := f.loginAs(t, entity.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true})
+	if !errors.Is(err, entity.ErrIdentityConflict) {
+		t.Fatalf("got %v, want ErrIdentityConflict", err)
+	}
+	if linked, _ := f.identities.ListByUserID(ctx, "u-1"); len(linked) != 0 {
+		t.Fatalf("identity was linked to an unverified account: %+v", linked)
+	}
+	if user, _ := f.users.GetByID(ctx, "u-1"); user.EmailVerified {
+		t.Fatal("unverified account was marked verified")
+	}
+}
+
+func TestSocialLoginRequiresTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	f := newSocialLoginFixture()
+	if _, err := f.users.Create(ctx, entity.User{
+		ID:               "u-1",
+		Email:            "jane@example.com",
+		Role:             vo.User,
+		EmailVerified:    true,
+		TwoFactorEnabled: true,
+		TwoFactorSecret:  "JBSWY3DPEHPK3PXP",
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := f.loginAs(t, entity.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if !result.TwoFactorRequired || result.ChallengeToken == "" || result.TokenPair != nil {
+		t.Fatalf("got %+v, want a two-factor challenge instead of a session", result)
+	}
+}
+
+func TestSocialLoginStateIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	f := newSocialLoginFixture()
+	f.provider.identity = entity.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true}
+
+	_, state, err := f.login.StartLogin(ctx, "stub")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	if _, err := f.login.CompleteLogin(ctx, "stub", state, "code"); err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if _, err := f.login.CompleteLogin(ctx, "stub", state, "code"); !errors.Is(err, entity.ErrInvalidLoginState) {
+		t.Fatalf("got %v reusing a state, want ErrInvalidLoginState", err)
+	}
+}
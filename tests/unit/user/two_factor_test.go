@@ -0,0 +1,131 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/pkg/totp"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+func TestTwoFactorRejectsReplayedCode(t *testing.T) {
+	ctx := context.Background()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{
+		ID:               "u-1",
+		Email:            "jane@example.com",
+		TwoFactorEnabled: true,
+		TwoFactorSecret:  secret,
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	twoFactor := usecase.NewTwoFactorUsecase(users, userstore.NewTokens(), usecase.TwoFactorOptions{
+		Issuer:       "ecom",
+		ChallengeTTL: time.Minute,
+	})
+
+	now := time.Now()
+	code, _ := totp.GenerateCode(secret, now)
+	previous, _ := totp.GenerateCode(secret, now.Add(-totp.DefaultPeriod))
+
+	challenge, err := twoFactor.IssueChallenge(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("IssueChallenge: %v", err)
+	}
+	if _, _, err := twoFactor.VerifyChallenge(ctx, challenge, code); err != nil {
+		t.Fatalf("VerifyChallenge with a fresh code: %v", err)
+	}
+
+	// The same code, or the previous one still within the skew, cannot complete another login
+	for _, replayed := range []string{code, previous} {
+		challenge, err := twoFactor.IssueChallenge(ctx, "u-1")
+		if err != nil {
+			t.Fatalf("IssueChallenge: %v", err)
+		}
+		if _, _, err := twoFactor.VerifyChallenge(ctx, challenge, replayed); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			t.Fatalf("got %v for a replayed code, want ErrInvalidTwoFactorCode", err)
+		}
+	}
+	if _, err := twoFactor.RegenerateRecoveryCodes(ctx, "u-1", code); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+		t.Fatalf("got %v regenerating recovery codes with a used code, want ErrInvalidTwoFactorCode", err)
+	}
+
+	// A code of a later time step is accepted
+	next, _ := totp.GenerateCode(secret, now.Add(totp.DefaultPeriod))
+	if _, err := twoFactor.RegenerateRecoveryCodes(ctx, "u-1", next); err != nil {
+		t.Fatalf("RegenerateRecoveryCodes with a later code: %v", err)
+	}
+}
+
+func TestRecoveryCodeIsSpentOnce(t *testing.T) {
+	ctx := context.Background()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{
+		ID:               "u-1",
+		Email:            "jane@example.com",
+		TwoFactorEnabled: true,
+		TwoFactorSecret:  secret,
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	twoFactor := usecase.NewTwoFactorUsecase(users, userstore.NewTokens(), usecase.TwoFactorOptions{
+		Issuer:       "ecom",
+		ChallengeTTL: time.Minute,
+	})
+
+	code, _ := totp.GenerateCode(secret, time.Now())
+	recoveryCodes, err := twoFactor.RegenerateRecoveryCodes(ctx, "u-1", code)
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes: %v", err)
+	}
+
+	// Concurrent logins with the same recovery code: only one gets in
+	const logins = 8
+	challenges := make([]string, logins)
+	for i := range challenges {
+		if challenges[i], err = twoFactor.IssueChallenge(ctx, "u-1"); err != nil {
+			t.Fatalf("IssueChallenge: %v", err)
+		}
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for _, challenge := range challenges {
+		wg.Add(1)
+		go func(challenge string) {
+			defer wg.Done()
+			if _, _, err := twoFactor.VerifyChallenge(ctx, challenge, recoveryCodes[0]); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(challenge)
+	}
+	wg.Wait()
+	if accepted != 1 {
+		t.Fatalf("recovery code accepted %d times, want once", accepted)
+	}
+
+	// The other codes are untouched
+	user, _ := users.GetByID(ctx, "u-1")
+	if len(user.RecoveryCodes) != len(recoveryCodes)-1 {
+		t.Fatalf("%d recovery codes left, want %d", len(user.RecoveryCodes), len(recoveryCodes)-1)
+	}
+	challenge, _ := twoFactor.IssueChallenge(ctx, "u-1")
+	if _, _, err := twoFactor.VerifyChallenge(ctx, challenge, recoveryCodes[1]); err != nil {
+		t.Fatalf("VerifyChallenge with another recovery code: %v", err)
+	}
+}
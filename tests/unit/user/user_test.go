@@ -0,0 +1,82 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/entity"
+	vo "github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/domain/valueobject"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/user_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/userstore"
+)
+
+func TestUpdateUserKeepsSecuritySettings(t *testing.T) {
+	ctx := context.Background()
+	users := userstore.NewUsers()
+	suspendedAt := time.Now().Add(-time.Hour)
+	if _, err := users.Create(ctx, entity.User{
+		ID:               "u-1",
+		Email:            "jane@example.com",
+		FirstName:        "Jane",
+		LastName:         "Doe",
+		Role:             vo.Admin,
+		Phone:            "+15551234567",
+		EmailVerified:    true,
+		TwoFactorEnabled: true,
+		TwoFactorSecret:  "JBSWY3DPEHPK3PXP",
+		RecoveryCodes:    []string{"hash-1", "hash-2"},
+		SuspendedAt:      &suspendedAt,
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	updated, err := usecase.NewUserUsecase(users).UpdateUser(ctx, "u-1", entity.User{FirstName: "Janet"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.FirstName != "Janet" || updated.LastName != "Doe" || updated.Email != "jane@example.com" {
+		t.Fatalf("unexpected profile after update: %+v", updated)
+	}
+
+	stored, _ := users.GetByID(ctx, "u-1")
+	if !stored.TwoFactorEnabled || stored.TwoFactorSecret != "JBSWY3DPEHPK3PXP" || len(stored.RecoveryCodes) != 2 {
+		t.Fatalf("two-factor enrolment lost: %+v", stored)
+	}
+	if stored.Role != vo.Admin || stored.SuspendedAt == nil || !stored.EmailVerified || stored.Phone != "+15551234567" {
+		t.Fatalf("managed fields lost: %+v", stored)
+	}
+}
+
+func TestUpdateUserUnknownUser(t *testing.T) {
+	_, err := usecase.NewUserUsecase(userstore.NewUsers()).UpdateUser(context.Background(), "missing", entity.User{FirstName: "Janet"})
+	if err == nil {
+		t.Fatal("expected updating an unknown user to fail")
+	}
+}
+
+func TestUpdateUserEmailNeedsVerification(t *testing.T) {
+	ctx := context.Background()
+	users := userstore.NewUsers()
+	if _, err := users.Create(ctx, entity.User{ID: "u-1", Email: "jane@example.com", EmailVerified: true}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	userUsecase := usecase.NewUserUsecase(users)
+
+	// Changing only the case keeps the address verified
+	updated, err := userUsecase.UpdateUser(ctx, "u-1", entity.User{Email: "Jane@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if !updated.EmailVerified {
+		t.Fatal("verification lost for the same address")
+	}
+
+	updated, err = userUsecase.UpdateUser(ctx, "u-1", entity.User{Email: "janet@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Email != "janet@example.com" || updated.EmailVerified {
+		t.Fatalf("got %+v, want the new address unverified", updated)
+	}
+}
@@ -76,3 +76,30 @@ func TestVerifyPassword(t *testing.T) {
 		t.Errorf("VerifyPassword returned unexpected error for empty hash: %v", err)
 	}
 }
+
+func TestGenerateSecureToken(t *testing.T) {
+	token1, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		t.Fatalf("GenerateSecureToken returned an error: %v", err)
+	}
+	token2, _ := utils.GenerateSecureToken(32)
+	if token1 == token2 {
+		t.Error("GenerateSecureToken produced identical tokens")
+	}
+	// 32 bytes encode to 43 unpadded base64url characters
+	if len(token1) != 43 {
+		t.Errorf("GenerateSecureToken returned token of unexpected length %d", len(token1))
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	if utils.HashToken("abc") != utils.HashToken("abc") {
+		t.Error("HashToken is not deterministic")
+	}
+	if utils.HashToken("abc") == utils.HashToken("abd") {
+		t.Error("HashToken produced identical digests for different tokens")
+	}
+	if utils.HashToken("abc") == "abc" {
+		t.Error("HashToken returned the raw token")
+	}
+}
@@ -0,0 +1,52 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/valueobject"
+)
+
+func TestProductStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from, to valueobject.ProductStatus
+		allowed  bool
+	}{
+		{valueobject.Draft, valueobject.Review, true},
+		{valueobject.Draft, valueobject.Published, false},
+		{valueobject.Review, valueobject.Published, true},
+		{valueobject.Review, valueobject.Draft, true},
+		{valueobject.Published, valueobject.Archived, true},
+		{valueobject.Published, valueobject.Draft, false},
+		{valueobject.Archived, valueobject.Draft, true},
+		{valueobject.Archived, valueobject.Published, false},
+	}
+	for _, c := range cases {
+		if got := c.from.CanTransitionTo(c.to); got != c.allowed {
+			t.Errorf("%s -> %s: expected allowed=%v, got %v", c.from, c.to, c.allowed, got)
+		}
+	}
+}
+
+func TestParseProductStatusAcceptsLegacyStatuses(t *testing.T) {
+	cases := map[string]valueobject.ProductStatus{
+		"draft":     valueobject.Draft,
+		" Review ":  valueobject.Review,
+		"active":    valueobject.Published,
+		"inactive":  valueobject.Archived,
+		"deleted":   valueobject.Archived,
+		"PUBLISHED": valueobject.Published,
+	}
+	for input, expected := range cases {
+		status, err := valueobject.ParseProductStatus(input)
+		if err != nil {
+			t.Fatalf("ParseProductStatus(%q) returned an error: %v", input, err)
+		}
+		if status != expected {
+			t.Errorf("ParseProductStatus(%q): expected %s, got %s", input, expected, status)
+		}
+	}
+
+	if _, err := valueobject.ParseProductStatus("sold-out"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
@@ -0,0 +1,92 @@
+package variant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/entity"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/domain/service"
+	"github.com/hydr0g3nz/ecom_back_microservice/internal/product_service/usecase"
+	"github.com/hydr0g3nz/ecom_back_microservice/tests/testutil/productstore"
+)
+
+// newVariants returns a variant usecase over a store holding product p-1 with a size option
+func newVariants(t *testing.T) (*productstore.Store, usecase.VariantUsecase) {
+	t.Helper()
+	ctx := context.Background()
+	store := productstore.NewStore()
+	if _, err := store.Create(ctx, entity.Product{ID: "p-1", SKU: "TEE", Name: "Tee", Price: 10}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	variants := usecase.NewVariantUsecase(store.Variants(), store, store, store)
+	if _, err := variants.SetOptions(ctx, "p-1", []entity.ProductOption{{Name: "size", Values: []string{"S", "M"}}}); err != nil {
+		t.Fatalf("SetOptions: %v", err)
+	}
+	return store, variants
+}
+
+func decodeEvent(t *testing.T, event entity.OutboxEvent) entity.ProductEvent {
+	t.Helper()
+	var payload entity.ProductEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		t.Fatalf("failed to decode %s: %v", event.EventType, err)
+	}
+	return payload
+}
+
+func TestVariantLifecycleIsAnnounced(t *testing.T) {
+	ctx := context.Background()
+	store, variants := newVariants(t)
+
+	variant, err := variants.CreateVariant(ctx, "p-1", &entity.ProductVariant{SKU: "TEE-S", Options: map[string]string{"size": "S"}})
+	if err != nil {
+		t.Fatalf("CreateVariant: %v", err)
+	}
+	if err := variants.DeleteVariant(ctx, "p-1", variant.ID); err != nil {
+		t.Fatalf("DeleteVariant: %v", err)
+	}
+
+	events := store.Outbox()
+	if len(events) != 2 {
+		t.Fatalf("got %d outbox events, want 2", len(events))
+	}
+	for i, want := range []string{service.EventTypeVariantCreated, service.EventTypeVariantDeleted} {
+		payload := decodeEvent(t, events[i])
+		// Keyed by the product so the variant events stay in order with the product's own
+		if events[i].EventType != want || events[i].AggregateID != "p-1" || payload.VariantID != variant.ID || payload.SKU != "TEE-S" {
+			t.Fatalf("event %d: got %s about %s/%s, want %s about p-1/%s",
+				i, events[i].EventType, events[i].AggregateID, payload.VariantID, want, variant.ID)
+		}
+	}
+}
+
+func TestVariantIsNotCreatedWithoutItsEvent(t *testing.T) {
+	ctx := context.Background()
+	store, variants := newVariants(t)
+
+	store.OutboxErr = errors.New("outbox unavailable")
+	if _, err := variants.CreateVariant(ctx, "p-1", &entity.ProductVariant{SKU: "TEE-S", Options: map[string]string{"size": "S"}}); err == nil {
+		t.Fatal("CreateVariant succeeded without storing its event")
+	}
+	list, _ := variants.ListVariants(ctx, "p-1")
+	if len(list) != 0 {
+		t.Fatalf("got %d variants, want the failed one rolled back", len(list))
+	}
+
+	store.OutboxErr = nil
+	variant, err := variants.CreateVariant(ctx, "p-1", &entity.ProductVariant{SKU: "TEE-S", Options: map[string]string{"size": "S"}})
+	if err != nil {
+		t.Fatalf("CreateVariant: %v", err)
+	}
+
+	// The variant stays when its deletion cannot be announced
+	store.OutboxErr = errors.New("outbox unavailable")
+	if err := variants.DeleteVariant(ctx, "p-1", variant.ID); err == nil {
+		t.Fatal("DeleteVariant succeeded without storing its event")
+	}
+	if _, err := variants.GetVariant(ctx, "p-1", variant.ID); err != nil {
+		t.Fatalf("variant lost with its deletion rolled back: %v", err)
+	}
+}